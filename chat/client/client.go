@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -301,3 +302,82 @@ func (rc RestClient) CheckAreUsersExists(c context.Context, userIds []int64) (*[
 	}
 	return resultMap, nil
 }
+
+type UploadedFile struct {
+	FileItemUuid string `json:"fileItemUuid"`
+	Id           string `json:"id"`
+	Url          string `json:"url"`
+}
+
+// puts the file into the chat's files bucket on behalf of ownerId
+func (rc RestClient) UploadFile(c context.Context, chatId int64, ownerId int64, filename string, contentType string, body io.Reader, size int64) (*UploadedFile, error) {
+	url0 := viper.GetString("storage.url.base")
+	url1 := viper.GetString("storage.url.upload")
+
+	fullUrl := fmt.Sprintf("%v?chatId=%v&ownerId=%v&fileName=%v", url0+url1, chatId, ownerId, url.QueryEscape(filename))
+
+	request, err := http.NewRequest("PUT", fullUrl, body)
+	if err != nil {
+		rc.lgr.WithTracing(c).Errorln("Failed during create storage request:", err)
+		return nil, err
+	}
+	request.ContentLength = size
+	request.Header.Set(echo.HeaderContentType, contentType)
+
+	ctx, span := rc.tracer.Start(c, "storage.Upload")
+	defer span.End()
+	request = request.WithContext(ctx)
+
+	response, err := rc.Do(request)
+	if err != nil {
+		rc.lgr.WithTracing(c).Errorw("Transport error during uploading file", err)
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		err = errors.New(fmt.Sprintf("Unexpected status uploading file %v", response.StatusCode))
+		return nil, err
+	}
+
+	bodyBytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		rc.lgr.WithTracing(c).Errorw("Failed to decode upload file response", err)
+		return nil, err
+	}
+
+	result := new(UploadedFile)
+	if err := json.Unmarshal(bodyBytes, result); err != nil {
+		rc.lgr.WithTracing(c).Errorln("Failed to parse result", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// removes all the files of the file item from the chat's files bucket
+func (rc RestClient) DeleteFileItem(c context.Context, chatId int64, fileItemUuid string) error {
+	url0 := viper.GetString("storage.url.base")
+	url1 := viper.GetString("storage.url.deleteFileItem")
+
+	fullUrl := fmt.Sprintf("%v?chatId=%v&fileItemUuid=%v", url0+url1, chatId, url.QueryEscape(fileItemUuid))
+
+	request, err := http.NewRequest("DELETE", fullUrl, nil)
+	if err != nil {
+		rc.lgr.WithTracing(c).Errorln("Failed during create storage request:", err)
+		return err
+	}
+
+	ctx, span := rc.tracer.Start(c, "storage.DeleteFileItem")
+	defer span.End()
+	request = request.WithContext(ctx)
+
+	response, err := rc.Do(request)
+	if err != nil {
+		rc.lgr.WithTracing(c).Errorw("Transport error during deleting file item", err)
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return errors.New(fmt.Sprintf("Unexpected status deleting file item %v", response.StatusCode))
+	}
+	return nil
+}
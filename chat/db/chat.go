@@ -8,6 +8,7 @@ import (
 	"github.com/guregu/null"
 	"github.com/rotisserie/eris"
 	"github.com/spf13/viper"
+	"nkonev.name/chat/dto"
 	"nkonev.name/chat/utils"
 	"time"
 )
@@ -39,7 +40,11 @@ func selectChatClause(performPersonalization bool) string {
 			ch.regular_participant_can_publish_message,
 			ch.regular_participant_can_pin_message,
 			ch.blog_about,
-			ch.regular_participant_can_write_message
+			ch.regular_participant_can_write_message,
+			ch.regular_participant_can_see_edit_history,
+			ch.message_retention_seconds,
+			ch.join_by_request,
+			ch.slow_mode_seconds
 	`, p)
 
 	var pp string
@@ -75,6 +80,10 @@ type Chat struct {
 	RegularParticipantCanPinMessage     bool
 	BlogAbout                           bool
 	RegularParticipantCanWriteMessage   bool
+	RegularParticipantCanSeeEditHistory bool
+	MessageRetentionSeconds             null.Int
+	JoinByRequest                       bool
+	SlowModeSeconds                     null.Int
 }
 
 type Blog struct {
@@ -88,7 +97,7 @@ type ChatWithParticipants struct {
 	Chat
 	ParticipantsIds    []int64
 	ParticipantsCount  int
-	IsAdmin            bool
+	Role               string // of the behalf user, empty if they aren't a participant
 	LastMessagePreview *string
 	LastMessageOwnerId *int64
 }
@@ -104,7 +113,7 @@ func (tx *Tx) CreateChat(ctx context.Context, u *Chat) (int64, *time.Time, error
 	}
 
 	// https://stackoverflow.com/questions/4547672/return-multiple-fields-as-a-record-in-postgresql-with-pl-pgsql/6085167#6085167
-	res := tx.QueryRowContext(ctx, `SELECT chat_id, last_update_date_time FROM CREATE_CHAT($1, $2, $3, $4, $5, $6, $7, $8, $9) AS (chat_id BIGINT, last_update_date_time TIMESTAMP)`, u.Title, u.TetATet, u.CanResend, u.AvailableToSearch, u.Blog, u.RegularParticipantCanPublishMessage, u.RegularParticipantCanPinMessage, u.BlogAbout, u.RegularParticipantCanWriteMessage)
+	res := tx.QueryRowContext(ctx, `SELECT chat_id, last_update_date_time FROM CREATE_CHAT($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) AS (chat_id BIGINT, last_update_date_time TIMESTAMP)`, u.Title, u.TetATet, u.CanResend, u.AvailableToSearch, u.Blog, u.RegularParticipantCanPublishMessage, u.RegularParticipantCanPinMessage, u.BlogAbout, u.RegularParticipantCanWriteMessage, u.RegularParticipantCanSeeEditHistory, u.MessageRetentionSeconds, u.JoinByRequest, u.SlowModeSeconds)
 	var id int64
 	var lastUpdateDateTime time.Time
 	if err := res.Scan(&id, &lastUpdateDateTime); err != nil {
@@ -149,6 +158,10 @@ func provideScanToChat(chat *Chat) []any {
 		&chat.RegularParticipantCanPinMessage,
 		&chat.BlogAbout,
 		&chat.RegularParticipantCanWriteMessage,
+		&chat.RegularParticipantCanSeeEditHistory,
+		&chat.MessageRetentionSeconds,
+		&chat.JoinByRequest,
+		&chat.SlowModeSeconds,
 	}
 }
 
@@ -180,7 +193,7 @@ func convertToWithParticipants(ctx context.Context, co CommonOperations, chat *C
 	if ids, err := co.GetParticipantIds(ctx, chat.Id, participantsSize, participantsOffset); err != nil {
 		return nil, err
 	} else {
-		admin, err := co.IsAdmin(ctx, behalfUserId, chat.Id)
+		role, err := co.GetParticipantRole(ctx, behalfUserId, chat.Id)
 		if err != nil {
 			return nil, err
 		}
@@ -197,7 +210,7 @@ func convertToWithParticipants(ctx context.Context, co CommonOperations, chat *C
 		ccc := &ChatWithParticipants{
 			Chat:              *chat,
 			ParticipantsIds:   ids,
-			IsAdmin:           admin,
+			Role:              role,
 			ParticipantsCount: participantsCount,
 		}
 
@@ -226,7 +239,7 @@ type ParticipantIds struct {
 	ParticipantIds []int64
 }
 
-func convertToWithParticipantsBatch(chat *Chat, participantIdsBatch []*ParticipantIds, isAdminBatch map[int64]bool, participantsCountBatch map[int64]int, messagePreviewsBatch map[int64]*LastMessagePreview) (*ChatWithParticipants, error) {
+func convertToWithParticipantsBatch(chat *Chat, participantIdsBatch []*ParticipantIds, roleBatch map[int64]string, participantsCountBatch map[int64]int, messagePreviewsBatch map[int64]*LastMessagePreview) (*ChatWithParticipants, error) {
 	participantsCount := participantsCountBatch[chat.Id]
 
 	var participantsIds []int64 = make([]int64, 0)
@@ -237,14 +250,14 @@ func convertToWithParticipantsBatch(chat *Chat, participantIdsBatch []*Participa
 		}
 	}
 
-	admin := isAdminBatch[chat.Id]
+	role := roleBatch[chat.Id]
 
 	messagePreview := messagePreviewsBatch[chat.Id]
 
 	ccc := &ChatWithParticipants{
 		Chat:              *chat,
 		ParticipantsIds:   participantsIds,
-		IsAdmin:           admin,
+		Role:              role,
 		ParticipantsCount: participantsCount,
 	}
 
@@ -274,7 +287,7 @@ func getLastMessagePreview(ctx context.Context, co CommonOperations, chatIds []i
 		if i != 0 {
 			bldr += " UNION ALL "
 		}
-		bldr += fmt.Sprintf("(select %v, substring(strip_tags(text), 0, %v), owner_id from message_chat_%v order by id desc limit 1)", chatId, maxPrevSizeDb, chatId)
+		bldr += fmt.Sprintf("(select %v, substring(strip_tags(text), 0, %v), owner_id from message_chat_%v where thread_id is null and deleted_date_time is null order by id desc limit 1)", chatId, maxPrevSizeDb, chatId)
 	}
 	rows, err := co.QueryContext(ctx, bldr)
 	if err != nil {
@@ -411,7 +424,7 @@ func getChatsWithParticipantsCommon(ctx context.Context, commonOps CommonOperati
 			return nil, err
 		}
 
-		isAdminBatch, err := commonOps.IsAdminBatch(ctx, participantId, chatIds)
+		roleBatch, err := commonOps.GetParticipantRoleBatch(ctx, participantId, chatIds)
 		if err != nil {
 			return nil, err
 		}
@@ -429,7 +442,7 @@ func getChatsWithParticipantsCommon(ctx context.Context, commonOps CommonOperati
 		list := make([]*ChatWithParticipants, 0)
 
 		for _, cc := range chats {
-			if ccc, err := convertToWithParticipantsBatch(cc, participantIdsBatch, isAdminBatch, participantsCountBatch, messagePreviewsBatch); err != nil {
+			if ccc, err := convertToWithParticipantsBatch(cc, participantIdsBatch, roleBatch, participantsCountBatch, messagePreviewsBatch); err != nil {
 				return nil, err
 			} else {
 				list = append(list, ccc)
@@ -513,14 +526,18 @@ func (tx *Tx) EditChat(
 	regularParticipantCanPinMessage bool,
 	blogAbout bool,
 	regularParticipantCanWriteMessage bool,
+	regularParticipantCanSeeEditHistory bool,
+	messageRetentionSeconds null.Int,
+	joinByRequest bool,
+	slowModeSeconds null.Int,
 ) (*time.Time, error) {
 	var res sql.Result
 	var err error
 	if blog != nil {
 		isBlog := utils.NullableToBoolean(blog)
-		res, err = tx.ExecContext(ctx, `UPDATE chat SET title = $2, avatar = $3, avatar_big = $4, last_update_date_time = utc_now(), can_resend = $5, available_to_search = $6, blog = $7, regular_participant_can_publish_message = $8, regular_participant_can_pin_message = $9, blog_about = $10, regular_participant_can_write_message = $11 WHERE id = $1`, id, newTitle, avatar, avatarBig, canResend, availableToSearch, isBlog, regularParticipantCanPublishMessage, regularParticipantCanPinMessage, blogAbout, regularParticipantCanWriteMessage)
+		res, err = tx.ExecContext(ctx, `UPDATE chat SET title = $2, avatar = $3, avatar_big = $4, last_update_date_time = utc_now(), can_resend = $5, available_to_search = $6, blog = $7, regular_participant_can_publish_message = $8, regular_participant_can_pin_message = $9, blog_about = $10, regular_participant_can_write_message = $11, regular_participant_can_see_edit_history = $12, message_retention_seconds = $13, join_by_request = $14, slow_mode_seconds = $15 WHERE id = $1`, id, newTitle, avatar, avatarBig, canResend, availableToSearch, isBlog, regularParticipantCanPublishMessage, regularParticipantCanPinMessage, blogAbout, regularParticipantCanWriteMessage, regularParticipantCanSeeEditHistory, messageRetentionSeconds, joinByRequest, slowModeSeconds)
 	} else {
-		res, err = tx.ExecContext(ctx, `UPDATE chat SET title = $2, avatar = $3, avatar_big = $4, last_update_date_time = utc_now(), can_resend = $5, available_to_search = $6, regular_participant_can_publish_message = $7, regular_participant_can_pin_message = $8, regular_participant_can_write_message = $9 WHERE id = $1`, id, newTitle, avatar, avatarBig, canResend, availableToSearch, regularParticipantCanPublishMessage, regularParticipantCanPinMessage, regularParticipantCanWriteMessage)
+		res, err = tx.ExecContext(ctx, `UPDATE chat SET title = $2, avatar = $3, avatar_big = $4, last_update_date_time = utc_now(), can_resend = $5, available_to_search = $6, regular_participant_can_publish_message = $7, regular_participant_can_pin_message = $8, regular_participant_can_write_message = $9, regular_participant_can_see_edit_history = $10, message_retention_seconds = $11, join_by_request = $12, slow_mode_seconds = $13 WHERE id = $1`, id, newTitle, avatar, avatarBig, canResend, availableToSearch, regularParticipantCanPublishMessage, regularParticipantCanPinMessage, regularParticipantCanWriteMessage, regularParticipantCanSeeEditHistory, messageRetentionSeconds, joinByRequest, slowModeSeconds)
 	}
 	if err != nil {
 		tx.lgr.WithTracing(ctx).Errorf("Error during editing chat id %v", err)
@@ -581,12 +598,15 @@ func getChatBasicCommon(ctx context.Context, co CommonOperations, chatId int64)
 				ch.blog,
 				ch.regular_participant_can_publish_message,
 				ch.regular_participant_can_pin_message,
-				ch.regular_participant_can_write_message
+				ch.regular_participant_can_write_message,
+				ch.regular_participant_can_see_edit_history,
+				ch.join_by_request,
+				ch.slow_mode_seconds
 			FROM chat ch 
 			WHERE ch.id = $1
 `, chatId)
 	chat := BasicChatDto{}
-	err := row.Scan(&chat.Id, &chat.Title, &chat.IsTetATet, &chat.CanResend, &chat.AvailableToSearch, &chat.IsBlog, &chat.RegularParticipantCanPublishMessage, &chat.RegularParticipantCanPinMessage, &chat.RegularParticipantCanWriteMessage)
+	err := row.Scan(&chat.Id, &chat.Title, &chat.IsTetATet, &chat.CanResend, &chat.AvailableToSearch, &chat.IsBlog, &chat.RegularParticipantCanPublishMessage, &chat.RegularParticipantCanPinMessage, &chat.RegularParticipantCanWriteMessage, &chat.RegularParticipantCanSeeEditHistory, &chat.JoinByRequest, &chat.SlowModeSeconds)
 	if errors.Is(err, sql.ErrNoRows) {
 		// there were no rows, but otherwise no error occurred
 		return nil, nil
@@ -713,6 +733,18 @@ type BasicChatDto struct {
 	RegularParticipantCanPublishMessage bool
 	RegularParticipantCanPinMessage     bool
 	RegularParticipantCanWriteMessage   bool
+	RegularParticipantCanSeeEditHistory bool
+	JoinByRequest                       bool
+	SlowModeSeconds                     null.Int
+}
+
+func (c *BasicChatDto) PermissionSettings() dto.ChatPermissionSettings {
+	return dto.ChatPermissionSettings{
+		MemberCanWriteMessage:   c.RegularParticipantCanWriteMessage,
+		MemberCanPinMessage:     c.RegularParticipantCanPinMessage,
+		MemberCanPublishMessage: c.RegularParticipantCanPublishMessage,
+		MemberCanSeeEditHistory: c.RegularParticipantCanSeeEditHistory,
+	}
 }
 
 type BasicBlogDto struct {
@@ -728,6 +760,15 @@ type BasicChatDtoExtended struct {
 	BehalfUserIsParticipant bool
 }
 
+// renames the chat without touching the rest of its settings
+func (tx *Tx) EditChatTitle(ctx context.Context, chatId int64, newTitle string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE chat SET title = $2, last_update_date_time = utc_now() WHERE id = $1`, chatId, newTitle)
+	if err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	return nil
+}
+
 func (tx *Tx) UpdateChatLastDatetimeChat(ctx context.Context, id int64) error {
 	if _, err := tx.ExecContext(ctx, "UPDATE chat SET last_update_date_time = utc_now() WHERE id = $1", id); err != nil {
 		return eris.Wrap(err, "error during interacting with db")
@@ -901,6 +942,28 @@ func (db *DB) GetChatIds(ctx context.Context, chatsSize, chatsOffset int) ([]int
 	return getChatIdsCommon(ctx, db, chatsSize, chatsOffset)
 }
 
+type ChatRetention struct {
+	ChatId                  int64
+	MessageRetentionSeconds int64
+}
+
+func (db *DB) GetChatsWithMessageRetention(ctx context.Context, chatsSize, chatsOffset int) ([]ChatRetention, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id, message_retention_seconds FROM chat WHERE message_retention_seconds IS NOT NULL ORDER BY id LIMIT $1 OFFSET $2`, chatsSize, chatsOffset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]ChatRetention, 0)
+	for rows.Next() {
+		cr := ChatRetention{}
+		if err := rows.Scan(&cr.ChatId, &cr.MessageRetentionSeconds); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, cr)
+	}
+	return list, nil
+}
+
 func getBlogPostsByLimitOffsetCommon(ctx context.Context, co CommonOperations, reverse bool, limit int, offset int) ([]*Blog, error) {
 	var rows *sql.Rows
 	var err error
@@ -969,7 +1032,7 @@ func getBlogPostsByChatIdsCommon(ctx context.Context, co CommonOperations, ids [
 		if !first {
 			builder += " UNION ALL "
 		}
-		builder += fmt.Sprintf("(select %v, id, owner_id, text, file_item_uuid from message_chat_%v where blog_post is true order by id limit 1)", chatId, chatId)
+		builder += fmt.Sprintf("(select %v, id, owner_id, text, file_item_uuid from message_chat_%v where blog_post is true and deleted_date_time is null order by id limit 1)", chatId, chatId)
 
 		first = false
 	}
@@ -1003,7 +1066,7 @@ func (db *DB) GetBlogPostsByChatIds(ctx context.Context, ids []int64) ([]*BlogPo
 }
 
 func (db *DB) GetBlogPostMessageId(ctx context.Context, chatId int64) (int64, error) {
-	res := db.QueryRowContext(ctx, fmt.Sprintf("(select id from message_chat_%v where blog_post is true order by id limit 1)", chatId))
+	res := db.QueryRowContext(ctx, fmt.Sprintf("(select id from message_chat_%v where blog_post is true and deleted_date_time is null order by id limit 1)", chatId))
 	var messageId int64
 	if err := res.Scan(&messageId); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -1028,7 +1091,7 @@ func (db *DB) GetBlobPostModifiedDates(ctx context.Context, chatIds []int64) (ma
 		if !first {
 			builder += " UNION ALL "
 		}
-		builder += fmt.Sprintf("(select %v, coalesce(edit_date_time, create_date_time) from message_chat_%v where blog_post is true order by id limit 1)", chatId, chatId)
+		builder += fmt.Sprintf("(select %v, coalesce(edit_date_time, create_date_time) from message_chat_%v where blog_post is true and deleted_date_time is null order by id limit 1)", chatId, chatId)
 
 		first = false
 	}
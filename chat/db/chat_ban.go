@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/guregu/null"
+	"github.com/rotisserie/eris"
+	"time"
+)
+
+type ChatBan struct {
+	Id             int64
+	ChatId         int64
+	UserId         int64
+	BannedBy       int64
+	Reason         null.String
+	ExpireDateTime null.Time
+	CreateDateTime time.Time
+}
+
+const selectChatBanClause = `SELECT
+		id,
+		chat_id,
+		user_id,
+		banned_by,
+		reason,
+		expire_date_time,
+		create_date_time
+	FROM chat_ban `
+
+// the expired bans are kept in the table, they are just ignored
+const activeChatBanCondition = ` (expire_date_time IS NULL OR expire_date_time > utc_now()) `
+
+func provideScanToChatBan(b *ChatBan) []any {
+	return []any{
+		&b.Id,
+		&b.ChatId,
+		&b.UserId,
+		&b.BannedBy,
+		&b.Reason,
+		&b.ExpireDateTime,
+		&b.CreateDateTime,
+	}
+}
+
+// the repeated ban of the same user replaces the previous one
+func (tx *Tx) CreateChatBan(ctx context.Context, chatId, userId, bannedBy int64, reason null.String, expireDateTime null.Time) (*ChatBan, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO chat_ban (chat_id, user_id, banned_by, reason, expire_date_time) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (chat_id, user_id) DO UPDATE SET banned_by = excluded.banned_by, reason = excluded.reason, expire_date_time = excluded.expire_date_time, create_date_time = utc_now()
+		RETURNING id, chat_id, user_id, banned_by, reason, expire_date_time, create_date_time`,
+		chatId, userId, bannedBy, reason, expireDateTime)
+	b := ChatBan{}
+	if err := row.Scan(provideScanToChatBan(&b)[:]...); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &b, nil
+}
+
+func getChatBansCommon(ctx context.Context, co CommonOperations, chatId int64, limit, offset int) ([]*ChatBan, error) {
+	rows, err := co.QueryContext(ctx, selectChatBanClause+`WHERE chat_id = $1 AND `+activeChatBanCondition+` ORDER BY id DESC LIMIT $2 OFFSET $3`, chatId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*ChatBan, 0)
+	for rows.Next() {
+		b := ChatBan{}
+		if err := rows.Scan(provideScanToChatBan(&b)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &b)
+	}
+	return list, nil
+}
+
+func (db *DB) GetChatBans(ctx context.Context, chatId int64, limit, offset int) ([]*ChatBan, error) {
+	return getChatBansCommon(ctx, db, chatId, limit, offset)
+}
+
+func (tx *Tx) GetChatBans(ctx context.Context, chatId int64, limit, offset int) ([]*ChatBan, error) {
+	return getChatBansCommon(ctx, tx, chatId, limit, offset)
+}
+
+func getChatBansCountCommon(ctx context.Context, co CommonOperations, chatId int64) (int64, error) {
+	row := co.QueryRowContext(ctx, `SELECT count(*) FROM chat_ban WHERE chat_id = $1 AND `+activeChatBanCondition, chatId)
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+func (db *DB) GetChatBansCount(ctx context.Context, chatId int64) (int64, error) {
+	return getChatBansCountCommon(ctx, db, chatId)
+}
+
+func (tx *Tx) GetChatBansCount(ctx context.Context, chatId int64) (int64, error) {
+	return getChatBansCountCommon(ctx, tx, chatId)
+}
+
+func isBannedCommon(ctx context.Context, co CommonOperations, userId, chatId int64) (bool, error) {
+	row := co.QueryRowContext(ctx, `SELECT exists(SELECT 1 FROM chat_ban WHERE chat_id = $1 AND user_id = $2 AND `+activeChatBanCondition+`)`, chatId, userId)
+	var exists bool
+	if err := row.Scan(&exists); err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return exists, nil
+}
+
+func (db *DB) IsBanned(ctx context.Context, userId, chatId int64) (bool, error) {
+	return isBannedCommon(ctx, db, userId, chatId)
+}
+
+func (tx *Tx) IsBanned(ctx context.Context, userId, chatId int64) (bool, error) {
+	return isBannedCommon(ctx, tx, userId, chatId)
+}
+
+// returns those of userIds who are banned in the chat
+func (tx *Tx) GetBannedUserIds(ctx context.Context, userIds []int64, chatId int64) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT user_id FROM chat_ban WHERE chat_id = $1 AND user_id = ANY($2) AND `+activeChatBanCondition, chatId, userIds)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]int64, 0)
+	for rows.Next() {
+		var userId int64
+		if err := rows.Scan(&userId); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, userId)
+	}
+	return list, nil
+}
+
+// checks whether bannedBy has banned userId in one of their former tet-a-tet chats, so userId can't start a new one
+func (tx *Tx) IsBannedInTetATet(ctx context.Context, userId, bannedBy int64) (bool, error) {
+	row := tx.QueryRowContext(ctx, `SELECT exists(SELECT 1 FROM chat_ban b JOIN chat ch ON ch.id = b.chat_id WHERE ch.tet_a_tet = true AND b.user_id = $1 AND b.banned_by = $2 AND `+activeChatBanCondition+`)`, userId, bannedBy)
+	var exists bool
+	if err := row.Scan(&exists); err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return exists, nil
+}
+
+// returns nil if there is no such ban
+func (tx *Tx) DeleteChatBan(ctx context.Context, chatId, userId int64) (*ChatBan, error) {
+	row := tx.QueryRowContext(ctx, `DELETE FROM chat_ban WHERE chat_id = $1 AND user_id = $2 RETURNING id, chat_id, user_id, banned_by, reason, expire_date_time, create_date_time`, chatId, userId)
+	b := ChatBan{}
+	err := row.Scan(provideScanToChatBan(&b)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &b, nil
+}
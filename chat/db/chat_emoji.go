@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+type ChatEmoji struct {
+	Id             int64
+	ChatId         int64
+	Shortcode      string
+	Url            string
+	CreatedBy      int64
+	CreateDateTime time.Time
+}
+
+const selectChatEmojiClause = `SELECT
+		id,
+		chat_id,
+		shortcode,
+		url,
+		created_by,
+		create_date_time
+	FROM chat_emoji `
+
+func provideScanToChatEmoji(e *ChatEmoji) []any {
+	return []any{
+		&e.Id,
+		&e.ChatId,
+		&e.Shortcode,
+		&e.Url,
+		&e.CreatedBy,
+		&e.CreateDateTime,
+	}
+}
+
+// returns nil if the chat has already the emoji with this shortcode
+func (tx *Tx) CreateChatEmoji(ctx context.Context, chatId int64, shortcode, url string, createdBy int64) (*ChatEmoji, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO chat_emoji (chat_id, shortcode, url, created_by) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (chat_id, shortcode) DO NOTHING
+		RETURNING id, chat_id, shortcode, url, created_by, create_date_time`,
+		chatId, shortcode, url, createdBy)
+	e := ChatEmoji{}
+	err := row.Scan(provideScanToChatEmoji(&e)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &e, nil
+}
+
+func getChatEmojisCommon(ctx context.Context, co CommonOperations, chatId int64) ([]*ChatEmoji, error) {
+	rows, err := co.QueryContext(ctx, selectChatEmojiClause+`WHERE chat_id = $1 ORDER BY shortcode`, chatId)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*ChatEmoji, 0)
+	for rows.Next() {
+		e := ChatEmoji{}
+		if err := rows.Scan(provideScanToChatEmoji(&e)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &e)
+	}
+	return list, nil
+}
+
+func (db *DB) GetChatEmojis(ctx context.Context, chatId int64) ([]*ChatEmoji, error) {
+	return getChatEmojisCommon(ctx, db, chatId)
+}
+
+func (tx *Tx) GetChatEmojis(ctx context.Context, chatId int64) ([]*ChatEmoji, error) {
+	return getChatEmojisCommon(ctx, tx, chatId)
+}
+
+func (tx *Tx) GetChatEmojiByShortcode(ctx context.Context, chatId int64, shortcode string) (*ChatEmoji, error) {
+	row := tx.QueryRowContext(ctx, selectChatEmojiClause+`WHERE chat_id = $1 AND shortcode = $2`, chatId, shortcode)
+	e := ChatEmoji{}
+	err := row.Scan(provideScanToChatEmoji(&e)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &e, nil
+}
+
+// the reactions with this emoji are left, they are shown as the text shortcode
+func (tx *Tx) DeleteChatEmoji(ctx context.Context, chatId, id int64) (bool, error) {
+	res, err := tx.ExecContext(ctx, `DELETE FROM chat_emoji WHERE id = $1 AND chat_id = $2`, id, chatId)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
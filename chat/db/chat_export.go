@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/guregu/null"
+	"github.com/rotisserie/eris"
+	"time"
+)
+
+const ChatExportStatusPending = "pending"
+const ChatExportStatusProcessing = "processing"
+const ChatExportStatusReady = "ready"
+const ChatExportStatusFailed = "failed"
+
+type ChatExport struct {
+	Id             int64
+	ChatId         int64
+	OwnerId        int64
+	Status         string
+	FileItemUuid   *string
+	Filename       *string
+	Url            *string
+	CreateDateTime time.Time
+	FinishDateTime null.Time
+}
+
+const selectChatExportClause = `SELECT
+		id,
+		chat_id,
+		owner_id,
+		status,
+		file_item_uuid,
+		filename,
+		url,
+		create_date_time,
+		finish_date_time
+	FROM chat_export `
+
+func provideScanToChatExport(e *ChatExport) []any {
+	return []any{
+		&e.Id,
+		&e.ChatId,
+		&e.OwnerId,
+		&e.Status,
+		&e.FileItemUuid,
+		&e.Filename,
+		&e.Url,
+		&e.CreateDateTime,
+		&e.FinishDateTime,
+	}
+}
+
+func (tx *Tx) CreateChatExport(ctx context.Context, chatId int64, ownerId int64) (int64, error) {
+	res := tx.QueryRowContext(ctx, `INSERT INTO chat_export (chat_id, owner_id, status) VALUES ($1, $2, $3) RETURNING id`, chatId, ownerId, ChatExportStatusPending)
+	var id int64
+	if err := res.Scan(&id); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return id, nil
+}
+
+// prevents the user from queueing the same chat many times
+func (tx *Tx) HasUnfinishedChatExport(ctx context.Context, chatId int64, ownerId int64) (bool, error) {
+	var exists bool
+	row := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM chat_export WHERE chat_id = $1 AND owner_id = $2 AND status IN ($3, $4))`, chatId, ownerId, ChatExportStatusPending, ChatExportStatusProcessing)
+	if err := row.Scan(&exists); err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return exists, nil
+}
+
+func getChatExportsCommon(ctx context.Context, co CommonOperations, chatId int64, ownerId int64, limit, offset int) ([]*ChatExport, error) {
+	rows, err := co.QueryContext(ctx, selectChatExportClause+`WHERE chat_id = $1 AND owner_id = $2 ORDER BY id DESC LIMIT $3 OFFSET $4`, chatId, ownerId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*ChatExport, 0)
+	for rows.Next() {
+		e := ChatExport{}
+		if err := rows.Scan(provideScanToChatExport(&e)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &e)
+	}
+	return list, nil
+}
+
+func (db *DB) GetChatExports(ctx context.Context, chatId int64, ownerId int64, limit, offset int) ([]*ChatExport, error) {
+	return getChatExportsCommon(ctx, db, chatId, ownerId, limit, offset)
+}
+
+func (tx *Tx) GetChatExports(ctx context.Context, chatId int64, ownerId int64, limit, offset int) ([]*ChatExport, error) {
+	return getChatExportsCommon(ctx, tx, chatId, ownerId, limit, offset)
+}
+
+func getChatExportsCountCommon(ctx context.Context, co CommonOperations, chatId int64, ownerId int64) (int64, error) {
+	row := co.QueryRowContext(ctx, `SELECT count(*) FROM chat_export WHERE chat_id = $1 AND owner_id = $2`, chatId, ownerId)
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+func (db *DB) GetChatExportsCount(ctx context.Context, chatId int64, ownerId int64) (int64, error) {
+	return getChatExportsCountCommon(ctx, db, chatId, ownerId)
+}
+
+func (tx *Tx) GetChatExportsCount(ctx context.Context, chatId int64, ownerId int64) (int64, error) {
+	return getChatExportsCountCommon(ctx, tx, chatId, ownerId)
+}
+
+func (db *DB) GetPendingChatExportIds(ctx context.Context, limit int) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id FROM chat_export WHERE status = $1 ORDER BY id LIMIT $2`, ChatExportStatusPending, limit)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, id)
+	}
+	return list, nil
+}
+
+// marks the pending export as processing, returns nil if it was already taken
+func (tx *Tx) TakeChatExport(ctx context.Context, id int64) (*ChatExport, error) {
+	row := tx.QueryRowContext(ctx, `UPDATE chat_export SET status = $2 WHERE id = $1 AND status = $3 RETURNING id, chat_id, owner_id, status, file_item_uuid, filename, url, create_date_time, finish_date_time`, id, ChatExportStatusProcessing, ChatExportStatusPending)
+	e := ChatExport{}
+	err := row.Scan(provideScanToChatExport(&e)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &e, nil
+}
+
+func (tx *Tx) FinishChatExport(ctx context.Context, id int64, status string, fileItemUuid, filename, url *string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE chat_export SET status = $2, file_item_uuid = $3, filename = $4, url = $5, finish_date_time = utc_now() WHERE id = $1`, id, status, fileItemUuid, filename, url)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+// returns the portion of all the messages of the chat including thread replies and tombstones, ordered by id
+func getMessagesForExportCommon(ctx context.Context, co CommonOperations, chatId int64, limit int, afterMessageId int64) ([]*Message, error) {
+	rows, err := co.QueryContext(ctx, fmt.Sprintf(`%v
+			WHERE m.id > $2
+			ORDER BY m.id
+			LIMIT $1`, selectMessageClause(chatId)),
+		limit, afterMessageId)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*Message, 0)
+	for rows.Next() {
+		message := Message{ChatId: chatId, Reactions: make([]Reaction, 0)}
+		if err := rows.Scan(provideScanToMessage(&message)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		} else {
+			list = append(list, &message)
+		}
+	}
+
+	err = enrichMessagesWithReactions(ctx, co, chatId, list)
+	if err != nil {
+		return nil, fmt.Errorf("Got error during enriching messages with reactions: %v", err)
+	}
+
+	return list, nil
+}
+
+func (db *DB) GetMessagesForExport(ctx context.Context, chatId int64, limit int, afterMessageId int64) ([]*Message, error) {
+	return getMessagesForExportCommon(ctx, db, chatId, limit, afterMessageId)
+}
+
+func (tx *Tx) GetMessagesForExport(ctx context.Context, chatId int64, limit int, afterMessageId int64) ([]*Message, error) {
+	return getMessagesForExportCommon(ctx, tx, chatId, limit, afterMessageId)
+}
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/guregu/null"
+	"github.com/rotisserie/eris"
+	"nkonev.name/chat/dto"
+	"time"
+)
+
+type ChatImport struct {
+	Id               int64
+	OwnerId          int64
+	Source           string
+	ExternalChatId   string
+	ChatId           int64
+	ImportedMessages int64
+	Finished         bool
+	CreateDateTime   time.Time
+	FinishDateTime   null.Time
+}
+
+type ChatImportUserMapping struct {
+	OwnerId        int64
+	Source         string
+	ExternalUserId string
+	UserId         int64
+	Confirmed      bool // the mapping onto the importing user themselves is confirmed from the beginning
+}
+
+func (tx *Tx) GetChatImport(ctx context.Context, ownerId int64, source string, externalChatId string) (*ChatImport, error) {
+	row := tx.QueryRowContext(ctx, `SELECT id, owner_id, source, external_chat_id, chat_id, imported_messages, finished, create_date_time, finish_date_time FROM chat_import WHERE owner_id = $1 AND source = $2 AND external_chat_id = $3`, ownerId, source, externalChatId)
+	ci := ChatImport{}
+	err := row.Scan(&ci.Id, &ci.OwnerId, &ci.Source, &ci.ExternalChatId, &ci.ChatId, &ci.ImportedMessages, &ci.Finished, &ci.CreateDateTime, &ci.FinishDateTime)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &ci, nil
+}
+
+func (tx *Tx) CreateChatImport(ctx context.Context, ownerId int64, source string, externalChatId string, chatId int64) (int64, error) {
+	res := tx.QueryRowContext(ctx, `INSERT INTO chat_import (owner_id, source, external_chat_id, chat_id) VALUES ($1, $2, $3, $4) RETURNING id`, ownerId, source, externalChatId, chatId)
+	var id int64
+	if err := res.Scan(&id); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return id, nil
+}
+
+func (tx *Tx) AddChatImportedMessages(ctx context.Context, importId int64, count int) error {
+	_, err := tx.ExecContext(ctx, `UPDATE chat_import SET imported_messages = imported_messages + $2 WHERE id = $1`, importId, count)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+func (tx *Tx) FinishChatImport(ctx context.Context, importId int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE chat_import SET finished = true, finish_date_time = utc_now() WHERE id = $1`, importId)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+// returns the already imported external message ids mapped to the local ones
+func (tx *Tx) GetChatImportMessageIds(ctx context.Context, importId int64) (map[string]int64, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT external_id, message_id FROM chat_import_message WHERE import_id = $1`, importId)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	ret := map[string]int64{}
+	for rows.Next() {
+		var externalId string
+		var messageId int64
+		if err := rows.Scan(&externalId, &messageId); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		ret[externalId] = messageId
+	}
+	return ret, nil
+}
+
+func (tx *Tx) AddChatImportMessage(ctx context.Context, importId int64, externalId string, messageId int64) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO chat_import_message (import_id, external_id, message_id) VALUES ($1, $2, $3)`, importId, externalId, messageId)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+// unlike CreateMessage keeps the original timestamps and flags
+func (tx *Tx) ImportMessage(ctx context.Context, m *Message) (int64, error) {
+	if m == nil {
+		return 0, eris.New("message required")
+	} else if m.Text == "" {
+		return 0, eris.New("text required")
+	}
+
+	var embedMessageId *int64
+	var embedMessageType *string
+	if m.RequestEmbeddedMessageId != nil {
+		var reply = dto.EmbedMessageTypeReply
+		embedMessageId = m.RequestEmbeddedMessageId
+		embedMessageType = &reply
+	}
+
+	res := tx.QueryRowContext(ctx, fmt.Sprintf(`INSERT INTO message_chat_%v (text, owner_id, file_item_uuid, embed_message_id, embed_message_type, blog_post, thread_id, pinned, published, create_date_time, edit_date_time, imported_owner_name) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`, m.ChatId), m.Text, m.OwnerId, m.FileItemUuid, embedMessageId, embedMessageType, m.BlogPost, m.ThreadId, m.Pinned, m.Published, m.CreateDateTime, m.EditDateTime, m.ImportedOwnerName)
+	var id int64
+	if err := res.Scan(&id); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return id, nil
+}
+
+func (tx *Tx) ImportReaction(ctx context.Context, chatId int64, messageId int64, userId int64, reaction string) error {
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO message_reaction_chat_%v(user_id, message_id, reaction) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`, chatId), userId, messageId, reaction)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+func scanChatImportUserMappings(rows *sql.Rows) ([]ChatImportUserMapping, error) {
+	list := make([]ChatImportUserMapping, 0)
+	for rows.Next() {
+		m := ChatImportUserMapping{}
+		if err := rows.Scan(&m.OwnerId, &m.Source, &m.ExternalUserId, &m.UserId, &m.Confirmed); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, m)
+	}
+	return list, nil
+}
+
+func getChatImportUserMappingsCommon(ctx context.Context, co CommonOperations, ownerId int64, source string) ([]ChatImportUserMapping, error) {
+	rows, err := co.QueryContext(ctx, `SELECT owner_id, source, external_user_id, user_id, confirmed FROM chat_import_user_mapping WHERE owner_id = $1 AND source = $2 ORDER BY external_user_id`, ownerId, source)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	return scanChatImportUserMappings(rows)
+}
+
+func (db *DB) GetChatImportUserMappings(ctx context.Context, ownerId int64, source string) ([]ChatImportUserMapping, error) {
+	return getChatImportUserMappingsCommon(ctx, db, ownerId, source)
+}
+
+func (tx *Tx) GetChatImportUserMappings(ctx context.Context, ownerId int64, source string) ([]ChatImportUserMapping, error) {
+	return getChatImportUserMappingsCommon(ctx, tx, ownerId, source)
+}
+
+// the mappings made by the other importers onto userId, they wait for the confirmation of userId
+func (db *DB) GetIncomingChatImportUserMappings(ctx context.Context, userId int64) ([]ChatImportUserMapping, error) {
+	rows, err := db.QueryContext(ctx, `SELECT owner_id, source, external_user_id, user_id, confirmed FROM chat_import_user_mapping WHERE user_id = $1 AND owner_id <> $1 ORDER BY owner_id, source, external_user_id`, userId)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	return scanChatImportUserMappings(rows)
+}
+
+// changing the mapped user drops the confirmation of the previous one
+func (tx *Tx) PutChatImportUserMapping(ctx context.Context, ownerId int64, source string, externalUserId string, userId int64) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO chat_import_user_mapping (owner_id, source, external_user_id, user_id, confirmed) VALUES ($1, $2, $3, $4, $4 = $1) 
+		ON CONFLICT (owner_id, source, external_user_id) DO UPDATE SET user_id = EXCLUDED.user_id, confirmed = EXCLUDED.confirmed OR (chat_import_user_mapping.confirmed AND chat_import_user_mapping.user_id = EXCLUDED.user_id)`, ownerId, source, externalUserId, userId)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+func (tx *Tx) DeleteChatImportUserMapping(ctx context.Context, ownerId int64, source string, externalUserId string) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM chat_import_user_mapping WHERE owner_id = $1 AND source = $2 AND external_user_id = $3`, ownerId, source, externalUserId)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+// returns false if there is no such mapping onto userId
+func (tx *Tx) ConfirmChatImportUserMapping(ctx context.Context, ownerId int64, source string, externalUserId string, userId int64) (bool, error) {
+	res, err := tx.ExecContext(ctx, `UPDATE chat_import_user_mapping SET confirmed = TRUE WHERE owner_id = $1 AND source = $2 AND external_user_id = $3 AND user_id = $4`, ownerId, source, externalUserId, userId)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
+
+// the mapped user declines the mapping made by the importer
+func (tx *Tx) DeclineChatImportUserMapping(ctx context.Context, ownerId int64, source string, externalUserId string, userId int64) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM chat_import_user_mapping WHERE owner_id = $1 AND source = $2 AND external_user_id = $3 AND user_id = $4`, ownerId, source, externalUserId, userId)
+	return eris.Wrap(err, "error during interacting with db")
+}
@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/guregu/null"
+	"github.com/rotisserie/eris"
+	"time"
+)
+
+type ChatInviteLink struct {
+	Id             int64
+	ChatId         int64
+	Token          string
+	OwnerId        int64
+	MakeAdmin      bool
+	MaxUses        null.Int
+	Uses           int64
+	ExpireDateTime null.Time
+	RevokeDateTime null.Time
+	CreateDateTime time.Time
+}
+
+// the link can be used until it's revoked, expired or exhausted
+func (l *ChatInviteLink) IsUsable(now time.Time) bool {
+	if l.RevokeDateTime.Valid {
+		return false
+	}
+	if l.ExpireDateTime.Valid && !now.Before(l.ExpireDateTime.Time) {
+		return false
+	}
+	if l.MaxUses.Valid && l.Uses >= l.MaxUses.Int64 {
+		return false
+	}
+	return true
+}
+
+const selectChatInviteLinkClause = `SELECT
+		id,
+		chat_id,
+		token,
+		owner_id,
+		make_admin,
+		max_uses,
+		uses,
+		expire_date_time,
+		revoke_date_time,
+		create_date_time
+	FROM chat_invite_link `
+
+func provideScanToChatInviteLink(l *ChatInviteLink) []any {
+	return []any{
+		&l.Id,
+		&l.ChatId,
+		&l.Token,
+		&l.OwnerId,
+		&l.MakeAdmin,
+		&l.MaxUses,
+		&l.Uses,
+		&l.ExpireDateTime,
+		&l.RevokeDateTime,
+		&l.CreateDateTime,
+	}
+}
+
+func (tx *Tx) CreateChatInviteLink(ctx context.Context, chatId int64, ownerId int64, token string, makeAdmin bool, maxUses null.Int, expireDateTime null.Time) (*ChatInviteLink, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO chat_invite_link (chat_id, owner_id, token, make_admin, max_uses, expire_date_time) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, chat_id, token, owner_id, make_admin, max_uses, uses, expire_date_time, revoke_date_time, create_date_time`,
+		chatId, ownerId, token, makeAdmin, maxUses, expireDateTime)
+	l := ChatInviteLink{}
+	if err := row.Scan(provideScanToChatInviteLink(&l)[:]...); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &l, nil
+}
+
+func getChatInviteLinksCommon(ctx context.Context, co CommonOperations, chatId int64, limit, offset int) ([]*ChatInviteLink, error) {
+	rows, err := co.QueryContext(ctx, selectChatInviteLinkClause+`WHERE chat_id = $1 ORDER BY id DESC LIMIT $2 OFFSET $3`, chatId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*ChatInviteLink, 0)
+	for rows.Next() {
+		l := ChatInviteLink{}
+		if err := rows.Scan(provideScanToChatInviteLink(&l)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &l)
+	}
+	return list, nil
+}
+
+func (db *DB) GetChatInviteLinks(ctx context.Context, chatId int64, limit, offset int) ([]*ChatInviteLink, error) {
+	return getChatInviteLinksCommon(ctx, db, chatId, limit, offset)
+}
+
+func (tx *Tx) GetChatInviteLinks(ctx context.Context, chatId int64, limit, offset int) ([]*ChatInviteLink, error) {
+	return getChatInviteLinksCommon(ctx, tx, chatId, limit, offset)
+}
+
+func getChatInviteLinksCountCommon(ctx context.Context, co CommonOperations, chatId int64) (int64, error) {
+	row := co.QueryRowContext(ctx, `SELECT count(*) FROM chat_invite_link WHERE chat_id = $1`, chatId)
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+func (db *DB) GetChatInviteLinksCount(ctx context.Context, chatId int64) (int64, error) {
+	return getChatInviteLinksCountCommon(ctx, db, chatId)
+}
+
+func (tx *Tx) GetChatInviteLinksCount(ctx context.Context, chatId int64) (int64, error) {
+	return getChatInviteLinksCountCommon(ctx, tx, chatId)
+}
+
+// locks the link so the concurrent joins can't exceed max_uses
+func (tx *Tx) GetChatInviteLinkByTokenForUpdate(ctx context.Context, token string) (*ChatInviteLink, error) {
+	row := tx.QueryRowContext(ctx, selectChatInviteLinkClause+`WHERE token = $1 FOR UPDATE`, token)
+	l := ChatInviteLink{}
+	err := row.Scan(provideScanToChatInviteLink(&l)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &l, nil
+}
+
+// returns false if there is no such not revoked link in the chat
+func (tx *Tx) RevokeChatInviteLink(ctx context.Context, chatId int64, id int64) (bool, error) {
+	res, err := tx.ExecContext(ctx, `UPDATE chat_invite_link SET revoke_date_time = utc_now() WHERE chat_id = $1 AND id = $2 AND revoke_date_time IS NULL`, chatId, id)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
+
+func (tx *Tx) IncrementChatInviteLinkUses(ctx context.Context, id int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE chat_invite_link SET uses = uses + 1 WHERE id = $1`, id)
+	return eris.Wrap(err, "error during interacting with db")
+}
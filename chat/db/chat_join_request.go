@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/rotisserie/eris"
+	"time"
+)
+
+type ChatJoinRequest struct {
+	Id             int64
+	ChatId         int64
+	UserId         int64
+	CreateDateTime time.Time
+}
+
+const selectChatJoinRequestClause = `SELECT
+		id,
+		chat_id,
+		user_id,
+		create_date_time
+	FROM chat_join_request `
+
+func provideScanToChatJoinRequest(r *ChatJoinRequest) []any {
+	return []any{
+		&r.Id,
+		&r.ChatId,
+		&r.UserId,
+		&r.CreateDateTime,
+	}
+}
+
+// returns false if the user has already requested to join this chat
+func (tx *Tx) CreateChatJoinRequest(ctx context.Context, chatId int64, userId int64) (int64, bool, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO chat_join_request (chat_id, user_id) VALUES ($1, $2) ON CONFLICT (chat_id, user_id) DO NOTHING RETURNING id`, chatId, userId)
+	var id int64
+	err := row.Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, eris.Wrap(err, "error during interacting with db")
+	}
+	return id, true, nil
+}
+
+func getChatJoinRequestsCommon(ctx context.Context, co CommonOperations, chatId int64, limit, offset int) ([]*ChatJoinRequest, error) {
+	rows, err := co.QueryContext(ctx, selectChatJoinRequestClause+`WHERE chat_id = $1 ORDER BY id LIMIT $2 OFFSET $3`, chatId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*ChatJoinRequest, 0)
+	for rows.Next() {
+		r := ChatJoinRequest{}
+		if err := rows.Scan(provideScanToChatJoinRequest(&r)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &r)
+	}
+	return list, nil
+}
+
+func (db *DB) GetChatJoinRequests(ctx context.Context, chatId int64, limit, offset int) ([]*ChatJoinRequest, error) {
+	return getChatJoinRequestsCommon(ctx, db, chatId, limit, offset)
+}
+
+func (tx *Tx) GetChatJoinRequests(ctx context.Context, chatId int64, limit, offset int) ([]*ChatJoinRequest, error) {
+	return getChatJoinRequestsCommon(ctx, tx, chatId, limit, offset)
+}
+
+func getChatJoinRequestsCountCommon(ctx context.Context, co CommonOperations, chatId int64) (int64, error) {
+	row := co.QueryRowContext(ctx, `SELECT count(*) FROM chat_join_request WHERE chat_id = $1`, chatId)
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+func (db *DB) GetChatJoinRequestsCount(ctx context.Context, chatId int64) (int64, error) {
+	return getChatJoinRequestsCountCommon(ctx, db, chatId)
+}
+
+func (tx *Tx) GetChatJoinRequestsCount(ctx context.Context, chatId int64) (int64, error) {
+	return getChatJoinRequestsCountCommon(ctx, tx, chatId)
+}
+
+// removes the request of the chat, returns nil if it was already decided by another admin
+func (tx *Tx) TakeChatJoinRequest(ctx context.Context, chatId int64, id int64) (*ChatJoinRequest, error) {
+	row := tx.QueryRowContext(ctx, `DELETE FROM chat_join_request WHERE chat_id = $1 AND id = $2 RETURNING id, chat_id, user_id, create_date_time`, chatId, id)
+	r := ChatJoinRequest{}
+	err := row.Scan(provideScanToChatJoinRequest(&r)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &r, nil
+}
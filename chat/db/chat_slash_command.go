@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/rotisserie/eris"
+	"time"
+)
+
+type ChatSlashCommand struct {
+	Id             int64
+	ChatId         int64
+	Name           string
+	Description    string
+	Url            string
+	Secret         string
+	OwnerId        int64
+	CreateDateTime time.Time
+}
+
+const selectChatSlashCommandClause = `SELECT
+		id,
+		chat_id,
+		name,
+		description,
+		url,
+		secret,
+		owner_id,
+		create_date_time
+	FROM chat_slash_command `
+
+func provideScanToChatSlashCommand(cmd *ChatSlashCommand) []any {
+	return []any{
+		&cmd.Id,
+		&cmd.ChatId,
+		&cmd.Name,
+		&cmd.Description,
+		&cmd.Url,
+		&cmd.Secret,
+		&cmd.OwnerId,
+		&cmd.CreateDateTime,
+	}
+}
+
+func (tx *Tx) CreateChatSlashCommand(ctx context.Context, chatId int64, ownerId int64, name, description, url, secret string) (*ChatSlashCommand, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO chat_slash_command (chat_id, owner_id, name, description, url, secret) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, chat_id, name, description, url, secret, owner_id, create_date_time`,
+		chatId, ownerId, name, description, url, secret)
+	cmd := ChatSlashCommand{}
+	if err := row.Scan(provideScanToChatSlashCommand(&cmd)[:]...); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &cmd, nil
+}
+
+// returns nil if there is no such command in the chat
+func (tx *Tx) EditChatSlashCommand(ctx context.Context, chatId, commandId int64, name, description, url string) (*ChatSlashCommand, error) {
+	row := tx.QueryRowContext(ctx, `UPDATE chat_slash_command SET name = $3, description = $4, url = $5 WHERE chat_id = $1 AND id = $2
+		RETURNING id, chat_id, name, description, url, secret, owner_id, create_date_time`,
+		chatId, commandId, name, description, url)
+	cmd := ChatSlashCommand{}
+	err := row.Scan(provideScanToChatSlashCommand(&cmd)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &cmd, nil
+}
+
+func (tx *Tx) GetChatSlashCommands(ctx context.Context, chatId int64, limit, offset int) ([]*ChatSlashCommand, error) {
+	rows, err := tx.QueryContext(ctx, selectChatSlashCommandClause+`WHERE chat_id = $1 ORDER BY name LIMIT $2 OFFSET $3`, chatId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*ChatSlashCommand, 0)
+	for rows.Next() {
+		cmd := ChatSlashCommand{}
+		if err := rows.Scan(provideScanToChatSlashCommand(&cmd)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &cmd)
+	}
+	return list, nil
+}
+
+func (tx *Tx) GetChatSlashCommandsCount(ctx context.Context, chatId int64) (int64, error) {
+	row := tx.QueryRowContext(ctx, `SELECT count(*) FROM chat_slash_command WHERE chat_id = $1`, chatId)
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+// returns nil if there is no such command in the chat
+func (tx *Tx) GetChatSlashCommandByName(ctx context.Context, chatId int64, name string) (*ChatSlashCommand, error) {
+	row := tx.QueryRowContext(ctx, selectChatSlashCommandClause+`WHERE chat_id = $1 AND name = $2`, chatId, name)
+	cmd := ChatSlashCommand{}
+	err := row.Scan(provideScanToChatSlashCommand(&cmd)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &cmd, nil
+}
+
+// returns false if there is no such command in the chat
+func (tx *Tx) DeleteChatSlashCommand(ctx context.Context, chatId, commandId int64) (bool, error) {
+	res, err := tx.ExecContext(ctx, `DELETE FROM chat_slash_command WHERE chat_id = $1 AND id = $2`, chatId, commandId)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
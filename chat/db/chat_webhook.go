@@ -0,0 +1,270 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/rotisserie/eris"
+	"strings"
+	"time"
+)
+
+type ChatIncomingWebhook struct {
+	Id             int64
+	ChatId         int64
+	Name           string
+	OwnerId        int64
+	CreateDateTime time.Time
+}
+
+type ChatOutgoingWebhook struct {
+	Id             int64
+	ChatId         int64
+	Url            string
+	Secret         string
+	EventTypes     []string
+	OwnerId        int64
+	CreateDateTime time.Time
+}
+
+type OutgoingWebhookDelivery struct {
+	Id        int64
+	WebhookId int64
+	Url       string
+	Secret    string
+	EventType string
+	Payload   []byte
+	Attempts  int
+}
+
+const selectChatIncomingWebhookClause = `SELECT
+		id,
+		chat_id,
+		name,
+		owner_id,
+		create_date_time
+	FROM chat_incoming_webhook `
+
+func provideScanToChatIncomingWebhook(w *ChatIncomingWebhook) []any {
+	return []any{
+		&w.Id,
+		&w.ChatId,
+		&w.Name,
+		&w.OwnerId,
+		&w.CreateDateTime,
+	}
+}
+
+// the event types are joined in order to not deal with the arrays of the driver, they don't contain commas
+const selectChatOutgoingWebhookClause = `SELECT
+		id,
+		chat_id,
+		url,
+		secret,
+		array_to_string(event_types, ','),
+		owner_id,
+		create_date_time
+	FROM chat_outgoing_webhook `
+
+type outgoingWebhookScan struct {
+	ChatOutgoingWebhook
+	eventTypes string
+}
+
+func provideScanToChatOutgoingWebhook(w *outgoingWebhookScan) []any {
+	return []any{
+		&w.Id,
+		&w.ChatId,
+		&w.Url,
+		&w.Secret,
+		&w.eventTypes,
+		&w.OwnerId,
+		&w.CreateDateTime,
+	}
+}
+
+func (w *outgoingWebhookScan) toChatOutgoingWebhook() *ChatOutgoingWebhook {
+	ret := w.ChatOutgoingWebhook
+	ret.EventTypes = []string{}
+	if w.eventTypes != "" {
+		ret.EventTypes = strings.Split(w.eventTypes, ",")
+	}
+	return &ret
+}
+
+// the token isn't stored, only its hash
+func (tx *Tx) CreateChatIncomingWebhook(ctx context.Context, chatId int64, ownerId int64, name, tokenHash string) (*ChatIncomingWebhook, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO chat_incoming_webhook (chat_id, owner_id, name, token_hash) VALUES ($1, $2, $3, $4)
+		RETURNING id, chat_id, name, owner_id, create_date_time`,
+		chatId, ownerId, name, tokenHash)
+	w := ChatIncomingWebhook{}
+	if err := row.Scan(provideScanToChatIncomingWebhook(&w)[:]...); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &w, nil
+}
+
+func (tx *Tx) GetChatIncomingWebhooks(ctx context.Context, chatId int64, limit, offset int) ([]*ChatIncomingWebhook, error) {
+	rows, err := tx.QueryContext(ctx, selectChatIncomingWebhookClause+`WHERE chat_id = $1 ORDER BY id DESC LIMIT $2 OFFSET $3`, chatId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*ChatIncomingWebhook, 0)
+	for rows.Next() {
+		w := ChatIncomingWebhook{}
+		if err := rows.Scan(provideScanToChatIncomingWebhook(&w)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &w)
+	}
+	return list, nil
+}
+
+func (tx *Tx) GetChatIncomingWebhooksCount(ctx context.Context, chatId int64) (int64, error) {
+	row := tx.QueryRowContext(ctx, `SELECT count(*) FROM chat_incoming_webhook WHERE chat_id = $1`, chatId)
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+// returns nil if there is no such webhook
+func (tx *Tx) GetChatIncomingWebhookByTokenHash(ctx context.Context, tokenHash string) (*ChatIncomingWebhook, error) {
+	row := tx.QueryRowContext(ctx, selectChatIncomingWebhookClause+`WHERE token_hash = $1`, tokenHash)
+	w := ChatIncomingWebhook{}
+	err := row.Scan(provideScanToChatIncomingWebhook(&w)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &w, nil
+}
+
+// returns false if there is no such webhook in the chat
+func (tx *Tx) DeleteChatIncomingWebhook(ctx context.Context, chatId, webhookId int64) (bool, error) {
+	res, err := tx.ExecContext(ctx, `DELETE FROM chat_incoming_webhook WHERE chat_id = $1 AND id = $2`, chatId, webhookId)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
+
+func (tx *Tx) CreateChatOutgoingWebhook(ctx context.Context, chatId int64, ownerId int64, url, secret string, eventTypes []string) (*ChatOutgoingWebhook, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO chat_outgoing_webhook (chat_id, owner_id, url, secret, event_types) VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, chat_id, url, secret, array_to_string(event_types, ','), owner_id, create_date_time`,
+		chatId, ownerId, url, secret, eventTypes)
+	w := outgoingWebhookScan{}
+	if err := row.Scan(provideScanToChatOutgoingWebhook(&w)[:]...); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return w.toChatOutgoingWebhook(), nil
+}
+
+// returns nil if there is no such webhook in the chat
+func (tx *Tx) EditChatOutgoingWebhook(ctx context.Context, chatId, webhookId int64, url string, eventTypes []string) (*ChatOutgoingWebhook, error) {
+	row := tx.QueryRowContext(ctx, `UPDATE chat_outgoing_webhook SET url = $3, event_types = $4 WHERE chat_id = $1 AND id = $2
+		RETURNING id, chat_id, url, secret, array_to_string(event_types, ','), owner_id, create_date_time`,
+		chatId, webhookId, url, eventTypes)
+	w := outgoingWebhookScan{}
+	err := row.Scan(provideScanToChatOutgoingWebhook(&w)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return w.toChatOutgoingWebhook(), nil
+}
+
+func (tx *Tx) GetChatOutgoingWebhooks(ctx context.Context, chatId int64, limit, offset int) ([]*ChatOutgoingWebhook, error) {
+	rows, err := tx.QueryContext(ctx, selectChatOutgoingWebhookClause+`WHERE chat_id = $1 ORDER BY id DESC LIMIT $2 OFFSET $3`, chatId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*ChatOutgoingWebhook, 0)
+	for rows.Next() {
+		w := outgoingWebhookScan{}
+		if err := rows.Scan(provideScanToChatOutgoingWebhook(&w)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, w.toChatOutgoingWebhook())
+	}
+	return list, nil
+}
+
+func (tx *Tx) GetChatOutgoingWebhooksCount(ctx context.Context, chatId int64) (int64, error) {
+	row := tx.QueryRowContext(ctx, `SELECT count(*) FROM chat_outgoing_webhook WHERE chat_id = $1`, chatId)
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+// the pending deliveries are removed along with the webhook
+func (tx *Tx) DeleteChatOutgoingWebhook(ctx context.Context, chatId, webhookId int64) (bool, error) {
+	res, err := tx.ExecContext(ctx, `DELETE FROM chat_outgoing_webhook WHERE chat_id = $1 AND id = $2`, chatId, webhookId)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
+
+// enqueues the event for every webhook of the chat which is subscribed to it
+func (tx *Tx) CreateOutgoingWebhookDeliveries(ctx context.Context, chatId int64, eventType string, payload []byte) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO chat_outgoing_webhook_delivery (webhook_id, event_type, payload)
+		SELECT id, $2, $3::jsonb FROM chat_outgoing_webhook WHERE chat_id = $1 AND $2 = ANY(event_types)`,
+		chatId, eventType, string(payload))
+	if err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	return nil
+}
+
+func (db *DB) GetDueOutgoingWebhookDeliveries(ctx context.Context, limit int) ([]*OutgoingWebhookDelivery, error) {
+	rows, err := db.QueryContext(ctx, `SELECT d.id, d.webhook_id, w.url, w.secret, d.event_type, d.payload, d.attempts
+		FROM chat_outgoing_webhook_delivery d JOIN chat_outgoing_webhook w ON w.id = d.webhook_id
+		WHERE d.next_attempt_date_time <= utc_now() ORDER BY d.next_attempt_date_time, d.id LIMIT $1`, limit)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*OutgoingWebhookDelivery, 0)
+	for rows.Next() {
+		d := OutgoingWebhookDelivery{}
+		if err := rows.Scan(&d.Id, &d.WebhookId, &d.Url, &d.Secret, &d.EventType, &d.Payload, &d.Attempts); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &d)
+	}
+	return list, nil
+}
+
+func (db *DB) DeleteOutgoingWebhookDelivery(ctx context.Context, deliveryId int64) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM chat_outgoing_webhook_delivery WHERE id = $1`, deliveryId)
+	if err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	return nil
+}
+
+func (db *DB) PostponeOutgoingWebhookDelivery(ctx context.Context, deliveryId int64, attempts int, delay time.Duration) error {
+	_, err := db.ExecContext(ctx, `UPDATE chat_outgoing_webhook_delivery SET attempts = $2, next_attempt_date_time = utc_now() + make_interval(secs => $3) WHERE id = $1`, deliveryId, attempts, delay.Seconds())
+	if err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/rotisserie/eris"
+)
+
+type BlocklistEntry struct {
+	Id             int64
+	ChatId         null.Int // null means the entry is global
+	Pattern        string
+	Regex          bool
+	Action         string
+	CreateDateTime time.Time
+}
+
+const selectBlocklistEntryClause = `SELECT
+		id,
+		chat_id,
+		pattern,
+		regex,
+		action,
+		create_date_time
+	FROM blocklist_entry `
+
+func provideScanToBlocklistEntry(e *BlocklistEntry) []any {
+	return []any{
+		&e.Id,
+		&e.ChatId,
+		&e.Pattern,
+		&e.Regex,
+		&e.Action,
+		&e.CreateDateTime,
+	}
+}
+
+func scanBlocklistEntries(rows *sql.Rows) ([]*BlocklistEntry, error) {
+	defer rows.Close()
+	list := make([]*BlocklistEntry, 0)
+	for rows.Next() {
+		e := BlocklistEntry{}
+		if err := rows.Scan(provideScanToBlocklistEntry(&e)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &e)
+	}
+	return list, nil
+}
+
+func (tx *Tx) CreateBlocklistEntry(ctx context.Context, chatId null.Int, pattern string, regex bool, action string) (*BlocklistEntry, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO blocklist_entry (chat_id, pattern, regex, action) VALUES ($1, $2, $3, $4) RETURNING id, chat_id, pattern, regex, action, create_date_time`,
+		chatId, pattern, regex, action)
+	e := BlocklistEntry{}
+	if err := row.Scan(provideScanToBlocklistEntry(&e)[:]...); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &e, nil
+}
+
+// the null chat means the global entries
+func (tx *Tx) GetBlocklistEntries(ctx context.Context, chatId null.Int) ([]*BlocklistEntry, error) {
+	rows, err := tx.QueryContext(ctx, selectBlocklistEntryClause+`WHERE chat_id IS NOT DISTINCT FROM $1 ORDER BY id`, chatId)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return scanBlocklistEntries(rows)
+}
+
+// the global entries and the entries of the chat, they are checked together
+func (tx *Tx) GetEffectiveBlocklistEntries(ctx context.Context, chatId int64) ([]*BlocklistEntry, error) {
+	rows, err := tx.QueryContext(ctx, selectBlocklistEntryClause+`WHERE chat_id IS NULL OR chat_id = $1 ORDER BY id`, chatId)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return scanBlocklistEntries(rows)
+}
+
+func (tx *Tx) DeleteBlocklistEntry(ctx context.Context, chatId null.Int, id int64) (bool, error) {
+	res, err := tx.ExecContext(ctx, `DELETE FROM blocklist_entry WHERE id = $1 AND chat_id IS NOT DISTINCT FROM $2`, id, chatId)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
+
+// the identical messages of the user in the chat, the deleted ones are counted as well, the edited message itself is excluded
+func (tx *Tx) GetRecentIdenticalMessagesCount(ctx context.Context, chatId, ownerId int64, text string, window time.Duration, excludeMessageId int64) (int64, error) {
+	var count int64
+	row := tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT count(*) FROM message_chat_%v WHERE owner_id = $1 AND text = $2 AND create_date_time > utc_now() - make_interval(secs => $3) AND id <> $4`, chatId), ownerId, text, window.Seconds(), excludeMessageId)
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+// the moment when the user has joined the chat, null if the user isn't a participant
+func (tx *Tx) GetParticipantCreateDateTime(ctx context.Context, userId, chatId int64) (null.Time, error) {
+	var createDateTime null.Time
+	row := tx.QueryRowContext(ctx, `SELECT create_date_time FROM chat_participant WHERE user_id = $1 AND chat_id = $2`, userId, chatId)
+	err := row.Scan(&createDateTime)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return createDateTime, nil
+	}
+	if err != nil {
+		return createDateTime, eris.Wrap(err, "error during interacting with db")
+	}
+	return createDateTime, nil
+}
+
+type HeldMessage struct {
+	Id               int64
+	ChatId           int64
+	OwnerId          int64
+	Text             string
+	FileItemUuid     *string
+	EmbedMessageId   *int64
+	EmbedChatId      *int64
+	EmbedMessageType *string
+	ThreadId         *int64
+	Reason           string
+	CreateDateTime   time.Time
+}
+
+const selectHeldMessageClause = `SELECT
+		id,
+		chat_id,
+		owner_id,
+		text,
+		file_item_uuid,
+		embed_message_id,
+		embed_chat_id,
+		embed_message_type,
+		thread_id,
+		reason,
+		create_date_time
+	FROM held_message `
+
+func provideScanToHeldMessage(m *HeldMessage) []any {
+	return []any{
+		&m.Id,
+		&m.ChatId,
+		&m.OwnerId,
+		&m.Text,
+		&m.FileItemUuid,
+		&m.EmbedMessageId,
+		&m.EmbedChatId,
+		&m.EmbedMessageType,
+		&m.ThreadId,
+		&m.Reason,
+		&m.CreateDateTime,
+	}
+}
+
+func (tx *Tx) CreateHeldMessage(ctx context.Context, m *HeldMessage) (int64, error) {
+	if m == nil {
+		return 0, eris.New("message required")
+	} else if m.Text == "" {
+		return 0, eris.New("text required")
+	}
+
+	res := tx.QueryRowContext(ctx, `INSERT INTO held_message (chat_id, owner_id, text, file_item_uuid, embed_message_id, embed_chat_id, embed_message_type, thread_id, reason) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		m.ChatId, m.OwnerId, m.Text, m.FileItemUuid, m.EmbedMessageId, m.EmbedChatId, m.EmbedMessageType, m.ThreadId, m.Reason)
+	var id int64
+	if err := res.Scan(&id); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return id, nil
+}
+
+// the queue of the chat, the oldest message goes first
+func (tx *Tx) GetHeldMessages(ctx context.Context, chatId int64, limit, offset int) ([]*HeldMessage, error) {
+	rows, err := tx.QueryContext(ctx, selectHeldMessageClause+`WHERE chat_id = $1 ORDER BY id LIMIT $2 OFFSET $3`, chatId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*HeldMessage, 0)
+	for rows.Next() {
+		m := HeldMessage{}
+		if err := rows.Scan(provideScanToHeldMessage(&m)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &m)
+	}
+	return list, nil
+}
+
+func (tx *Tx) GetHeldMessagesCount(ctx context.Context, chatId int64) (int64, error) {
+	var count int64
+	row := tx.QueryRowContext(ctx, `SELECT count(*) FROM held_message WHERE chat_id = $1`, chatId)
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+// takes the message for the decision, the row is removed, so two moderators can't approve the same message twice
+func (tx *Tx) TakeHeldMessage(ctx context.Context, chatId, id int64) (*HeldMessage, error) {
+	row := tx.QueryRowContext(ctx, `DELETE FROM held_message WHERE chat_id = $1 AND id = $2 RETURNING id, chat_id, owner_id, text, file_item_uuid, embed_message_id, embed_chat_id, embed_message_type, thread_id, reason, create_date_time`, chatId, id)
+	m := HeldMessage{}
+	err := row.Scan(provideScanToHeldMessage(&m)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &m, nil
+}
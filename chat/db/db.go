@@ -40,9 +40,9 @@ type MigrationsConfig struct {
 	AppendTestData bool
 }
 
-type UserAdminDbDTO struct {
+type UserRoleDbDTO struct {
 	UserId int64
-	Admin  bool
+	Role   string
 }
 
 // enumerates common tx and non-tx operations
@@ -56,9 +56,9 @@ type CommonOperations interface {
 	IterateOverAllParticipantIds(ctx context.Context, consumer func(participantIds []int64) error) error
 	IterateOverCoChattedParticipantIds(ctx context.Context, participantId int64, consumer func(participantIds []int64) error) error
 	GetParticipantsCount(ctx context.Context, chatId int64) (int, error)
-	IsAdmin(ctx context.Context, userId int64, chatId int64) (bool, error)
-	IsAdminBatch(ctx context.Context, userId int64, chatIds []int64) (map[int64]bool, error)
-	IsAdminBatchByParticipants(ctx context.Context, userIds []int64, chatId int64) ([]UserAdminDbDTO, error)
+	GetParticipantRole(ctx context.Context, userId int64, chatId int64) (string, error)
+	GetParticipantRoleBatch(ctx context.Context, userId int64, chatIds []int64) (map[int64]string, error)
+	GetRolesBatchByParticipants(ctx context.Context, userIds []int64, chatId int64) ([]UserRoleDbDTO, error)
 	IsParticipant(ctx context.Context, userId int64, chatId int64) (bool, error)
 	GetChat(ctx context.Context, performPersonalization bool, participantId, chatId int64) (*Chat, error)
 	GetChatWithParticipants(ctx context.Context, performPersonalization bool, behalfParticipantId, chatId int64, participantsSize, participantsOffset int) (*ChatWithParticipants, error)
@@ -66,7 +66,8 @@ type CommonOperations interface {
 	GetMessages(ctx context.Context, chatId int64, limit int, startingFromItemId *int64, includeStartingFrom, reverse bool, searchString string) ([]*Message, error)
 	GetMessage(ctx context.Context, chatId int64, userId int64, messageId int64) (*Message, error)
 	GetUnreadMessagesCount(ctx context.Context, chatId int64, userId int64) (int64, error)
-	SetAdmin(ctx context.Context, userId int64, chatId int64, newAdmin bool) error
+	GetMessageDraft(ctx context.Context, userId, chatId int64) (*MessageDraft, error)
+	SetParticipantRole(ctx context.Context, userId int64, chatId int64, role string) error
 	GetChatBasic(ctx context.Context, chatId int64) (*BasicChatDto, error)
 	GetChatsBasic(ctx context.Context, chatIds map[int64]bool, behalfParticipantId int64) (map[int64]*BasicChatDtoExtended, error)
 	GetBlogPostsByLimitOffset(ctx context.Context, reverse bool, limit int, offset int) ([]*Blog, error)
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/guregu/null"
+	"github.com/rotisserie/eris"
+	"time"
+)
+
+type LinkPreview struct {
+	Url         string
+	Title       null.String
+	Description null.String
+	ImageUrl    null.String
+	SiteName    null.String
+}
+
+// returns found = false in case the url isn't cached or the cached value is older than notOlderThan,
+// the nil preview with found = true means the page was fetched, but it has nothing to show
+func (db *DB) GetCachedLinkPreview(ctx context.Context, url string, notOlderThan time.Time) (*LinkPreview, bool, error) {
+	row := db.QueryRowContext(ctx, `SELECT found, title, description, image_url, site_name FROM link_preview_cache WHERE url = $1 AND fetch_date_time >= $2`, url, notOlderThan)
+	var found bool
+	lp := LinkPreview{Url: url}
+	err := row.Scan(&found, &lp.Title, &lp.Description, &lp.ImageUrl, &lp.SiteName)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, eris.Wrap(err, "error during interacting with db")
+	}
+	if !found {
+		return nil, true, nil
+	}
+	return &lp, true, nil
+}
+
+func (db *DB) PutCachedLinkPreview(ctx context.Context, url string, lp *LinkPreview) error {
+	var title, description, imageUrl, siteName null.String
+	if lp != nil {
+		title, description, imageUrl, siteName = lp.Title, lp.Description, lp.ImageUrl, lp.SiteName
+	}
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO link_preview_cache (url, found, title, description, image_url, site_name) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (url) DO UPDATE SET found = EXCLUDED.found, title = EXCLUDED.title, description = EXCLUDED.description, image_url = EXCLUDED.image_url, site_name = EXCLUDED.site_name, fetch_date_time = utc_now()
+	`, url, lp != nil, title, description, imageUrl, siteName)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+func (db *DB) DeleteCachedLinkPreviews(ctx context.Context, olderThan time.Time) (int64, error) {
+	res, err := db.ExecContext(ctx, `DELETE FROM link_preview_cache WHERE fetch_date_time < $1`, olderThan)
+	if err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected, nil
+}
+
+// the nil preview removes the card, the update is skipped in case the message was changed to have another link meanwhile
+func (db *DB) SetMessageLinkPreview(ctx context.Context, chatId int64, messageId int64, expectedText string, lp *LinkPreview) (bool, error) {
+	var url, title, description, imageUrl, siteName null.String
+	if lp != nil {
+		url, title, description, imageUrl, siteName = null.StringFrom(lp.Url), lp.Title, lp.Description, lp.ImageUrl, lp.SiteName
+	}
+	res, err := db.ExecContext(ctx, fmt.Sprintf(`UPDATE message_chat_%v SET link_preview_url = $3, link_preview_title = $4, link_preview_description = $5, link_preview_image_url = $6, link_preview_site_name = $7 WHERE id = $1 AND text = $2 AND deleted_date_time IS NULL`, chatId), messageId, expectedText, url, title, description, imageUrl, siteName)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
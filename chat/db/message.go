@@ -10,7 +10,10 @@ import (
 	"math"
 	"nkonev.name/chat/dto"
 	"nkonev.name/chat/utils"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 )
 
 const MessageNotFoundId = 0
@@ -19,6 +22,7 @@ type Reaction struct {
 	MessageId int64
 	UserId    int64
 	Reaction  string
+	EmojiUrl  null.String // the image of the custom emoji of the chat
 }
 
 type Message struct {
@@ -50,9 +54,31 @@ type Message struct {
 	BlogPost    bool
 	Published   bool
 	Reactions   []Reaction
+	Poll        *Poll
+
+	ThreadId                *int64
+	ThreadReplyCount        null.Int
+	ThreadLastReplyDateTime null.Time
+
+	DeletedDateTime null.Time
+
+	LinkPreviewUrl         null.String
+	LinkPreviewTitle       null.String
+	LinkPreviewDescription null.String
+	LinkPreviewImageUrl    null.String
+	LinkPreviewSiteName    null.String
+
+	ImportedOwnerName null.String // the author of the imported message who isn't mapped onto a local user
+
+	SearchHighlight *string
 }
 
 func selectMessageClause(chatId int64) string {
+	return selectMessageClauseWithAdditionalColumns(chatId, "")
+}
+
+// additionalColumns should start with a comma
+func selectMessageClauseWithAdditionalColumns(chatId int64, additionalColumns string) string {
 	return fmt.Sprintf(`SELECT 
     		m.id, 
     		m.text, 
@@ -70,11 +96,50 @@ func selectMessageClause(chatId int64) string {
 			m.pinned,
 			m.pin_promoted,
 			m.blog_post,
-			m.published
+			m.published,
+			m.thread_id,
+			mt.reply_count,
+			mt.last_reply_date_time,
+			m.deleted_date_time,
+			m.link_preview_url,
+			m.link_preview_title,
+			m.link_preview_description,
+			m.link_preview_image_url,
+			m.link_preview_site_name,
+			m.imported_owner_name
+			%s
 		FROM message_chat_%v m 
 		LEFT JOIN message_chat_%v me 
-			ON (m.embed_message_id = me.id AND m.embed_message_type = '%v')
-		`, chatId, chatId, dto.EmbedMessageTypeReply)
+			ON (m.embed_message_id = me.id AND m.embed_message_type = '%v' AND me.deleted_date_time IS NULL)
+		LEFT JOIN message_thread mt 
+			ON (mt.chat_id = %v AND mt.root_message_id = m.id)
+		`, additionalColumns, chatId, chatId, dto.EmbedMessageTypeReply, chatId)
+}
+
+// converts user's input to the prefix-matching tsquery, so "gen mess" becomes "gen:* & mess:*"
+// returns an empty string when there is nothing to search
+func toPrefixTsQuery(searchString string) string {
+	words := strings.FieldsFunc(searchString, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, word+":*")
+	}
+	return strings.Join(terms, " & ")
+}
+
+// tombstones are never found
+func messageSearchCondition(tsQueryParamNumber int) string {
+	return fmt.Sprintf("m.text_search @@ to_tsquery(message_search_config(), $%v) AND m.deleted_date_time IS NULL", tsQueryParamNumber)
+}
+
+func messageSearchRank(tsQueryParamNumber int) string {
+	return fmt.Sprintf("ts_rank(m.text_search, to_tsquery(message_search_config(), $%v))", tsQueryParamNumber)
+}
+
+func messageSearchHighlightColumn(tsQueryParamNumber int) string {
+	return fmt.Sprintf(", ts_headline(message_search_config(), strip_tags(m.text), to_tsquery(message_search_config(), $%v), 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5') as search_highlight", tsQueryParamNumber)
 }
 
 func provideScanToMessage(message *Message) []any {
@@ -96,11 +161,21 @@ func provideScanToMessage(message *Message) []any {
 		&message.PinPromoted,
 		&message.BlogPost,
 		&message.Published,
+		&message.ThreadId,
+		&message.ThreadReplyCount,
+		&message.ThreadLastReplyDateTime,
+		&message.DeletedDateTime,
+		&message.LinkPreviewUrl,
+		&message.LinkPreviewTitle,
+		&message.LinkPreviewDescription,
+		&message.LinkPreviewImageUrl,
+		&message.LinkPreviewSiteName,
+		&message.ImportedOwnerName,
 	}
 }
 
 func selectMessageReactionsClause(chatId int64) string {
-	return fmt.Sprintf("SELECT user_id, message_id, reaction FROM message_reaction_chat_%v ", chatId)
+	return fmt.Sprintf("SELECT r.user_id, r.message_id, r.reaction, e.url FROM message_reaction_chat_%v r LEFT JOIN chat_emoji e ON e.chat_id = %v AND ':' || e.shortcode || ':' = r.reaction ", chatId, chatId)
 }
 
 // see also its copy in aaa::UserListViewRepository
@@ -109,7 +184,7 @@ func getMessagesCommon(ctx context.Context, co CommonOperations, chatId int64, l
 	var err error
 
 	// startingFromItemId is used as the top or the bottom limit of the portion
-	list, err = getMessagesSimple(ctx, co, chatId, limit, startingFromItemId, includeStartingFrom, reverse, searchString)
+	list, err = getMessagesSimple(ctx, co, chatId, nil, limit, startingFromItemId, includeStartingFrom, reverse, searchString)
 	if err != nil {
 		return nil, eris.Wrap(err, "error during interacting with db")
 	}
@@ -119,11 +194,17 @@ func getMessagesCommon(ctx context.Context, co CommonOperations, chatId int64, l
 		return nil, fmt.Errorf("Got error during enriching messages with reactions: %v", err)
 	}
 
+	err = enrichMessagesWithPolls(ctx, co, chatId, list)
+	if err != nil {
+		return nil, fmt.Errorf("Got error during enriching messages with polls: %v", err)
+	}
+
 	return list, nil
 }
 
 // implements keyset pagination
-func getMessagesSimple(ctx context.Context, co CommonOperations, chatId int64, limit int, startingFromItemId0 *int64, includeStartingFrom, reverse bool, searchString string) ([]*Message, error) {
+// threadId == nil means the main timeline, otherwise the replies of the thread
+func getMessagesSimple(ctx context.Context, co CommonOperations, chatId int64, threadId *int64, limit int, startingFromItemId0 *int64, includeStartingFrom, reverse bool, searchString string) ([]*Message, error) {
 	list := make([]*Message, 0)
 
 	// see also getSafeDefaultUserId() in aaa
@@ -159,17 +240,27 @@ func getMessagesSimple(ctx context.Context, co CommonOperations, chatId int64, l
 		}
 		nonEquality = fmt.Sprintf("m.id %v $2", s)
 	}
+
+	threadCondition := "m.thread_id IS NULL"
+	if threadId != nil {
+		threadCondition = fmt.Sprintf("m.thread_id = %v", *threadId)
+	}
+
 	var err error
 	var rows *sql.Rows
 	if searchString != "" {
-		searchStringPercents := "%" + searchString + "%"
+		tsQuery := toPrefixTsQuery(searchString)
+		if tsQuery == "" {
+			return list, nil
+		}
 		rows, err = co.QueryContext(ctx, fmt.Sprintf(`%v
 			WHERE 
 		    	    %s 
-				AND strip_tags(m.text) ILIKE $3 
+				AND %s 
+				AND %s 
 			ORDER BY m.id %s 
-			LIMIT $1`, selectMessageClause(chatId), nonEquality, order),
-			limit, startingFromItemIdVal, searchStringPercents)
+			LIMIT $1`, selectMessageClauseWithAdditionalColumns(chatId, messageSearchHighlightColumn(3)), nonEquality, threadCondition, messageSearchCondition(3), order),
+			limit, startingFromItemIdVal, tsQuery)
 		if err != nil {
 			return nil, eris.Wrap(err, "error during interacting with db")
 		}
@@ -178,8 +269,9 @@ func getMessagesSimple(ctx context.Context, co CommonOperations, chatId int64, l
 		rows, err = co.QueryContext(ctx, fmt.Sprintf(`%v
 			WHERE 
 				  %s 
+				AND %s 
 			ORDER BY m.id %s 
-			LIMIT $1`, selectMessageClause(chatId), nonEquality, order),
+			LIMIT $1`, selectMessageClause(chatId), nonEquality, threadCondition, order),
 			limit, startingFromItemIdVal)
 		if err != nil {
 			return nil, eris.Wrap(err, "error during interacting with db")
@@ -189,7 +281,11 @@ func getMessagesSimple(ctx context.Context, co CommonOperations, chatId int64, l
 
 	for rows.Next() {
 		message := Message{ChatId: chatId, Reactions: make([]Reaction, 0)}
-		if err := rows.Scan(provideScanToMessage(&message)[:]...); err != nil {
+		dest := provideScanToMessage(&message)
+		if searchString != "" {
+			dest = append(dest, &message.SearchHighlight)
+		}
+		if err := rows.Scan(dest[:]...); err != nil {
 			return nil, eris.Wrap(err, "error during interacting with db")
 		} else {
 			list = append(list, &message)
@@ -207,6 +303,177 @@ func (tx *Tx) GetMessages(ctx context.Context, chatId int64, limit int, starting
 	return getMessagesCommon(ctx, tx, chatId, limit, startingFromItemId, includeStartingFrom, reverse, searchString)
 }
 
+// returns the most relevant messages first
+func searchMessagesRankedCommon(ctx context.Context, co CommonOperations, chatId int64, limit, offset int, searchString string) ([]*Message, error) {
+	list := make([]*Message, 0)
+
+	tsQuery := toPrefixTsQuery(searchString)
+	if tsQuery == "" {
+		return list, nil
+	}
+
+	rows, err := co.QueryContext(ctx, fmt.Sprintf(`%v
+			WHERE %s 
+			ORDER BY %s DESC, m.id DESC 
+			LIMIT $1 OFFSET $2`, selectMessageClauseWithAdditionalColumns(chatId, messageSearchHighlightColumn(3)), messageSearchCondition(3), messageSearchRank(3)),
+		limit, offset, tsQuery)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		message := Message{ChatId: chatId, Reactions: make([]Reaction, 0)}
+		if err := rows.Scan(append(provideScanToMessage(&message), &message.SearchHighlight)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		} else {
+			list = append(list, &message)
+		}
+	}
+
+	err = enrichMessagesWithReactions(ctx, co, chatId, list)
+	if err != nil {
+		return nil, fmt.Errorf("Got error during enriching messages with reactions: %v", err)
+	}
+
+	err = enrichMessagesWithPolls(ctx, co, chatId, list)
+	if err != nil {
+		return nil, fmt.Errorf("Got error during enriching messages with polls: %v", err)
+	}
+
+	return list, nil
+}
+
+func (db *DB) SearchMessagesRanked(ctx context.Context, chatId int64, limit, offset int, searchString string) ([]*Message, error) {
+	return searchMessagesRankedCommon(ctx, db, chatId, limit, offset, searchString)
+}
+
+func (tx *Tx) SearchMessagesRanked(ctx context.Context, chatId int64, limit, offset int, searchString string) ([]*Message, error) {
+	return searchMessagesRankedCommon(ctx, tx, chatId, limit, offset, searchString)
+}
+
+type MessageSearchItemId struct {
+	CreateDateTime time.Time
+	ChatId         int64
+	MessageId      int64
+}
+
+type FoundMessage struct {
+	ChatId         int64
+	ChatTitle      string
+	ChatTetATet    bool
+	MessageId      int64
+	OwnerId        int64
+	CreateDateTime time.Time
+	Highlight      string
+
+	ImportedOwnerName null.String
+}
+
+// searches over all the chats where participantId is a participant, implements keyset pagination, the newest messages go first
+func searchMessagesGloballyCommon(ctx context.Context, co CommonOperations, participantId int64, limit int, startingFromItemId *MessageSearchItemId, includeStartingFrom bool, searchString string) ([]*FoundMessage, error) {
+	list := make([]*FoundMessage, 0)
+
+	tsQuery := toPrefixTsQuery(searchString)
+	if tsQuery == "" {
+		return list, nil
+	}
+
+	var startingFrom MessageSearchItemId
+	if startingFromItemId == nil {
+		startingFrom = MessageSearchItemId{CreateDateTime: time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC), ChatId: math.MaxInt64, MessageId: math.MaxInt64}
+	} else {
+		startingFrom = *startingFromItemId
+	}
+
+	nonEquality := "<"
+	if includeStartingFrom {
+		nonEquality = "<="
+	}
+
+	// the planner can't prune the inherited tables by a subquery, so we query only the tables of the chats of the participant, portion by portion
+	err := getAllMyChatIdsCommon(ctx, co, participantId, func(chatIds []int64) error {
+		portion, err := searchMessagesInChatsCommon(ctx, co, chatIds, limit, startingFrom, nonEquality, tsQuery)
+		if err != nil {
+			return err
+		}
+		list = append(list, portion...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if !a.CreateDateTime.Equal(b.CreateDateTime) {
+			return a.CreateDateTime.After(b.CreateDateTime)
+		}
+		if a.ChatId != b.ChatId {
+			return a.ChatId > b.ChatId
+		}
+		return a.MessageId > b.MessageId
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+// every branch has its own limit, so each inherited table returns at most limit rows
+func searchMessagesInChatsCommon(ctx context.Context, co CommonOperations, chatIds []int64, limit int, startingFrom MessageSearchItemId, nonEquality string, tsQuery string) ([]*FoundMessage, error) {
+	list := make([]*FoundMessage, 0)
+	if len(chatIds) == 0 {
+		return list, nil
+	}
+
+	branches := make([]string, 0, len(chatIds))
+	for _, chatId := range chatIds {
+		branches = append(branches, fmt.Sprintf(`(
+			SELECT %v::bigint AS chat_id, m.id, m.owner_id, m.create_date_time, m.text, m.imported_owner_name
+			FROM message_chat_%v m
+			WHERE %s AND (m.create_date_time, %v::bigint, m.id) %s ($3, $4, $5)
+			ORDER BY m.create_date_time DESC, m.id DESC
+			LIMIT $2)`, chatId, chatId, messageSearchCondition(1), chatId, nonEquality))
+	}
+
+	rows, err := co.QueryContext(ctx, fmt.Sprintf(`
+		SELECT 
+			ms.chat_id,
+			c.title,
+			c.tet_a_tet,
+			ms.id,
+			ms.owner_id,
+			ms.create_date_time,
+			ts_headline(message_search_config(), strip_tags(ms.text), to_tsquery(message_search_config(), $1), 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5'),
+			ms.imported_owner_name
+		FROM (%s) ms
+		JOIN chat c ON c.id = ms.chat_id
+		ORDER BY ms.create_date_time DESC, ms.chat_id DESC, ms.id DESC
+		LIMIT $2`, strings.Join(branches, " UNION ALL ")),
+		tsQuery, limit, startingFrom.CreateDateTime, startingFrom.ChatId, startingFrom.MessageId)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		fm := FoundMessage{}
+		if err := rows.Scan(&fm.ChatId, &fm.ChatTitle, &fm.ChatTetATet, &fm.MessageId, &fm.OwnerId, &fm.CreateDateTime, &fm.Highlight, &fm.ImportedOwnerName); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		} else {
+			list = append(list, &fm)
+		}
+	}
+	return list, nil
+}
+
+func (db *DB) SearchMessagesGlobally(ctx context.Context, participantId int64, limit int, startingFromItemId *MessageSearchItemId, includeStartingFrom bool, searchString string) ([]*FoundMessage, error) {
+	return searchMessagesGloballyCommon(ctx, db, participantId, limit, startingFromItemId, includeStartingFrom, searchString)
+}
+
+func (tx *Tx) SearchMessagesGlobally(ctx context.Context, participantId int64, limit int, startingFromItemId *MessageSearchItemId, includeStartingFrom bool, searchString string) ([]*FoundMessage, error) {
+	return searchMessagesGloballyCommon(ctx, tx, participantId, limit, startingFromItemId, includeStartingFrom, searchString)
+}
+
 type embedMessage struct {
 	embedMessageId      *int64
 	embedMessageChatId  *int64
@@ -242,6 +509,16 @@ func (tx *Tx) HasMessages(ctx context.Context, chatId int64) (bool, error) {
 	}
 }
 
+// the deleted messages are counted as well, so deleting the message doesn't bypass the slow mode
+func (tx *Tx) GetLastMessageCreateDateTime(ctx context.Context, chatId, ownerId int64) (null.Time, error) {
+	var lastDateTime null.Time
+	row := tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT max(create_date_time) FROM message_chat_%v WHERE owner_id = $1`, chatId), ownerId)
+	if err := row.Scan(&lastDateTime); err != nil {
+		return lastDateTime, eris.Wrap(err, "error during interacting with db")
+	}
+	return lastDateTime, nil
+}
+
 func (tx *Tx) CreateMessage(ctx context.Context, m *Message) (id int64, createDatetime time.Time, editDatetime null.Time, err error) {
 	if m == nil {
 		return id, createDatetime, editDatetime, eris.New("message required")
@@ -253,7 +530,7 @@ func (tx *Tx) CreateMessage(ctx context.Context, m *Message) (id int64, createDa
 	if err != nil {
 		return id, createDatetime, editDatetime, eris.Wrap(err, "error during initializing embed struct")
 	}
-	res := tx.QueryRowContext(ctx, fmt.Sprintf(`INSERT INTO message_chat_%v (text, owner_id, file_item_uuid, embed_message_id, embed_chat_id, embed_owner_id, embed_message_type, blog_post) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, create_date_time, edit_date_time`, m.ChatId), m.Text, m.OwnerId, m.FileItemUuid, embed.embedMessageId, embed.embedMessageChatId, embed.embedMessageOwnerId, embed.embedMessageType, m.BlogPost)
+	res := tx.QueryRowContext(ctx, fmt.Sprintf(`INSERT INTO message_chat_%v (text, owner_id, file_item_uuid, embed_message_id, embed_chat_id, embed_owner_id, embed_message_type, blog_post, thread_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, create_date_time, edit_date_time`, m.ChatId), m.Text, m.OwnerId, m.FileItemUuid, embed.embedMessageId, embed.embedMessageChatId, embed.embedMessageOwnerId, embed.embedMessageType, m.BlogPost, m.ThreadId)
 	if err := res.Scan(&id, &createDatetime, &editDatetime); err != nil {
 		return id, createDatetime, editDatetime, eris.Wrap(err, "error during interacting with db")
 	}
@@ -262,7 +539,8 @@ func (tx *Tx) CreateMessage(ctx context.Context, m *Message) (id int64, createDa
 
 func (db *DB) CountMessages(ctx context.Context) (int64, error) {
 	var count int64
-	row := db.QueryRowContext(ctx, "SELECT count(*) FROM message")
+	// the tombstones aren't counted
+	row := db.QueryRowContext(ctx, "SELECT count(*) FROM message WHERE deleted_date_time IS NULL")
 	err := row.Scan(&count)
 	if err != nil {
 		return 0, eris.Wrap(err, "error during interacting with db")
@@ -276,6 +554,10 @@ func getMessageCommon(ctx context.Context, co CommonOperations, chatId int64, us
 	    m.id = $1 
 		AND $3 in (SELECT chat_id FROM chat_participant WHERE user_id = $2 AND chat_id = $3)`, selectMessageClause(chatId)),
 		messageId, userId, chatId)
+	return scanMessageWithDetailsCommon(ctx, co, row, chatId, messageId)
+}
+
+func scanMessageWithDetailsCommon(ctx context.Context, co CommonOperations, row *sql.Row, chatId int64, messageId int64) (*Message, error) {
 	message := Message{ChatId: chatId, Reactions: make([]Reaction, 0)}
 	err := row.Scan(provideScanToMessage(&message)[:]...)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -292,6 +574,12 @@ func getMessageCommon(ctx context.Context, co CommonOperations, chatId int64, us
 	}
 	message.Reactions = reactions
 
+	poll, err := getPollCommon(ctx, co, chatId, messageId)
+	if err != nil {
+		return nil, err
+	}
+	message.Poll = poll
+
 	return &message, nil
 }
 
@@ -303,6 +591,15 @@ func (tx *Tx) GetMessage(ctx context.Context, chatId int64, userId int64, messag
 	return getMessageCommon(ctx, tx, chatId, userId, messageId)
 }
 
+// the service admin moderates the chats they don't participate in, so there is no participant check
+func (tx *Tx) GetMessageForModeration(ctx context.Context, chatId int64, messageId int64) (*Message, error) {
+	row := tx.QueryRowContext(ctx, fmt.Sprintf(`%v
+	WHERE 
+	    m.id = $1`, selectMessageClause(chatId)),
+		messageId)
+	return scanMessageWithDetailsCommon(ctx, tx, row, chatId, messageId)
+}
+
 func getMessagePublicCommon(ctx context.Context, co CommonOperations, chatId int64, messageId int64) (*Message, error) {
 	row := co.QueryRowContext(ctx, fmt.Sprintf(`%v
 	WHERE 
@@ -325,6 +622,12 @@ func getMessagePublicCommon(ctx context.Context, co CommonOperations, chatId int
 	}
 	message.Reactions = reactions
 
+	poll, err := getPollCommon(ctx, co, chatId, messageId)
+	if err != nil {
+		return nil, err
+	}
+	message.Poll = poll
+
 	return &message, nil
 }
 
@@ -350,14 +653,17 @@ func getMessageBasicCommon(ctx context.Context, co CommonOperations, chatId int6
     	m.owner_id,
     	m.blog_post,
     	m.published,
-    	m.file_item_uuid
+    	m.file_item_uuid,
+    	m.thread_id,
+    	m.deleted_date_time,
+    	m.link_preview_url
 	FROM message_chat_%v m 
 	WHERE 
 	    m.id = $1 
 `, chatId),
 		messageId)
 	var mb = MessageBasic{}
-	err := row.Scan(&mb.Text, &mb.OwnerId, &mb.BlogPost, &mb.Published, &mb.FileItemUuid)
+	err := row.Scan(&mb.Text, &mb.OwnerId, &mb.BlogPost, &mb.Published, &mb.FileItemUuid, &mb.ThreadId, &mb.DeletedDateTime, &mb.LinkPreviewUrl)
 	if errors.Is(err, sql.ErrNoRows) {
 		// there were no rows, but otherwise no error occurred
 		return nil, nil
@@ -370,11 +676,14 @@ func getMessageBasicCommon(ctx context.Context, co CommonOperations, chatId int6
 }
 
 type MessageBasic struct {
-	Text         string
-	OwnerId      int64
-	BlogPost     bool
-	Published    bool
-	FileItemUuid *string
+	Text            string
+	OwnerId         int64
+	BlogPost        bool
+	Published       bool
+	FileItemUuid    *string
+	ThreadId        *int64
+	DeletedDateTime null.Time
+	LinkPreviewUrl  null.String
 }
 
 func (tx *Tx) GetMessageBasic(ctx context.Context, chatId int64, messageId int64) (*MessageBasic, error) {
@@ -391,7 +700,7 @@ func (tx *Tx) GetBlogPostMessageId(ctx context.Context, chatId int64) (*int64, e
 								m.id 
 							FROM message_chat_%v m 
 							WHERE 
-								m.blog_post IS TRUE
+								m.blog_post IS TRUE AND m.deleted_date_time IS NULL
 							ORDER BY id LIMIT 1
 						`, chatId),
 	)
@@ -475,7 +784,12 @@ func (tx *Tx) EditMessage(ctx context.Context, m *Message) error {
 		return err
 	}
 
-	if res, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE message_chat_%v SET text = $1, edit_date_time = utc_now(), file_item_uuid = $2, embed_message_id = $5, embed_chat_id = $6, embed_owner_id = $7, embed_message_type = $8, blog_post = $9 WHERE owner_id = $3 AND id = $4`, m.ChatId), m.Text, m.FileItemUuid, m.OwnerId, m.Id, embed.embedMessageId, embed.embedMessageChatId, embed.embedMessageOwnerId, embed.embedMessageType, m.BlogPost); err != nil {
+	// keep the original text as the first revision
+	if err := tx.addInitialMessageRevision(ctx, m.ChatId, m.Id); err != nil {
+		return err
+	}
+
+	if res, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE message_chat_%v SET text = $1, edit_date_time = utc_now(), file_item_uuid = $2, embed_message_id = $5, embed_chat_id = $6, embed_owner_id = $7, embed_message_type = $8, blog_post = $9 WHERE owner_id = $3 AND id = $4 AND deleted_date_time IS NULL`, m.ChatId), m.Text, m.FileItemUuid, m.OwnerId, m.Id, embed.embedMessageId, embed.embedMessageChatId, embed.embedMessageOwnerId, embed.embedMessageType, m.BlogPost); err != nil {
 		return eris.Wrap(err, "error during interacting with db")
 	} else {
 		affected, err := res.RowsAffected()
@@ -486,11 +800,12 @@ func (tx *Tx) EditMessage(ctx context.Context, m *Message) error {
 			return eris.New("No rows affected")
 		}
 	}
-	return nil
+	return tx.addMessageRevision(ctx, m.ChatId, m.Id, m.OwnerId)
 }
 
-func deleteMessageCommon(ctx context.Context, co CommonOperations, messageId int64, ownerId int64, chatId int64) error {
-	if res, err := co.ExecContext(ctx, fmt.Sprintf(`DELETE FROM message_chat_%v WHERE id = $1 AND owner_id = $2`, chatId), messageId, ownerId); err != nil {
+// leaves a tombstone in place of the message, it is unpinned and unpublished until it is restored. The caller checks that deletedBy is allowed to delete the message of another owner
+func deleteMessageCommon(ctx context.Context, co CommonOperations, messageId int64, deletedBy int64, chatId int64) error {
+	if res, err := co.ExecContext(ctx, fmt.Sprintf(`UPDATE message_chat_%v SET deleted_date_time = utc_now(), deleted_by = $2, pinned_before_delete = pinned, published_before_delete = published, pinned = false, pin_promoted = false, published = false WHERE id = $1 AND deleted_date_time IS NULL`, chatId), messageId, deletedBy); err != nil {
 		return eris.Wrap(err, "error during interacting with db")
 	} else {
 		affected, err := res.RowsAffected()
@@ -501,15 +816,96 @@ func deleteMessageCommon(ctx context.Context, co CommonOperations, messageId int
 			return eris.New("No rows affected")
 		}
 	}
-	return nil
+	// the bookmark isn't restored together with the message
+	return deleteMessageBookmarksCommon(ctx, co, chatId, messageId)
+}
+
+func (db *DB) DeleteMessage(ctx context.Context, messageId int64, deletedBy int64, chatId int64) error {
+	return deleteMessageCommon(ctx, db, messageId, deletedBy, chatId)
+}
+
+func (tx *Tx) DeleteMessage(ctx context.Context, messageId int64, deletedBy int64, chatId int64) error {
+	return deleteMessageCommon(ctx, tx, messageId, deletedBy, chatId)
+}
+
+// returns false when there is no tombstone deleted after deletedAfter, the pin and the publication are brought back
+func (tx *Tx) RestoreMessage(ctx context.Context, chatId int64, messageId int64, deletedAfter time.Time) (bool, error) {
+	res, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE message_chat_%v SET deleted_date_time = NULL, deleted_by = NULL, pinned = pinned_before_delete, published = published_before_delete, pinned_before_delete = false, published_before_delete = false WHERE id = $1 AND deleted_date_time >= $2`, chatId), messageId, deletedAfter)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
+
+type PurgedMessage struct {
+	Id       int64
+	ThreadId *int64
+}
+
+// hard-deletes the portion of the tombstones deleted before deletedBefore, their reactions go away via ON DELETE CASCADE
+func (tx *Tx) PurgeDeletedMessages(ctx context.Context, chatId int64, deletedBefore time.Time, limit int) ([]PurgedMessage, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+		DELETE FROM message_chat_%v WHERE id IN (
+			SELECT id FROM message_chat_%v WHERE deleted_date_time < $1 ORDER BY id LIMIT $2
+		)
+		RETURNING id, thread_id`, chatId, chatId), deletedBefore, limit)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]PurgedMessage, 0)
+	for rows.Next() {
+		pm := PurgedMessage{}
+		if err := rows.Scan(&pm.Id, &pm.ThreadId); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		} else {
+			list = append(list, pm)
+		}
+	}
+	return list, nil
+}
+
+type ExpiredMessage struct {
+	Id           int64
+	ThreadId     *int64
+	FileItemUuid *string
 }
 
-func (db *DB) DeleteMessage(ctx context.Context, messageId int64, ownerId int64, chatId int64) error {
-	return deleteMessageCommon(ctx, db, messageId, ownerId, chatId)
+// hard-deletes the portion of the messages created before createdBefore including the tombstones, their reactions go away via ON DELETE CASCADE
+func (tx *Tx) DeleteExpiredMessages(ctx context.Context, chatId int64, createdBefore time.Time, limit int) ([]ExpiredMessage, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+		DELETE FROM message_chat_%v WHERE id IN (
+			SELECT id FROM message_chat_%v WHERE create_date_time < $1 ORDER BY create_date_time LIMIT $2
+		)
+		RETURNING id, thread_id, file_item_uuid`, chatId, chatId), createdBefore, limit)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]ExpiredMessage, 0)
+	for rows.Next() {
+		em := ExpiredMessage{}
+		if err := rows.Scan(&em.Id, &em.ThreadId, &em.FileItemUuid); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		} else {
+			list = append(list, em)
+		}
+	}
+	return list, nil
 }
 
-func (tx *Tx) DeleteMessage(ctx context.Context, messageId int64, ownerId int64, chatId int64) error {
-	return deleteMessageCommon(ctx, tx, messageId, ownerId, chatId)
+// unlike FindMessageByFileItemUuid takes the tombstones into account because they can be restored
+func (tx *Tx) IsFileItemUuidReferenced(ctx context.Context, chatId int64, fileItemUuid string) (bool, error) {
+	var exists bool
+	row := tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM message_chat_%v WHERE file_item_uuid = $1)`, chatId), fileItemUuid)
+	if err := row.Scan(&exists); err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return exists, nil
 }
 
 func (dbR *DB) SetFileItemUuidToNull(ctx context.Context, ownerId, chatId int64, fileItemUuid string) (int64, bool, error) {
@@ -621,11 +1017,11 @@ func getNotificationSettingsByUsersBatch(ctx context.Context, co CommonOperation
 }
 
 func getCountUnreadMessages(marker, chatId, userId int64) string {
-	return fmt.Sprintf(`SELECT %v, COUNT(1) FROM message_chat_%v WHERE id > COALESCE((SELECT last_message_id FROM message_read WHERE user_id = %v AND chat_id = %v), 0)`, marker, chatId, userId, chatId)
+	return fmt.Sprintf(`SELECT %v, COUNT(1) FROM message_chat_%v WHERE thread_id IS NULL AND deleted_date_time IS NULL AND id > COALESCE((SELECT last_message_id FROM message_read WHERE user_id = %v AND chat_id = %v), 0)`, marker, chatId, userId, chatId)
 }
 
 func getHasUnreadMessages(marker, chatId, userId int64) string {
-	return fmt.Sprintf(`SELECT %v, EXISTS(SELECT 1 FROM message_chat_%v WHERE id > COALESCE((SELECT last_message_id FROM message_read WHERE user_id = %v AND chat_id = %v), 0)) inn`, marker, chatId, userId, chatId)
+	return fmt.Sprintf(`SELECT %v, EXISTS(SELECT 1 FROM message_chat_%v WHERE thread_id IS NULL AND deleted_date_time IS NULL AND id > COALESCE((SELECT last_message_id FROM message_read WHERE user_id = %v AND chat_id = %v), 0)) inn`, marker, chatId, userId, chatId)
 }
 
 func getUnreadMessagesCountByChatsBatchCommon(ctx context.Context, co CommonOperations, chatIds []int64, userId int64) (map[int64]int64, error) {
@@ -1031,7 +1427,7 @@ func (db *DB) FindMessageByFileItemUuid(ctx context.Context, chatId int64, fileI
 	}
 	fileItemUuidWithPercents := "%" + fileItemUuid + "%"
 	sqlFormatted := fmt.Sprintf(`
-			select id from message_chat_%v where file_item_uuid = $1 or text ilike $2 order by id limit 1
+			select id from message_chat_%v where deleted_date_time is null and (file_item_uuid = $1 or text ilike $2) order by id limit 1
 			`, chatId,
 	)
 	row := db.QueryRowContext(ctx, sqlFormatted, fileItemUuid, fileItemUuidWithPercents)
@@ -1128,7 +1524,7 @@ func getMessageReactionsCommon(ctx context.Context, co CommonOperations, chatId,
 
 	for rows.Next() {
 		reaction := Reaction{}
-		if err := rows.Scan(&reaction.UserId, &reaction.MessageId, &reaction.Reaction); err != nil {
+		if err := rows.Scan(&reaction.UserId, &reaction.MessageId, &reaction.Reaction, &reaction.EmojiUrl); err != nil {
 			return nil, eris.Wrap(err, "error during interacting with db")
 		}
 
@@ -1159,7 +1555,7 @@ func enrichMessagesWithReactions(ctx context.Context, co CommonOperations, chatI
 
 	for rows.Next() { // iterate by reactions
 		reaction := Reaction{}
-		if err = rows.Scan(&reaction.UserId, &reaction.MessageId, &reaction.Reaction); err != nil {
+		if err = rows.Scan(&reaction.UserId, &reaction.MessageId, &reaction.Reaction, &reaction.EmojiUrl); err != nil {
 			return eris.Wrap(err, "error during interacting with db")
 		}
 
@@ -1173,8 +1569,12 @@ func enrichMessagesWithReactions(ctx context.Context, co CommonOperations, chatI
 }
 
 func (tx *Tx) MessageFilter(ctx context.Context, chatId int64, searchString string, messageId int64) (bool, error) {
-	searchStringWithPercents := "%" + searchString + "%"
-	row := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT EXISTS (SELECT * FROM message_chat_%v m WHERE m.id = $1 AND strip_tags(m.text) ILIKE $2)", chatId), messageId, searchStringWithPercents)
+	tsQuery := toPrefixTsQuery(searchString)
+	if tsQuery == "" {
+		// an empty filter matches everything
+		return searchString == "", nil
+	}
+	row := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT EXISTS (SELECT * FROM message_chat_%v m WHERE m.id = $1 AND %s)", chatId, messageSearchCondition(2)), messageId, tsQuery)
 	if row.Err() != nil {
 		tx.lgr.WithTracing(ctx).Errorf("Error during get Search %v", row.Err())
 		return false, eris.Wrap(row.Err(), "error during interacting with db")
@@ -1225,6 +1625,11 @@ func getCommentsCommon(ctx context.Context, co CommonOperations, chatId int64, b
 		return nil, fmt.Errorf("Got error during enriching messages with reactions: %v", err)
 	}
 
+	err = enrichMessagesWithPolls(ctx, co, chatId, list)
+	if err != nil {
+		return nil, fmt.Errorf("Got error during enriching messages with polls: %v", err)
+	}
+
 	return list, nil
 }
 
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+type MessageBookmark struct {
+	Id             int64
+	UserId         int64
+	ChatId         int64
+	MessageId      int64
+	Note           *string
+	Tags           []string
+	CreateDateTime time.Time
+}
+
+// the tags are joined in order to not deal with the arrays of the driver, they don't contain commas
+const selectMessageBookmarkClause = `SELECT
+		b.id,
+		b.user_id,
+		b.chat_id,
+		b.message_id,
+		b.note,
+		array_to_string(b.tags, ','),
+		b.create_date_time
+	FROM message_bookmark b `
+
+const returningMessageBookmarkClause = `RETURNING id, user_id, chat_id, message_id, note, array_to_string(tags, ','), create_date_time`
+
+type messageBookmarkScan struct {
+	MessageBookmark
+	tags string
+}
+
+func provideScanToMessageBookmark(b *messageBookmarkScan) []any {
+	return []any{
+		&b.Id,
+		&b.UserId,
+		&b.ChatId,
+		&b.MessageId,
+		&b.Note,
+		&b.tags,
+		&b.CreateDateTime,
+	}
+}
+
+func (b *messageBookmarkScan) toMessageBookmark() *MessageBookmark {
+	ret := b.MessageBookmark
+	ret.Tags = []string{}
+	if b.tags != "" {
+		ret.Tags = strings.Split(b.tags, ",")
+	}
+	return &ret
+}
+
+// the second bookmark of the same message replaces the note and the tags of the first one
+func (tx *Tx) PutMessageBookmark(ctx context.Context, userId, chatId, messageId int64, note *string, tags []string) (*MessageBookmark, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO message_bookmark (user_id, chat_id, message_id, note, tags) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, chat_id, message_id) DO UPDATE SET note = excluded.note, tags = excluded.tags
+		`+returningMessageBookmarkClause,
+		userId, chatId, messageId, note, tags)
+	b := messageBookmarkScan{}
+	if err := row.Scan(provideScanToMessageBookmark(&b)[:]...); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return b.toMessageBookmark(), nil
+}
+
+// only the bookmarks of the chats the user still participates in are returned, the empty tag means all of them
+func (tx *Tx) GetMessageBookmarks(ctx context.Context, userId int64, tag string, limit, offset int) ([]*MessageBookmark, error) {
+	rows, err := tx.QueryContext(ctx, selectMessageBookmarkClause+`
+		JOIN chat_participant cp ON cp.chat_id = b.chat_id AND cp.user_id = b.user_id
+		WHERE b.user_id = $1 AND ($2 = '' OR $2 = ANY(b.tags))
+		ORDER BY b.id DESC LIMIT $3 OFFSET $4`,
+		userId, tag, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*MessageBookmark, 0)
+	for rows.Next() {
+		b := messageBookmarkScan{}
+		if err := rows.Scan(provideScanToMessageBookmark(&b)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, b.toMessageBookmark())
+	}
+	return list, nil
+}
+
+func (tx *Tx) GetMessageBookmarksCount(ctx context.Context, userId int64, tag string) (int64, error) {
+	var count int64
+	row := tx.QueryRowContext(ctx, `SELECT count(*) FROM message_bookmark b
+		JOIN chat_participant cp ON cp.chat_id = b.chat_id AND cp.user_id = b.user_id
+		WHERE b.user_id = $1 AND ($2 = '' OR $2 = ANY(b.tags))`,
+		userId, tag)
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+// returns false if the user has no such bookmark
+func (tx *Tx) DeleteMessageBookmark(ctx context.Context, userId, chatId, messageId int64) (bool, error) {
+	res, err := tx.ExecContext(ctx, `DELETE FROM message_bookmark WHERE user_id = $1 AND chat_id = $2 AND message_id = $3`, userId, chatId, messageId)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
+
+// removes the bookmarks of all the users, is called when the message goes away
+func deleteMessageBookmarksCommon(ctx context.Context, co CommonOperations, chatId int64, messageId int64) error {
+	_, err := co.ExecContext(ctx, `DELETE FROM message_bookmark WHERE chat_id = $1 AND message_id = $2`, chatId, messageId)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+func (tx *Tx) DeleteMessageBookmarks(ctx context.Context, chatId int64, messageId int64) error {
+	return deleteMessageBookmarksCommon(ctx, tx, chatId, messageId)
+}
+
+func deleteThreadBookmarks(ctx context.Context, tx *Tx, chatId int64, rootMessageId int64) error {
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM message_bookmark WHERE chat_id = $1 AND message_id IN (SELECT id FROM message_chat_%v WHERE thread_id = $2)`, chatId), chatId, rootMessageId)
+	return eris.Wrap(err, "error during interacting with db")
+}
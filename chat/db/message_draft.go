@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/rotisserie/eris"
+	"time"
+)
+
+type MessageDraft struct {
+	UserId           int64
+	ChatId           int64
+	Text             string
+	ThreadId         *int64
+	EmbedMessageId   *int64
+	EmbedChatId      *int64
+	EmbedMessageType *string
+	EditDateTime     time.Time
+}
+
+const selectMessageDraftClause = `SELECT
+		user_id,
+		chat_id,
+		text,
+		thread_id,
+		embed_message_id,
+		embed_chat_id,
+		embed_message_type,
+		edit_date_time
+	FROM message_draft `
+
+func provideScanToMessageDraft(d *MessageDraft) []any {
+	return []any{
+		&d.UserId,
+		&d.ChatId,
+		&d.Text,
+		&d.ThreadId,
+		&d.EmbedMessageId,
+		&d.EmbedChatId,
+		&d.EmbedMessageType,
+		&d.EditDateTime,
+	}
+}
+
+// the only draft of the user in the chat is replaced
+func (tx *Tx) PutMessageDraft(ctx context.Context, d *MessageDraft) (*MessageDraft, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO message_draft (user_id, chat_id, text, thread_id, embed_message_id, embed_chat_id, embed_message_type) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, chat_id) DO UPDATE SET text = excluded.text, thread_id = excluded.thread_id, embed_message_id = excluded.embed_message_id, embed_chat_id = excluded.embed_chat_id, embed_message_type = excluded.embed_message_type, edit_date_time = utc_now()
+		RETURNING user_id, chat_id, text, thread_id, embed_message_id, embed_chat_id, embed_message_type, edit_date_time`,
+		d.UserId, d.ChatId, d.Text, d.ThreadId, d.EmbedMessageId, d.EmbedChatId, d.EmbedMessageType)
+	ret := MessageDraft{}
+	if err := row.Scan(provideScanToMessageDraft(&ret)[:]...); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &ret, nil
+}
+
+func getMessageDraftCommon(ctx context.Context, co CommonOperations, userId, chatId int64) (*MessageDraft, error) {
+	row := co.QueryRowContext(ctx, selectMessageDraftClause+`WHERE user_id = $1 AND chat_id = $2`, userId, chatId)
+	d := MessageDraft{}
+	err := row.Scan(provideScanToMessageDraft(&d)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &d, nil
+}
+
+// returns nil if the user has no draft in the chat
+func (db *DB) GetMessageDraft(ctx context.Context, userId, chatId int64) (*MessageDraft, error) {
+	return getMessageDraftCommon(ctx, db, userId, chatId)
+}
+
+func (tx *Tx) GetMessageDraft(ctx context.Context, userId, chatId int64) (*MessageDraft, error) {
+	return getMessageDraftCommon(ctx, tx, userId, chatId)
+}
+
+// returns the drafts of the user by the chat id
+func (tx *Tx) GetMessageDraftsBatch(ctx context.Context, userId int64, chatIds []int64) (map[int64]*MessageDraft, error) {
+	res := map[int64]*MessageDraft{}
+	if len(chatIds) == 0 {
+		return res, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, selectMessageDraftClause+`WHERE user_id = $1 AND chat_id = ANY($2)`, userId, chatIds)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		d := MessageDraft{}
+		if err := rows.Scan(provideScanToMessageDraft(&d)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		res[d.ChatId] = &d
+	}
+	return res, nil
+}
+
+func deleteMessageDraftCommon(ctx context.Context, co CommonOperations, userId, chatId int64) (bool, error) {
+	res, err := co.ExecContext(ctx, `DELETE FROM message_draft WHERE user_id = $1 AND chat_id = $2`, userId, chatId)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
+
+// returns false if the user had no draft in the chat
+func (db *DB) DeleteMessageDraft(ctx context.Context, userId, chatId int64) (bool, error) {
+	return deleteMessageDraftCommon(ctx, db, userId, chatId)
+}
+
+func (tx *Tx) DeleteMessageDraft(ctx context.Context, userId, chatId int64) (bool, error) {
+	return deleteMessageDraftCommon(ctx, tx, userId, chatId)
+}
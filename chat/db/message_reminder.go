@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+type MessageReminder struct {
+	Id             int64
+	UserId         int64
+	ChatId         int64
+	MessageId      int64
+	RemindDateTime time.Time
+	Fired          bool
+	CreateDateTime time.Time
+}
+
+const selectMessageReminderClause = `SELECT
+		id,
+		user_id,
+		chat_id,
+		message_id,
+		remind_date_time,
+		fired,
+		create_date_time
+	FROM message_reminder `
+
+const returningMessageReminderClause = `RETURNING id, user_id, chat_id, message_id, remind_date_time, fired, create_date_time`
+
+func provideScanToMessageReminder(r *MessageReminder) []any {
+	return []any{
+		&r.Id,
+		&r.UserId,
+		&r.ChatId,
+		&r.MessageId,
+		&r.RemindDateTime,
+		&r.Fired,
+		&r.CreateDateTime,
+	}
+}
+
+func (tx *Tx) CreateMessageReminder(ctx context.Context, userId, chatId, messageId int64, remindDateTime time.Time) (*MessageReminder, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO message_reminder (user_id, chat_id, message_id, remind_date_time) VALUES ($1, $2, $3, $4)
+		`+returningMessageReminderClause,
+		userId, chatId, messageId, remindDateTime)
+	r := MessageReminder{}
+	if err := row.Scan(provideScanToMessageReminder(&r)[:]...); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &r, nil
+}
+
+// moves both the pending and the fired reminder, returns nil if the user has no such reminder
+func (tx *Tx) SnoozeMessageReminder(ctx context.Context, userId, reminderId int64, remindDateTime time.Time) (*MessageReminder, error) {
+	row := tx.QueryRowContext(ctx, `UPDATE message_reminder SET remind_date_time = $3, fired = false WHERE user_id = $1 AND id = $2
+		`+returningMessageReminderClause,
+		userId, reminderId, remindDateTime)
+	r := MessageReminder{}
+	err := row.Scan(provideScanToMessageReminder(&r)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &r, nil
+}
+
+// the pending reminders go first, the nearest one at the top
+func (tx *Tx) GetMessageReminders(ctx context.Context, userId int64, limit, offset int) ([]*MessageReminder, error) {
+	rows, err := tx.QueryContext(ctx, selectMessageReminderClause+`WHERE user_id = $1 ORDER BY fired, remind_date_time, id LIMIT $2 OFFSET $3`, userId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*MessageReminder, 0)
+	for rows.Next() {
+		r := MessageReminder{}
+		if err := rows.Scan(provideScanToMessageReminder(&r)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &r)
+	}
+	return list, nil
+}
+
+func (tx *Tx) GetMessageRemindersCount(ctx context.Context, userId int64) (int64, error) {
+	var count int64
+	row := tx.QueryRowContext(ctx, `SELECT count(*) FROM message_reminder WHERE user_id = $1`, userId)
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+// returns false if the user has no such reminder
+func (tx *Tx) DeleteMessageReminder(ctx context.Context, userId, reminderId int64) (bool, error) {
+	res, err := tx.ExecContext(ctx, `DELETE FROM message_reminder WHERE user_id = $1 AND id = $2`, userId, reminderId)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
+
+func (db *DB) GetDueMessageReminderIds(ctx context.Context, limit int) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id FROM message_reminder WHERE NOT fired AND remind_date_time <= utc_now() ORDER BY remind_date_time, id LIMIT $1`, limit)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, id)
+	}
+	return list, nil
+}
+
+// marks the due reminder as fired and returns it, returns nil if it was cancelled or snoozed in the meantime
+func (tx *Tx) FireMessageReminder(ctx context.Context, reminderId int64) (*MessageReminder, error) {
+	row := tx.QueryRowContext(ctx, `UPDATE message_reminder SET fired = true WHERE id = $1 AND NOT fired AND remind_date_time <= utc_now()
+		`+returningMessageReminderClause,
+		reminderId)
+	r := MessageReminder{}
+	err := row.Scan(provideScanToMessageReminder(&r)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &r, nil
+}
+
+// the fired reminder which wasn't snoozed during the retention is forgotten
+func (db *DB) DeleteFiredMessageReminders(ctx context.Context, retention time.Duration) (int64, error) {
+	res, err := db.ExecContext(ctx, `DELETE FROM message_reminder WHERE fired AND remind_date_time < utc_now() - make_interval(secs => $1)`, retention.Seconds())
+	if err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected, nil
+}
@@ -0,0 +1,210 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/rotisserie/eris"
+)
+
+type MessageReport struct {
+	Id               int64
+	ChatId           int64
+	MessageId        int64
+	MessageOwnerId   int64
+	MessageText      string
+	ReporterId       int64
+	Reason           string
+	Resolution       null.String
+	ResolvedBy       null.Int
+	ResolvedDateTime null.Time
+	CreateDateTime   time.Time
+}
+
+const selectMessageReportClause = `SELECT
+		id,
+		chat_id,
+		message_id,
+		message_owner_id,
+		message_text,
+		reporter_id,
+		reason,
+		resolution,
+		resolved_by,
+		resolved_date_time,
+		create_date_time
+	FROM message_report `
+
+const returningMessageReportClause = `RETURNING id, chat_id, message_id, message_owner_id, message_text, reporter_id, reason, resolution, resolved_by, resolved_date_time, create_date_time`
+
+func provideScanToMessageReport(r *MessageReport) []any {
+	return []any{
+		&r.Id,
+		&r.ChatId,
+		&r.MessageId,
+		&r.MessageOwnerId,
+		&r.MessageText,
+		&r.ReporterId,
+		&r.Reason,
+		&r.Resolution,
+		&r.ResolvedBy,
+		&r.ResolvedDateTime,
+		&r.CreateDateTime,
+	}
+}
+
+// returns nil if the reporter has already an open report about this message
+func (tx *Tx) CreateMessageReport(ctx context.Context, chatId, messageId, messageOwnerId int64, messageText string, reporterId int64, reason string) (*MessageReport, error) {
+	row := tx.QueryRowContext(ctx, `INSERT INTO message_report (chat_id, message_id, message_owner_id, message_text, reporter_id, reason) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (chat_id, message_id, reporter_id) WHERE resolution IS NULL DO NOTHING
+		`+returningMessageReportClause,
+		chatId, messageId, messageOwnerId, messageText, reporterId, reason)
+	r := MessageReport{}
+	err := row.Scan(provideScanToMessageReport(&r)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &r, nil
+}
+
+func (tx *Tx) GetMessageReport(ctx context.Context, chatId, reportId int64) (*MessageReport, error) {
+	row := tx.QueryRowContext(ctx, selectMessageReportClause+`WHERE chat_id = $1 AND id = $2`, chatId, reportId)
+	r := MessageReport{}
+	err := row.Scan(provideScanToMessageReport(&r)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &r, nil
+}
+
+func scanMessageReports(rows *sql.Rows) ([]*MessageReport, error) {
+	defer rows.Close()
+	list := make([]*MessageReport, 0)
+	for rows.Next() {
+		r := MessageReport{}
+		if err := rows.Scan(provideScanToMessageReport(&r)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &r)
+	}
+	return list, nil
+}
+
+// the queue of the chat, the oldest report goes first
+func (tx *Tx) GetOpenMessageReports(ctx context.Context, chatId int64, limit, offset int) ([]*MessageReport, error) {
+	rows, err := tx.QueryContext(ctx, selectMessageReportClause+`WHERE chat_id = $1 AND resolution IS NULL ORDER BY id LIMIT $2 OFFSET $3`, chatId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return scanMessageReports(rows)
+}
+
+func (tx *Tx) GetOpenMessageReportsCount(ctx context.Context, chatId int64) (int64, error) {
+	var count int64
+	row := tx.QueryRowContext(ctx, `SELECT count(*) FROM message_report WHERE chat_id = $1 AND resolution IS NULL`, chatId)
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+// the queue of all the chats for the admins of the service
+func (tx *Tx) GetAllOpenMessageReports(ctx context.Context, limit, offset int) ([]*MessageReport, error) {
+	rows, err := tx.QueryContext(ctx, selectMessageReportClause+`WHERE resolution IS NULL ORDER BY id LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return scanMessageReports(rows)
+}
+
+func (tx *Tx) GetAllOpenMessageReportsCount(ctx context.Context) (int64, error) {
+	var count int64
+	row := tx.QueryRowContext(ctx, `SELECT count(*) FROM message_report WHERE resolution IS NULL`)
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+// closes all the open reports about the message, the decision is made about the message, not about the single report
+func (tx *Tx) ResolveMessageReports(ctx context.Context, chatId, messageId int64, resolution string, resolvedBy int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE message_report SET resolution = $3, resolved_by = $4, resolved_date_time = utc_now() WHERE chat_id = $1 AND message_id = $2 AND resolution IS NULL`, chatId, messageId, resolution, resolvedBy)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+type ModerationAction struct {
+	Id             int64
+	ChatId         int64
+	MessageId      null.Int
+	TargetUserId   null.Int
+	Action         string
+	ActorId        int64
+	Comment        null.String
+	CreateDateTime time.Time
+}
+
+const selectModerationActionClause = `SELECT
+		id,
+		chat_id,
+		message_id,
+		target_user_id,
+		action,
+		actor_id,
+		comment,
+		create_date_time
+	FROM moderation_action `
+
+func provideScanToModerationAction(a *ModerationAction) []any {
+	return []any{
+		&a.Id,
+		&a.ChatId,
+		&a.MessageId,
+		&a.TargetUserId,
+		&a.Action,
+		&a.ActorId,
+		&a.Comment,
+		&a.CreateDateTime,
+	}
+}
+
+func (tx *Tx) CreateModerationAction(ctx context.Context, chatId int64, messageId, targetUserId null.Int, action string, actorId int64, comment null.String) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO moderation_action (chat_id, message_id, target_user_id, action, actor_id, comment) VALUES ($1, $2, $3, $4, $5, $6)`,
+		chatId, messageId, targetUserId, action, actorId, comment)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+// the newest action goes first
+func (tx *Tx) GetModerationActions(ctx context.Context, chatId int64, limit, offset int) ([]*ModerationAction, error) {
+	rows, err := tx.QueryContext(ctx, selectModerationActionClause+`WHERE chat_id = $1 ORDER BY id DESC LIMIT $2 OFFSET $3`, chatId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*ModerationAction, 0)
+	for rows.Next() {
+		a := ModerationAction{}
+		if err := rows.Scan(provideScanToModerationAction(&a)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &a)
+	}
+	return list, nil
+}
+
+func (tx *Tx) GetModerationActionsCount(ctx context.Context, chatId int64) (int64, error) {
+	var count int64
+	row := tx.QueryRowContext(ctx, `SELECT count(*) FROM moderation_action WHERE chat_id = $1`, chatId)
+	if err := row.Scan(&count); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
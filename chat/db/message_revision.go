@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/rotisserie/eris"
+	"time"
+)
+
+type MessageRevision struct {
+	Id             int64
+	ChatId         int64
+	MessageId      int64
+	Text           string
+	EditorId       int64
+	CreateDateTime time.Time
+}
+
+const selectMessageRevisionClause = `SELECT id, chat_id, message_id, text, editor_id, create_date_time FROM message_revision `
+
+func provideScanToMessageRevision(r *MessageRevision) []any {
+	return []any{
+		&r.Id,
+		&r.ChatId,
+		&r.MessageId,
+		&r.Text,
+		&r.EditorId,
+		&r.CreateDateTime,
+	}
+}
+
+// stores the not yet edited message as the first revision, does nothing if the message already has revisions
+func (tx *Tx) addInitialMessageRevision(ctx context.Context, chatId int64, messageId int64) error {
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO message_revision(chat_id, message_id, text, editor_id, create_date_time) 
+			SELECT $1, m.id, m.text, m.owner_id, m.create_date_time FROM message_chat_%v m 
+			WHERE m.id = $2 AND NOT EXISTS (SELECT 1 FROM message_revision r WHERE r.chat_id = $1 AND r.message_id = $2)
+	`, chatId), chatId, messageId)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+// stores the current text of the message as a revision
+func (tx *Tx) addMessageRevision(ctx context.Context, chatId int64, messageId int64, editorId int64) error {
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO message_revision(chat_id, message_id, text, editor_id) 
+			SELECT $1, m.id, m.text, $3 FROM message_chat_%v m WHERE m.id = $2
+	`, chatId), chatId, messageId, editorId)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+func (tx *Tx) DeleteMessageRevisions(ctx context.Context, chatId int64, messageId int64) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM message_revision WHERE chat_id = $1 AND message_id = $2`, chatId, messageId)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+func getMessageRevisionsCommon(ctx context.Context, co CommonOperations, chatId int64, messageId int64, limit int, offset int) ([]*MessageRevision, error) {
+	rows, err := co.QueryContext(ctx, selectMessageRevisionClause+`WHERE chat_id = $1 AND message_id = $2 ORDER BY id DESC LIMIT $3 OFFSET $4`, chatId, messageId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]*MessageRevision, 0)
+	for rows.Next() {
+		r := MessageRevision{}
+		if err := rows.Scan(provideScanToMessageRevision(&r)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &r)
+	}
+	return list, nil
+}
+
+func (db *DB) GetMessageRevisions(ctx context.Context, chatId int64, messageId int64, limit int, offset int) ([]*MessageRevision, error) {
+	return getMessageRevisionsCommon(ctx, db, chatId, messageId, limit, offset)
+}
+
+func (tx *Tx) GetMessageRevisions(ctx context.Context, chatId int64, messageId int64, limit int, offset int) ([]*MessageRevision, error) {
+	return getMessageRevisionsCommon(ctx, tx, chatId, messageId, limit, offset)
+}
+
+func getMessageRevisionsCountCommon(ctx context.Context, co CommonOperations, chatId int64, messageId int64) (int64, error) {
+	var count int64
+	row := co.QueryRowContext(ctx, `SELECT count(*) FROM message_revision WHERE chat_id = $1 AND message_id = $2`, chatId, messageId)
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+func (db *DB) GetMessageRevisionsCount(ctx context.Context, chatId int64, messageId int64) (int64, error) {
+	return getMessageRevisionsCountCommon(ctx, db, chatId, messageId)
+}
+
+func (tx *Tx) GetMessageRevisionsCount(ctx context.Context, chatId int64, messageId int64) (int64, error) {
+	return getMessageRevisionsCountCommon(ctx, tx, chatId, messageId)
+}
+
+func scanMessageRevision(row *sql.Row) (*MessageRevision, error) {
+	r := MessageRevision{}
+	err := row.Scan(provideScanToMessageRevision(&r)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &r, nil
+}
+
+func getMessageRevisionCommon(ctx context.Context, co CommonOperations, chatId int64, messageId int64, revisionId int64) (*MessageRevision, error) {
+	row := co.QueryRowContext(ctx, selectMessageRevisionClause+`WHERE chat_id = $1 AND message_id = $2 AND id = $3`, chatId, messageId, revisionId)
+	return scanMessageRevision(row)
+}
+
+func (db *DB) GetMessageRevision(ctx context.Context, chatId int64, messageId int64, revisionId int64) (*MessageRevision, error) {
+	return getMessageRevisionCommon(ctx, db, chatId, messageId, revisionId)
+}
+
+func (tx *Tx) GetMessageRevision(ctx context.Context, chatId int64, messageId int64, revisionId int64) (*MessageRevision, error) {
+	return getMessageRevisionCommon(ctx, tx, chatId, messageId, revisionId)
+}
+
+// returns the revision which precedes the given one, it is used to show the difference
+func getPreviousMessageRevisionCommon(ctx context.Context, co CommonOperations, chatId int64, messageId int64, revisionId int64) (*MessageRevision, error) {
+	row := co.QueryRowContext(ctx, selectMessageRevisionClause+`WHERE chat_id = $1 AND message_id = $2 AND id < $3 ORDER BY id DESC LIMIT 1`, chatId, messageId, revisionId)
+	return scanMessageRevision(row)
+}
+
+func (db *DB) GetPreviousMessageRevision(ctx context.Context, chatId int64, messageId int64, revisionId int64) (*MessageRevision, error) {
+	return getPreviousMessageRevisionCommon(ctx, db, chatId, messageId, revisionId)
+}
+
+func (tx *Tx) GetPreviousMessageRevision(ctx context.Context, chatId int64, messageId int64, revisionId int64) (*MessageRevision, error) {
+	return getPreviousMessageRevisionCommon(ctx, tx, chatId, messageId, revisionId)
+}
@@ -0,0 +1,17 @@
+package db
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestToPrefixTsQuery(t *testing.T) {
+	assert.Equal(t, "gen:* & mess:*", toPrefixTsQuery("gen mess"))
+	// the tsquery operators and the quotes can't break the query
+	assert.Equal(t, "it:* & s:* & a:* & b:*", toPrefixTsQuery(`it's "a" & | b`))
+	assert.Equal(t, "a:* & b:* & c:* & d:*", toPrefixTsQuery("a&b|!c:*d"))
+	assert.Equal(t, "привет:*", toPrefixTsQuery("!привет:*"))
+	// there is nothing to search
+	assert.Equal(t, "", toPrefixTsQuery(""))
+	assert.Equal(t, "", toPrefixTsQuery("  &|!:* ''"))
+}
@@ -0,0 +1,73 @@
+-- 'russian' configuration stems cyrillic words with russian_stem and latin words with english_stem
+CREATE OR REPLACE FUNCTION message_search_config() RETURNS regconfig AS $$
+SELECT 'russian'::regconfig
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE message ADD COLUMN text_search tsvector;
+
+CREATE OR REPLACE FUNCTION message_text_search_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.text_search := to_tsvector(message_search_config(), strip_tags(NEW.text));
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+-- create the trigger and the index for each message table
+DO $$
+    DECLARE
+        chat_id BIGINT;
+        query1 TEXT;
+    BEGIN
+        FOR chat_id IN SELECT id FROM chat
+            LOOP
+                query1 := format('UPDATE %s SET text_search = to_tsvector(message_search_config(), strip_tags(text))', 'message_chat_' || chat_id);
+                EXECUTE query1;
+                query1 := format('CREATE TRIGGER %s BEFORE INSERT OR UPDATE OF text ON %s FOR EACH ROW EXECUTE FUNCTION message_text_search_update()', 'message_chat_text_search_' || chat_id, 'message_chat_' || chat_id);
+                EXECUTE query1;
+                query1 := format('CREATE INDEX %s ON %s USING GIN (text_search)', 'message_chat_text_search_idx_' || chat_id, 'message_chat_' || chat_id);
+                EXECUTE query1;
+            END LOOP;
+    END
+$$ LANGUAGE plpgsql;
+
+-- redefine CREATE_CHAT
+CREATE OR REPLACE FUNCTION CREATE_CHAT(IN chat_name TEXT, IN tet_a_tet BOOLEAN DEFAULT FALSE, IN can_resend BOOLEAN DEFAULT FALSE, IN available_to_search BOOLEAN DEFAULT FALSE, IN blog BOOLEAN DEFAULT FALSE, IN regular_participant_can_publish_message BOOLEAN DEFAULT FALSE, IN regular_participant_can_pin_message BOOLEAN DEFAULT FALSE, IN blog_about BOOLEAN DEFAULT FALSE, IN regular_participant_can_write_message BOOLEAN DEFAULT TRUE) RETURNS RECORD AS $$
+DECLARE
+    chat_id BIGINT;
+    chat_last_update_date_time TIMESTAMP;
+    query1 TEXT;
+    ret RECORD;
+BEGIN
+    -- insert into chat table
+    INSERT INTO chat(title, tet_a_tet, can_resend, available_to_search, blog, regular_participant_can_publish_message, regular_participant_can_pin_message, blog_about, regular_participant_can_write_message)
+    VALUES(chat_name, tet_a_tet, can_resend, available_to_search, blog, regular_participant_can_publish_message, regular_participant_can_pin_message, blog_about, regular_participant_can_write_message)
+    RETURNING id, last_update_date_time INTO chat_id, chat_last_update_date_time;
+
+    -- create message table
+    query1 := format('CREATE TABLE %s() INHERITS (message)', 'message_chat_' || chat_id);
+    EXECUTE query1;
+    query1 := format('ALTER TABLE %s ADD PRIMARY KEY(id)', 'message_chat_' || chat_id);
+    EXECUTE query1;
+    query1 := format('CREATE SEQUENCE %s OWNED BY %s START 1;', 'message_chat_id_' || chat_id, 'message_chat_' || chat_id || '.id');
+    EXECUTE query1;
+    query1 := format('ALTER TABLE %s ALTER COLUMN id SET DEFAULT nextval(''%s'');', 'message_chat_' || chat_id, 'message_chat_id_' || chat_id);
+    EXECUTE query1;
+
+    -- full-text search
+    query1 := format('CREATE TRIGGER %s BEFORE INSERT OR UPDATE OF text ON %s FOR EACH ROW EXECUTE FUNCTION message_text_search_update()', 'message_chat_text_search_' || chat_id, 'message_chat_' || chat_id);
+    EXECUTE query1;
+    query1 := format('CREATE INDEX %s ON %s USING GIN (text_search)', 'message_chat_text_search_idx_' || chat_id, 'message_chat_' || chat_id);
+    EXECUTE query1;
+
+    -- create reaction table
+    query1 := format('CREATE TABLE %s() INHERITS (message_reaction)', 'message_reaction_chat_' || chat_id);
+    EXECUTE query1;
+    query1 := format('ALTER TABLE %s ADD PRIMARY KEY(user_id, message_id, reaction)', 'message_reaction_chat_' || chat_id);
+    EXECUTE query1;
+    query1 := format('ALTER TABLE %s ADD FOREIGN KEY(message_id) REFERENCES %s ON DELETE CASCADE;', 'message_reaction_chat_' || chat_id, 'message_chat_' || chat_id || '(id)');
+    EXECUTE query1;
+
+    SELECT chat_id, chat_last_update_date_time INTO ret;
+    RETURN ret;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,17 @@
+CREATE TABLE scheduled_message (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    owner_id BIGINT NOT NULL,
+    text TEXT NOT NULL,
+    file_item_uuid VARCHAR(36),
+    embed_message_id BIGINT,
+    embed_chat_id BIGINT,
+    embed_message_type VARCHAR(16),
+    thread_id BIGINT,
+    send_date_time TIMESTAMP NOT NULL,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now(),
+    edit_date_time TIMESTAMP
+);
+
+CREATE INDEX scheduled_message_send_date_time_idx ON scheduled_message(send_date_time);
+CREATE INDEX scheduled_message_chat_owner_idx ON scheduled_message(chat_id, owner_id);
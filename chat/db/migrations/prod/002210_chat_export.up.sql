@@ -0,0 +1,14 @@
+CREATE TABLE chat_export (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    owner_id BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    file_item_uuid VARCHAR(36),
+    filename TEXT,
+    url TEXT,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now(),
+    finish_date_time TIMESTAMP
+);
+
+CREATE INDEX chat_export_status_idx ON chat_export(status, id);
+CREATE INDEX chat_export_chat_owner_idx ON chat_export(chat_id, owner_id);
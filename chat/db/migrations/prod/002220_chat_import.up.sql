@@ -0,0 +1,30 @@
+-- one row per the imported external chat, the unique key makes the repeated import of the same archive to continue instead of creating a duplicate
+CREATE TABLE chat_import (
+    id BIGSERIAL PRIMARY KEY,
+    owner_id BIGINT NOT NULL,
+    source VARCHAR(16) NOT NULL,
+    external_chat_id VARCHAR(256) NOT NULL,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    imported_messages BIGINT NOT NULL DEFAULT 0,
+    finished BOOLEAN NOT NULL DEFAULT FALSE,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now(),
+    finish_date_time TIMESTAMP,
+    UNIQUE (owner_id, source, external_chat_id)
+);
+
+-- which external messages are already imported and what they became
+CREATE TABLE chat_import_message (
+    import_id BIGINT NOT NULL REFERENCES chat_import(id) ON DELETE CASCADE,
+    external_id VARCHAR(256) NOT NULL,
+    message_id BIGINT NOT NULL,
+    PRIMARY KEY (import_id, external_id)
+);
+
+-- maintained by the importing user, maps the authors of the external messenger onto the local users
+CREATE TABLE chat_import_user_mapping (
+    owner_id BIGINT NOT NULL,
+    source VARCHAR(16) NOT NULL,
+    external_user_id VARCHAR(256) NOT NULL,
+    user_id BIGINT NOT NULL,
+    PRIMARY KEY (owner_id, source, external_user_id)
+);
@@ -0,0 +1,26 @@
+-- the poll's question is the text of its message
+CREATE TABLE message_poll (
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    message_id BIGINT NOT NULL,
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    closed_date_time TIMESTAMP,
+    PRIMARY KEY (chat_id, message_id)
+);
+
+CREATE TABLE message_poll_option (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    message_id BIGINT NOT NULL,
+    text TEXT NOT NULL,
+    FOREIGN KEY (chat_id, message_id) REFERENCES message_poll(chat_id, message_id) ON DELETE CASCADE
+);
+
+CREATE INDEX message_poll_option_message_idx ON message_poll_option(chat_id, message_id, id);
+
+CREATE TABLE message_poll_vote (
+    option_id BIGINT NOT NULL REFERENCES message_poll_option(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now(),
+    PRIMARY KEY (option_id, user_id)
+);
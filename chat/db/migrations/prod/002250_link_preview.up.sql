@@ -0,0 +1,19 @@
+-- the preview card of the first link of the message, filled asynchronously after the message is saved
+ALTER TABLE message ADD COLUMN link_preview_url TEXT;
+ALTER TABLE message ADD COLUMN link_preview_title TEXT;
+ALTER TABLE message ADD COLUMN link_preview_description TEXT;
+ALTER TABLE message ADD COLUMN link_preview_image_url TEXT;
+ALTER TABLE message ADD COLUMN link_preview_site_name TEXT;
+
+-- the fetched pages shared between all the chats, found = false remembers the pages without any metadata
+CREATE TABLE link_preview_cache (
+    url TEXT PRIMARY KEY,
+    found BOOLEAN NOT NULL,
+    title TEXT,
+    description TEXT,
+    image_url TEXT,
+    site_name TEXT,
+    fetch_date_time TIMESTAMP NOT NULL DEFAULT utc_now()
+);
+
+CREATE INDEX link_preview_cache_fetch_date_time_idx ON link_preview_cache(fetch_date_time);
@@ -0,0 +1,14 @@
+CREATE TABLE chat_invite_link (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    owner_id BIGINT NOT NULL,
+    make_admin BOOLEAN NOT NULL DEFAULT FALSE,
+    max_uses BIGINT,
+    uses BIGINT NOT NULL DEFAULT 0,
+    expire_date_time TIMESTAMP,
+    revoke_date_time TIMESTAMP,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now()
+);
+
+CREATE INDEX chat_invite_link_chat_idx ON chat_invite_link(chat_id, id);
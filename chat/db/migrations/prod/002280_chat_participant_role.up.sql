@@ -0,0 +1,13 @@
+-- the named role replaces the admin flag of the participant
+ALTER TABLE chat_participant ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'member';
+
+UPDATE chat_participant SET role = 'admin' WHERE admin = TRUE;
+
+-- the earliest admin of each chat becomes its owner
+UPDATE chat_participant cp SET role = 'owner'
+FROM (
+    SELECT DISTINCT ON (chat_id) chat_id, user_id FROM chat_participant WHERE admin = TRUE ORDER BY chat_id, create_date_time, user_id
+) o
+WHERE cp.chat_id = o.chat_id AND cp.user_id = o.user_id;
+
+ALTER TABLE chat_participant DROP COLUMN admin;
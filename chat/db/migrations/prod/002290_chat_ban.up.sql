@@ -0,0 +1,13 @@
+-- the banned user can't come back to the chat until the ban expires or is lifted
+CREATE TABLE chat_ban (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    banned_by BIGINT NOT NULL,
+    reason TEXT,
+    expire_date_time TIMESTAMP,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now(),
+    UNIQUE (chat_id, user_id)
+);
+
+CREATE INDEX chat_ban_user_id_idx ON chat_ban(user_id);
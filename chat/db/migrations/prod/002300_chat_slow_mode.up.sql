@@ -0,0 +1,75 @@
+-- a non-admin participant can post one message per this interval, NULL turns the slow mode off
+ALTER TABLE chat ADD COLUMN slow_mode_seconds BIGINT;
+
+-- create the index for the last message of the user lookup for each message table
+DO $$
+    DECLARE
+        chat_id BIGINT;
+        query1 TEXT;
+    BEGIN
+        FOR chat_id IN SELECT id FROM chat
+            LOOP
+                query1 := format('CREATE INDEX %s ON %s (owner_id, create_date_time)', 'message_chat_owner_create_idx_' || chat_id, 'message_chat_' || chat_id);
+                EXECUTE query1;
+            END LOOP;
+    END
+$$ LANGUAGE plpgsql;
+
+-- redefine CREATE_CHAT
+DROP FUNCTION IF EXISTS CREATE_CHAT(IN chat_name TEXT, IN tet_a_tet BOOLEAN, IN can_resend BOOLEAN, IN available_to_search BOOLEAN, IN blog BOOLEAN, IN regular_participant_can_publish_message BOOLEAN, IN regular_participant_can_pin_message BOOLEAN, IN blog_about BOOLEAN, IN regular_participant_can_write_message BOOLEAN, IN regular_participant_can_see_edit_history BOOLEAN, IN message_retention_seconds BIGINT, IN join_by_request BOOLEAN);
+CREATE OR REPLACE FUNCTION CREATE_CHAT(IN chat_name TEXT, IN tet_a_tet BOOLEAN DEFAULT FALSE, IN can_resend BOOLEAN DEFAULT FALSE, IN available_to_search BOOLEAN DEFAULT FALSE, IN blog BOOLEAN DEFAULT FALSE, IN regular_participant_can_publish_message BOOLEAN DEFAULT FALSE, IN regular_participant_can_pin_message BOOLEAN DEFAULT FALSE, IN blog_about BOOLEAN DEFAULT FALSE, IN regular_participant_can_write_message BOOLEAN DEFAULT TRUE, IN regular_participant_can_see_edit_history BOOLEAN DEFAULT TRUE, IN message_retention_seconds BIGINT DEFAULT NULL, IN join_by_request BOOLEAN DEFAULT FALSE, IN slow_mode_seconds BIGINT DEFAULT NULL) RETURNS RECORD AS $$
+DECLARE
+    chat_id BIGINT;
+    chat_last_update_date_time TIMESTAMP;
+    query1 TEXT;
+    ret RECORD;
+BEGIN
+    -- insert into chat table
+    INSERT INTO chat(title, tet_a_tet, can_resend, available_to_search, blog, regular_participant_can_publish_message, regular_participant_can_pin_message, blog_about, regular_participant_can_write_message, regular_participant_can_see_edit_history, message_retention_seconds, join_by_request, slow_mode_seconds)
+    VALUES(chat_name, tet_a_tet, can_resend, available_to_search, blog, regular_participant_can_publish_message, regular_participant_can_pin_message, blog_about, regular_participant_can_write_message, regular_participant_can_see_edit_history, message_retention_seconds, join_by_request, slow_mode_seconds)
+    RETURNING id, last_update_date_time INTO chat_id, chat_last_update_date_time;
+
+    -- create message table
+    query1 := format('CREATE TABLE %s() INHERITS (message)', 'message_chat_' || chat_id);
+    EXECUTE query1;
+    query1 := format('ALTER TABLE %s ADD PRIMARY KEY(id)', 'message_chat_' || chat_id);
+    EXECUTE query1;
+    query1 := format('CREATE SEQUENCE %s OWNED BY %s START 1;', 'message_chat_id_' || chat_id, 'message_chat_' || chat_id || '.id');
+    EXECUTE query1;
+    query1 := format('ALTER TABLE %s ALTER COLUMN id SET DEFAULT nextval(''%s'');', 'message_chat_' || chat_id, 'message_chat_id_' || chat_id);
+    EXECUTE query1;
+
+    -- full-text search
+    query1 := format('CREATE TRIGGER %s BEFORE INSERT OR UPDATE OF text ON %s FOR EACH ROW EXECUTE FUNCTION message_text_search_update()', 'message_chat_text_search_' || chat_id, 'message_chat_' || chat_id);
+    EXECUTE query1;
+    query1 := format('CREATE INDEX %s ON %s USING GIN (text_search)', 'message_chat_text_search_idx_' || chat_id, 'message_chat_' || chat_id);
+    EXECUTE query1;
+
+    -- threads
+    query1 := format('CREATE INDEX %s ON %s (thread_id)', 'message_chat_thread_idx_' || chat_id, 'message_chat_' || chat_id);
+    EXECUTE query1;
+
+    -- tombstones
+    query1 := format('CREATE INDEX %s ON %s (deleted_date_time) WHERE deleted_date_time IS NOT NULL', 'message_chat_deleted_idx_' || chat_id, 'message_chat_' || chat_id);
+    EXECUTE query1;
+
+    -- retention
+    query1 := format('CREATE INDEX %s ON %s (create_date_time)', 'message_chat_create_idx_' || chat_id, 'message_chat_' || chat_id);
+    EXECUTE query1;
+
+    -- slow mode
+    query1 := format('CREATE INDEX %s ON %s (owner_id, create_date_time)', 'message_chat_owner_create_idx_' || chat_id, 'message_chat_' || chat_id);
+    EXECUTE query1;
+
+    -- create reaction table
+    query1 := format('CREATE TABLE %s() INHERITS (message_reaction)', 'message_reaction_chat_' || chat_id);
+    EXECUTE query1;
+    query1 := format('ALTER TABLE %s ADD PRIMARY KEY(user_id, message_id, reaction)', 'message_reaction_chat_' || chat_id);
+    EXECUTE query1;
+    query1 := format('ALTER TABLE %s ADD FOREIGN KEY(message_id) REFERENCES %s ON DELETE CASCADE;', 'message_reaction_chat_' || chat_id, 'message_chat_' || chat_id || '(id)');
+    EXECUTE query1;
+
+    SELECT chat_id, chat_last_update_date_time INTO ret;
+    RETURN ret;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,37 @@
+-- the external systems post the messages into the chat by the token
+CREATE TABLE chat_incoming_webhook (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    name VARCHAR(256) NOT NULL,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    owner_id BIGINT NOT NULL,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now()
+);
+
+CREATE INDEX chat_incoming_webhook_chat_idx ON chat_incoming_webhook(chat_id, id);
+
+-- the events of the chat are posted to the external systems
+CREATE TABLE chat_outgoing_webhook (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    event_types TEXT[] NOT NULL,
+    owner_id BIGINT NOT NULL,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now()
+);
+
+CREATE INDEX chat_outgoing_webhook_chat_idx ON chat_outgoing_webhook(chat_id, id);
+
+-- the queue of the events to be posted, the failed ones are retried later
+CREATE TABLE chat_outgoing_webhook_delivery (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES chat_outgoing_webhook(id) ON DELETE CASCADE,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_date_time TIMESTAMP NOT NULL DEFAULT utc_now(),
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now()
+);
+
+CREATE INDEX chat_outgoing_webhook_delivery_next_attempt_idx ON chat_outgoing_webhook_delivery(next_attempt_date_time);
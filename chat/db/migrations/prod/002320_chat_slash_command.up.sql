@@ -0,0 +1,12 @@
+-- the commands registered by the chat admins, the invocation is forwarded to the external endpoint
+CREATE TABLE chat_slash_command (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    name VARCHAR(32) NOT NULL,
+    description VARCHAR(256) NOT NULL DEFAULT '',
+    url TEXT NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    owner_id BIGINT NOT NULL,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now(),
+    UNIQUE (chat_id, name)
+);
@@ -0,0 +1,12 @@
+-- the unsent message of the user, is synchronized between the devices
+CREATE TABLE message_draft (
+    user_id BIGINT NOT NULL,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    thread_id BIGINT,
+    embed_message_id BIGINT,
+    embed_chat_id BIGINT,
+    embed_message_type VARCHAR(16),
+    edit_date_time TIMESTAMP NOT NULL DEFAULT utc_now(),
+    PRIMARY KEY (user_id, chat_id)
+);
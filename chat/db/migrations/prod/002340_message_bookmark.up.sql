@@ -0,0 +1,13 @@
+-- the personal saved message, unlike the pin it is visible only to its owner
+CREATE TABLE message_bookmark (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    message_id BIGINT NOT NULL,
+    note TEXT,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now(),
+    UNIQUE (user_id, chat_id, message_id)
+);
+
+CREATE INDEX message_bookmark_message_idx ON message_bookmark(chat_id, message_id);
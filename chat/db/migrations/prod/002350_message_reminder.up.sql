@@ -0,0 +1,13 @@
+-- the private reminder about the message, the fired one is kept for a while in order to be snoozed
+CREATE TABLE message_reminder (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    message_id BIGINT NOT NULL,
+    remind_date_time TIMESTAMP NOT NULL,
+    fired BOOLEAN NOT NULL DEFAULT false,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now()
+);
+
+CREATE INDEX message_reminder_remind_date_time_idx ON message_reminder(remind_date_time) WHERE NOT fired;
+CREATE INDEX message_reminder_user_idx ON message_reminder(user_id, remind_date_time);
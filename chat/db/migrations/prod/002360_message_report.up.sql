@@ -0,0 +1,32 @@
+-- the complaint of the participant about the message, the text is copied in order to keep the evidence after the editing
+CREATE TABLE message_report (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    message_id BIGINT NOT NULL,
+    message_owner_id BIGINT NOT NULL,
+    message_text TEXT NOT NULL,
+    reporter_id BIGINT NOT NULL,
+    reason VARCHAR(1024) NOT NULL,
+    resolution VARCHAR(16),
+    resolved_by BIGINT,
+    resolved_date_time TIMESTAMP,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now()
+);
+
+-- the participant can't report the same message twice while the first report is open
+CREATE UNIQUE INDEX message_report_open_idx ON message_report(chat_id, message_id, reporter_id) WHERE resolution IS NULL;
+CREATE INDEX message_report_queue_idx ON message_report(chat_id, id) WHERE resolution IS NULL;
+
+-- the trail of the moderation, it outlives the chat
+CREATE TABLE moderation_action (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    message_id BIGINT,
+    target_user_id BIGINT,
+    action VARCHAR(16) NOT NULL,
+    actor_id BIGINT NOT NULL,
+    comment VARCHAR(1024),
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now()
+);
+
+CREATE INDEX moderation_action_chat_idx ON moderation_action(chat_id, id);
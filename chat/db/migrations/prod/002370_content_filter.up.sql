@@ -0,0 +1,28 @@
+-- the words which aren't welcome, the entry without the chat is applied to all the chats
+CREATE TABLE blocklist_entry (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT REFERENCES chat(id) ON DELETE CASCADE,
+    pattern VARCHAR(256) NOT NULL,
+    regex BOOLEAN NOT NULL DEFAULT FALSE,
+    action VARCHAR(16) NOT NULL,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now()
+);
+
+CREATE INDEX blocklist_entry_chat_idx ON blocklist_entry(chat_id);
+
+-- the message which was held by the content filter until the decision of the moderator
+CREATE TABLE held_message (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    owner_id BIGINT NOT NULL,
+    text TEXT NOT NULL,
+    file_item_uuid VARCHAR(36),
+    embed_message_id BIGINT,
+    embed_chat_id BIGINT,
+    embed_message_type VARCHAR(16),
+    thread_id BIGINT,
+    reason VARCHAR(1024) NOT NULL,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now()
+);
+
+CREATE INDEX held_message_chat_idx ON held_message(chat_id, id);
@@ -0,0 +1,13 @@
+-- the custom emoji of the chat, the reactions and the messages reference it as :shortcode:
+CREATE TABLE chat_emoji (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chat(id) ON DELETE CASCADE,
+    shortcode VARCHAR(32) NOT NULL,
+    url VARCHAR(1024) NOT NULL,
+    created_by BIGINT NOT NULL,
+    create_date_time TIMESTAMP NOT NULL DEFAULT utc_now(),
+    UNIQUE (chat_id, shortcode)
+);
+
+-- the shortcode with the colons doesn't fit into the unicode emoji size, the tables of the chats are altered as well
+ALTER TABLE message_reaction ALTER COLUMN reaction TYPE VARCHAR(34);
@@ -0,0 +1,3 @@
+-- the tombstone remembers whether the message was pinned or published, so the restore brings it back
+ALTER TABLE message ADD COLUMN pinned_before_delete BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE message ADD COLUMN published_before_delete BOOLEAN NOT NULL DEFAULT FALSE;
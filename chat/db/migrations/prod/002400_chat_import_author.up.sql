@@ -0,0 +1,23 @@
+-- the mapping onto another user takes effect only after that user confirms it
+ALTER TABLE chat_import_user_mapping ADD COLUMN confirmed BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE chat_import_user_mapping SET confirmed = TRUE WHERE user_id = owner_id;
+CREATE INDEX chat_import_user_mapping_user_id_idx ON chat_import_user_mapping (user_id);
+
+-- the name of the unmapped external author is kept on the imported message
+ALTER TABLE message ADD COLUMN imported_owner_name VARCHAR(256);
+
+-- the placeholders of the unmapped authors were -1, -2, ... which clashed with the special users, they're moved to -1000000 and below
+DO $$
+    DECLARE
+        chat_id BIGINT;
+        query1 TEXT;
+    BEGIN
+        FOR chat_id IN SELECT DISTINCT ci.chat_id FROM chat_import ci
+            LOOP
+                query1 := format('UPDATE %s SET owner_id = owner_id - 999999 WHERE owner_id < 0 AND owner_id > -65000', 'message_chat_' || chat_id);
+                EXECUTE query1;
+                query1 := format('UPDATE %s SET user_id = user_id - 999999 WHERE user_id < 0 AND user_id > -65000', 'message_reaction_chat_' || chat_id);
+                EXECUTE query1;
+            END LOOP;
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,8 @@
+-- only the hash of the token is kept, the token itself is shown once on creating the webhook
+ALTER TABLE chat_incoming_webhook ADD COLUMN token_hash VARCHAR(64);
+
+UPDATE chat_incoming_webhook SET token_hash = encode(sha256(convert_to(token, 'UTF8')), 'hex');
+
+ALTER TABLE chat_incoming_webhook ALTER COLUMN token_hash SET NOT NULL;
+ALTER TABLE chat_incoming_webhook ADD CONSTRAINT chat_incoming_webhook_token_hash_key UNIQUE (token_hash);
+ALTER TABLE chat_incoming_webhook DROP COLUMN token;
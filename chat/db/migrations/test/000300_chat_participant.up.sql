@@ -1,2 +1,2 @@
-INSERT INTO chat_participant (chat_id, user_id, admin)
-SELECT id AS chat_id, 1 AS user_id, TRUE FROM chat;
+INSERT INTO chat_participant (chat_id, user_id, role)
+SELECT id AS chat_id, 1 AS user_id, 'owner' FROM chat;
@@ -3,8 +3,10 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/rotisserie/eris"
+	"nkonev.name/chat/dto"
 	"nkonev.name/chat/utils"
 )
 
@@ -15,8 +17,8 @@ type ChatParticipant struct {
 	UserId int64
 }
 
-func (tx *Tx) AddParticipant(ctx context.Context, userId int64, chatId int64, admin bool) error {
-	_, err := tx.ExecContext(ctx, `INSERT INTO chat_participant (chat_id, user_id, admin) VALUES ($1, $2, $3)`, chatId, userId, admin)
+func (tx *Tx) AddParticipant(ctx context.Context, userId int64, chatId int64, role string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO chat_participant (chat_id, user_id, role) VALUES ($1, $2, $3)`, chatId, userId, role)
 	return eris.Wrap(err, "error during interacting with db")
 }
 
@@ -315,101 +317,118 @@ func (db *DB) GetParticipantsCountBatch(ctx context.Context, chatIds []int64) (m
 	return getParticipantsCountBatchCommon(ctx, db, chatIds)
 }
 
-func getIsAdminCommon(ctx context.Context, qq CommonOperations, userId int64, chatId int64) (bool, error) {
-	var admin bool = false
-	row := qq.QueryRowContext(ctx, `SELECT exists(SELECT * FROM chat_participant WHERE user_id = $1 AND chat_id = $2 AND admin = true LIMIT 1)`, userId, chatId)
-	if err := row.Scan(&admin); err != nil {
-		return false, eris.Wrap(err, "error during interacting with db")
-	} else {
-		return admin, nil
+// returns the empty role if the user isn't a participant
+func getParticipantRoleCommon(ctx context.Context, qq CommonOperations, userId int64, chatId int64) (string, error) {
+	var role string
+	row := qq.QueryRowContext(ctx, `SELECT role FROM chat_participant WHERE user_id = $1 AND chat_id = $2`, userId, chatId)
+	err := row.Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return "", nil
+	}
+	if err != nil {
+		return "", eris.Wrap(err, "error during interacting with db")
 	}
+	return role, nil
 }
 
-func (tx *Tx) IsAdmin(ctx context.Context, userId int64, chatId int64) (bool, error) {
-	return getIsAdminCommon(ctx, tx, userId, chatId)
+func (tx *Tx) GetParticipantRole(ctx context.Context, userId int64, chatId int64) (string, error) {
+	return getParticipantRoleCommon(ctx, tx, userId, chatId)
 }
 
-func (db *DB) IsAdmin(ctx context.Context, userId int64, chatId int64) (bool, error) {
-	return getIsAdminCommon(ctx, db, userId, chatId)
+func (db *DB) GetParticipantRole(ctx context.Context, userId int64, chatId int64) (string, error) {
+	return getParticipantRoleCommon(ctx, db, userId, chatId)
 }
 
-func getIsAdminBatchCommon(ctx context.Context, qq CommonOperations, userId int64, chatIds []int64) (map[int64]bool, error) {
-	var result = map[int64]bool{}
+func getParticipantRoleBatchCommon(ctx context.Context, qq CommonOperations, userId int64, chatIds []int64) (map[int64]string, error) {
+	var result = map[int64]string{}
 
 	if len(chatIds) == 0 {
 		return result, nil
 	}
 
-	for _, chatId := range chatIds {
-		result[chatId] = false // prefill all with false
-	}
-
-	var builder = ""
-	var first = true
-	for _, chatId := range chatIds {
-		if !first {
-			builder += ", "
-		}
-		builder += utils.Int64ToString(chatId)
-		first = false
-	}
-
-	if rows, err := qq.QueryContext(ctx, fmt.Sprintf(`SELECT chat_id, admin FROM chat_participant WHERE user_id = $1 AND chat_id IN (%v) AND admin = true`, builder), userId); err != nil {
+	if rows, err := qq.QueryContext(ctx, `SELECT chat_id, role FROM chat_participant WHERE user_id = $1 AND chat_id = ANY($2)`, userId, chatIds); err != nil {
 		return nil, eris.Wrap(err, "error during interacting with db")
 	} else {
 		defer rows.Close()
 
 		for rows.Next() {
-			var admin bool = false
+			var role string
 			var chatId int64 = 0
-			if err := rows.Scan(&chatId, &admin); err != nil {
+			if err := rows.Scan(&chatId, &role); err != nil {
 				return nil, eris.Wrap(err, "error during interacting with db")
 			} else {
-				result[chatId] = admin
+				result[chatId] = role
 			}
 		}
 		return result, nil
 	}
 }
 
-func (tx *Tx) IsAdminBatch(ctx context.Context, userId int64, chatIds []int64) (map[int64]bool, error) {
-	return getIsAdminBatchCommon(ctx, tx, userId, chatIds)
+func (tx *Tx) GetParticipantRoleBatch(ctx context.Context, userId int64, chatIds []int64) (map[int64]string, error) {
+	return getParticipantRoleBatchCommon(ctx, tx, userId, chatIds)
 }
 
-func (db *DB) IsAdminBatch(ctx context.Context, userId int64, chatIds []int64) (map[int64]bool, error) {
-	return getIsAdminBatchCommon(ctx, db, userId, chatIds)
+func (db *DB) GetParticipantRoleBatch(ctx context.Context, userId int64, chatIds []int64) (map[int64]string, error) {
+	return getParticipantRoleBatchCommon(ctx, db, userId, chatIds)
 }
 
-func getIsAdminBatchByParticipantsCommon(ctx context.Context, qq CommonOperations, userIds []int64, chatId int64) ([]UserAdminDbDTO, error) {
-	var result = []UserAdminDbDTO{}
+func getRolesBatchByParticipantsCommon(ctx context.Context, qq CommonOperations, userIds []int64, chatId int64) ([]UserRoleDbDTO, error) {
+	var result = []UserRoleDbDTO{}
 
 	if len(userIds) == 0 {
 		return result, nil
 	}
 
-	if rows, err := qq.QueryContext(ctx, fmt.Sprintf(`SELECT user_id, admin FROM chat_participant WHERE user_id = ANY($1) AND chat_id = $2 ORDER BY create_date_time DESC`), userIds, chatId); err != nil {
+	if rows, err := qq.QueryContext(ctx, `SELECT user_id, role FROM chat_participant WHERE user_id = ANY($1) AND chat_id = $2 ORDER BY create_date_time DESC`, userIds, chatId); err != nil {
 		return nil, eris.Wrap(err, "error during interacting with db")
 	} else {
 		defer rows.Close()
 
 		for rows.Next() {
-			var admin bool = false
+			var role string
 			var userId int64 = 0
-			if err := rows.Scan(&userId, &admin); err != nil {
+			if err := rows.Scan(&userId, &role); err != nil {
 				return nil, eris.Wrap(err, "error during interacting with db")
 			} else {
-				result = append(result, UserAdminDbDTO{userId, admin})
+				result = append(result, UserRoleDbDTO{userId, role})
 			}
 		}
 		return result, nil
 	}
 }
-func (db *DB) IsAdminBatchByParticipants(ctx context.Context, userIds []int64, chatId int64) ([]UserAdminDbDTO, error) {
-	return getIsAdminBatchByParticipantsCommon(ctx, db, userIds, chatId)
+
+func (db *DB) GetRolesBatchByParticipants(ctx context.Context, userIds []int64, chatId int64) ([]UserRoleDbDTO, error) {
+	return getRolesBatchByParticipantsCommon(ctx, db, userIds, chatId)
+}
+
+func (tx *Tx) GetRolesBatchByParticipants(ctx context.Context, userIds []int64, chatId int64) ([]UserRoleDbDTO, error) {
+	return getRolesBatchByParticipantsCommon(ctx, tx, userIds, chatId)
+}
+
+func getChatAdminIdsCommon(ctx context.Context, co CommonOperations, chatId int64) ([]int64, error) {
+	rows, err := co.QueryContext(ctx, `SELECT user_id FROM chat_participant WHERE chat_id = $1 AND role IN ($2, $3) ORDER BY user_id`, chatId, dto.ChatRoleOwner, dto.ChatRoleAdmin)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]int64, 0)
+	for rows.Next() {
+		var userId int64
+		if err := rows.Scan(&userId); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, userId)
+	}
+	return list, nil
+}
+
+func (db *DB) GetChatAdminIds(ctx context.Context, chatId int64) ([]int64, error) {
+	return getChatAdminIdsCommon(ctx, db, chatId)
 }
 
-func (tx *Tx) IsAdminBatchByParticipants(ctx context.Context, userIds []int64, chatId int64) ([]UserAdminDbDTO, error) {
-	return getIsAdminBatchByParticipantsCommon(ctx, tx, userIds, chatId)
+func (tx *Tx) GetChatAdminIds(ctx context.Context, chatId int64) ([]int64, error) {
+	return getChatAdminIdsCommon(ctx, tx, chatId)
 }
 
 func isParticipantCommon(ctx context.Context, qq CommonOperations, userId int64, chatId int64) (bool, error) {
@@ -502,19 +521,19 @@ func (tx *Tx) IterateOverCoChattedParticipantIds(ctx context.Context, participan
 	return iterateOverCoChattedParticipantIdsCommon(ctx, tx, participantId, consumer)
 }
 
-func setAdminCommon(ctx context.Context, qq CommonOperations, userId int64, chatId int64, newAdmin bool) error {
-	if _, err := qq.ExecContext(ctx, "UPDATE chat_participant SET admin = $3 WHERE user_id = $1 AND chat_id = $2", userId, chatId, newAdmin); err != nil {
+func setParticipantRoleCommon(ctx context.Context, qq CommonOperations, userId int64, chatId int64, role string) error {
+	if _, err := qq.ExecContext(ctx, "UPDATE chat_participant SET role = $3 WHERE user_id = $1 AND chat_id = $2", userId, chatId, role); err != nil {
 		return eris.Wrap(err, "error during interacting with db")
 	}
 	return nil
 }
 
-func (tx *Tx) SetAdmin(ctx context.Context, userId int64, chatId int64, newAdmin bool) error {
-	return setAdminCommon(ctx, tx, userId, chatId, newAdmin)
+func (tx *Tx) SetParticipantRole(ctx context.Context, userId int64, chatId int64, role string) error {
+	return setParticipantRoleCommon(ctx, tx, userId, chatId, role)
 }
 
-func (db *DB) SetAdmin(ctx context.Context, userId int64, chatId int64, newAdmin bool) error {
-	return setAdminCommon(ctx, db, userId, chatId, newAdmin)
+func (db *DB) SetParticipantRole(ctx context.Context, userId int64, chatId int64, role string) error {
+	return setParticipantRoleCommon(ctx, db, userId, chatId, role)
 }
 
 func (tx *Tx) HasParticipants(ctx context.Context, chatIds []int64) (map[int64]bool, error) {
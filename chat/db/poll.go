@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"github.com/guregu/null"
+	"github.com/rotisserie/eris"
+)
+
+type Poll struct {
+	MessageId      int64
+	MultipleChoice bool
+	Anonymous      bool
+	ClosedDateTime null.Time
+	Options        []*PollOption
+}
+
+type PollOption struct {
+	Id       int64
+	Text     string
+	VoterIds []int64 // in the order of voting
+}
+
+func (p *Poll) HasOption(optionId int64) bool {
+	for _, o := range p.Options {
+		if o.Id == optionId {
+			return true
+		}
+	}
+	return false
+}
+
+func getPollsCommon(ctx context.Context, co CommonOperations, chatId int64, messageIds []int64) (map[int64]*Poll, error) {
+	ret := map[int64]*Poll{}
+	if len(messageIds) == 0 {
+		return ret, nil
+	}
+
+	rows, err := co.QueryContext(ctx, `SELECT message_id, multiple_choice, anonymous, closed_date_time FROM message_poll WHERE chat_id = $1 AND message_id = ANY ($2)`, chatId, messageIds)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		p := Poll{Options: make([]*PollOption, 0)}
+		if err := rows.Scan(&p.MessageId, &p.MultipleChoice, &p.Anonymous, &p.ClosedDateTime); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		ret[p.MessageId] = &p
+	}
+	if len(ret) == 0 {
+		return ret, nil
+	}
+
+	optionRows, err := co.QueryContext(ctx, `
+		SELECT o.message_id, o.id, o.text, v.user_id FROM message_poll_option o
+			LEFT JOIN message_poll_vote v ON v.option_id = o.id
+		WHERE o.chat_id = $1 AND o.message_id = ANY ($2)
+		ORDER BY o.id, v.create_date_time, v.user_id`, chatId, messageIds)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer optionRows.Close()
+	for optionRows.Next() {
+		var messageId int64
+		var option PollOption
+		var voterId null.Int
+		if err := optionRows.Scan(&messageId, &option.Id, &option.Text, &voterId); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		p, ok := ret[messageId]
+		if !ok {
+			continue
+		}
+		var current *PollOption
+		if len(p.Options) > 0 && p.Options[len(p.Options)-1].Id == option.Id {
+			current = p.Options[len(p.Options)-1]
+		} else {
+			option.VoterIds = make([]int64, 0)
+			current = &option
+			p.Options = append(p.Options, current)
+		}
+		if voterId.Valid {
+			current.VoterIds = append(current.VoterIds, voterId.Int64)
+		}
+	}
+	return ret, nil
+}
+
+func getPollCommon(ctx context.Context, co CommonOperations, chatId int64, messageId int64) (*Poll, error) {
+	polls, err := getPollsCommon(ctx, co, chatId, []int64{messageId})
+	if err != nil {
+		return nil, err
+	}
+	return polls[messageId], nil
+}
+
+func (db *DB) GetPoll(ctx context.Context, chatId int64, messageId int64) (*Poll, error) {
+	return getPollCommon(ctx, db, chatId, messageId)
+}
+
+func (tx *Tx) GetPoll(ctx context.Context, chatId int64, messageId int64) (*Poll, error) {
+	return getPollCommon(ctx, tx, chatId, messageId)
+}
+
+func enrichMessagesWithPolls(ctx context.Context, co CommonOperations, chatId int64, list []*Message) error {
+	messageIds := make([]int64, 0)
+	for _, message := range list {
+		messageIds = append(messageIds, message.Id)
+	}
+
+	polls, err := getPollsCommon(ctx, co, chatId, messageIds)
+	if err != nil {
+		return err
+	}
+	for _, message := range list {
+		message.Poll = polls[message.Id]
+	}
+	return nil
+}
+
+func (tx *Tx) CreatePoll(ctx context.Context, chatId int64, messageId int64, multipleChoice bool, anonymous bool, options []string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO message_poll (chat_id, message_id, multiple_choice, anonymous) VALUES ($1, $2, $3, $4)`, chatId, messageId, multipleChoice, anonymous)
+	if err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	for _, option := range options {
+		_, err = tx.ExecContext(ctx, `INSERT INTO message_poll_option (chat_id, message_id, text) VALUES ($1, $2, $3)`, chatId, messageId, option)
+		if err != nil {
+			return eris.Wrap(err, "error during interacting with db")
+		}
+	}
+	return nil
+}
+
+// replaces the user's previous choice, an empty optionIds retracts the vote
+func (tx *Tx) VotePoll(ctx context.Context, chatId int64, messageId int64, userId int64, optionIds []int64) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM message_poll_vote WHERE user_id = $3 AND option_id IN (SELECT id FROM message_poll_option WHERE chat_id = $1 AND message_id = $2)`, chatId, messageId, userId)
+	if err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	for _, optionId := range optionIds {
+		_, err = tx.ExecContext(ctx, `INSERT INTO message_poll_vote (option_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, optionId, userId)
+		if err != nil {
+			return eris.Wrap(err, "error during interacting with db")
+		}
+	}
+	return nil
+}
+
+func (tx *Tx) ClosePoll(ctx context.Context, chatId int64, messageId int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE message_poll SET closed_date_time = utc_now() WHERE chat_id = $1 AND message_id = $2 AND closed_date_time IS NULL`, chatId, messageId)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+func (tx *Tx) DeletePoll(ctx context.Context, chatId int64, messageId int64) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM message_poll WHERE chat_id = $1 AND message_id = $2`, chatId, messageId)
+	return eris.Wrap(err, "error during interacting with db")
+}
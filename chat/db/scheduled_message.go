@@ -0,0 +1,222 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/guregu/null"
+	"github.com/rotisserie/eris"
+	"time"
+)
+
+type ScheduledMessage struct {
+	Id               int64
+	ChatId           int64
+	OwnerId          int64
+	Text             string
+	FileItemUuid     *string
+	EmbedMessageId   *int64
+	EmbedChatId      *int64
+	EmbedMessageType *string
+	ThreadId         *int64
+	SendDateTime     time.Time
+	CreateDateTime   time.Time
+	EditDateTime     null.Time
+}
+
+const selectScheduledMessageClause = `SELECT 
+		id, 
+		chat_id, 
+		owner_id, 
+		text, 
+		file_item_uuid, 
+		embed_message_id, 
+		embed_chat_id, 
+		embed_message_type, 
+		thread_id, 
+		send_date_time, 
+		create_date_time, 
+		edit_date_time 
+	FROM scheduled_message `
+
+func provideScanToScheduledMessage(m *ScheduledMessage) []any {
+	return []any{
+		&m.Id,
+		&m.ChatId,
+		&m.OwnerId,
+		&m.Text,
+		&m.FileItemUuid,
+		&m.EmbedMessageId,
+		&m.EmbedChatId,
+		&m.EmbedMessageType,
+		&m.ThreadId,
+		&m.SendDateTime,
+		&m.CreateDateTime,
+		&m.EditDateTime,
+	}
+}
+
+func scanScheduledMessages(rows *sql.Rows) ([]*ScheduledMessage, error) {
+	list := make([]*ScheduledMessage, 0)
+	for rows.Next() {
+		m := ScheduledMessage{}
+		if err := rows.Scan(provideScanToScheduledMessage(&m)[:]...); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, &m)
+	}
+	return list, nil
+}
+
+func (tx *Tx) CreateScheduledMessage(ctx context.Context, m *ScheduledMessage) (int64, error) {
+	if m == nil {
+		return 0, eris.New("message required")
+	} else if m.Text == "" {
+		return 0, eris.New("text required")
+	}
+
+	res := tx.QueryRowContext(ctx, `INSERT INTO scheduled_message (chat_id, owner_id, text, file_item_uuid, embed_message_id, embed_chat_id, embed_message_type, thread_id, send_date_time) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		m.ChatId, m.OwnerId, m.Text, m.FileItemUuid, m.EmbedMessageId, m.EmbedChatId, m.EmbedMessageType, m.ThreadId, m.SendDateTime)
+	var id int64
+	if err := res.Scan(&id); err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return id, nil
+}
+
+func (tx *Tx) EditScheduledMessage(ctx context.Context, m *ScheduledMessage) error {
+	if m == nil {
+		return eris.New("message required")
+	} else if m.Text == "" {
+		return eris.New("text required")
+	} else if m.Id == 0 {
+		return eris.New("id required")
+	}
+
+	if res, err := tx.ExecContext(ctx, `UPDATE scheduled_message SET text = $1, file_item_uuid = $2, embed_message_id = $3, embed_chat_id = $4, embed_message_type = $5, thread_id = $6, send_date_time = $7, edit_date_time = utc_now() WHERE id = $8 AND chat_id = $9 AND owner_id = $10`,
+		m.Text, m.FileItemUuid, m.EmbedMessageId, m.EmbedChatId, m.EmbedMessageType, m.ThreadId, m.SendDateTime, m.Id, m.ChatId, m.OwnerId); err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	} else {
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return eris.Wrap(err, "error during interacting with db")
+		}
+		if affected == 0 {
+			return eris.New("No rows affected")
+		}
+	}
+	return nil
+}
+
+func (tx *Tx) DeleteScheduledMessage(ctx context.Context, chatId int64, id int64, ownerId int64) (bool, error) {
+	res, err := tx.ExecContext(ctx, `DELETE FROM scheduled_message WHERE id = $1 AND chat_id = $2 AND owner_id = $3`, id, chatId, ownerId)
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, eris.Wrap(err, "error during interacting with db")
+	}
+	return affected > 0, nil
+}
+
+// moves the send time of the message which cannot be sent yet, e.g. because of the slow mode
+func (tx *Tx) PostponeScheduledMessage(ctx context.Context, id int64, sendDateTime time.Time) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE scheduled_message SET send_date_time = $2 WHERE id = $1`, id, sendDateTime); err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	return nil
+}
+
+// takes the due message for sending, the row lock prevents the concurrent edit or cancel
+func (tx *Tx) TakeScheduledMessage(ctx context.Context, id int64) (*ScheduledMessage, error) {
+	row := tx.QueryRowContext(ctx, selectScheduledMessageClause+`WHERE id = $1 FOR UPDATE`, id)
+	m := ScheduledMessage{}
+	err := row.Scan(provideScanToScheduledMessage(&m)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM scheduled_message WHERE id = $1`, id); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &m, nil
+}
+
+func getScheduledMessageCommon(ctx context.Context, co CommonOperations, chatId int64, id int64, ownerId int64) (*ScheduledMessage, error) {
+	row := co.QueryRowContext(ctx, selectScheduledMessageClause+`WHERE id = $1 AND chat_id = $2 AND owner_id = $3`, id, chatId, ownerId)
+	m := ScheduledMessage{}
+	err := row.Scan(provideScanToScheduledMessage(&m)[:]...)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &m, nil
+}
+
+func (db *DB) GetScheduledMessage(ctx context.Context, chatId int64, id int64, ownerId int64) (*ScheduledMessage, error) {
+	return getScheduledMessageCommon(ctx, db, chatId, id, ownerId)
+}
+
+func (tx *Tx) GetScheduledMessage(ctx context.Context, chatId int64, id int64, ownerId int64) (*ScheduledMessage, error) {
+	return getScheduledMessageCommon(ctx, tx, chatId, id, ownerId)
+}
+
+func getScheduledMessagesCommon(ctx context.Context, co CommonOperations, chatId int64, ownerId int64, limit int, offset int) ([]*ScheduledMessage, error) {
+	rows, err := co.QueryContext(ctx, selectScheduledMessageClause+`WHERE chat_id = $1 AND owner_id = $2 ORDER BY send_date_time, id LIMIT $3 OFFSET $4`, chatId, ownerId, limit, offset)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	return scanScheduledMessages(rows)
+}
+
+func (db *DB) GetScheduledMessages(ctx context.Context, chatId int64, ownerId int64, limit int, offset int) ([]*ScheduledMessage, error) {
+	return getScheduledMessagesCommon(ctx, db, chatId, ownerId, limit, offset)
+}
+
+func (tx *Tx) GetScheduledMessages(ctx context.Context, chatId int64, ownerId int64, limit int, offset int) ([]*ScheduledMessage, error) {
+	return getScheduledMessagesCommon(ctx, tx, chatId, ownerId, limit, offset)
+}
+
+func getScheduledMessagesCountCommon(ctx context.Context, co CommonOperations, chatId int64, ownerId int64) (int64, error) {
+	var count int64
+	row := co.QueryRowContext(ctx, `SELECT count(*) FROM scheduled_message WHERE chat_id = $1 AND owner_id = $2`, chatId, ownerId)
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, eris.Wrap(err, "error during interacting with db")
+	}
+	return count, nil
+}
+
+func (db *DB) GetScheduledMessagesCount(ctx context.Context, chatId int64, ownerId int64) (int64, error) {
+	return getScheduledMessagesCountCommon(ctx, db, chatId, ownerId)
+}
+
+func (tx *Tx) GetScheduledMessagesCount(ctx context.Context, chatId int64, ownerId int64) (int64, error) {
+	return getScheduledMessagesCountCommon(ctx, tx, chatId, ownerId)
+}
+
+// returns the ids of the messages which should be sent, the earliest first
+func (db *DB) GetDueScheduledMessageIds(ctx context.Context, limit int) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id FROM scheduled_message WHERE send_date_time <= utc_now() ORDER BY send_date_time, id LIMIT $1`, limit)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, id)
+	}
+	return list, nil
+}
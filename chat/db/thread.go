@@ -0,0 +1,195 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/guregu/null"
+	"github.com/rotisserie/eris"
+)
+
+type MessageThread struct {
+	ChatId            int64
+	RootMessageId     int64
+	ReplyCount        int64
+	LastReplyDateTime null.Time
+}
+
+func getThreadMessagesCommon(ctx context.Context, co CommonOperations, chatId int64, rootMessageId int64, limit int, startingFromItemId *int64, includeStartingFrom, reverse bool) ([]*Message, error) {
+	list, err := getMessagesSimple(ctx, co, chatId, &rootMessageId, limit, startingFromItemId, includeStartingFrom, reverse, "")
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+
+	err = enrichMessagesWithReactions(ctx, co, chatId, list)
+	if err != nil {
+		return nil, fmt.Errorf("Got error during enriching messages with reactions: %v", err)
+	}
+
+	err = enrichMessagesWithPolls(ctx, co, chatId, list)
+	if err != nil {
+		return nil, fmt.Errorf("Got error during enriching messages with polls: %v", err)
+	}
+
+	return list, nil
+}
+
+func (db *DB) GetThreadMessages(ctx context.Context, chatId int64, rootMessageId int64, limit int, startingFromItemId *int64, includeStartingFrom, reverse bool) ([]*Message, error) {
+	return getThreadMessagesCommon(ctx, db, chatId, rootMessageId, limit, startingFromItemId, includeStartingFrom, reverse)
+}
+
+func (tx *Tx) GetThreadMessages(ctx context.Context, chatId int64, rootMessageId int64, limit int, startingFromItemId *int64, includeStartingFrom, reverse bool) ([]*Message, error) {
+	return getThreadMessagesCommon(ctx, tx, chatId, rootMessageId, limit, startingFromItemId, includeStartingFrom, reverse)
+}
+
+// recalculates reply count and last reply time from the actual replies, so it can be used both after adding and after removing a reply
+func (tx *Tx) RefreshThread(ctx context.Context, chatId int64, rootMessageId int64) (*MessageThread, error) {
+	row := tx.QueryRowContext(ctx, fmt.Sprintf(`
+		INSERT INTO message_thread(chat_id, root_message_id, reply_count, last_reply_date_time)
+			SELECT $1, $2, count(m.id), max(m.create_date_time) FROM message_chat_%v m WHERE m.thread_id = $2 AND m.deleted_date_time IS NULL
+		ON CONFLICT (chat_id, root_message_id) DO UPDATE SET reply_count = EXCLUDED.reply_count, last_reply_date_time = EXCLUDED.last_reply_date_time
+		RETURNING chat_id, root_message_id, reply_count, last_reply_date_time
+	`, chatId), chatId, rootMessageId)
+	var mt = MessageThread{}
+	if err := row.Scan(&mt.ChatId, &mt.RootMessageId, &mt.ReplyCount, &mt.LastReplyDateTime); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &mt, nil
+}
+
+func getThreadCommon(ctx context.Context, co CommonOperations, chatId int64, rootMessageId int64) (*MessageThread, error) {
+	row := co.QueryRowContext(ctx, `SELECT chat_id, root_message_id, reply_count, last_reply_date_time FROM message_thread WHERE chat_id = $1 AND root_message_id = $2`, chatId, rootMessageId)
+	var mt = MessageThread{}
+	err := row.Scan(&mt.ChatId, &mt.RootMessageId, &mt.ReplyCount, &mt.LastReplyDateTime)
+	if errors.Is(err, sql.ErrNoRows) {
+		// there were no rows, but otherwise no error occurred
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	return &mt, nil
+}
+
+func (db *DB) GetThread(ctx context.Context, chatId int64, rootMessageId int64) (*MessageThread, error) {
+	return getThreadCommon(ctx, db, chatId, rootMessageId)
+}
+
+func (tx *Tx) GetThread(ctx context.Context, chatId int64, rootMessageId int64) (*MessageThread, error) {
+	return getThreadCommon(ctx, tx, chatId, rootMessageId)
+}
+
+// removes the replies and the thread's bookkeeping, is used when the root message is purged
+func (tx *Tx) DeleteThread(ctx context.Context, chatId int64, rootMessageId int64) error {
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM message_revision WHERE chat_id = $1 AND message_id IN (SELECT id FROM message_chat_%v WHERE thread_id = $2)`, chatId), chatId, rootMessageId); err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM message_poll WHERE chat_id = $1 AND message_id IN (SELECT id FROM message_chat_%v WHERE thread_id = $2)`, chatId), chatId, rootMessageId); err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	if err := deleteThreadBookmarks(ctx, tx, chatId, rootMessageId); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM message_chat_%v WHERE thread_id = $1`, chatId), rootMessageId); err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_thread_read WHERE chat_id = $1 AND root_message_id = $2`, chatId, rootMessageId); err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_thread WHERE chat_id = $1 AND root_message_id = $2`, chatId, rootMessageId); err != nil {
+		return eris.Wrap(err, "error during interacting with db")
+	}
+	return nil
+}
+
+// removes the replies with their revisions and returns them, the thread itself stays until DeleteThread
+func (tx *Tx) DeleteThreadReplies(ctx context.Context, chatId int64, rootMessageId int64) ([]ExpiredMessage, error) {
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM message_revision WHERE chat_id = $1 AND message_id IN (SELECT id FROM message_chat_%v WHERE thread_id = $2)`, chatId), chatId, rootMessageId); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM message_poll WHERE chat_id = $1 AND message_id IN (SELECT id FROM message_chat_%v WHERE thread_id = $2)`, chatId), chatId, rootMessageId); err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	if err := deleteThreadBookmarks(ctx, tx, chatId, rootMessageId); err != nil {
+		return nil, err
+	}
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`DELETE FROM message_chat_%v WHERE thread_id = $1 RETURNING id, thread_id, file_item_uuid`, chatId), rootMessageId)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	list := make([]ExpiredMessage, 0)
+	for rows.Next() {
+		em := ExpiredMessage{}
+		if err := rows.Scan(&em.Id, &em.ThreadId, &em.FileItemUuid); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		}
+		list = append(list, em)
+	}
+	return list, nil
+}
+
+func (tx *Tx) MarkThreadAsRead(ctx context.Context, chatId int64, rootMessageId int64, participantId int64, messageId *int64) error {
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		WITH calced_last_message_id AS (SELECT COALESCE((SELECT max(id) from message_chat_%v WHERE thread_id = $4), 0))
+		INSERT INTO message_thread_read (last_message_id, user_id, chat_id, root_message_id)
+			VALUES((SELECT * FROM calced_last_message_id), $1, $2, $4)
+		ON CONFLICT (user_id, chat_id, root_message_id) DO UPDATE SET last_message_id = (
+			CASE
+				WHEN ($3::bigint <= (SELECT * FROM calced_last_message_id)) THEN (
+					CASE
+						WHEN ($3::bigint > message_thread_read.last_message_id) THEN $3::bigint
+						ELSE message_thread_read.last_message_id
+					END
+				)
+				ELSE (SELECT * FROM calced_last_message_id)
+			END
+		)
+			WHERE message_thread_read.user_id = $1 AND message_thread_read.chat_id = $2 AND message_thread_read.root_message_id = $4
+		`, chatId),
+		participantId, chatId, messageId, rootMessageId)
+	return eris.Wrap(err, "error during interacting with db")
+}
+
+func getThreadUnreadRepliesCountBatchByParticipantsCommon(ctx context.Context, co CommonOperations, userIds []int64, chatId int64, rootMessageId int64) (map[int64]int64, error) {
+	res := map[int64]int64{}
+
+	if len(userIds) == 0 {
+		return res, nil
+	}
+
+	for _, uid := range userIds {
+		res[uid] = 0
+	}
+
+	rows, err := co.QueryContext(ctx, fmt.Sprintf(`
+		SELECT u.user_id, (
+			SELECT count(1) FROM message_chat_%v m
+			WHERE m.thread_id = $2 AND m.deleted_date_time IS NULL AND m.id > COALESCE((SELECT r.last_message_id FROM message_thread_read r WHERE r.user_id = u.user_id AND r.chat_id = $1 AND r.root_message_id = $2), 0)
+		)
+		FROM unnest($3::bigint[]) AS u(user_id)
+	`, chatId), chatId, rootMessageId, userIds)
+	if err != nil {
+		return nil, eris.Wrap(err, "error during interacting with db")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userId int64
+		var count int64
+		if err := rows.Scan(&userId, &count); err != nil {
+			return nil, eris.Wrap(err, "error during interacting with db")
+		} else {
+			res[userId] = count
+		}
+	}
+	return res, nil
+}
+
+func (db *DB) GetThreadUnreadRepliesCountBatchByParticipants(ctx context.Context, userIds []int64, chatId int64, rootMessageId int64) (map[int64]int64, error) {
+	return getThreadUnreadRepliesCountBatchByParticipantsCommon(ctx, db, userIds, chatId, rootMessageId)
+}
+
+func (tx *Tx) GetThreadUnreadRepliesCountBatchByParticipants(ctx context.Context, userIds []int64, chatId int64, rootMessageId int64) (map[int64]int64, error) {
+	return getThreadUnreadRepliesCountBatchByParticipantsCommon(ctx, tx, userIds, chatId, rootMessageId)
+}
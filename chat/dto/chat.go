@@ -35,28 +35,55 @@ type BaseChatDto struct {
 	BlogAbout                           bool        `json:"blogAbout"`
 	RegularParticipantCanWriteMessage   bool        `json:"regularParticipantCanWriteMessage"`
 	CanWriteMessage                     bool        `json:"canWriteMessage"`
+	RegularParticipantCanSeeEditHistory bool        `json:"regularParticipantCanSeeEditHistory"`
+	CanSeeEditHistory                   bool        `json:"canSeeEditHistory"`
+	MessageRetentionSeconds             null.Int    `json:"messageRetentionSeconds"` // null keeps the messages forever
+	JoinByRequest                       bool        `json:"joinByRequest"`           // the searchable chat is joined via the approval of an admin
+	SlowModeSeconds                     null.Int    `json:"slowModeSeconds"`         // a non-admin participant can post one message per this interval, null turns it off
+	Role                                string      `json:"role"`                    // of the current user
+	Permissions                         []string    `json:"permissions"`             // the effective permissions of the current user
 }
 
-func (copied *BaseChatDto) SetPersonalizedFields(admin bool, unreadMessages int64, participant bool, pinned bool) {
-	copied.CanEdit = null.BoolFrom(admin && !copied.IsTetATet)
-	copied.CanDelete = null.BoolFrom(admin)
-	copied.CanLeave = null.BoolFrom(!admin && !copied.IsTetATet && participant)
+func (copied *BaseChatDto) PermissionSettings() ChatPermissionSettings {
+	return ChatPermissionSettings{
+		MemberCanWriteMessage:   copied.RegularParticipantCanWriteMessage,
+		MemberCanPinMessage:     copied.RegularParticipantCanPinMessage,
+		MemberCanPublishMessage: copied.RegularParticipantCanPublishMessage,
+		MemberCanSeeEditHistory: copied.RegularParticipantCanSeeEditHistory,
+	}
+}
+
+func (copied *BaseChatDto) SetPersonalizedFields(role string, unreadMessages int64, participant bool, pinned bool) {
+	settings := copied.PermissionSettings()
+	canManageChat := HasChatPermission(role, settings, PermissionManageChat)
+
+	copied.CanEdit = null.BoolFrom(canManageChat && !copied.IsTetATet)
+	copied.CanDelete = null.BoolFrom(canManageChat)
+	copied.CanLeave = null.BoolFrom(role != ChatRoleOwner && !copied.IsTetATet && participant)
 	copied.UnreadMessages = unreadMessages
-	copied.CanVideoKick = admin
-	copied.CanAudioMute = admin
-	copied.CanChangeChatAdmins = admin && !copied.IsTetATet
-	copied.CanBroadcast = admin
+	copied.CanVideoKick = HasChatPermission(role, settings, PermissionKickFromVideo)
+	copied.CanAudioMute = HasChatPermission(role, settings, PermissionKickFromVideo)
+	copied.CanChangeChatAdmins = HasChatPermission(role, settings, PermissionManageParticipants) && !copied.IsTetATet
+	copied.CanBroadcast = canManageChat
 
 	if !participant {
 		copied.IsResultFromSearch = null.BoolFrom(true)
 	}
 
-	copied.CanWriteMessage = true
-	// see also handlers PostMessage, EditMessage, DeleteMessage
-	if !copied.RegularParticipantCanWriteMessage && !admin {
-		copied.CanWriteMessage = false
+	// the search result shows what the user is able to do after joining
+	prospectiveRole := role
+	if !participant && role == "" {
+		prospectiveRole = ChatRoleMember
 	}
 
+	// see also handlers PostMessage, EditMessage, DeleteMessage
+	copied.CanWriteMessage = HasChatPermission(prospectiveRole, settings, PermissionWrite)
+
+	copied.CanSeeEditHistory = HasChatPermission(prospectiveRole, settings, PermissionSeeEditHistory)
+
+	copied.Role = role
+	copied.Permissions = GetChatPermissions(role, settings)
+
 	copied.Pinned = pinned
 }
 
@@ -66,8 +93,9 @@ type ChatDeletedDto struct {
 
 type ChatDto struct {
 	BaseChatDto
-	Participants       []*User `json:"participants"`
-	LastMessagePreview *string `json:"lastMessagePreview"`
+	Participants       []*User          `json:"participants"`
+	LastMessagePreview *string          `json:"lastMessagePreview"`
+	Draft              *MessageDraftDto `json:"draft"` // of the current user
 }
 
 type ChatDtoWithTetATet interface {
@@ -156,3 +184,261 @@ type ChatId struct {
 	LastUpdateDateTime time.Time `json:"lastUpdateDateTime"`
 	Id                 int64     `json:"id"`
 }
+
+type ChatExportDto struct {
+	Id             int64     `json:"id"`
+	ChatId         int64     `json:"chatId"`
+	Status         string    `json:"status"`
+	Filename       *string   `json:"filename"`
+	Url            *string   `json:"url"`
+	FileItemUuid   *string   `json:"fileItemUuid"`
+	CreateDateTime time.Time `json:"createDateTime"`
+	FinishDateTime null.Time `json:"finishDateTime"`
+}
+
+type ChatInviteLinkDto struct {
+	Id             int64     `json:"id"`
+	ChatId         int64     `json:"chatId"`
+	Token          string    `json:"token"`
+	OwnerId        int64     `json:"ownerId"`
+	MakeAdmin      bool      `json:"makeAdmin"`
+	MaxUses        null.Int  `json:"maxUses"`
+	Uses           int64     `json:"uses"`
+	ExpireDateTime null.Time `json:"expireDateTime"`
+	RevokeDateTime null.Time `json:"revokeDateTime"`
+	CreateDateTime time.Time `json:"createDateTime"`
+	Usable         bool      `json:"usable"`
+}
+
+type ChatJoinRequestDto struct {
+	Id             int64     `json:"id"`
+	ChatId         int64     `json:"chatId"`
+	User           *User     `json:"user"`
+	CreateDateTime time.Time `json:"createDateTime"`
+}
+
+type ChatBanDto struct {
+	Id             int64       `json:"id"`
+	ChatId         int64       `json:"chatId"`
+	User           *User       `json:"user"`
+	BannedBy       *User       `json:"bannedBy"`
+	Reason         null.String `json:"reason"`
+	ExpireDateTime null.Time   `json:"expireDateTime"` // null means the ban is permanent
+	CreateDateTime time.Time   `json:"createDateTime"`
+}
+
+const (
+	ModerationActionDismiss       = "dismiss"
+	ModerationActionDeleteMessage = "delete_message"
+	ModerationActionRemoveUser    = "remove_user"
+	ModerationActionBanUser       = "ban_user"
+	// the decisions about the message which was held by the content filter
+	ModerationActionApproveMessage = "approve_message"
+	ModerationActionRejectMessage  = "reject_message"
+)
+
+// the decisions which can be made about the reported message
+var ModerationResolutions = []interface{}{ModerationActionDismiss, ModerationActionDeleteMessage, ModerationActionRemoveUser, ModerationActionBanUser}
+
+type MessageReportDto struct {
+	Id             int64     `json:"id"`
+	ChatId         int64     `json:"chatId"`
+	ChatTitle      string    `json:"chatTitle"`
+	MessageId      int64     `json:"messageId"`
+	MessageOwner   *User     `json:"messageOwner"`
+	MessageText    string    `json:"messageText"` // as it was at the moment of reporting
+	Reporter       *User     `json:"reporter"`
+	Reason         string    `json:"reason"`
+	CreateDateTime time.Time `json:"createDateTime"`
+}
+
+const (
+	ContentFilterActionReject = "reject"
+	ContentFilterActionMask   = "mask"
+	ContentFilterActionHold   = "hold"
+)
+
+var ContentFilterActions = []interface{}{ContentFilterActionReject, ContentFilterActionMask, ContentFilterActionHold}
+
+type BlocklistEntryDto struct {
+	Id             int64     `json:"id"`
+	ChatId         null.Int  `json:"chatId"` // null means the entry is global
+	Pattern        string    `json:"pattern"`
+	Regex          bool      `json:"regex"`
+	Action         string    `json:"action"`
+	CreateDateTime time.Time `json:"createDateTime"`
+}
+
+type ChatEmojiDto struct {
+	Id             int64     `json:"id"`
+	ChatId         int64     `json:"chatId"`
+	Shortcode      string    `json:"shortcode"`
+	Url            string    `json:"url"`
+	CreatedBy      int64     `json:"createdBy"`
+	CreateDateTime time.Time `json:"createDateTime"`
+}
+
+type ModerationActionDto struct {
+	Id             int64       `json:"id"`
+	ChatId         int64       `json:"chatId"`
+	MessageId      null.Int    `json:"messageId"`
+	TargetUser     *User       `json:"targetUser"`
+	Action         string      `json:"action"`
+	Actor          *User       `json:"actor"`
+	Comment        null.String `json:"comment"`
+	CreateDateTime time.Time   `json:"createDateTime"`
+}
+
+// the structures below form the exported archive
+
+type ExportedChatDto struct {
+	Id             int64     `json:"id"`
+	Title          string    `json:"title"`
+	IsTetATet      bool      `json:"tetATet"`
+	IsBlog         bool      `json:"blog"`
+	CreateDateTime time.Time `json:"createDateTime"`
+	ExportDateTime time.Time `json:"exportDateTime"`
+}
+
+type ExportedUserDto struct {
+	Id    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+type ExportedEmbedMessageDto struct {
+	Id         int64  `json:"id"`
+	ChatId     *int64 `json:"chatId"` // the chat of the resent message
+	EmbedType  string `json:"embedType"`
+	OwnerId    int64  `json:"ownerId"`
+	OwnerLogin string `json:"ownerLogin"`
+	Text       string `json:"text"`
+}
+
+type ExportedReactionDto struct {
+	Reaction string   `json:"reaction"`
+	UserIds  []int64  `json:"userIds"`
+	Logins   []string `json:"logins"`
+}
+
+type ExportedMessageDto struct {
+	Id             int64                    `json:"id"`
+	OwnerId        int64                    `json:"ownerId"`
+	OwnerLogin     string                   `json:"ownerLogin"`
+	Text           string                   `json:"text"`
+	CreateDateTime time.Time                `json:"createDateTime"`
+	EditDateTime   null.Time                `json:"editDateTime"`
+	FileItemUuid   *string                  `json:"fileItemUuid"`
+	EmbedMessage   *ExportedEmbedMessageDto `json:"embedMessage"`
+	Reactions      []ExportedReactionDto    `json:"reactions"`
+	Pinned         bool                     `json:"pinned"`
+	Published      bool                     `json:"published"`
+	BlogPost       bool                     `json:"blogPost"`
+	ThreadId       *int64                   `json:"threadId"`
+	Deleted        bool                     `json:"deleted"`
+}
+
+type ChatImportResultDto struct {
+	ChatId                int64                   `json:"chatId"`
+	ImportId              int64                   `json:"importId"`
+	Created               bool                    `json:"created"`
+	ImportedMessages      int                     `json:"importedMessages"`      // during this request
+	SkippedMessages       int                     `json:"skippedMessages"`       // were imported previously
+	TotalImportedMessages int64                   `json:"totalImportedMessages"` // during all the attempts
+	UnmappedAuthors       []ChatImportedAuthorDto `json:"unmappedAuthors"`
+}
+
+// the author of the external archive who was neither found in the mapping nor recognized as the local user
+type ChatImportedAuthorDto struct {
+	ExternalUserId string `json:"externalUserId"`
+	Name           string `json:"name"`
+	PlaceholderId  int64  `json:"placeholderId"`
+}
+
+type ChatIncomingWebhookDto struct {
+	Id             int64     `json:"id"`
+	ChatId         int64     `json:"chatId"`
+	Name           string    `json:"name"`
+	Token          string    `json:"token,omitempty"` // is returned only once, on creating the webhook
+	OwnerId        int64     `json:"ownerId"`
+	CreateDateTime time.Time `json:"createDateTime"`
+}
+
+// the events of the chat which can be posted by the outgoing webhooks, they're named as the events published to the users
+const (
+	OutgoingWebhookMessageCreated     = "message_created"
+	OutgoingWebhookMessageEdited      = "message_edited"
+	OutgoingWebhookMessageDeleted     = "message_deleted"
+	OutgoingWebhookParticipantAdded   = "participant_added"
+	OutgoingWebhookParticipantDeleted = "participant_deleted"
+	OutgoingWebhookParticipantEdited  = "participant_edited"
+)
+
+var OutgoingWebhookEventTypes = []interface{}{
+	OutgoingWebhookMessageCreated,
+	OutgoingWebhookMessageEdited,
+	OutgoingWebhookMessageDeleted,
+	OutgoingWebhookParticipantAdded,
+	OutgoingWebhookParticipantDeleted,
+	OutgoingWebhookParticipantEdited,
+}
+
+type ChatOutgoingWebhookDto struct {
+	Id             int64     `json:"id"`
+	ChatId         int64     `json:"chatId"`
+	Url            string    `json:"url"`
+	Secret         string    `json:"secret"` // the key of the signature of the payloads
+	EventTypes     []string  `json:"eventTypes"`
+	OwnerId        int64     `json:"ownerId"`
+	CreateDateTime time.Time `json:"createDateTime"`
+}
+
+// the payload of the outgoing webhook, it's the ChatEvent which isn't addressed to a particular user
+type OutgoingWebhookEvent struct {
+	EventType                  string             `json:"eventType"`
+	ChatId                     int64              `json:"chatId"`
+	MessageNotification        *DisplayMessageDto `json:"messageNotification,omitempty"`
+	MessageDeletedNotification *MessageDeletedDto `json:"messageDeletedNotification,omitempty"`
+	Participants               *[]*UserWithAdmin  `json:"participants,omitempty"`
+	CreateDateTime             time.Time          `json:"createDateTime"`
+}
+
+type ChatSlashCommandDto struct {
+	Id             int64     `json:"id"`
+	ChatId         int64     `json:"chatId"`
+	Name           string    `json:"name"` // without the leading slash
+	Description    string    `json:"description"`
+	Url            string    `json:"url"`
+	Secret         string    `json:"secret"` // the key of the signature of the invocations
+	OwnerId        int64     `json:"ownerId"`
+	CreateDateTime time.Time `json:"createDateTime"`
+}
+
+// is shown to the participants for the autocompletion
+type AvailableSlashCommandDto struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Usage       string `json:"usage"`
+	BuiltIn     bool   `json:"builtIn"`
+}
+
+// the responses of the external command
+const (
+	SlashCommandResponseEphemeral = "ephemeral"
+	SlashCommandResponseVisible   = "visible"
+)
+
+// is posted to the url of the registered command
+type SlashCommandInvocation struct {
+	Command   string `json:"command"`
+	Text      string `json:"text"` // the arguments, without the command itself
+	ChatId    int64  `json:"chatId"`
+	ThreadId  *int64 `json:"threadId"`
+	UserId    int64  `json:"userId"`
+	UserLogin string `json:"userLogin"`
+}
+
+// is expected in the response of the registered command, the empty text means no reply
+type SlashCommandResponse struct {
+	Text         string `json:"text"`
+	ResponseType string `json:"responseType"` // ephemeral by default
+}
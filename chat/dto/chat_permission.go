@@ -0,0 +1,133 @@
+package dto
+
+const (
+	ChatRoleOwner     = "owner"
+	ChatRoleAdmin     = "admin"
+	ChatRoleModerator = "moderator"
+	ChatRoleMember    = "member"
+	ChatRoleReadOnly  = "read_only"
+)
+
+// the roles which can be assigned via the api, the owner is only the creator of the chat
+var AssignableChatRoles = []interface{}{ChatRoleAdmin, ChatRoleModerator, ChatRoleMember, ChatRoleReadOnly}
+
+const (
+	PermissionWrite                = "write"
+	PermissionPin                  = "pin"
+	PermissionPublish              = "publish"
+	PermissionSeeEditHistory       = "see_edit_history"
+	PermissionDeleteOthersMessages = "delete_others_messages"
+	PermissionManageParticipants   = "manage_participants"
+	PermissionManageChat           = "manage_chat"
+	PermissionStartRecording       = "start_recording"
+	PermissionKickFromVideo        = "kick_from_video"
+	PermissionModerate             = "moderate"
+)
+
+var allPermissions = []string{
+	PermissionWrite,
+	PermissionPin,
+	PermissionPublish,
+	PermissionSeeEditHistory,
+	PermissionDeleteOthersMessages,
+	PermissionManageParticipants,
+	PermissionManageChat,
+	PermissionStartRecording,
+	PermissionKickFromVideo,
+	PermissionModerate,
+}
+
+var chatRolePermissions = map[string]map[string]bool{
+	ChatRoleOwner: setOfPermissions(allPermissions...),
+	ChatRoleAdmin: setOfPermissions(allPermissions...),
+	ChatRoleModerator: setOfPermissions(
+		PermissionWrite,
+		PermissionPin,
+		PermissionPublish,
+		PermissionSeeEditHistory,
+		PermissionDeleteOthersMessages,
+		PermissionKickFromVideo,
+		PermissionModerate,
+	),
+	// the upper bound of the member, the chat narrows it by ChatPermissionSettings
+	ChatRoleMember: setOfPermissions(
+		PermissionWrite,
+		PermissionPin,
+		PermissionPublish,
+		PermissionSeeEditHistory,
+	),
+	ChatRoleReadOnly: setOfPermissions(
+		PermissionSeeEditHistory,
+	),
+}
+
+func setOfPermissions(permissions ...string) map[string]bool {
+	ret := map[string]bool{}
+	for _, p := range permissions {
+		ret[p] = true
+	}
+	return ret
+}
+
+// the per-chat overrides of the member and the read-only roles, they are stored in the regular_participant_can_* columns of the chat.
+// the roles are the same for all the chats, so these switches are the only thing which the chat admin can tune,
+// they can only take away the permission of chatRolePermissions, never grant it
+type ChatPermissionSettings struct {
+	MemberCanWriteMessage   bool
+	MemberCanPinMessage     bool
+	MemberCanPublishMessage bool
+	MemberCanSeeEditHistory bool
+}
+
+// the second value is false when the permission isn't overridable per chat
+func (s ChatPermissionSettings) override(permission string) (bool, bool) {
+	switch permission {
+	case PermissionWrite:
+		return s.MemberCanWriteMessage, true
+	case PermissionPin:
+		return s.MemberCanPinMessage, true
+	case PermissionPublish:
+		return s.MemberCanPublishMessage, true
+	case PermissionSeeEditHistory:
+		return s.MemberCanSeeEditHistory, true
+	default:
+		return false, false
+	}
+}
+
+func isOverridableRole(role string) bool {
+	return role == ChatRoleMember || role == ChatRoleReadOnly
+}
+
+func IsValidChatRole(role string) bool {
+	_, ok := chatRolePermissions[role]
+	return ok
+}
+
+// the only place which decides what the participant is allowed to do in the chat, the empty role means not a participant
+func HasChatPermission(role string, settings ChatPermissionSettings, permission string) bool {
+	if !chatRolePermissions[role][permission] {
+		return false
+	}
+	if isOverridableRole(role) {
+		if allowed, overridden := settings.override(permission); overridden {
+			return allowed
+		}
+	}
+	return true
+}
+
+func GetChatPermissions(role string, settings ChatPermissionSettings) []string {
+	ret := make([]string, 0)
+	for _, p := range allPermissions {
+		if HasChatPermission(role, settings, p) {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// the roles having all the permissions of the chat, they were the admins before the roles
+func IsChatAdminRole(role string) bool {
+	return role == ChatRoleOwner || role == ChatRoleAdmin
+}
@@ -25,30 +25,97 @@ type EmbedMessageRequest struct {
 }
 
 type Reaction struct {
-	Count    int64   `json:"count"`
-	Users    []*User `json:"users"`
-	Reaction string  `json:"reaction"`
+	Count    int64       `json:"count"`
+	Users    []*User     `json:"users"`
+	Reaction string      `json:"reaction"`
+	Url      null.String `json:"url"` // the image of the custom emoji
 }
 
 type DisplayMessageDto struct {
-	Id             int64                 `json:"id"`
-	Text           string                `json:"text"`
-	ChatId         int64                 `json:"chatId"`
-	OwnerId        int64                 `json:"ownerId"`
-	CreateDateTime time.Time             `json:"createDateTime"`
-	EditDateTime   null.Time             `json:"editDateTime"`
-	Owner          *User                 `json:"owner"`
-	CanEdit        bool                  `json:"canEdit"`
-	CanDelete      bool                  `json:"canDelete"`
-	FileItemUuid   *string               `json:"fileItemUuid"`
-	EmbedMessage   *EmbedMessageResponse `json:"embedMessage"`
-	Pinned         bool                  `json:"pinned"`
-	BlogPost       bool                  `json:"blogPost"`
-	PinnedPromoted *bool                 `json:"pinnedPromoted"`
-	Reactions      []Reaction            `json:"reactions"`
-	Published      bool                  `json:"published"`
-	CanPublish     bool                  `json:"canPublish"`
-	CanPin         bool                  `json:"canPin"`
+	Id              int64                 `json:"id"`
+	Text            string                `json:"text"`
+	ChatId          int64                 `json:"chatId"`
+	OwnerId         int64                 `json:"ownerId"`
+	CreateDateTime  time.Time             `json:"createDateTime"`
+	EditDateTime    null.Time             `json:"editDateTime"`
+	Owner           *User                 `json:"owner"`
+	CanEdit         bool                  `json:"canEdit"`
+	CanDelete       bool                  `json:"canDelete"`
+	FileItemUuid    *string               `json:"fileItemUuid"`
+	EmbedMessage    *EmbedMessageResponse `json:"embedMessage"`
+	Pinned          bool                  `json:"pinned"`
+	BlogPost        bool                  `json:"blogPost"`
+	PinnedPromoted  *bool                 `json:"pinnedPromoted"`
+	Reactions       []Reaction            `json:"reactions"`
+	Published       bool                  `json:"published"`
+	CanPublish      bool                  `json:"canPublish"`
+	CanPin          bool                  `json:"canPin"`
+	Highlight       *string               `json:"highlight"`
+	ThreadId        *int64                `json:"threadId"`        // root message id in case this message is a thread reply
+	Thread          *ThreadDto            `json:"thread"`          // in case this message is a thread root
+	DeletedDateTime null.Time             `json:"deletedDateTime"` // in case this message is a tombstone
+	RestorableUntil null.Time             `json:"restorableUntil"`
+	CanRestore      bool                  `json:"canRestore"`
+	Poll            *PollDto              `json:"poll"` // in case this message is a poll
+	LinkPreview     *LinkPreviewDto       `json:"linkPreview"`
+}
+
+type LinkPreviewDto struct {
+	Url         string      `json:"url"`
+	Title       null.String `json:"title"`
+	Description null.String `json:"description"`
+	ImageUrl    null.String `json:"imageUrl"`
+	SiteName    null.String `json:"siteName"`
+}
+
+type PollOptionDto struct {
+	Id       int64   `json:"id"`
+	Text     string  `json:"text"`
+	Count    int64   `json:"count"`
+	Users    []*User `json:"users"` // empty for the anonymous poll
+	Voted    bool    `json:"voted"`
+	voterIds []int64
+}
+
+func (o *PollOptionDto) SetVoterIds(voterIds []int64) {
+	o.voterIds = voterIds
+}
+
+type PollDto struct {
+	MultipleChoice bool             `json:"multipleChoice"`
+	Anonymous      bool             `json:"anonymous"`
+	Closed         bool             `json:"closed"`
+	ClosedDateTime null.Time        `json:"closedDateTime"`
+	Options        []*PollOptionDto `json:"options"`
+	TotalVoters    int64            `json:"totalVoters"`
+	CanVote        bool             `json:"canVote"`
+	CanClose       bool             `json:"canClose"`
+}
+
+// returns the copy with the behalf user's fields, the original keeps the voters so it can be personalized for everyone
+func (p *PollDto) Personalized(messageOwnerId int64, canCloseOthers bool, participantId int64) *PollDto {
+	ret := *p
+	ret.Options = make([]*PollOptionDto, 0, len(p.Options))
+	for _, o := range p.Options {
+		copiedOption := *o
+		copiedOption.Voted = false
+		for _, voterId := range o.voterIds {
+			if voterId == participantId {
+				copiedOption.Voted = true
+				break
+			}
+		}
+		ret.Options = append(ret.Options, &copiedOption)
+	}
+	ret.CanVote = !p.Closed
+	ret.CanClose = !p.Closed && (messageOwnerId == participantId || canCloseOthers)
+	return &ret
+}
+
+type ThreadDto struct {
+	RootMessageId     int64     `json:"rootMessageId"`
+	ReplyCount        int64     `json:"replyCount"`
+	LastReplyDateTime null.Time `json:"lastReplyDateTime"`
 }
 
 type PublishedMessageDto struct {
@@ -72,26 +139,43 @@ type PinnedMessageDto struct {
 	CanPin         bool      `json:"canPin"`
 }
 
-func CanPublishMessage(chatRegularParticipantCanPublishMessage, chatIsAdmin bool, messageOwnerId, behalfUserId int64) bool {
-	return chatIsAdmin || (chatRegularParticipantCanPublishMessage && messageOwnerId == behalfUserId)
+// the own messages are published with the publish permission, the others' ones require also the moderation permission
+func CanPublishMessage(settings ChatPermissionSettings, role string, messageOwnerId, behalfUserId int64) bool {
+	return HasChatPermission(role, settings, PermissionPublish) && (messageOwnerId == behalfUserId || HasChatPermission(role, settings, PermissionDeleteOthersMessages))
 }
 
-func CanPinMessage(chatRegularParticipantCanPinMessage, chatIsAdmin bool) bool {
-	return chatIsAdmin || chatRegularParticipantCanPinMessage
+func CanDeleteMessage(settings ChatPermissionSettings, role string, messageOwnerId, behalfUserId int64) bool {
+	return (messageOwnerId == behalfUserId && HasChatPermission(role, settings, PermissionWrite)) || HasChatPermission(role, settings, PermissionDeleteOthersMessages)
 }
 
-func (copied *DisplayMessageDto) SetPersonalizedFields(chatRegularParticipantCanPublishMessage, chatRegularParticipantCanPinMessage, chatCanWriteMessage, chatIsAdmin bool, participantId int64) {
-	canWriteMessage := chatIsAdmin || chatCanWriteMessage
+func (copied *DisplayMessageDto) SetPersonalizedFields(settings ChatPermissionSettings, role string, participantId int64) {
+	canWriteMessage := HasChatPermission(role, settings, PermissionWrite)
 
 	copied.CanEdit = ((copied.OwnerId == participantId) && (copied.EmbedMessage == nil || copied.EmbedMessage.EmbedType != EmbedMessageTypeResend)) && canWriteMessage
-	copied.CanDelete = copied.OwnerId == participantId && canWriteMessage
-	copied.CanPublish = CanPublishMessage(chatRegularParticipantCanPublishMessage, chatIsAdmin, copied.OwnerId, participantId)
-	copied.CanPin = CanPinMessage(chatRegularParticipantCanPinMessage, chatIsAdmin)
+	copied.CanDelete = CanDeleteMessage(settings, role, copied.OwnerId, participantId)
+	copied.CanPublish = CanPublishMessage(settings, role, copied.OwnerId, participantId)
+	copied.CanPin = HasChatPermission(role, settings, PermissionPin)
+
+	if copied.DeletedDateTime.Valid {
+		copied.CanEdit = false
+		copied.CanDelete = false
+		copied.CanPublish = false
+		copied.CanPin = false
+	}
+	copied.CanRestore = HasChatPermission(role, settings, PermissionDeleteOthersMessages) && copied.RestorableUntil.Valid && time.Now().UTC().Before(copied.RestorableUntil.Time)
+}
+
+// the voters don't survive the deep copy, so the poll is personalized from the original message
+func (copied *DisplayMessageDto) SetPersonalizedPoll(original *DisplayMessageDto, settings ChatPermissionSettings, role string, participantId int64) {
+	if original.Poll != nil {
+		copied.Poll = original.Poll.Personalized(original.OwnerId, HasChatPermission(role, settings, PermissionDeleteOthersMessages), participantId)
+	}
 }
 
 type MessageDeletedDto struct {
-	Id     int64 `json:"id"`
-	ChatId int64 `json:"chatId"`
+	Id       int64  `json:"id"`
+	ChatId   int64  `json:"chatId"`
+	ThreadId *int64 `json:"threadId"`
 }
 
 type UserTypingNotification struct {
@@ -106,12 +190,98 @@ type MessageBroadcastNotification struct {
 	Text   string `json:"text"`
 }
 
+// the unsent message of the user in the chat, the chat list shows it instead of the last message
+type MessageDraftDto struct {
+	ChatId              int64                `json:"chatId"`
+	Text                string               `json:"text"`
+	ThreadId            *int64               `json:"threadId"`
+	EmbedMessageRequest *EmbedMessageRequest `json:"embedMessage"`
+	EditDateTime        time.Time            `json:"editDateTime"`
+}
+
+// the message saved by the user for themselves, is resolved on reading
+type MessageBookmarkDto struct {
+	Id             int64              `json:"id"`
+	ChatId         int64              `json:"chatId"`
+	ChatTitle      string             `json:"chatTitle"`
+	MessageId      int64              `json:"messageId"`
+	Note           *string            `json:"note"`
+	Tags           []string           `json:"tags"`
+	CreateDateTime time.Time          `json:"createDateTime"`
+	Message        *DisplayMessageDto `json:"message"`
+}
+
+// the private reminder about the message, the fired one can be snoozed
+type MessageReminderDto struct {
+	Id             int64     `json:"id"`
+	ChatId         int64     `json:"chatId"`
+	MessageId      int64     `json:"messageId"`
+	RemindDateTime time.Time `json:"remindDateTime"`
+	Fired          bool      `json:"fired"`
+	CreateDateTime time.Time `json:"createDateTime"`
+}
+
+// the reply to the slash command which is seen only by the user who invoked it, it isn't stored
+type EphemeralMessageNotification struct {
+	Command  string `json:"command"`
+	Text     string `json:"text"`
+	ThreadId *int64 `json:"threadId"`
+}
+
 type AllUnreadMessages struct {
 	MessagesCount int64 `json:"allUnreadMessages"`
 }
 
+type MessageSearchItemId struct {
+	CreateDateTime time.Time `json:"createDateTime"`
+	ChatId         int64     `json:"chatId"`
+	MessageId      int64     `json:"messageId"`
+}
+
+type FoundMessageDto struct {
+	ChatId         int64     `json:"chatId"`
+	ChatName       string    `json:"chatName"`
+	MessageId      int64     `json:"messageId"`
+	Owner          *User     `json:"owner"`
+	CreateDateTime time.Time `json:"createDateTime"`
+	Highlight      string    `json:"highlight"`
+}
+
 type ReplyDto struct {
 	MessageId        int64  `json:"messageId"`
 	ChatId           int64  `json:"chatId"`
 	ReplyableMessage string `json:"replyableMessage"`
 }
+
+type ScheduledMessageDto struct {
+	Id                  int64                `json:"id"`
+	ChatId              int64                `json:"chatId"`
+	Text                string               `json:"text"`
+	FileItemUuid        *string              `json:"fileItemUuid"`
+	EmbedMessageRequest *EmbedMessageRequest `json:"embedMessage"`
+	ThreadId            *int64               `json:"threadId"`
+	SendDateTime        time.Time            `json:"sendDateTime"`
+	CreateDateTime      time.Time            `json:"createDateTime"`
+	EditDateTime        null.Time            `json:"editDateTime"`
+}
+
+type HeldMessageDto struct {
+	Id                  int64                `json:"id"`
+	ChatId              int64                `json:"chatId"`
+	Owner               *User                `json:"owner"`
+	Text                string               `json:"text"`
+	FileItemUuid        *string              `json:"fileItemUuid"`
+	EmbedMessageRequest *EmbedMessageRequest `json:"embedMessage"`
+	ThreadId            *int64               `json:"threadId"`
+	Reason              string               `json:"reason"` // why the content filter has held the message
+	CreateDateTime      time.Time            `json:"createDateTime"`
+}
+
+type MessageRevisionDto struct {
+	Id             int64     `json:"id"`
+	MessageId      int64     `json:"messageId"`
+	Text           string    `json:"text"`
+	EditorId       int64     `json:"editorId"`
+	Editor         *User     `json:"editor"`
+	CreateDateTime time.Time `json:"createDateTime"`
+}
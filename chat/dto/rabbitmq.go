@@ -15,6 +15,16 @@ type ReactionChangedEvent struct {
 	Reaction  Reaction `json:"reaction"`
 }
 
+type ThreadChangedEvent struct {
+	Thread        ThreadDto `json:"thread"`
+	UnreadReplies int64     `json:"unreadReplies"`
+}
+
+type PollChangedEvent struct {
+	MessageId int64    `json:"messageId"`
+	Poll      *PollDto `json:"poll"`
+}
+
 type ChatEvent struct {
 	EventType                    string                        `json:"eventType"`
 	ChatId                       int64                         `json:"chatId"`
@@ -26,6 +36,9 @@ type ChatEvent struct {
 	PromoteMessageNotification   *PinnedMessageEvent           `json:"promoteMessageNotification"`
 	PublishedMessageNotification *PublishedMessageEvent        `json:"publishedMessageEvent"`
 	ReactionChangedEvent         *ReactionChangedEvent         `json:"reactionChangedEvent"`
+	ThreadChangedEvent           *ThreadChangedEvent           `json:"threadChangedEvent"`
+	PollChangedEvent             *PollChangedEvent             `json:"pollChangedEvent"`
+	EphemeralMessageNotification *EphemeralMessageNotification `json:"ephemeralMessageNotification"`
 }
 
 type HasUnreadMessagesChanged struct {
@@ -53,6 +66,7 @@ type GlobalUserEvent struct {
 	HasUnreadMessagesChanged         *HasUnreadMessagesChanged `json:"hasUnreadMessagesChanged"`
 	BrowserNotification              *BrowserNotification      `json:"browserNotification"`
 	UserTypingNotification           *UserTypingNotification   `json:"userTypingNotification"`
+	MessageDraftNotification         *MessageDraftDto          `json:"messageDraftNotification"`
 }
 
 type MentionNotification struct {
@@ -66,15 +80,34 @@ type ReactionEvent struct {
 	MessageId int64  `json:"messageId"`
 }
 
+type ChatExportNotification struct {
+	ExportId int64  `json:"exportId"`
+	Filename string `json:"filename"`
+	Url      string `json:"url"`
+}
+
+type JoinRequestNotification struct {
+	RequestId int64 `json:"requestId"`
+}
+
+// the user asked to be reminded about the message
+type ReminderNotification struct {
+	MessageId int64  `json:"messageId"`
+	Text      string `json:"text"`
+}
+
 type NotificationEvent struct {
-	EventType           string               `json:"eventType"`
-	ChatId              int64                `json:"chatId"`
-	UserId              int64                `json:"userId"`
-	ByUserId            int64                `json:"byUserId"`
-	ByLogin             string               `json:"byLogin"`
-	ByAvatar            *string              `json:"byAvatar"`
-	ChatTitle           string               `json:"chatTitle"`
-	MentionNotification *MentionNotification `json:"mentionNotification"`
-	ReplyNotification   *ReplyDto            `json:"replyNotification"`
-	ReactionEvent       *ReactionEvent       `json:"reactionEvent"`
+	EventType               string                   `json:"eventType"`
+	ChatId                  int64                    `json:"chatId"`
+	UserId                  int64                    `json:"userId"`
+	ByUserId                int64                    `json:"byUserId"`
+	ByLogin                 string                   `json:"byLogin"`
+	ByAvatar                *string                  `json:"byAvatar"`
+	ChatTitle               string                   `json:"chatTitle"`
+	MentionNotification     *MentionNotification     `json:"mentionNotification"`
+	ReplyNotification       *ReplyDto                `json:"replyNotification"`
+	ReactionEvent           *ReactionEvent           `json:"reactionEvent"`
+	ChatExportNotification  *ChatExportNotification  `json:"chatExportNotification"`
+	JoinRequestNotification *JoinRequestNotification `json:"joinRequestNotification"`
+	ReminderNotification    *ReminderNotification    `json:"reminderNotification"`
 }
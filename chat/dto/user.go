@@ -18,7 +18,8 @@ type UserAccountEventChanged struct {
 
 type UserWithAdmin struct {
 	User
-	Admin bool `json:"admin"`
+	Admin bool   `json:"admin"`
+	Role  string `json:"role"`
 }
 
 type UserOnline struct {
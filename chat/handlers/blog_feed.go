@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/spf13/viper"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/services"
+	"nkonev.name/chat/utils"
+)
+
+// see public/common/router/routes.js
+const publicBlogPostPath = "/public/blog/post"
+const publicBlogPath = "/public/blog"
+const messageIdHashPrefix = "#message-"
+
+type feedRenderer func(f *services.Feed) ([]byte, error)
+
+func absoluteFrontendUrl(path string) string {
+	if path == "" || !strings.HasPrefix(path, "/") {
+		return path
+	}
+	return strings.TrimSuffix(viper.GetString("frontendUrl"), "/") + path
+}
+
+func blogPostUrl(blogId int64) string {
+	return absoluteFrontendUrl(fmt.Sprintf("%v/%v", publicBlogPostPath, blogId))
+}
+
+func getLoginOrDeleted(users map[int64]*dto.User, userId int64) string {
+	if user := users[userId]; user != nil {
+		return user.Login
+	}
+	return getDeletedUser(userId).Login
+}
+
+// responds 304 if the reader already has this version of the feed
+func checkNotModified(c echo.Context, etag string, lastModified time.Time) bool {
+	c.Response().Header().Set("ETag", etag)
+	c.Response().Header().Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+
+	if ifNoneMatch := c.Request().Header.Get("If-None-Match"); ifNoneMatch != "" {
+		// If-Modified-Since is ignored when If-None-Match is present, see RFC 9110
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if ifModifiedSince := c.Request().Header.Get(echo.HeaderIfModifiedSince); ifModifiedSince != "" {
+		since, err := http.ParseTime(ifModifiedSince)
+		// the header has the precision of seconds
+		if err == nil && !lastModified.Truncate(time.Second).After(since) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *BlogHandler) respondFeed(c echo.Context, feed *services.Feed, render feedRenderer, contentType string) error {
+	if checkNotModified(c, feed.ETag(contentType), feed.Updated) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	body, err := render(feed)
+	if err != nil {
+		return err
+	}
+	return c.Blob(http.StatusOK, contentType, body)
+}
+
+func (h *BlogHandler) getBlogPostsFeed(ctx context.Context, selfLink string, size int) (*services.Feed, error) {
+	blogs, err := h.db.GetBlogPostsByLimitOffset(ctx, false, size, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := h.getPostsWoUsers(ctx, blogs)
+	if err != nil {
+		return nil, err
+	}
+
+	var chatIds = make([]int64, 0)
+	var ownersSet = map[int64]bool{}
+	for _, post := range posts {
+		chatIds = append(chatIds, post.Id)
+		if post.OwnerId != nil {
+			ownersSet[*post.OwnerId] = true
+		}
+	}
+
+	dates, err := h.db.GetBlobPostModifiedDates(ctx, chatIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var users = getUsersRemotelyOrEmpty(ctx, h.lgr, ownersSet, h.restClient)
+
+	entries := make([]*services.FeedEntry, 0)
+	for _, post := range posts {
+		if post.MessageId == nil {
+			// the blog without the post
+			continue
+		}
+		link := blogPostUrl(post.Id)
+		entry := &services.FeedEntry{
+			Id:        link,
+			Title:     post.Title,
+			Link:      link,
+			Author:    getLoginOrDeleted(users, *post.OwnerId),
+			Published: post.CreateDateTime,
+			Updated:   post.CreateDateTime,
+		}
+		if modified, ok := dates[post.Id]; ok {
+			entry.Updated = modified
+		}
+		if post.Preview != nil {
+			entry.Summary = *post.Preview
+		}
+		if post.ImageUrl != nil {
+			entry.ImageUrl = absoluteFrontendUrl(*post.ImageUrl)
+		}
+		entries = append(entries, entry)
+	}
+
+	blogUrl := absoluteFrontendUrl(publicBlogPath)
+	return &services.Feed{
+		Id:       blogUrl,
+		Title:    viper.GetString("blogFeed.title"),
+		Link:     blogUrl,
+		SelfLink: selfLink,
+		Updated:  services.LatestFeedUpdate(entries, time.Unix(0, 0).UTC()),
+		Entries:  entries,
+	}, nil
+}
+
+// nil means there is no such blog
+func (h *BlogHandler) getBlogCommentsFeed(ctx context.Context, blogId int64, selfLink string, size int) (*services.Feed, error) {
+	chatBasic, err := h.db.GetChatBasic(ctx, blogId)
+	if err != nil {
+		return nil, err
+	}
+	if chatBasic == nil || !chatBasic.IsBlog {
+		return nil, nil
+	}
+
+	postMessageId, err := h.db.GetBlogPostMessageId(ctx, blogId)
+	if err != nil {
+		return nil, err
+	}
+
+	// the newest comments go first
+	messages, err := h.db.GetComments(ctx, blogId, postMessageId, size, 0, true)
+	if err != nil {
+		return nil, err
+	}
+
+	dates, err := h.db.GetBlobPostModifiedDates(ctx, []int64{blogId})
+	if err != nil {
+		return nil, err
+	}
+
+	var ownersSet = map[int64]bool{}
+	for _, message := range messages {
+		ownersSet[message.OwnerId] = true
+	}
+	var users = getUsersRemotelyOrEmpty(ctx, h.lgr, ownersSet, h.restClient)
+
+	postLink := blogPostUrl(blogId)
+	entries := make([]*services.FeedEntry, 0)
+	for _, message := range messages {
+		if message.DeletedDateTime.Valid {
+			continue
+		}
+		login := getLoginOrDeleted(users, message.OwnerId)
+		link := postLink + messageIdHashPrefix + utils.Int64ToString(message.Id)
+		entry := &services.FeedEntry{
+			Id:        link,
+			Title:     fmt.Sprintf("Comment by %v", login),
+			Link:      link,
+			Summary:   *h.cutText(message.Text),
+			Author:    login,
+			Published: message.CreateDateTime,
+			Updated:   message.CreateDateTime,
+		}
+		if message.EditDateTime.Valid {
+			entry.Updated = message.EditDateTime.Time
+		}
+		entries = append(entries, entry)
+	}
+
+	updated := chatBasic.CreateDateTime
+	if modified, ok := dates[blogId]; ok {
+		updated = modified
+	}
+	return &services.Feed{
+		Id:       postLink + "/comments",
+		Title:    fmt.Sprintf("Comments on %v", chatBasic.Title),
+		Link:     postLink,
+		SelfLink: selfLink,
+		Updated:  services.LatestFeedUpdate(entries, updated),
+		Entries:  entries,
+	}, nil
+}
+
+func (h *BlogHandler) respondBlogPostsFeed(c echo.Context, render feedRenderer, contentType string) error {
+	size := utils.FixSizeString(c.QueryParam("size"))
+
+	feed, err := h.getBlogPostsFeed(c.Request().Context(), absoluteFrontendUrl(c.Request().URL.RequestURI()), size)
+	if err != nil {
+		return err
+	}
+	return h.respondFeed(c, feed, render, contentType)
+}
+
+func (h *BlogHandler) respondBlogCommentsFeed(c echo.Context, render feedRenderer, contentType string) error {
+	blogId, err := utils.ParseInt64(c.Param("id"))
+	if err != nil {
+		return err
+	}
+	size := utils.FixSizeString(c.QueryParam("size"))
+
+	feed, err := h.getBlogCommentsFeed(c.Request().Context(), blogId, absoluteFrontendUrl(c.Request().URL.RequestURI()), size)
+	if err != nil {
+		return err
+	}
+	if feed == nil {
+		h.lgr.WithTracing(c.Request().Context()).Infof("This chat %v is not blog", blogId)
+		return c.NoContent(http.StatusNoContent)
+	}
+	return h.respondFeed(c, feed, render, contentType)
+}
+
+func (h *BlogHandler) GetBlogPostsAtomFeed(c echo.Context) error {
+	return h.respondBlogPostsFeed(c, services.RenderAtom, services.AtomContentType)
+}
+
+func (h *BlogHandler) GetBlogPostsRssFeed(c echo.Context) error {
+	return h.respondBlogPostsFeed(c, services.RenderRss, services.RssContentType)
+}
+
+func (h *BlogHandler) GetBlogPostCommentsAtomFeed(c echo.Context) error {
+	return h.respondBlogCommentsFeed(c, services.RenderAtom, services.AtomContentType)
+}
+
+func (h *BlogHandler) GetBlogPostCommentsRssFeed(c echo.Context) error {
+	return h.respondBlogCommentsFeed(c, services.RenderRss, services.RssContentType)
+}
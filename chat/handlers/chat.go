@@ -22,6 +22,12 @@ import (
 const minChatNameLen = 1
 const maxChatNameLen = 256
 
+// the shortest lifetime of the disappearing messages
+const minMessageRetentionSeconds = 60
+
+const minSlowModeSeconds = 1
+const maxSlowModeSeconds = 60 * 60 * 24
+
 const noUser = -10
 
 type ParticipantsWithAdminWrapper struct {
@@ -46,6 +52,10 @@ type CreateChatDto struct {
 	RegularParticipantCanPinMessage     bool        `json:"regularParticipantCanPinMessage"`
 	BlogAbout                           bool        `json:"blogAbout"`
 	RegularParticipantCanWriteMessage   bool        `json:"regularParticipantCanWriteMessage"`
+	RegularParticipantCanSeeEditHistory bool        `json:"regularParticipantCanSeeEditHistory"`
+	MessageRetentionSeconds             null.Int    `json:"messageRetentionSeconds"`
+	JoinByRequest                       bool        `json:"joinByRequest"`
+	SlowModeSeconds                     null.Int    `json:"slowModeSeconds"`
 }
 
 type ChatHandler struct {
@@ -73,6 +83,8 @@ func NewChatHandler(dbR *db.DB, notificator *services.Events, restClient *client
 func (a *CreateChatDto) Validate() error {
 	return validation.ValidateStruct(a,
 		validation.Field(&a.Name, validation.Required, validation.Length(minChatNameLen, maxChatNameLen), validation.NotIn(db.ReservedPublicallyAvailableForSearchChats)),
+		validation.Field(&a.MessageRetentionSeconds, validation.Min(int64(minMessageRetentionSeconds))),
+		validation.Field(&a.SlowModeSeconds, validation.Min(int64(minSlowModeSeconds)), validation.Max(int64(maxSlowModeSeconds))),
 	)
 }
 
@@ -80,6 +92,8 @@ func (a *EditChatDto) Validate() error {
 	return validation.ValidateStruct(a,
 		validation.Field(&a.Name, validation.Required, validation.Length(minChatNameLen, maxChatNameLen), validation.NotIn(db.ReservedPublicallyAvailableForSearchChats)),
 		validation.Field(&a.Id, validation.Required),
+		validation.Field(&a.MessageRetentionSeconds, validation.Min(int64(minMessageRetentionSeconds))),
+		validation.Field(&a.SlowModeSeconds, validation.Min(int64(minSlowModeSeconds)), validation.Max(int64(maxSlowModeSeconds))),
 	)
 }
 
@@ -120,6 +134,11 @@ func (ch *ChatHandler) getChats(ctx context.Context, tx *db.Tx, userId int64, si
 		return nil, err
 	}
 
+	drafts, err := tx.GetMessageDraftsBatch(ctx, userId, chatIds)
+	if err != nil {
+		return nil, err
+	}
+
 	var participantIdSet = map[int64]bool{}
 	var participantOftetAtetIdSet = map[int64]bool{}
 	for _, dc := range dbChats {
@@ -143,6 +162,7 @@ func (ch *ChatHandler) getChats(ctx context.Context, tx *db.Tx, userId int64, si
 		isParticipant := membership[cc.Id]
 
 		cd := ch.convertToDto(cc, true, users, messages, isParticipant)
+		cd.Draft = convertToMessageDraftDto(drafts[cc.Id])
 
 		chatDtos = append(chatDtos, cd)
 	}
@@ -325,6 +345,14 @@ func (ch *ChatHandler) getChatCommon(
 
 	chatDto := ch.convertToDto(cc, performPersonalization, users, unreadMessages, isParticipant)
 
+	if performPersonalization && isParticipant {
+		draft, err := co.GetMessageDraft(ctx, behalfParticipantId, chatId)
+		if err != nil {
+			return nil, err
+		}
+		chatDto.Draft = convertToMessageDraftDto(draft)
+	}
+
 	if performPersonalization && chatDto.IsTetATet {
 		tetAtetOnlines, err := ch.getParticipantsOnlineForTetATetMap(ctx, utils.GetInt64BoolMap(cc.ParticipantsIds))
 		if err != nil {
@@ -504,10 +532,14 @@ func (ch *ChatHandler) convertToDto(c *db.ChatWithParticipants, performPersonali
 		RegularParticipantCanPinMessage:     c.RegularParticipantCanPinMessage,
 		BlogAbout:                           c.BlogAbout,
 		RegularParticipantCanWriteMessage:   c.RegularParticipantCanWriteMessage,
+		RegularParticipantCanSeeEditHistory: c.RegularParticipantCanSeeEditHistory,
+		MessageRetentionSeconds:             c.MessageRetentionSeconds,
+		JoinByRequest:                       c.JoinByRequest,
+		SlowModeSeconds:                     c.SlowModeSeconds,
 	}
 
 	if performPersonalization {
-		b.SetPersonalizedFields(c.IsAdmin, unreadMessages, participant, c.Pinned)
+		b.SetPersonalizedFields(c.Role, unreadMessages, participant, c.Pinned)
 	}
 
 	// set participant order as in c.ParticipantsIds
@@ -567,19 +599,19 @@ func (ch *ChatHandler) CreateChat(c echo.Context) error {
 		if err != nil {
 			return 0, err
 		}
-		// add admin
-		if err = tx.AddParticipant(c.Request().Context(), userPrincipalDto.UserId, id, true); err != nil {
+		// add owner
+		if err = tx.AddParticipant(c.Request().Context(), userPrincipalDto.UserId, id, dto.ChatRoleOwner); err != nil {
 			return 0, err
 		}
 
 		if bindTo.ParticipantIds != nil {
 			participantIds := *bindTo.ParticipantIds
-			// add other participants except admin
+			// add other participants except owner
 			for _, participantId := range participantIds {
 				if participantId == userPrincipalDto.UserId {
 					continue
 				}
-				if err = tx.AddParticipant(c.Request().Context(), participantId, id, false); err != nil {
+				if err = tx.AddParticipant(c.Request().Context(), participantId, id, dto.ChatRoleMember); err != nil {
 					return 0, err
 				}
 			}
@@ -598,12 +630,12 @@ func (ch *ChatHandler) CreateChat(c echo.Context) error {
 		}
 
 		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-			areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
+			roles, err := getRolesOfUserIds(c.Request().Context(), tx, participantIds, chatId)
 			if err != nil {
 				return err
 			}
 
-			ch.notificator.NotifyAboutNewChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, areAdmins)
+			ch.notificator.NotifyAboutNewChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, roles)
 			return nil
 		})
 		if err != nil {
@@ -635,6 +667,10 @@ func convertToCreatableChat(d *CreateChatDto, policy *services.StripTagsPolicy)
 		RegularParticipantCanPinMessage:     d.RegularParticipantCanPinMessage,
 		BlogAbout:                           d.BlogAbout,
 		RegularParticipantCanWriteMessage:   d.RegularParticipantCanWriteMessage,
+		RegularParticipantCanSeeEditHistory: d.RegularParticipantCanSeeEditHistory,
+		MessageRetentionSeconds:             d.MessageRetentionSeconds,
+		JoinByRequest:                       d.JoinByRequest,
+		SlowModeSeconds:                     d.SlowModeSeconds,
 	}
 }
 
@@ -651,10 +687,10 @@ func (ch *ChatHandler) DeleteChat(c echo.Context) error {
 	}
 
 	errOuter := db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
-		if admin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId); err != nil {
+		if allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat); err != nil {
 			return err
-		} else if !admin {
-			return errors.New(fmt.Sprintf("User %v is not admin of chat %v", userPrincipalDto.UserId, chatId))
+		} else if !allowed {
+			return errors.New(fmt.Sprintf("User %v is not allowed to delete chat %v", userPrincipalDto.UserId, chatId))
 		}
 
 		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
@@ -708,11 +744,11 @@ func (ch *ChatHandler) EditChat(c echo.Context) error {
 	}
 
 	errOuter := db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
-		admin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, bindTo.Id)
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, bindTo.Id, dto.PermissionManageChat)
 		if err != nil {
 			return err
-		} else if !admin {
-			return errors.New(fmt.Sprintf("User %v is not admin of chat %v", userPrincipalDto.UserId, bindTo.Id))
+		} else if !allowed {
+			return errors.New(fmt.Sprintf("User %v is not allowed to edit chat %v", userPrincipalDto.UserId, bindTo.Id))
 		}
 
 		var oldBlogAboutChatId *int64
@@ -750,64 +786,92 @@ func (ch *ChatHandler) EditChat(c echo.Context) error {
 			bindTo.RegularParticipantCanPinMessage,
 			bindTo.BlogAbout,
 			bindTo.RegularParticipantCanWriteMessage,
+			bindTo.RegularParticipantCanSeeEditHistory,
+			bindTo.MessageRetentionSeconds,
+			bindTo.JoinByRequest,
+			bindTo.SlowModeSeconds,
 		)
 		if err != nil {
 			return err
 		}
 
-		chatDto, err := ch.getChatWithoutPersonalization(c.Request().Context(), tx, bindTo.Id, 0, 0)
+		permissionsChanged := chatBasicBefore.RegularParticipantCanPublishMessage != bindTo.RegularParticipantCanPublishMessage ||
+			chatBasicBefore.RegularParticipantCanPinMessage != bindTo.RegularParticipantCanPinMessage ||
+			chatBasicBefore.RegularParticipantCanWriteMessage != bindTo.RegularParticipantCanWriteMessage
+		err = ch.notifyAboutEditedChat(c.Request().Context(), tx, bindTo.Id, oldBlogAboutChatId, permissionsChanged)
 		if err != nil {
 			return err
 		}
+		return c.NoContent(http.StatusAccepted)
 
-		var oldBlogAboutChatDto *dto.ChatDto
-		if oldBlogAboutChatId != nil {
-			oldBlogAboutChatDto, err = ch.getChatWithoutPersonalization(c.Request().Context(), tx, *oldBlogAboutChatId, 0, 0)
-			if err != nil {
-				return err
-			}
+	})
+	if errOuter != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+	}
+	return errOuter
+}
+
+// sends the changed chat to its participants, the regular ones reload the messages in case their permissions were changed
+func (ch *ChatHandler) notifyAboutEditedChat(ctx context.Context, tx *db.Tx, chatId int64, oldBlogAboutChatId *int64, permissionsChanged bool) error {
+	chatDto, err := ch.getChatWithoutPersonalization(ctx, tx, chatId, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	var oldBlogAboutChatDto *dto.ChatDto
+	if oldBlogAboutChatId != nil {
+		oldBlogAboutChatDto, err = ch.getChatWithoutPersonalization(ctx, tx, *oldBlogAboutChatId, 0, 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.IterateOverChatParticipantIds(ctx, chatId, func(participantIds []int64) error {
+		roles, err := getRolesOfUserIds(ctx, tx, participantIds, chatId)
+		if err != nil {
+			return err
 		}
 
-		err = tx.IterateOverChatParticipantIds(c.Request().Context(), bindTo.Id, func(participantIds []int64) error {
-			areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, bindTo.Id)
+		if oldBlogAboutChatDto != nil {
+			rolesOld, err := getRolesOfUserIds(ctx, tx, participantIds, *oldBlogAboutChatId)
 			if err != nil {
 				return err
 			}
+			ch.notificator.NotifyAboutChangeChat(ctx, oldBlogAboutChatDto, participantIds, len(oldBlogAboutChatDto.ParticipantIds) == 1, true, tx, rolesOld)
+		}
 
-			if oldBlogAboutChatDto != nil {
-				areAdminsOld, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, *oldBlogAboutChatId)
-				if err != nil {
-					return err
-				}
-				ch.notificator.NotifyAboutChangeChat(c.Request().Context(), oldBlogAboutChatDto, participantIds, len(oldBlogAboutChatDto.ParticipantIds) == 1, true, tx, areAdminsOld)
-			}
-
-			ch.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, areAdmins)
+		ch.notificator.NotifyAboutChangeChat(ctx, chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, roles)
 
-			if chatBasicBefore.RegularParticipantCanPublishMessage != bindTo.RegularParticipantCanPublishMessage ||
-				chatBasicBefore.RegularParticipantCanPinMessage != bindTo.RegularParticipantCanPinMessage ||
-				chatBasicBefore.RegularParticipantCanWriteMessage != bindTo.RegularParticipantCanWriteMessage {
-				regularParticipants := make([]int64, 0)
-				for userId, isAdmin := range areAdmins {
-					if !isAdmin {
-						regularParticipants = append(regularParticipants, userId)
-					}
+		if permissionsChanged {
+			regularParticipants := make([]int64, 0)
+			for userId, role := range roles {
+				if !dto.IsChatAdminRole(role) {
+					regularParticipants = append(regularParticipants, userId)
 				}
-
-				ch.notificator.NotifyMessagesReloadCommand(c.Request().Context(), bindTo.Id, regularParticipants)
 			}
-			return nil
-		})
-		if err != nil {
-			return err
-		}
-		return c.NoContent(http.StatusAccepted)
 
+			ch.notificator.NotifyMessagesReloadCommand(ctx, chatId, regularParticipants)
+		}
+		return nil
 	})
-	if errOuter != nil {
-		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+}
+
+// changes only the title, the same way as EditChat does, returns false if the user isn't allowed to do it
+func (ch *ChatHandler) editChatTitle(ctx context.Context, tx *db.Tx, chatId, userId int64, title string) (bool, error) {
+	allowed, err := hasChatPermission(ctx, tx, userId, chatId, dto.PermissionManageChat)
+	if err != nil {
+		return false, err
 	}
-	return errOuter
+	if !allowed {
+		return false, nil
+	}
+
+	err = tx.EditChatTitle(ctx, chatId, title)
+	if err != nil {
+		return false, err
+	}
+
+	return true, ch.notifyAboutEditedChat(ctx, tx, chatId, nil, false)
 }
 
 func (ch *ChatHandler) LeaveChat(c echo.Context) error {
@@ -832,13 +896,13 @@ func (ch *ChatHandler) LeaveChat(c echo.Context) error {
 		} else {
 
 			err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-				areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
+				roles, err := getRolesOfUserIds(c.Request().Context(), tx, participantIds, chatId)
 				if err != nil {
 					return err
 				}
 
 				ch.notificator.NotifyAboutDeleteParticipants(c.Request().Context(), participantIds, chatId, []int64{userPrincipalDto.UserId})
-				ch.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, areAdmins)
+				ch.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, roles)
 				return nil
 			})
 			if err != nil {
@@ -847,10 +911,11 @@ func (ch *ChatHandler) LeaveChat(c echo.Context) error {
 			}
 			// also send to user himself
 			ch.notificator.NotifyAboutDeleteParticipants(c.Request().Context(), []int64{userPrincipalDto.UserId}, chatId, []int64{userPrincipalDto.UserId})
+			ch.notifyOutgoingWebhooksAboutDeletedParticipant(c.Request().Context(), tx, chatId, userPrincipalDto.UserId)
 
 			if chatDto.AvailableToSearch || chatDto.Blog {
 				// send duplicated event to the former user to re-draw chat on their search results
-				ch.notificator.NotifyAboutRedrawLeftChat(c.Request().Context(), chatDto, userPrincipalDto.UserId, len(chatDto.ParticipantIds) == 1, false, tx, map[int64]bool{userPrincipalDto.UserId: false}) // false because userPrincipalDto left the chat
+				ch.notificator.NotifyAboutRedrawLeftChat(c.Request().Context(), chatDto, userPrincipalDto.UserId, len(chatDto.ParticipantIds) == 1, false, tx, map[int64]string{userPrincipalDto.UserId: ""}) // empty because userPrincipalDto left the chat
 			} else {
 				ch.notificator.NotifyAboutDeleteChat(c.Request().Context(), chatDto.Id, []int64{userPrincipalDto.UserId}, tx)
 			}
@@ -875,8 +940,6 @@ func (ch *ChatHandler) JoinChat(c echo.Context) error {
 		return errors.New("Error during getting auth context")
 	}
 
-	isAdmin := false
-
 	errOuter := db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
 		chat, err := tx.GetChatBasic(c.Request().Context(), chatId)
 		if err != nil {
@@ -886,8 +949,18 @@ func (ch *ChatHandler) JoinChat(c echo.Context) error {
 			ch.lgr.WithTracing(c.Request().Context()).Infof("User %d isn't allowed to loin to this chat beacuse chat isn't avaliable for search", userPrincipalDto.UserId)
 			return c.NoContent(http.StatusUnauthorized)
 		}
+		if chat.JoinByRequest {
+			ch.lgr.WithTracing(c.Request().Context()).Infof("User %d isn't allowed to join to this chat because it requires the approved join request", userPrincipalDto.UserId)
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "This chat can be joined only by the approved join request"})
+		}
+		if banned, err := tx.IsBanned(c.Request().Context(), userPrincipalDto.UserId, chatId); err != nil {
+			return err
+		} else if banned {
+			ch.lgr.WithTracing(c.Request().Context()).Infof("User %d isn't allowed to join to this chat because they are banned", userPrincipalDto.UserId)
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You are banned in this chat"})
+		}
 
-		if err := tx.AddParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId, isAdmin); err != nil {
+		if err := tx.AddParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId, dto.ChatRoleMember); err != nil {
 			return err
 		}
 		return nil
@@ -896,6 +969,10 @@ func (ch *ChatHandler) JoinChat(c echo.Context) error {
 		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
 		return errOuter
 	}
+	if c.Response().Committed {
+		// the user was refused, there is nobody to notify
+		return nil
+	}
 
 	errOuter = db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
 		chatDto, err := ch.getChatWithoutPersonalization(c.Request().Context(), tx, chatId, 0, 0)
@@ -903,26 +980,30 @@ func (ch *ChatHandler) JoinChat(c echo.Context) error {
 			return err
 		}
 
+		joinedUsers := []*dto.UserWithAdmin{
+			{
+				User: dto.User{
+					Id:    userPrincipalDto.UserId,
+					Login: userPrincipalDto.UserLogin,
+				},
+				Admin: false,
+				Role:  dto.ChatRoleMember,
+			},
+		}
+		ch.notifyOutgoingWebhooksAboutParticipants(c.Request().Context(), tx, chatId, dto.OutgoingWebhookParticipantAdded, joinedUsers)
+
 		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-			areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
+			roles, err := getRolesOfUserIds(c.Request().Context(), tx, participantIds, chatId)
 			if err != nil {
 				return err
 			}
 
-			ch.notificator.NotifyAboutNewParticipants(c.Request().Context(), participantIds, chatId, []*dto.UserWithAdmin{
-				{
-					User: dto.User{
-						Id:    userPrincipalDto.UserId,
-						Login: userPrincipalDto.UserLogin,
-					},
-					Admin: isAdmin,
-				},
-			})
-			ch.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, areAdmins)
+			ch.notificator.NotifyAboutNewParticipants(c.Request().Context(), participantIds, chatId, joinedUsers)
+			ch.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, roles)
 
 			// update chats at left for the new user who joined
 			if utils.Contains(participantIds, userPrincipalDto.UserId) {
-				ch.notificator.NotifyAboutNewChat(c.Request().Context(), chatDto, []int64{userPrincipalDto.UserId}, len(chatDto.ParticipantIds) == 1, true, tx, areAdmins)
+				ch.notificator.NotifyAboutNewChat(c.Request().Context(), chatDto, []int64{userPrincipalDto.UserId}, len(chatDto.ParticipantIds) == 1, true, tx, roles)
 			}
 
 			return nil
@@ -957,28 +1038,43 @@ func (ch *ChatHandler) ChangeParticipant(c echo.Context) error {
 
 	errOuter := db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
 
-		// check that I am admin
-		admin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		// check that I can manage the participants
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
 		if err != nil {
 			return err
 		}
-		if !admin {
+		if !allowed {
 			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
 		}
 		interestingUserId, err := GetPathParamAsInt64(c, "participantId")
-		participant, err := tx.IsParticipant(c.Request().Context(), interestingUserId, chatId)
+		oldRole, err := tx.GetParticipantRole(c.Request().Context(), interestingUserId, chatId)
 		if err != nil {
 			return err
 		}
-		if !participant {
+		if oldRole == "" {
 			return c.JSON(http.StatusBadRequest, &utils.H{"message": "User is not belong to chat"})
 		}
+		if oldRole == dto.ChatRoleOwner {
+			return c.JSON(http.StatusBadRequest, &utils.H{"message": "The role of the owner cannot be changed"})
+		}
 
-		newAdmin, err := GetQueryParamAsBoolean(c, "admin")
-		if err != nil {
-			return err
+		// the role parameter takes precedence over the legacy admin one
+		newRole := c.QueryParam("role")
+		if newRole == "" {
+			newAdmin, err := GetQueryParamAsBoolean(c, "admin")
+			if err != nil {
+				return err
+			}
+			newRole = dto.ChatRoleMember
+			if newAdmin {
+				newRole = dto.ChatRoleAdmin
+			}
+		}
+		if err := validation.Validate(newRole, validation.In(dto.AssignableChatRoles...)); err != nil {
+			return c.JSON(http.StatusBadRequest, &utils.H{"message": "Wrong role", "error": err.Error()})
 		}
-		err = tx.SetAdmin(c.Request().Context(), interestingUserId, chatId, newAdmin)
+
+		err = tx.SetParticipantRole(c.Request().Context(), interestingUserId, chatId, newRole)
 		if err != nil {
 			ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during changing chat admin in database %v", err)
 			return err
@@ -989,6 +1085,7 @@ func (ch *ChatHandler) ChangeParticipant(c echo.Context) error {
 			ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting participants aith admin %v", err)
 			return err
 		}
+		ch.notifyOutgoingWebhooksAboutParticipants(c.Request().Context(), tx, chatId, dto.OutgoingWebhookParticipantEdited, newUsersWithAdmin)
 
 		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
 			ch.notificator.NotifyAboutChangeParticipants(c.Request().Context(), participantIds, chatId, newUsersWithAdmin)
@@ -1003,7 +1100,7 @@ func (ch *ChatHandler) ChangeParticipant(c echo.Context) error {
 		if err != nil {
 			return err
 		}
-		ch.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, []int64{interestingUserId}, len(chatDto.ParticipantIds) == 1, true, tx, map[int64]bool{interestingUserId: newAdmin})
+		ch.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, []int64{interestingUserId}, len(chatDto.ParticipantIds) == 1, true, tx, map[int64]string{interestingUserId: newRole})
 
 		ch.notificator.NotifyMessagesReloadCommand(c.Request().Context(), chatId, []int64{interestingUserId})
 
@@ -1044,7 +1141,7 @@ func (ch *ChatHandler) PinChat(c echo.Context) error {
 			return err
 		}
 
-		admin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		role, err := tx.GetParticipantRole(c.Request().Context(), userPrincipalDto.UserId, chatId)
 		if err != nil {
 			return err
 		}
@@ -1054,7 +1151,7 @@ func (ch *ChatHandler) PinChat(c echo.Context) error {
 			return err
 		}
 
-		ch.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, []int64{userPrincipalDto.UserId}, len(chatDto.ParticipantIds) == 1, true, tx, map[int64]bool{userPrincipalDto.UserId: admin})
+		ch.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, []int64{userPrincipalDto.UserId}, len(chatDto.ParticipantIds) == 1, true, tx, map[int64]string{userPrincipalDto.UserId: role})
 
 		return c.NoContent(http.StatusOK)
 	})
@@ -1078,16 +1175,16 @@ func (ch *ChatHandler) DeleteParticipant(c echo.Context) error {
 	}
 
 	errOuter := db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
-		// check that I am admin
-		admin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		// check that I can manage the participants
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
 		if err != nil {
 			return err
 		}
-		if !admin {
+		if !allowed {
 			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
 		}
 
-		err = tx.DeleteParticipant(c.Request().Context(), interestingUserId, chatId)
+		err = ch.removeParticipant(c.Request().Context(), tx, chatId, interestingUserId, userPrincipalDto.UserId, null.String{})
 		if err != nil {
 			ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during changing chat admin in database %v", err)
 			return err
@@ -1098,42 +1195,60 @@ func (ch *ChatHandler) DeleteParticipant(c echo.Context) error {
 		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
 		return errOuter
 	}
+	if c.Response().Committed {
+		return nil
+	}
 	errOuter = db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
-		chatDto, err := ch.getChatWithoutPersonalization(c.Request().Context(), tx, chatId, 0, 0)
-		if err != nil {
-			return err
-		}
-		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-			areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
-			if err != nil {
-				return err
-			}
+		return ch.notifyAboutDeletedParticipant(c.Request().Context(), tx, chatId, interestingUserId)
+	})
+	if errOuter != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	return c.NoContent(http.StatusAccepted)
+}
 
-			ch.notificator.NotifyAboutDeleteParticipants(c.Request().Context(), participantIds, chatId, []int64{interestingUserId})
-			ch.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, areAdmins)
-			return nil
-		})
+// removes the participant on behalf of the actor and records it, the caller notifies about the removal after the commit
+func (ch *ChatHandler) removeParticipant(ctx context.Context, tx *db.Tx, chatId, userId, actorId int64, comment null.String) error {
+	err := tx.DeleteParticipant(ctx, userId, chatId)
+	if err != nil {
+		return err
+	}
+	return tx.CreateModerationAction(ctx, chatId, null.Int{}, null.IntFrom(userId), dto.ModerationActionRemoveUser, actorId, comment)
+}
+
+// the remaining participants get the changed chat, the deleted one gets the chat redrawn or removed
+func (ch *ChatHandler) notifyAboutDeletedParticipant(ctx context.Context, tx *db.Tx, chatId int64, deletedUserId int64) error {
+	chatDto, err := ch.getChatWithoutPersonalization(ctx, tx, chatId, 0, 0)
+	if err != nil {
+		return err
+	}
+	err = tx.IterateOverChatParticipantIds(ctx, chatId, func(participantIds []int64) error {
+		roles, err := getRolesOfUserIds(ctx, tx, participantIds, chatId)
 		if err != nil {
-			ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting chat participants %v", err)
 			return err
 		}
 
-		// also send to the user who we delete
-		ch.notificator.NotifyAboutDeleteParticipants(c.Request().Context(), []int64{interestingUserId}, chatId, []int64{interestingUserId})
-
-		if chatDto.AvailableToSearch || chatDto.Blog {
-			// send duplicated event to the former user to re-draw chat on their search results
-			ch.notificator.NotifyAboutRedrawLeftChat(c.Request().Context(), chatDto, interestingUserId, len(chatDto.ParticipantIds) == 1, false, tx, map[int64]bool{interestingUserId: false}) // // false because interestingUserId left the chat
-		} else {
-			ch.notificator.NotifyAboutDeleteChat(c.Request().Context(), chatId, []int64{interestingUserId}, tx)
-		}
+		ch.notificator.NotifyAboutDeleteParticipants(ctx, participantIds, chatId, []int64{deletedUserId})
+		ch.notificator.NotifyAboutChangeChat(ctx, chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, roles)
 		return nil
 	})
-	if errOuter != nil {
-		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
-		return errOuter
+	if err != nil {
+		ch.lgr.WithTracing(ctx).Errorf("Error during getting chat participants %v", err)
+		return err
 	}
-	return c.NoContent(http.StatusAccepted)
+
+	// also send to the user who we delete
+	ch.notificator.NotifyAboutDeleteParticipants(ctx, []int64{deletedUserId}, chatId, []int64{deletedUserId})
+	ch.notifyOutgoingWebhooksAboutDeletedParticipant(ctx, tx, chatId, deletedUserId)
+
+	if chatDto.AvailableToSearch || chatDto.Blog {
+		// send duplicated event to the former user to re-draw chat on their search results
+		ch.notificator.NotifyAboutRedrawLeftChat(ctx, chatDto, deletedUserId, len(chatDto.ParticipantIds) == 1, false, tx, map[int64]string{deletedUserId: ""}) // empty because deletedUserId left the chat
+	} else {
+		ch.notificator.NotifyAboutDeleteChat(ctx, chatId, []int64{deletedUserId}, tx)
+	}
+	return nil
 }
 
 func (ch *ChatHandler) getParticipantsWithAdmin(cdo db.CommonOperations, participantIds []int64, chatId int64, ctx context.Context) ([]*dto.UserWithAdmin, error) {
@@ -1153,16 +1268,16 @@ func (ch *ChatHandler) enrichWithAdmin(ctx context.Context, cdo db.CommonOperati
 		userIds = append(userIds, anUser.Id)
 	}
 
-	areAdmins, err := cdo.IsAdminBatchByParticipants(ctx, userIds, chatId)
+	roles, err := cdo.GetRolesBatchByParticipants(ctx, userIds, chatId)
 	if err != nil {
 		ch.lgr.WithTracing(ctx).Errorf("Error during getting users %v", err)
 		return nil, err
 	}
 
-	for _, anAdmin := range areAdmins { // keep order
+	for _, aRole := range roles { // keep order
 		var anUser *dto.User
 		for _, u := range users {
-			if u.Id == anAdmin.UserId {
+			if u.Id == aRole.UserId {
 				anUser = u
 				break
 			}
@@ -1174,7 +1289,8 @@ func (ch *ChatHandler) enrichWithAdmin(ctx context.Context, cdo db.CommonOperati
 
 		newUsersWithAdmin = append(newUsersWithAdmin, &dto.UserWithAdmin{
 			User:  *anUser,
-			Admin: anAdmin.Admin,
+			Admin: dto.IsChatAdminRole(aRole.Role),
+			Role:  aRole.Role,
 		})
 	}
 	return newUsersWithAdmin, nil
@@ -1204,17 +1320,26 @@ func (ch *ChatHandler) AddParticipants(c echo.Context) error {
 
 	errOuter := db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
 
-		// check that I am admin
-		admin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		// check that I can manage the participants
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
 		if err != nil {
 			return err
 		}
-		if !admin {
+		if !allowed {
 			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
 		}
 
+		// the ban has to be lifted explicitly before the user can be added back
+		bannedUserIds, err := tx.GetBannedUserIds(c.Request().Context(), bindTo.ParticipantIds, chatId)
+		if err != nil {
+			return err
+		}
+		if len(bannedUserIds) > 0 {
+			return c.JSON(http.StatusConflict, &utils.H{"message": "Some of the users are banned in this chat", "bannedUserIds": bannedUserIds})
+		}
+
 		for _, participantId := range bindTo.ParticipantIds {
-			err = tx.AddParticipant(c.Request().Context(), participantId, chatId, false)
+			err = tx.AddParticipant(c.Request().Context(), participantId, chatId, dto.ChatRoleMember)
 			if err != nil {
 				ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during changing chat admin in database %v", err)
 				return err
@@ -1226,47 +1351,60 @@ func (ch *ChatHandler) AddParticipants(c echo.Context) error {
 		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
 		return errOuter
 	}
+	if c.Response().Committed {
+		return nil
+	}
 
 	errOuter = db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
-		newUsersWithAdmin, err := ch.getParticipantsWithAdmin(tx, bindTo.ParticipantIds, chatId, c.Request().Context())
+		err := ch.notifyAboutAddedParticipants(c.Request().Context(), tx, chatId, bindTo.ParticipantIds)
 		if err != nil {
-			ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting participants aith admin %v", err)
 			return err
 		}
 
-		chatDto, err := ch.getChatWithoutPersonalization(c.Request().Context(), tx, chatId, 0, 0)
+		return c.NoContent(http.StatusAccepted)
+	})
+	if errOuter != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+	}
+	return errOuter
+}
+
+// the new participants get the chat, the old ones get the new participants
+func (ch *ChatHandler) notifyAboutAddedParticipants(ctx context.Context, tx *db.Tx, chatId int64, addedParticipantIds []int64) error {
+	newUsersWithAdmin, err := ch.getParticipantsWithAdmin(tx, addedParticipantIds, chatId, ctx)
+	if err != nil {
+		ch.lgr.WithTracing(ctx).Errorf("Error during getting participants aith admin %v", err)
+		return err
+	}
+	ch.notifyOutgoingWebhooksAboutParticipants(ctx, tx, chatId, dto.OutgoingWebhookParticipantAdded, newUsersWithAdmin)
+
+	chatDto, err := ch.getChatWithoutPersonalization(ctx, tx, chatId, 0, 0)
+	if err != nil {
+		return err
+	}
+	err = tx.IterateOverChatParticipantIds(ctx, chatId, func(participantIds []int64) error {
+		roles, err := getRolesOfUserIds(ctx, tx, participantIds, chatId)
 		if err != nil {
 			return err
 		}
-		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-			areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
-			if err != nil {
-				return err
-			}
 
-			for _, aParticipant := range participantIds {
-				if utils.Contains(bindTo.ParticipantIds, aParticipant) {
-					// for the new participants of participantIds
-					ch.notificator.NotifyAboutNewChat(c.Request().Context(), chatDto, []int64{aParticipant}, false, true, tx, areAdmins)
-				} else {
-					// for the old participants of participantIds
-					ch.notificator.NotifyAboutNewParticipants(c.Request().Context(), []int64{aParticipant}, chatId, newUsersWithAdmin)
-					ch.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, []int64{aParticipant}, len(chatDto.ParticipantIds) == 1, true, tx, areAdmins)
-				}
+		for _, aParticipant := range participantIds {
+			if utils.Contains(addedParticipantIds, aParticipant) {
+				// for the new participants of participantIds
+				ch.notificator.NotifyAboutNewChat(ctx, chatDto, []int64{aParticipant}, false, true, tx, roles)
+			} else {
+				// for the old participants of participantIds
+				ch.notificator.NotifyAboutNewParticipants(ctx, []int64{aParticipant}, chatId, newUsersWithAdmin)
+				ch.notificator.NotifyAboutChangeChat(ctx, chatDto, []int64{aParticipant}, len(chatDto.ParticipantIds) == 1, true, tx, roles)
 			}
-			return nil
-		})
-		if err != nil {
-			ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting chat participants %v", err)
-			return err
 		}
-
-		return c.NoContent(http.StatusAccepted)
+		return nil
 	})
-	if errOuter != nil {
-		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+	if err != nil {
+		ch.lgr.WithTracing(ctx).Errorf("Error during getting chat participants %v", err)
+		return err
 	}
-	return errOuter
+	return nil
 }
 
 func (ch *ChatHandler) SearchForUsersToAdd(c echo.Context) error {
@@ -1281,11 +1419,11 @@ func (ch *ChatHandler) SearchForUsersToAdd(c echo.Context) error {
 		return errors.New("Error during getting auth context")
 	}
 
-	admin, err := ch.db.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+	allowed, err := hasChatPermission(c.Request().Context(), ch.db, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
 	if err != nil {
 		return err
 	}
-	if !admin {
+	if !allowed {
 		return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
 	}
 
@@ -1645,6 +1783,12 @@ func (ch *ChatHandler) CheckAccess(c echo.Context) error {
 		ch.lgr.WithTracing(c.Request().Context()).Infof("Unable to get userId: %v", err) // it can be error when overrideChatId and overrideMessageId are missed
 		return c.NoContent(http.StatusUnauthorized)
 	}
+	// the video service also enters the call through here
+	if banned, err := ch.db.IsBanned(c.Request().Context(), userId, chatId); err != nil {
+		return err
+	} else if banned {
+		return c.NoContent(http.StatusUnauthorized)
+	}
 	useCanResend, _ := GetQueryParamAsBoolean(c, "considerCanResend")
 	participant, err := ch.db.IsParticipant(c.Request().Context(), userId, chatId)
 	if err != nil {
@@ -1665,7 +1809,7 @@ func (ch *ChatHandler) CheckAccess(c echo.Context) error {
 	return c.NoContent(http.StatusUnauthorized)
 }
 
-func (ch *ChatHandler) IsAdmin(c echo.Context) error {
+func (ch *ChatHandler) HasPermission(c echo.Context) error {
 	chatId, err := GetQueryParamAsInt64(c, "chatId")
 	if err != nil {
 		return err
@@ -1674,11 +1818,12 @@ func (ch *ChatHandler) IsAdmin(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	isAdmin, err := ch.db.IsAdmin(c.Request().Context(), userId, chatId)
+	permission := c.QueryParam("permission")
+	allowed, err := hasChatPermission(c.Request().Context(), ch.db, userId, chatId, permission)
 	if err != nil {
 		return err
 	}
-	if isAdmin {
+	if allowed {
 		return c.NoContent(http.StatusOK)
 	} else {
 		return c.NoContent(http.StatusUnauthorized)
@@ -1712,6 +1857,13 @@ func (ch *ChatHandler) TetATet(c echo.Context) error {
 			return c.JSON(http.StatusAccepted, TetATetResponse{Id: chatId})
 		}
 
+		// the user who was banned in the former tet-a-tet can't start the new one
+		if banned, err := tx.IsBannedInTetATet(c.Request().Context(), userPrincipalDto.UserId, toParticipantId); err != nil {
+			return err
+		} else if banned {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You are banned by this user"})
+		}
+
 		// create tet-a-tet chat
 		chatId2, err := tx.CreateTetATetChat(c.Request().Context(), userPrincipalDto.UserId, toParticipantId)
 		if err != nil {
@@ -1719,11 +1871,11 @@ func (ch *ChatHandler) TetATet(c echo.Context) error {
 			return err
 		}
 
-		if err := tx.AddParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId2, true); err != nil {
+		if err := tx.AddParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId2, dto.ChatRoleAdmin); err != nil {
 			return err
 		}
 		if userPrincipalDto.UserId != toParticipantId {
-			if err := tx.AddParticipant(c.Request().Context(), toParticipantId, chatId2, true); err != nil {
+			if err := tx.AddParticipant(c.Request().Context(), toParticipantId, chatId2, dto.ChatRoleAdmin); err != nil {
 				return err
 			}
 		}
@@ -1733,7 +1885,7 @@ func (ch *ChatHandler) TetATet(c echo.Context) error {
 			return err
 		}
 
-		ch.notificator.NotifyAboutNewChat(c.Request().Context(), chatDto, chatDto.ParticipantIds, len(chatDto.ParticipantIds) == 1, true, tx, map[int64]bool{userPrincipalDto.UserId: true, toParticipantId: true}) // true because in tet-a-tet both are admins
+		ch.notificator.NotifyAboutNewChat(c.Request().Context(), chatDto, chatDto.ParticipantIds, len(chatDto.ParticipantIds) == 1, true, tx, map[int64]string{userPrincipalDto.UserId: dto.ChatRoleAdmin, toParticipantId: dto.ChatRoleAdmin}) // because in tet-a-tet both are admins
 
 		return c.JSON(http.StatusCreated, TetATetResponse{Id: chatId2})
 	})
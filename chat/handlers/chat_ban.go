@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/guregu/null"
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+const maxBanReasonLength = 1024
+
+type BanParticipantDto struct {
+	Reason         *string    `json:"reason"`
+	ExpireDateTime *time.Time `json:"expireDateTime"` // nil means the ban is permanent
+}
+
+func (a *BanParticipantDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Reason, validation.Length(0, maxBanReasonLength)),
+		validation.Field(&a.ExpireDateTime, validation.Min(time.Now().UTC())),
+	)
+}
+
+type ChatBansWrapper struct {
+	Data  []*dto.ChatBanDto `json:"items"`
+	Count int64             `json:"count"` // total active bans number in this chat
+}
+
+func convertToChatBanDto(b *db.ChatBan, users map[int64]*dto.User) *dto.ChatBanDto {
+	user := users[b.UserId]
+	if user == nil {
+		user = getDeletedUser(b.UserId)
+	}
+	bannedBy := users[b.BannedBy]
+	if bannedBy == nil {
+		bannedBy = getDeletedUser(b.BannedBy)
+	}
+	return &dto.ChatBanDto{
+		Id:             b.Id,
+		ChatId:         b.ChatId,
+		User:           user,
+		BannedBy:       bannedBy,
+		Reason:         b.Reason,
+		ExpireDateTime: b.ExpireDateTime,
+		CreateDateTime: b.CreateDateTime,
+	}
+}
+
+// removes the participant, if they are still in the chat, and prevents them from coming back
+func (ch *ChatHandler) BanParticipant(c echo.Context) error {
+	var bindTo = new(BanParticipantDto)
+	if err := c.Bind(bindTo); err != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, ch.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok || userPrincipalDto == nil {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	interestingUserId, err := GetPathParamAsInt64(c, "participantId")
+	if err != nil {
+		return err
+	}
+
+	if interestingUserId == userPrincipalDto.UserId {
+		return c.JSON(http.StatusBadRequest, &utils.H{"message": "You cannot ban yourself"})
+	}
+
+	var ban *db.ChatBan
+	var wasParticipant bool
+	errOuter := db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		role, err := tx.GetParticipantRole(c.Request().Context(), interestingUserId, chatId)
+		if err != nil {
+			return err
+		}
+		if role == dto.ChatRoleOwner {
+			return c.JSON(http.StatusBadRequest, &utils.H{"message": "The owner cannot be banned"})
+		}
+
+		ban, err = ch.banParticipant(c.Request().Context(), tx, chatId, interestingUserId, role, userPrincipalDto.UserId, null.StringFromPtr(bindTo.Reason), null.TimeFromPtr(bindTo.ExpireDateTime))
+		wasParticipant = role != ""
+		return err
+	})
+	if errOuter != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	if c.Response().Committed {
+		return nil
+	}
+
+	if wasParticipant {
+		errOuter = db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+			return ch.notifyAboutDeletedParticipant(c.Request().Context(), tx, chatId, interestingUserId)
+		})
+		if errOuter != nil {
+			ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+			return errOuter
+		}
+	}
+
+	users := getUsersRemotelyOrEmptyFromSlice(c.Request().Context(), ch.lgr, []int64{ban.UserId, ban.BannedBy}, ch.restClient)
+	return c.JSON(http.StatusOK, convertToChatBanDto(ban, users))
+}
+
+// creates the ban and removes the participant with the given role, the caller notifies about the removal after the commit
+func (ch *ChatHandler) banParticipant(ctx context.Context, tx *db.Tx, chatId, userId int64, role string, bannedBy int64, reason null.String, expireDateTime null.Time) (*db.ChatBan, error) {
+	ban, err := tx.CreateChatBan(ctx, chatId, userId, bannedBy, reason, expireDateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if role != "" {
+		err = tx.DeleteParticipant(ctx, userId, chatId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.CreateModerationAction(ctx, chatId, null.Int{}, null.IntFrom(userId), dto.ModerationActionBanUser, bannedBy, reason)
+	if err != nil {
+		return nil, err
+	}
+	return ban, nil
+}
+
+func (ch *ChatHandler) GetChatBans(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		bans, err := tx.GetChatBans(c.Request().Context(), chatId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		var userIds = make([]int64, 0)
+		for _, ban := range bans {
+			userIds = append(userIds, ban.UserId, ban.BannedBy)
+		}
+		users := getUsersRemotelyOrEmptyFromSlice(c.Request().Context(), ch.lgr, userIds, ch.restClient)
+
+		banDtos := make([]*dto.ChatBanDto, 0)
+		for _, ban := range bans {
+			banDtos = append(banDtos, convertToChatBanDto(ban, users))
+		}
+
+		count, err := tx.GetChatBansCount(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, ChatBansWrapper{
+			Data:  banDtos,
+			Count: count,
+		})
+	})
+}
+
+// the lifted ban doesn't return the user to the chat, they can join again
+func (ch *ChatHandler) UnbanParticipant(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	interestingUserId, err := GetPathParamAsInt64(c, "participantId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		ban, err := tx.DeleteChatBan(c.Request().Context(), chatId, interestingUserId)
+		if err != nil {
+			return err
+		}
+		if ban == nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/services"
+	"nkonev.name/chat/utils"
+)
+
+const maxEmojiUrlLength = 1024
+
+// the unicode emoji takes up to 4 code points, e.g. with the variation selector
+const maxUnicodeReactionLength = 4
+
+type CreateChatEmojiDto struct {
+	Shortcode string `json:"shortcode"`
+	Url       string `json:"url"` // the relativeUrl which is returned by the storage after uploading
+
+	chatId int64
+}
+
+func (a *CreateChatEmojiDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Shortcode, validation.Required, validation.By(func(value interface{}) error {
+			if !services.IsValidEmojiShortcode(a.Shortcode) {
+				return errors.New("must consist of 2-32 lowercase letters, digits, '_', '+' or '-'")
+			}
+			return nil
+		})),
+		validation.Field(&a.Url, validation.Required, validation.Length(0, maxEmojiUrlLength), validation.By(func(value interface{}) error {
+			// only the images which were uploaded to this chat
+			if !strings.HasPrefix(a.Url, fmt.Sprintf("%v/%v_", utils.UrlStoragePublicGetChatEmoji, a.chatId)) {
+				return errors.New("must be the emoji of this chat")
+			}
+			return nil
+		})),
+	)
+}
+
+type ChatEmojisWrapper struct {
+	Data []*dto.ChatEmojiDto `json:"items"`
+}
+
+func convertToChatEmojiDto(e *db.ChatEmoji) *dto.ChatEmojiDto {
+	return &dto.ChatEmojiDto{
+		Id:             e.Id,
+		ChatId:         e.ChatId,
+		Shortcode:      e.Shortcode,
+		Url:            e.Url,
+		CreatedBy:      e.CreatedBy,
+		CreateDateTime: e.CreateDateTime,
+	}
+}
+
+// returns the url of the custom emoji, the empty string means the unicode emoji
+// the removed emoji can still be taken back by the user who has reacted with it
+func (mc *MessageHandler) checkReaction(ctx context.Context, tx *db.Tx, chatId, messageId, userId int64, reaction string) (string, bool, error) {
+	shortcode, isCustom := services.ParseEmojiReference(reaction)
+	if !isCustom {
+		length := len([]rune(reaction))
+		return "", length > 0 && length <= maxUnicodeReactionLength, nil
+	}
+	emoji, err := tx.GetChatEmojiByShortcode(ctx, chatId, shortcode)
+	if err != nil {
+		return "", false, err
+	}
+	if emoji != nil {
+		return emoji.Url, true, nil
+	}
+	reactionUserIds, err := tx.GetReactionUsers(ctx, chatId, messageId, reaction)
+	if err != nil {
+		return "", false, err
+	}
+	return "", utils.Contains(reactionUserIds, userId), nil
+}
+
+// renders the :shortcode: of the chat emojis in the sanitized text
+func (mc *MessageHandler) replaceCustomEmojis(ctx context.Context, tx *db.Tx, chatId int64, text string) (string, error) {
+	if !services.HasEmojiReferences(text) {
+		return text, nil
+	}
+	emojis, err := tx.GetChatEmojis(ctx, chatId)
+	if err != nil {
+		return "", err
+	}
+	urls := map[string]string{}
+	for _, e := range emojis {
+		urls[e.Shortcode] = e.Url
+	}
+	return services.ReplaceCustomEmojis(text, urls), nil
+}
+
+func (mc *MessageHandler) GetChatEmojis(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if !isParticipant {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		emojis, err := tx.GetChatEmojis(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+
+		emojiDtos := make([]*dto.ChatEmojiDto, 0)
+		for _, e := range emojis {
+			emojiDtos = append(emojiDtos, convertToChatEmojiDto(e))
+		}
+		return c.JSON(http.StatusOK, ChatEmojisWrapper{Data: emojiDtos})
+	})
+}
+
+func (mc *MessageHandler) CreateChatEmoji(c echo.Context) error {
+	var bindTo = new(CreateChatEmojiDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	bindTo.Shortcode = strings.Trim(Trim(bindTo.Shortcode), ":")
+	bindTo.Url = Trim(bindTo.Url)
+	bindTo.chatId = chatId
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		allowed, err := mc.canModerate(c.Request().Context(), tx, userPrincipalDto, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		emoji, err := tx.CreateChatEmoji(c.Request().Context(), chatId, bindTo.Shortcode, bindTo.Url, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+		if emoji == nil {
+			return c.JSON(http.StatusConflict, &utils.H{"message": "The emoji with this shortcode already exists"})
+		}
+		return c.JSON(http.StatusCreated, convertToChatEmojiDto(emoji))
+	})
+}
+
+func (mc *MessageHandler) DeleteChatEmoji(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	emojiId, err := GetPathParamAsInt64(c, "emojiId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		allowed, err := mc.canModerate(c.Request().Context(), tx, userPrincipalDto, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		deleted, err := tx.DeleteChatEmoji(c.Request().Context(), chatId, emojiId)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,465 @@
+package handlers
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/client"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+type ChatExportsWrapper struct {
+	Data  []*dto.ChatExportDto `json:"items"`
+	Count int64                `json:"count"` // total exports number of the user in this chat
+}
+
+const exportJsonFilename = "messages.json"
+const exportHtmlFilename = "messages.html"
+
+func (ch *ChatHandler) ExportChat(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if !isParticipant {
+			return c.NoContent(http.StatusUnauthorized)
+		}
+
+		hasUnfinished, err := tx.HasUnfinishedChatExport(c.Request().Context(), chatId, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+		if hasUnfinished {
+			return c.JSON(http.StatusConflict, &utils.H{"message": "The export of this chat is already in progress"})
+		}
+
+		id, err := tx.CreateChatExport(c.Request().Context(), chatId, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusAccepted, &utils.H{"id": id})
+	})
+}
+
+func (ch *ChatHandler) GetChatExports(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		exports, err := tx.GetChatExports(c.Request().Context(), chatId, userPrincipalDto.UserId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		exportDtos := make([]*dto.ChatExportDto, 0)
+		for _, export := range exports {
+			exportDtos = append(exportDtos, convertToChatExportDto(export))
+		}
+
+		count, err := tx.GetChatExportsCount(c.Request().Context(), chatId, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, ChatExportsWrapper{
+			Data:  exportDtos,
+			Count: count,
+		})
+	})
+}
+
+func convertToChatExportDto(e *db.ChatExport) *dto.ChatExportDto {
+	return &dto.ChatExportDto{
+		Id:             e.Id,
+		ChatId:         e.ChatId,
+		Status:         e.Status,
+		Filename:       e.Filename,
+		Url:            e.Url,
+		FileItemUuid:   e.FileItemUuid,
+		CreateDateTime: e.CreateDateTime,
+		FinishDateTime: e.FinishDateTime,
+	}
+}
+
+// is invoked by the scheduler, builds the zip archive with the json and the html renderings, puts it into the storage and notifies the requester
+func (ch *ChatHandler) ProcessChatExport(ctx context.Context, id int64) error {
+	export, err := db.TransactWithResult(ctx, ch.db, func(tx *db.Tx) (*db.ChatExport, error) {
+		return tx.TakeChatExport(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+	if export == nil {
+		ch.lgr.WithTracing(ctx).Infof("Chat export %v was already taken", id)
+		return nil
+	}
+
+	chatBasic, filename, uploaded, err := ch.buildAndUploadChatExport(ctx, export)
+	if err != nil {
+		ch.lgr.WithTracing(ctx).Errorf("Unable to export chat %v for the export %v: %v", export.ChatId, export.Id, err)
+		return db.Transact(ctx, ch.db, func(tx *db.Tx) error {
+			return tx.FinishChatExport(ctx, export.Id, db.ChatExportStatusFailed, nil, nil, nil)
+		})
+	}
+
+	err = db.Transact(ctx, ch.db, func(tx *db.Tx) error {
+		return tx.FinishChatExport(ctx, export.Id, db.ChatExportStatusReady, &uploaded.FileItemUuid, &filename, &uploaded.Url)
+	})
+	if err != nil {
+		return err
+	}
+
+	chatTitle := chatBasic.Title
+	if chatBasic.IsTetATet {
+		chatTitle = ""
+	}
+	ch.notificator.SendChatExportReady(ctx, export.ChatId, export.OwnerId, chatTitle, export.Id, filename, uploaded.Url)
+	return nil
+}
+
+func (ch *ChatHandler) buildAndUploadChatExport(ctx context.Context, export *db.ChatExport) (*db.BasicChatDto, string, *client.UploadedFile, error) {
+	chatBasic, err := ch.db.GetChatBasic(ctx, export.ChatId)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if chatBasic == nil {
+		return nil, "", nil, fmt.Errorf("chat %v is not found", export.ChatId)
+	}
+
+	tmpFile, err := os.CreateTemp("", "chat_export_*.zip")
+	if err != nil {
+		return nil, "", nil, err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	exportDateTime := time.Now().UTC()
+	exportedChat := dto.ExportedChatDto{
+		Id:             chatBasic.Id,
+		Title:          chatBasic.Title,
+		IsTetATet:      chatBasic.IsTetATet,
+		IsBlog:         chatBasic.IsBlog,
+		CreateDateTime: chatBasic.CreateDateTime,
+		ExportDateTime: exportDateTime,
+	}
+
+	users := map[int64]*dto.User{}
+	participants, err := ch.getExportParticipants(ctx, export.ChatId, users)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	zipWriter := zip.NewWriter(tmpFile)
+	jsonWriter, err := zipWriter.Create(exportJsonFilename)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	err = ch.writeExportJson(ctx, jsonWriter, &exportedChat, participants, users)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	htmlWriter, err := zipWriter.Create(exportHtmlFilename)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	err = ch.writeExportHtml(ctx, htmlWriter, &exportedChat, participants, users)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	err = zipWriter.Close()
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	size, err := tmpFile.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	_, err = tmpFile.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	filename := fmt.Sprintf("chat_%v_export_%v.zip", export.ChatId, exportDateTime.Format("2006-01-02_15-04-05"))
+	uploaded, err := ch.restClient.UploadFile(ctx, export.ChatId, export.OwnerId, filename, "application/zip", tmpFile, size)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return chatBasic, filename, uploaded, nil
+}
+
+// fills the users cache, the users absent in aaa are represented as the deleted ones
+func (ch *ChatHandler) resolveExportUsers(ctx context.Context, users map[int64]*dto.User, userIds map[int64]bool) {
+	var missing = map[int64]bool{}
+	for userId := range userIds {
+		if _, ok := users[userId]; !ok {
+			missing[userId] = true
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+	remoteUsers := getUsersRemotelyOrEmpty(ctx, ch.lgr, missing, ch.restClient)
+	for userId := range missing {
+		if user, ok := remoteUsers[userId]; ok && user != nil {
+			users[userId] = user
+		} else {
+			users[userId] = getDeletedUser(userId)
+		}
+	}
+}
+
+func (ch *ChatHandler) getExportParticipants(ctx context.Context, chatId int64, users map[int64]*dto.User) ([]dto.ExportedUserDto, error) {
+	participants := make([]dto.ExportedUserDto, 0)
+	var hasMore = true
+	for page := 0; hasMore; page++ {
+		participantIds, err := ch.db.GetParticipantIds(ctx, chatId, utils.DefaultSize, utils.GetOffset(page, utils.DefaultSize))
+		if err != nil {
+			return nil, err
+		}
+		hasMore = len(participantIds) == utils.DefaultSize
+
+		ch.resolveExportUsers(ctx, users, utils.ArrayToSet(participantIds))
+		for _, participantId := range participantIds {
+			participants = append(participants, dto.ExportedUserDto{Id: participantId, Login: users[participantId].Login})
+		}
+	}
+	return participants, nil
+}
+
+// reads all the messages page by page in order to keep the memory consumption bounded on the big chats
+func (ch *ChatHandler) iterateOverExportMessages(ctx context.Context, chatId int64, users map[int64]*dto.User, consumer func(message *dto.ExportedMessageDto) error) error {
+	const exportPageSize = 100
+	var lastMessageId int64 = 0
+	var hasMore = true
+	for hasMore {
+		messages, err := ch.db.GetMessagesForExport(ctx, chatId, exportPageSize, lastMessageId)
+		if err != nil {
+			return err
+		}
+		hasMore = len(messages) == exportPageSize
+
+		var userIds = map[int64]bool{}
+		for _, message := range messages {
+			userIds[message.OwnerId] = true
+			if message.ResponseEmbeddedMessageReplyOwnerId != nil {
+				userIds[*message.ResponseEmbeddedMessageReplyOwnerId] = true
+			}
+			if message.ResponseEmbeddedMessageResendOwnerId != nil {
+				userIds[*message.ResponseEmbeddedMessageResendOwnerId] = true
+			}
+			for _, reaction := range message.Reactions {
+				userIds[reaction.UserId] = true
+			}
+		}
+		ch.resolveExportUsers(ctx, users, userIds)
+
+		for _, message := range messages {
+			lastMessageId = message.Id
+			err = consumer(convertToExportedMessageDto(message, users))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func convertToExportedMessageDto(dbMessage *db.Message, users map[int64]*dto.User) *dto.ExportedMessageDto {
+	ret := &dto.ExportedMessageDto{
+		Id:             dbMessage.Id,
+		OwnerId:        dbMessage.OwnerId,
+		OwnerLogin:     getOwnerOrDeleted(users, dbMessage.OwnerId, dbMessage.ImportedOwnerName).Login,
+		Text:           dbMessage.Text,
+		CreateDateTime: dbMessage.CreateDateTime,
+		EditDateTime:   dbMessage.EditDateTime,
+		FileItemUuid:   dbMessage.FileItemUuid,
+		Reactions:      make([]dto.ExportedReactionDto, 0),
+		Pinned:         dbMessage.Pinned,
+		Published:      dbMessage.Published,
+		BlogPost:       dbMessage.BlogPost,
+		ThreadId:       dbMessage.ThreadId,
+	}
+
+	if dbMessage.DeletedDateTime.Valid {
+		ret.Deleted = true
+		ret.Text = ""
+		ret.FileItemUuid = nil
+		return ret
+	}
+
+	if dbMessage.ResponseEmbeddedMessageReplyOwnerId != nil {
+		ret.EmbedMessage = &dto.ExportedEmbedMessageDto{
+			Id:         *dbMessage.ResponseEmbeddedMessageReplyId,
+			EmbedType:  *dbMessage.ResponseEmbeddedMessageType,
+			OwnerId:    *dbMessage.ResponseEmbeddedMessageReplyOwnerId,
+			OwnerLogin: users[*dbMessage.ResponseEmbeddedMessageReplyOwnerId].Login,
+			Text:       *dbMessage.ResponseEmbeddedMessageReplyText,
+		}
+	} else if dbMessage.ResponseEmbeddedMessageResendOwnerId != nil {
+		ret.EmbedMessage = &dto.ExportedEmbedMessageDto{
+			Id:         *dbMessage.ResponseEmbeddedMessageResendId,
+			ChatId:     dbMessage.ResponseEmbeddedMessageResendChatId,
+			EmbedType:  *dbMessage.ResponseEmbeddedMessageType,
+			OwnerId:    *dbMessage.ResponseEmbeddedMessageResendOwnerId,
+			OwnerLogin: users[*dbMessage.ResponseEmbeddedMessageResendOwnerId].Login,
+			Text:       dbMessage.Text,
+		}
+		ret.Text = ""
+	}
+
+	var reactionIndexes = map[string]int{}
+	for _, reaction := range dbMessage.Reactions {
+		idx, ok := reactionIndexes[reaction.Reaction]
+		if !ok {
+			idx = len(ret.Reactions)
+			reactionIndexes[reaction.Reaction] = idx
+			ret.Reactions = append(ret.Reactions, dto.ExportedReactionDto{Reaction: reaction.Reaction, UserIds: make([]int64, 0), Logins: make([]string, 0)})
+		}
+		ret.Reactions[idx].UserIds = append(ret.Reactions[idx].UserIds, reaction.UserId)
+		ret.Reactions[idx].Logins = append(ret.Reactions[idx].Logins, users[reaction.UserId].Login)
+	}
+
+	return ret
+}
+
+// writes {"chat":...,"participants":[...],"messages":[...]} without holding all the messages in memory
+func (ch *ChatHandler) writeExportJson(ctx context.Context, w io.Writer, exportedChat *dto.ExportedChatDto, participants []dto.ExportedUserDto, users map[int64]*dto.User) error {
+	chatBytes, err := json.Marshal(exportedChat)
+	if err != nil {
+		return err
+	}
+	participantsBytes, err := json.Marshal(participants)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, `{"chat":%s,"participants":%s,"messages":[`, chatBytes, participantsBytes)
+	if err != nil {
+		return err
+	}
+
+	var first = true
+	err = ch.iterateOverExportMessages(ctx, exportedChat.Id, users, func(message *dto.ExportedMessageDto) error {
+		messageBytes, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err = io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		_, err = w.Write(messageBytes)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]}")
+	return err
+}
+
+type exportHtmlMessage struct {
+	*dto.ExportedMessageDto
+	TextHtml      template.HTML
+	EmbedTextHtml template.HTML
+}
+
+var exportHtmlTemplate = template.Must(template.New("export").Parse(`
+{{define "header"}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Chat.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 960px; margin: 0 auto; }
+.message { border-bottom: 1px solid #ddd; padding: 8px 0; }
+.meta { color: #777; font-size: 0.85em; }
+.embed { border-left: 3px solid #aaa; padding-left: 8px; color: #555; }
+.deleted { color: #999; font-style: italic; }
+.thread { margin-left: 32px; }
+</style>
+</head>
+<body>
+<h1>{{.Chat.Title}}</h1>
+<p class="meta">Exported at {{.Chat.ExportDateTime.Format "2006-01-02 15:04:05"}} UTC</p>
+<p class="meta">Participants: {{range $i, $p := .Participants}}{{if $i}}, {{end}}{{$p.Login}}{{end}}</p>
+{{end}}
+{{define "message"}}<div class="message{{if .ThreadId}} thread{{end}}" id="message-{{.Id}}">
+<div class="meta">#{{.Id}} {{.OwnerLogin}} {{.CreateDateTime.Format "2006-01-02 15:04:05"}}{{if .EditDateTime.Valid}} (edited){{end}}{{if .ThreadId}} in thread #{{.ThreadId}}{{end}}{{if .Pinned}} pinned{{end}}</div>
+{{if .Deleted}}<div class="deleted">The message was deleted</div>{{else}}
+{{if .EmbedMessage}}<div class="embed">{{.EmbedMessage.EmbedType}} #{{.EmbedMessage.Id}} {{.EmbedMessage.OwnerLogin}}<div>{{.EmbedTextHtml}}</div></div>{{end}}
+<div>{{.TextHtml}}</div>
+{{if .FileItemUuid}}<div class="meta">Files: {{.FileItemUuid}}</div>{{end}}
+{{if .Reactions}}<div class="meta">{{range .Reactions}}{{.Reaction}} {{len .UserIds}} {{end}}</div>{{end}}
+{{end}}</div>
+{{end}}
+{{define "footer"}}</body>
+</html>
+{{end}}
+`))
+
+func (ch *ChatHandler) writeExportHtml(ctx context.Context, w io.Writer, exportedChat *dto.ExportedChatDto, participants []dto.ExportedUserDto, users map[int64]*dto.User) error {
+	err := exportHtmlTemplate.ExecuteTemplate(w, "header", map[string]any{
+		"Chat":         exportedChat,
+		"Participants": participants,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ch.iterateOverExportMessages(ctx, exportedChat.Id, users, func(message *dto.ExportedMessageDto) error {
+		view := exportHtmlMessage{
+			ExportedMessageDto: message,
+			TextHtml:           template.HTML(ch.policy.Sanitize(message.Text)),
+		}
+		if message.EmbedMessage != nil {
+			view.EmbedTextHtml = template.HTML(ch.policy.Sanitize(message.EmbedMessage.Text))
+		}
+		return exportHtmlTemplate.ExecuteTemplate(w, "message", view)
+	})
+	if err != nil {
+		return err
+	}
+
+	return exportHtmlTemplate.ExecuteTemplate(w, "footer", nil)
+}
@@ -0,0 +1,535 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/guregu/null"
+	"github.com/labstack/echo/v4"
+	"github.com/spf13/viper"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/services"
+	"nkonev.name/chat/utils"
+)
+
+const importedChatDefaultTitle = "Imported chat"
+
+// see the column message.imported_owner_name
+const maxImportedOwnerNameLength = 256
+
+// the mapping onto another user takes effect only after that user confirms it, otherwise the archive could speak for anybody
+type ChatImportUserMappingDto struct {
+	OwnerId        int64  `json:"ownerId"` // the importing user, is set from the principal
+	Source         string `json:"source"`
+	ExternalUserId string `json:"externalUserId"`
+	UserId         int64  `json:"userId"` // zero means the importing user themselves
+	Confirmed      bool   `json:"confirmed"`
+}
+
+func (a *ChatImportUserMappingDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Source, validation.Required, validation.In(services.ChatImportSourceVideochat, services.ChatImportSourceTelegram)),
+		validation.Field(&a.ExternalUserId, validation.Required, validation.Length(1, 256)),
+		validation.Field(&a.UserId, validation.Min(int64(0))),
+	)
+}
+
+func convertToChatImportUserMappingDto(m db.ChatImportUserMapping) ChatImportUserMappingDto {
+	return ChatImportUserMappingDto{
+		OwnerId:        m.OwnerId,
+		Source:         m.Source,
+		ExternalUserId: m.ExternalUserId,
+		UserId:         m.UserId,
+		Confirmed:      m.Confirmed,
+	}
+}
+
+type ChatImportUserMappingsWrapper struct {
+	Data []ChatImportUserMappingDto `json:"items"`
+}
+
+// maps the authors of the archive onto the local users
+type importedAuthors struct {
+	userIds  map[string]int64
+	names    map[string]string
+	unmapped []dto.ChatImportedAuthorDto
+}
+
+// the name is kept on the message of the unmapped author only
+func (a *importedAuthors) importedOwnerName(externalUserId string) null.String {
+	if a.userIds[externalUserId] > ImportedUserPlaceholderMax {
+		return null.String{}
+	}
+	name := []rune(strings.TrimSpace(a.names[externalUserId]))
+	if len(name) == 0 {
+		return null.String{}
+	}
+	if len(name) > maxImportedOwnerNameLength {
+		name = name[:maxImportedOwnerNameLength]
+	}
+	return null.StringFrom(string(name))
+}
+
+// Imports the archive (the own export or the Telegram desktop json) into a new chat.
+// Posting the same archive again continues the interrupted import and skips the already imported messages.
+func (ch *ChatHandler) ImportChat(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+	ctx := c.Request().Context()
+
+	formFile, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, &utils.H{"message": "The file is required"})
+	}
+	maxSize := viper.GetInt64("chatImport.maxSize")
+	if formFile.Size > maxSize {
+		return c.JSON(http.StatusBadRequest, &utils.H{"message": "The file is too large"})
+	}
+	file, err := formFile.Open()
+	if err != nil {
+		return err
+	}
+	content, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return err
+	}
+
+	importedChat, err := services.ParseChatImportArchive(content, maxSize)
+	if err != nil {
+		ch.lgr.WithTracing(ctx).Infof("Unable to parse the imported archive: %v", err)
+		return c.JSON(http.StatusBadRequest, &utils.H{"message": "Unable to parse the archive: " + err.Error()})
+	}
+
+	authors, err := ch.resolveImportedAuthors(ctx, userPrincipalDto.UserId, importedChat)
+	if err != nil {
+		return err
+	}
+
+	chatImport, created, err := ch.getOrCreateChatImport(ctx, userPrincipalDto.UserId, importedChat)
+	if err != nil {
+		return err
+	}
+
+	result := dto.ChatImportResultDto{
+		ChatId:          chatImport.ChatId,
+		ImportId:        chatImport.Id,
+		Created:         created,
+		UnmappedAuthors: authors.unmapped,
+	}
+	if chatImport.Finished {
+		result.SkippedMessages = len(importedChat.Messages)
+		result.TotalImportedMessages = chatImport.ImportedMessages
+		return c.JSON(http.StatusOK, result)
+	}
+
+	err = db.Transact(ctx, ch.db, func(tx *db.Tx) error {
+		return ch.addImportedParticipants(ctx, tx, chatImport.ChatId, userPrincipalDto.UserId, authors)
+	})
+	if err != nil {
+		return err
+	}
+
+	imported, skipped, err := ch.importMessages(ctx, chatImport, importedChat, authors)
+	if err != nil {
+		ch.lgr.WithTracing(ctx).Errorf("Error during importing messages into chat %v, the import can be resumed: %v", chatImport.ChatId, err)
+		return err
+	}
+	result.ImportedMessages = imported
+	result.SkippedMessages = skipped
+	result.TotalImportedMessages = chatImport.ImportedMessages + int64(imported)
+
+	err = db.Transact(ctx, ch.db, func(tx *db.Tx) error {
+		err := tx.UpdateChatLastDatetimeChat(ctx, chatImport.ChatId)
+		if err != nil {
+			return err
+		}
+		err = tx.FinishChatImport(ctx, chatImport.Id)
+		if err != nil {
+			return err
+		}
+
+		chatDto, err := ch.getChatWithoutPersonalization(ctx, tx, chatImport.ChatId, 0, 0)
+		if err != nil {
+			return err
+		}
+		return tx.IterateOverChatParticipantIds(ctx, chatImport.ChatId, func(participantIds []int64) error {
+			roles, err := getRolesOfUserIds(ctx, tx, participantIds, chatImport.ChatId)
+			if err != nil {
+				return err
+			}
+			ch.notificator.NotifyAboutNewChat(ctx, chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, roles)
+			ch.notificator.NotifyMessagesReloadCommand(ctx, chatImport.ChatId, participantIds)
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	return c.JSON(status, result)
+}
+
+func (ch *ChatHandler) getOrCreateChatImport(ctx context.Context, ownerId int64, importedChat *services.ImportedChat) (*db.ChatImport, bool, error) {
+	var created bool
+	chatImport, err := db.TransactWithResult(ctx, ch.db, func(tx *db.Tx) (*db.ChatImport, error) {
+		existing, err := tx.GetChatImport(ctx, ownerId, importedChat.Source, importedChat.ExternalId)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+
+		title := TrimAmdSanitizeChatTitle(ch.stripTagsPolicy, importedChat.Title)
+		if lateValidateChatTitle(title) != nil {
+			title = importedChatDefaultTitle
+		}
+		chatId, _, err := tx.CreateChat(ctx, &db.Chat{
+			Title:                               title,
+			RegularParticipantCanWriteMessage:   true,
+			RegularParticipantCanSeeEditHistory: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err = tx.AddParticipant(ctx, ownerId, chatId, dto.ChatRoleOwner); err != nil {
+			return nil, err
+		}
+		if _, err = tx.CreateChatImport(ctx, ownerId, importedChat.Source, importedChat.ExternalId, chatId); err != nil {
+			return nil, err
+		}
+		created = true
+		return tx.GetChatImport(ctx, ownerId, importedChat.Source, importedChat.ExternalId)
+	})
+	return chatImport, created, err
+}
+
+// The archive is supplied by the uploader, so its authors become the real users only through the mappings which were confirmed by those users,
+// the mapping onto the importing user themselves is confirmed from the beginning. The rest get the placeholder ids which are rendered with their names
+// from the archive. The placeholders are derived from the order of the first appearance, so the same archive always gives the same ids.
+func (ch *ChatHandler) resolveImportedAuthors(ctx context.Context, ownerId int64, importedChat *services.ImportedChat) (*importedAuthors, error) {
+	var names = map[string]string{}
+	var order = make([]string, 0)
+	var remember = func(externalUserId, name string) {
+		if _, ok := names[externalUserId]; !ok {
+			names[externalUserId] = name
+			order = append(order, externalUserId)
+		}
+	}
+	for _, m := range importedChat.Messages {
+		remember(m.ExternalOwnerId, m.OwnerName)
+		for _, r := range m.Reactions {
+			for i, externalUserId := range r.ExternalUserIds {
+				var name string
+				if i < len(r.UserNames) {
+					name = r.UserNames[i]
+				}
+				remember(externalUserId, name)
+			}
+		}
+	}
+
+	mappings, err := ch.db.GetChatImportUserMappings(ctx, ownerId, importedChat.Source)
+	if err != nil {
+		return nil, err
+	}
+	ret := &importedAuthors{userIds: map[string]int64{}, names: names, unmapped: make([]dto.ChatImportedAuthorDto, 0)}
+	for _, mapping := range mappings {
+		if _, ok := names[mapping.ExternalUserId]; ok && mapping.Confirmed {
+			ret.userIds[mapping.ExternalUserId] = mapping.UserId
+		}
+	}
+
+	for i, externalUserId := range order {
+		if _, ok := ret.userIds[externalUserId]; ok {
+			continue
+		}
+		// depends only on the archive, so adding a mapping between the attempts doesn't shift the ids of the others
+		placeholderId := ImportedUserPlaceholderMax - int64(i)
+		ret.userIds[externalUserId] = placeholderId
+		ret.unmapped = append(ret.unmapped, dto.ChatImportedAuthorDto{
+			ExternalUserId: externalUserId,
+			Name:           names[externalUserId],
+			PlaceholderId:  placeholderId,
+		})
+	}
+	return ret, nil
+}
+
+// the users who have confirmed the mapping join the imported chat together with their messages
+func (ch *ChatHandler) addImportedParticipants(ctx context.Context, tx *db.Tx, chatId int64, ownerId int64, authors *importedAuthors) error {
+	var added = map[int64]bool{}
+	for _, userId := range authors.userIds {
+		if userId <= ImportedUserPlaceholderMax || userId == ownerId || added[userId] {
+			continue
+		}
+		added[userId] = true
+		isParticipant, err := tx.IsParticipant(ctx, userId, chatId)
+		if err != nil {
+			return err
+		}
+		if isParticipant {
+			continue
+		}
+		if err = tx.AddParticipant(ctx, userId, chatId, dto.ChatRoleMember); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inserts the messages in batches, each batch is committed together with the ids of its messages so the interrupted import can be resumed
+func (ch *ChatHandler) importMessages(ctx context.Context, chatImport *db.ChatImport, importedChat *services.ImportedChat, authors *importedAuthors) (int, int, error) {
+	batchSize := viper.GetInt("chatImport.batchSize")
+
+	messageIds, err := db.TransactWithResult(ctx, ch.db, func(tx *db.Tx) (map[string]int64, error) {
+		return tx.GetChatImportMessageIds(ctx, chatImport.Id)
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var imported, skipped int
+	var touchedThreads = map[int64]bool{}
+	for start := 0; start < len(importedChat.Messages); start += batchSize {
+		end := utils.Min(start+batchSize, len(importedChat.Messages))
+		var batchMessageIds = map[string]int64{}
+		var batchImported int
+		err = db.Transact(ctx, ch.db, func(tx *db.Tx) error {
+			for _, im := range importedChat.Messages[start:end] {
+				if _, ok := messageIds[im.ExternalId]; ok {
+					skipped++
+					continue
+				}
+				localId := func(externalId *string) *int64 {
+					if externalId == nil {
+						return nil
+					}
+					if id, ok := messageIds[*externalId]; ok {
+						return &id
+					}
+					if id, ok := batchMessageIds[*externalId]; ok {
+						return &id
+					}
+					return nil
+				}
+
+				// the same checks as for the posted message, e.g. the media urls which are not allowed
+				text, err := TrimAmdSanitizeMessage(ctx, ch.lgr, ch.policy, im.Text)
+				if err != nil {
+					ch.lgr.WithTracing(ctx).Infof("Skipping the imported message %v: %v", im.ExternalId, err)
+					skipped++
+					continue
+				}
+				if text == "" {
+					skipped++
+					continue
+				}
+				m := &db.Message{
+					ChatId:                   chatImport.ChatId,
+					OwnerId:                  authors.userIds[im.ExternalOwnerId],
+					Text:                     text,
+					CreateDateTime:           im.CreateDateTime,
+					EditDateTime:             im.EditDateTime,
+					ImportedOwnerName:        authors.importedOwnerName(im.ExternalOwnerId),
+					RequestEmbeddedMessageId: localId(im.ReplyToExternalId),
+					ThreadId:                 localId(im.ThreadExternalId),
+					Pinned:                   im.Pinned,
+					Published:                im.Published,
+					BlogPost:                 im.BlogPost,
+				}
+				messageId, err := tx.ImportMessage(ctx, m)
+				if err != nil {
+					return err
+				}
+				if err = tx.AddChatImportMessage(ctx, chatImport.Id, im.ExternalId, messageId); err != nil {
+					return err
+				}
+				for _, r := range im.Reactions {
+					for _, externalUserId := range r.ExternalUserIds {
+						if err = tx.ImportReaction(ctx, chatImport.ChatId, messageId, authors.userIds[externalUserId], r.Reaction); err != nil {
+							return err
+						}
+					}
+				}
+				if m.ThreadId != nil {
+					touchedThreads[*m.ThreadId] = true
+				}
+				batchMessageIds[im.ExternalId] = messageId
+				batchImported++
+			}
+			return tx.AddChatImportedMessages(ctx, chatImport.Id, batchImported)
+		})
+		if err != nil {
+			return imported, skipped, err
+		}
+		for externalId, messageId := range batchMessageIds {
+			messageIds[externalId] = messageId
+		}
+		imported += batchImported
+	}
+
+	err = db.Transact(ctx, ch.db, func(tx *db.Tx) error {
+		for rootMessageId := range touchedThreads {
+			if _, err := tx.RefreshThread(ctx, chatImport.ChatId, rootMessageId); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return imported, skipped, err
+}
+
+func (ch *ChatHandler) GetChatImportUserMappings(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	source := c.QueryParam("source")
+	mappings, err := ch.db.GetChatImportUserMappings(c.Request().Context(), userPrincipalDto.UserId, source)
+	if err != nil {
+		return err
+	}
+
+	ret := make([]ChatImportUserMappingDto, 0, len(mappings))
+	for _, mapping := range mappings {
+		ret = append(ret, convertToChatImportUserMappingDto(mapping))
+	}
+	return c.JSON(http.StatusOK, ChatImportUserMappingsWrapper{Data: ret})
+}
+
+func (ch *ChatHandler) PutChatImportUserMapping(c echo.Context) error {
+	var bindTo = new(ChatImportUserMappingDto)
+	if err := c.Bind(bindTo); err != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, ch.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+	if bindTo.UserId == 0 {
+		bindTo.UserId = userPrincipalDto.UserId
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		err := tx.PutChatImportUserMapping(c.Request().Context(), userPrincipalDto.UserId, bindTo.Source, bindTo.ExternalUserId, bindTo.UserId)
+		if err != nil {
+			return err
+		}
+		mappings, err := tx.GetChatImportUserMappings(c.Request().Context(), userPrincipalDto.UserId, bindTo.Source)
+		if err != nil {
+			return err
+		}
+		for _, mapping := range mappings {
+			if mapping.ExternalUserId == bindTo.ExternalUserId {
+				return c.JSON(http.StatusOK, convertToChatImportUserMappingDto(mapping))
+			}
+		}
+		return errors.New("The mapping is not found after saving")
+	})
+}
+
+func (ch *ChatHandler) DeleteChatImportUserMapping(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		err := tx.DeleteChatImportUserMapping(c.Request().Context(), userPrincipalDto.UserId, c.QueryParam("source"), c.QueryParam("externalUserId"))
+		if err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusOK)
+	})
+}
+
+// the mappings onto the current user made by the other importers
+func (ch *ChatHandler) GetIncomingChatImportUserMappings(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	mappings, err := ch.db.GetIncomingChatImportUserMappings(c.Request().Context(), userPrincipalDto.UserId)
+	if err != nil {
+		return err
+	}
+
+	ret := make([]ChatImportUserMappingDto, 0, len(mappings))
+	for _, mapping := range mappings {
+		ret = append(ret, convertToChatImportUserMappingDto(mapping))
+	}
+	return c.JSON(http.StatusOK, ChatImportUserMappingsWrapper{Data: ret})
+}
+
+// the current user confirms that they are the external author, so the next imports of the owner attribute the messages to them
+func (ch *ChatHandler) ConfirmChatImportUserMapping(c echo.Context) error {
+	var bindTo = new(ChatImportUserMappingDto)
+	if err := c.Bind(bindTo); err != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		confirmed, err := tx.ConfirmChatImportUserMapping(c.Request().Context(), bindTo.OwnerId, bindTo.Source, bindTo.ExternalUserId, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return c.NoContent(http.StatusOK)
+	})
+}
+
+func (ch *ChatHandler) DeclineChatImportUserMapping(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	ownerId, err := GetQueryParamAsInt64(c, "ownerId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		err := tx.DeclineChatImportUserMapping(c.Request().Context(), ownerId, c.QueryParam("source"), c.QueryParam("externalUserId"), userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusOK)
+	})
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/guregu/null"
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+const inviteLinkTokenBytes = 24
+
+type CreateChatInviteLinkDto struct {
+	ExpireDateTime *time.Time `json:"expireDateTime"` // nil means the link never expires
+	MaxUses        *int64     `json:"maxUses"`        // nil means the link can be used unlimited times
+	MakeAdmin      bool       `json:"makeAdmin"`
+}
+
+func (a *CreateChatInviteLinkDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.ExpireDateTime, validation.Min(time.Now().UTC())),
+		validation.Field(&a.MaxUses, validation.Min(int64(1))),
+	)
+}
+
+type ChatInviteLinksWrapper struct {
+	Data  []*dto.ChatInviteLinkDto `json:"items"`
+	Count int64                    `json:"count"` // total invite links number in this chat
+}
+
+func generateInviteLinkToken() (string, error) {
+	b := make([]byte, inviteLinkTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (ch *ChatHandler) CreateChatInviteLink(c echo.Context) error {
+	var bindTo = new(CreateChatInviteLinkDto)
+	if err := c.Bind(bindTo); err != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, ch.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	token, err := generateInviteLinkToken()
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		link, err := tx.CreateChatInviteLink(c.Request().Context(), chatId, userPrincipalDto.UserId, token, bindTo.MakeAdmin, null.IntFromPtr(bindTo.MaxUses), null.TimeFromPtr(bindTo.ExpireDateTime))
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, convertToChatInviteLinkDto(link))
+	})
+}
+
+func (ch *ChatHandler) GetChatInviteLinks(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		links, err := tx.GetChatInviteLinks(c.Request().Context(), chatId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		linkDtos := make([]*dto.ChatInviteLinkDto, 0)
+		for _, link := range links {
+			linkDtos = append(linkDtos, convertToChatInviteLinkDto(link))
+		}
+
+		count, err := tx.GetChatInviteLinksCount(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, ChatInviteLinksWrapper{
+			Data:  linkDtos,
+			Count: count,
+		})
+	})
+}
+
+func (ch *ChatHandler) RevokeChatInviteLink(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	linkId, err := GetPathParamAsInt64(c, "linkId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		revoked, err := tx.RevokeChatInviteLink(c.Request().Context(), chatId, linkId)
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+}
+
+// adds the current user to the chat of the link, the same way as an admin does in AddParticipants
+func (ch *ChatHandler) JoinChatByInviteLink(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok || userPrincipalDto == nil {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	token := c.Param("token")
+
+	var chatId int64
+	var joined bool
+	errOuter := db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		link, err := tx.GetChatInviteLinkByTokenForUpdate(c.Request().Context(), token)
+		if err != nil {
+			return err
+		}
+		if link == nil {
+			return c.JSON(http.StatusNotFound, &utils.H{"message": "The invite link is not found"})
+		}
+		chatId = link.ChatId
+
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if isParticipant {
+			// the repeated click on the link shouldn't consume it
+			return c.JSON(http.StatusOK, &utils.H{"chatId": chatId})
+		}
+
+		if !link.IsUsable(time.Now().UTC()) {
+			return c.JSON(http.StatusGone, &utils.H{"message": "The invite link is revoked, expired or exhausted"})
+		}
+
+		if banned, err := tx.IsBanned(c.Request().Context(), userPrincipalDto.UserId, chatId); err != nil {
+			return err
+		} else if banned {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You are banned in this chat"})
+		}
+
+		role := dto.ChatRoleMember
+		if link.MakeAdmin {
+			role = dto.ChatRoleAdmin
+		}
+		err = tx.AddParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId, role)
+		if err != nil {
+			return err
+		}
+		err = tx.IncrementChatInviteLinkUses(c.Request().Context(), link.Id)
+		if err != nil {
+			return err
+		}
+		joined = true
+		return nil
+	})
+	if errOuter != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	if !joined {
+		// the response is already written
+		return nil
+	}
+
+	errOuter = db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		err := ch.notifyAboutAddedParticipants(c.Request().Context(), tx, chatId, []int64{userPrincipalDto.UserId})
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &utils.H{"chatId": chatId})
+	})
+	if errOuter != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+	}
+	return errOuter
+}
+
+func convertToChatInviteLinkDto(l *db.ChatInviteLink) *dto.ChatInviteLinkDto {
+	return &dto.ChatInviteLinkDto{
+		Id:             l.Id,
+		ChatId:         l.ChatId,
+		Token:          l.Token,
+		OwnerId:        l.OwnerId,
+		MakeAdmin:      l.MakeAdmin,
+		MaxUses:        l.MaxUses,
+		Uses:           l.Uses,
+		ExpireDateTime: l.ExpireDateTime,
+		RevokeDateTime: l.RevokeDateTime,
+		CreateDateTime: l.CreateDateTime,
+		Usable:         l.IsUsable(time.Now().UTC()),
+	}
+}
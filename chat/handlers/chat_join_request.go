@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+type ChatJoinRequestsWrapper struct {
+	Data  []*dto.ChatJoinRequestDto `json:"items"`
+	Count int64                     `json:"count"` // total pending requests number in this chat
+}
+
+func (ch *ChatHandler) RequestToJoinChat(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok || userPrincipalDto == nil {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		chat, err := tx.GetChatBasic(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+		if chat == nil || !chat.AvailableToSearch || !chat.JoinByRequest {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "This chat doesn't accept join requests"})
+		}
+
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if isParticipant {
+			return c.JSON(http.StatusConflict, &utils.H{"message": "You are already a participant of this chat"})
+		}
+
+		if banned, err := tx.IsBanned(c.Request().Context(), userPrincipalDto.UserId, chatId); err != nil {
+			return err
+		} else if banned {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You are banned in this chat"})
+		}
+
+		requestId, created, err := tx.CreateChatJoinRequest(c.Request().Context(), chatId, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+
+		if created {
+			adminIds, err := tx.GetChatAdminIds(c.Request().Context(), chatId)
+			if err != nil {
+				return err
+			}
+			ch.notificator.SendJoinRequest(c.Request().Context(), adminIds, chatId, requestId, userPrincipalDto.UserId, userPrincipalDto.UserLogin, userPrincipalDto.Avatar, chat.Title)
+		}
+
+		return c.NoContent(http.StatusAccepted)
+	})
+}
+
+func (ch *ChatHandler) GetChatJoinRequests(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		requests, err := tx.GetChatJoinRequests(c.Request().Context(), chatId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		var userIds = make([]int64, 0)
+		for _, request := range requests {
+			userIds = append(userIds, request.UserId)
+		}
+		users := getUsersRemotelyOrEmptyFromSlice(c.Request().Context(), ch.lgr, userIds, ch.restClient)
+
+		requestDtos := make([]*dto.ChatJoinRequestDto, 0)
+		for _, request := range requests {
+			user := users[request.UserId]
+			if user == nil {
+				user = getDeletedUser(request.UserId)
+			}
+			requestDtos = append(requestDtos, &dto.ChatJoinRequestDto{
+				Id:             request.Id,
+				ChatId:         request.ChatId,
+				User:           user,
+				CreateDateTime: request.CreateDateTime,
+			})
+		}
+
+		count, err := tx.GetChatJoinRequestsCount(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, ChatJoinRequestsWrapper{
+			Data:  requestDtos,
+			Count: count,
+		})
+	})
+}
+
+// adds the requester the same way as an admin does in AddParticipants
+func (ch *ChatHandler) ApproveChatJoinRequest(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	requestId, err := GetPathParamAsInt64(c, "requestId")
+	if err != nil {
+		return err
+	}
+
+	var requesterId int64
+	errOuter := db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		request, err := tx.TakeChatJoinRequest(c.Request().Context(), chatId, requestId)
+		if err != nil {
+			return err
+		}
+		if request == nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), request.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if isParticipant {
+			// has joined another way meanwhile
+			return c.NoContent(http.StatusAccepted)
+		}
+
+		if banned, err := tx.IsBanned(c.Request().Context(), request.UserId, chatId); err != nil {
+			return err
+		} else if banned {
+			// was banned after the request was made, the request is taken anyway
+			return c.JSON(http.StatusConflict, &utils.H{"message": "The user is banned in this chat"})
+		}
+
+		err = tx.AddParticipant(c.Request().Context(), request.UserId, chatId, dto.ChatRoleMember)
+		if err != nil {
+			return err
+		}
+		requesterId = request.UserId
+		return nil
+	})
+	if errOuter != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	if c.Response().Committed {
+		return nil
+	}
+
+	errOuter = db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		err := ch.notifyAboutAddedParticipants(c.Request().Context(), tx, chatId, []int64{requesterId})
+		if err != nil {
+			return err
+		}
+
+		return c.NoContent(http.StatusAccepted)
+	})
+	if errOuter != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+	}
+	return errOuter
+}
+
+func (ch *ChatHandler) RejectChatJoinRequest(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	requestId, err := GetPathParamAsInt64(c, "requestId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageParticipants)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		request, err := tx.TakeChatJoinRequest(c.Request().Context(), chatId, requestId)
+		if err != nil {
+			return err
+		}
+		if request == nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"context"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+)
+
+// every permission check of the handlers goes through this function, so the rules live only in dto.HasChatPermission
+func hasChatPermission(ctx context.Context, co db.CommonOperations, userId, chatId int64, permission string) (bool, error) {
+	role, err := co.GetParticipantRole(ctx, userId, chatId)
+	if err != nil {
+		return false, err
+	}
+	if role == "" {
+		return false, nil
+	}
+	chatBasic, err := co.GetChatBasic(ctx, chatId)
+	if err != nil {
+		return false, err
+	}
+	if chatBasic == nil {
+		return false, nil
+	}
+	return dto.HasChatPermission(role, chatBasic.PermissionSettings(), permission), nil
+}
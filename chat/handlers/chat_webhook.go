@@ -0,0 +1,553 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+const webhookTokenBytes = 24
+const maxWebhookNameLen = 256
+const webhookBotLogin = "bot"
+
+var webhookUrlRegexp = regexp.MustCompile(`^https?://`)
+
+type CreateChatIncomingWebhookDto struct {
+	Name string `json:"name"` // the messages are signed by it
+}
+
+func (a *CreateChatIncomingWebhookDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Name, validation.Required, validation.Length(1, maxWebhookNameLen)),
+	)
+}
+
+type ChatOutgoingWebhookRequestDto struct {
+	Url        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+}
+
+func (a *ChatOutgoingWebhookRequestDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Url, validation.Required, is.URL, validation.Match(webhookUrlRegexp)),
+		validation.Field(&a.EventTypes, validation.Required, validation.Each(validation.In(dto.OutgoingWebhookEventTypes...))),
+	)
+}
+
+type IncomingWebhookMessageDto struct {
+	Text     string `json:"text"`
+	ThreadId *int64 `json:"threadId"`
+}
+
+func (a *IncomingWebhookMessageDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Text, validation.Required, validation.Length(minMessageLen, maxMessageLen)),
+	)
+}
+
+type ChatIncomingWebhooksWrapper struct {
+	Data  []*dto.ChatIncomingWebhookDto `json:"items"`
+	Count int64                         `json:"count"` // total incoming webhooks number in this chat
+}
+
+type ChatOutgoingWebhooksWrapper struct {
+	Data  []*dto.ChatOutgoingWebhookDto `json:"items"`
+	Count int64                         `json:"count"` // total outgoing webhooks number in this chat
+}
+
+// is used both for the tokens of the incoming webhooks and for the secrets of the outgoing ones
+func generateWebhookToken() (string, error) {
+	b := make([]byte, webhookTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// the token of the incoming webhook is looked up by its hash, so the leaked database doesn't allow to post
+func hashWebhookToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func convertToChatIncomingWebhookDto(w *db.ChatIncomingWebhook) *dto.ChatIncomingWebhookDto {
+	return &dto.ChatIncomingWebhookDto{
+		Id:             w.Id,
+		ChatId:         w.ChatId,
+		Name:           w.Name,
+		OwnerId:        w.OwnerId,
+		CreateDateTime: w.CreateDateTime,
+	}
+}
+
+func convertToChatOutgoingWebhookDto(w *db.ChatOutgoingWebhook) *dto.ChatOutgoingWebhookDto {
+	return &dto.ChatOutgoingWebhookDto{
+		Id:             w.Id,
+		ChatId:         w.ChatId,
+		Url:            w.Url,
+		Secret:         w.Secret,
+		EventTypes:     w.EventTypes,
+		OwnerId:        w.OwnerId,
+		CreateDateTime: w.CreateDateTime,
+	}
+}
+
+func (ch *ChatHandler) CreateChatIncomingWebhook(c echo.Context) error {
+	var bindTo = new(CreateChatIncomingWebhookDto)
+	if err := c.Bind(bindTo); err != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, ch.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	token, err := generateWebhookToken()
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		webhook, err := tx.CreateChatIncomingWebhook(c.Request().Context(), chatId, userPrincipalDto.UserId, bindTo.Name, hashWebhookToken(token))
+		if err != nil {
+			return err
+		}
+
+		webhookDto := convertToChatIncomingWebhookDto(webhook)
+		webhookDto.Token = token
+		return c.JSON(http.StatusCreated, webhookDto)
+	})
+}
+
+func (ch *ChatHandler) GetChatIncomingWebhooks(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		webhooks, err := tx.GetChatIncomingWebhooks(c.Request().Context(), chatId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		webhookDtos := make([]*dto.ChatIncomingWebhookDto, 0)
+		for _, webhook := range webhooks {
+			webhookDtos = append(webhookDtos, convertToChatIncomingWebhookDto(webhook))
+		}
+
+		count, err := tx.GetChatIncomingWebhooksCount(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, ChatIncomingWebhooksWrapper{
+			Data:  webhookDtos,
+			Count: count,
+		})
+	})
+}
+
+func (ch *ChatHandler) DeleteChatIncomingWebhook(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	webhookId, err := GetPathParamAsInt64(c, "webhookId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		deleted, err := tx.DeleteChatIncomingWebhook(c.Request().Context(), chatId, webhookId)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+}
+
+func (ch *ChatHandler) CreateChatOutgoingWebhook(c echo.Context) error {
+	var bindTo = new(ChatOutgoingWebhookRequestDto)
+	if err := c.Bind(bindTo); err != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, ch.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	secret, err := generateWebhookToken()
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		webhook, err := tx.CreateChatOutgoingWebhook(c.Request().Context(), chatId, userPrincipalDto.UserId, bindTo.Url, secret, bindTo.EventTypes)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, convertToChatOutgoingWebhookDto(webhook))
+	})
+}
+
+// the secret stays the same, in order to change it the webhook should be recreated
+func (ch *ChatHandler) EditChatOutgoingWebhook(c echo.Context) error {
+	var bindTo = new(ChatOutgoingWebhookRequestDto)
+	if err := c.Bind(bindTo); err != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, ch.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	webhookId, err := GetPathParamAsInt64(c, "webhookId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		webhook, err := tx.EditChatOutgoingWebhook(c.Request().Context(), chatId, webhookId, bindTo.Url, bindTo.EventTypes)
+		if err != nil {
+			return err
+		}
+		if webhook == nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		return c.JSON(http.StatusOK, convertToChatOutgoingWebhookDto(webhook))
+	})
+}
+
+func (ch *ChatHandler) GetChatOutgoingWebhooks(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		webhooks, err := tx.GetChatOutgoingWebhooks(c.Request().Context(), chatId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		webhookDtos := make([]*dto.ChatOutgoingWebhookDto, 0)
+		for _, webhook := range webhooks {
+			webhookDtos = append(webhookDtos, convertToChatOutgoingWebhookDto(webhook))
+		}
+
+		count, err := tx.GetChatOutgoingWebhooksCount(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, ChatOutgoingWebhooksWrapper{
+			Data:  webhookDtos,
+			Count: count,
+		})
+	})
+}
+
+func (ch *ChatHandler) DeleteChatOutgoingWebhook(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	webhookId, err := GetPathParamAsInt64(c, "webhookId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		deleted, err := tx.DeleteChatOutgoingWebhook(c.Request().Context(), chatId, webhookId)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+}
+
+// the public endpoint, the token of the webhook is the only authentication
+// the message is posted on behalf of the bot, the name of the webhook is used as the login in the notifications
+func (mc *MessageHandler) PostMessageByIncomingWebhook(c echo.Context) error {
+	var bindTo = new(IncomingWebhookMessageDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	token := c.Param("token")
+
+	var chatId int64
+	var webhookName string
+	messageId, errOuter := db.TransactWithResult(c.Request().Context(), mc.db, func(tx *db.Tx) (int64, error) {
+		webhook, err := tx.GetChatIncomingWebhookByTokenHash(c.Request().Context(), hashWebhookToken(token))
+		if err != nil {
+			return 0, err
+		}
+		if webhook == nil {
+			return 0, c.NoContent(http.StatusNotFound)
+		}
+		if allowed, retryAfter := mc.rateLimiter.AllowIncomingWebhook(c.Request().Context(), webhook.Id); !allowed {
+			return 0, respondRateLimited(c, retryAfter)
+		}
+		chatId = webhook.ChatId
+		webhookName = webhook.Name
+
+		return mc.createBotMessage(c.Request().Context(), tx, chatId, bindTo.ThreadId, bindTo.Text)
+	})
+	if errOuter != nil {
+		if handled, err := respondCreateMessageError(c, errOuter); handled {
+			return err
+		}
+
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	if c.Response().Committed {
+		return nil
+	}
+
+	botPrincipal := &auth.AuthResult{
+		UserId:    WebhookBotUser,
+		UserLogin: webhookName,
+	}
+	errOuter = mc.notifyAboutCreatedMessage(c.Request().Context(), chatId, bindTo.ThreadId, messageId, botPrincipal)
+	if errOuter != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	mc.fillLinkPreviewAsync(c.Request().Context(), chatId, messageId)
+	return c.JSON(http.StatusCreated, &utils.H{"id": messageId})
+}
+
+// stores the message on behalf of the bot, the bot isn't a participant, so the checks of createMessage aren't applicable
+// the content filter is applied, the held message is rejected because nobody can post it on behalf of the bot after the approval
+func (mc *MessageHandler) createBotMessage(ctx context.Context, tx *db.Tx, chatId int64, threadId *int64, input string) (int64, error) {
+	text, err := TrimAmdSanitizeMessage(ctx, mc.lgr, mc.policy, input)
+	if err != nil {
+		return 0, err
+	}
+
+	verdict, err := mc.filterMessage(ctx, tx, chatId, WebhookBotUser, 0, text)
+	if err != nil {
+		return 0, err
+	}
+	if verdict != nil {
+		if verdict.Action == dto.ContentFilterActionHold {
+			return 0, &contentRejectedError{reason: verdict.Reason}
+		}
+		text = verdict.Text
+	}
+
+	creatableMessage := &db.Message{
+		Text:    text,
+		ChatId:  chatId,
+		OwnerId: WebhookBotUser,
+	}
+	if threadId != nil {
+		rootMessage, err := tx.GetMessageBasic(ctx, chatId, *threadId)
+		if err != nil {
+			return 0, err
+		}
+		if rootMessage == nil || rootMessage.DeletedDateTime.Valid || rootMessage.ThreadId != nil {
+			return 0, &wrongThreadError{}
+		}
+		creatableMessage.ThreadId = threadId
+	}
+
+	messageId, _, _, err := tx.CreateMessage(ctx, creatableMessage)
+	if err != nil {
+		return 0, err
+	}
+	if creatableMessage.ThreadId != nil {
+		_, err = tx.RefreshThread(ctx, chatId, *creatableMessage.ThreadId)
+		if err != nil {
+			return 0, err
+		}
+		return messageId, nil
+	}
+	err = tx.UpdateChatLastDatetimeChat(ctx, chatId)
+	if err != nil {
+		return 0, err
+	}
+	return messageId, nil
+}
+
+// the messages of the incoming webhooks aren't sent to the outgoing ones, so two bots can't make a loop
+func (mc *MessageHandler) notifyOutgoingWebhooksAboutCreatedMessage(ctx context.Context, tx *db.Tx, chatId int64, message *dto.DisplayMessageDto, principal *auth.AuthResult) {
+	if principal.UserId == WebhookBotUser || message == nil {
+		return
+	}
+	mc.notificator.NotifyOutgoingWebhooks(ctx, tx, &dto.OutgoingWebhookEvent{
+		EventType:           dto.OutgoingWebhookMessageCreated,
+		ChatId:              chatId,
+		MessageNotification: message,
+	})
+}
+
+func (ch *ChatHandler) notifyOutgoingWebhooksAboutParticipants(ctx context.Context, tx *db.Tx, chatId int64, eventType string, participants []*dto.UserWithAdmin) {
+	ch.notificator.NotifyOutgoingWebhooks(ctx, tx, &dto.OutgoingWebhookEvent{
+		EventType:    eventType,
+		ChatId:       chatId,
+		Participants: &participants,
+	})
+}
+
+// only the id is sent, the same as to the users
+func (ch *ChatHandler) notifyOutgoingWebhooksAboutDeletedParticipant(ctx context.Context, tx *db.Tx, chatId int64, deletedUserId int64) {
+	ch.notifyOutgoingWebhooksAboutParticipants(ctx, tx, chatId, dto.OutgoingWebhookParticipantDeleted, []*dto.UserWithAdmin{
+		{
+			User: dto.User{Id: deletedUserId},
+		},
+	})
+}
@@ -268,7 +268,10 @@ func TrimAmdSanitizeMessage(ctx context.Context, lgr *logger.Logger, policy *ser
 				maybeImage.SetAttr("data-original", fixedSrc)
 			}
 
-			mediaCount++
+			// the custom emojis are the part of the text
+			if !isCustomEmoji(maybeImage) {
+				mediaCount++
+			}
 		}
 	})
 	if retErr != nil {
@@ -306,6 +309,11 @@ func TrimAmdSanitizeMessage(ctx context.Context, lgr *logger.Logger, policy *ser
 	return ret, nil
 }
 
+func isCustomEmoji(s *goquery.Selection) bool {
+	src, _ := s.Attr("src")
+	return s.HasClass(services.CustomEmojiClass) && strings.HasPrefix(src, utils.UrlStoragePublicGetChatEmoji+"/")
+}
+
 func removeProtocolHostPortIfNeed(src, frontendUrl string) (string, error) {
 	parsed, err := url.Parse(src)
 	if err != nil {
@@ -0,0 +1,557 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/guregu/null"
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/services"
+	"nkonev.name/chat/utils"
+)
+
+type contentRejectedError struct {
+	reason string
+}
+
+func (m *contentRejectedError) Error() string {
+	return m.reason
+}
+
+type CreateBlocklistEntryDto struct {
+	Pattern string `json:"pattern"`
+	Regex   bool   `json:"regex"` // otherwise the pattern is the whole word
+	Action  string `json:"action"`
+}
+
+func (a *CreateBlocklistEntryDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Pattern, validation.Required, validation.By(func(value interface{}) error {
+			_, err := services.CompileBlocklistPattern(a.Pattern, a.Regex)
+			return err
+		})),
+		validation.Field(&a.Action, validation.Required, validation.In(dto.ContentFilterActions...)),
+	)
+}
+
+type ModerationCommentDto struct {
+	Comment *string `json:"comment"`
+}
+
+func (a *ModerationCommentDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Comment, validation.Length(0, maxModerationCommentLength)),
+	)
+}
+
+type BlocklistEntriesWrapper struct {
+	Data []*dto.BlocklistEntryDto `json:"items"`
+}
+
+type HeldMessagesWrapper struct {
+	Data  []*dto.HeldMessageDto `json:"items"`
+	Count int64                 `json:"count"` // total held messages number in this chat
+}
+
+// runs the content filter against the sanitized text, the nil verdict means the filter is turned off
+// the rejection is returned as contentRejectedError, the editedMessageId is 0 for the new message
+func (mc *MessageHandler) filterMessage(ctx context.Context, tx *db.Tx, chatId, userId, editedMessageId int64, text string) (*services.ContentFilterVerdict, error) {
+	if !mc.contentFilter.Enabled() || text == "" {
+		return nil, nil
+	}
+
+	role, err := tx.GetParticipantRole(ctx, userId, chatId)
+	if err != nil {
+		return nil, err
+	}
+
+	participantSince, err := tx.GetParticipantCreateDateTime(ctx, userId, chatId)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := tx.GetEffectiveBlocklistEntries(ctx, chatId)
+	if err != nil {
+		return nil, err
+	}
+	blocklist := make([]*services.BlocklistPattern, 0, len(entries))
+	for _, e := range entries {
+		blocklist = append(blocklist, &services.BlocklistPattern{
+			Pattern: e.Pattern,
+			Regex:   e.Regex,
+			Action:  e.Action,
+		})
+	}
+
+	var repeatedCount int64
+	if window := mc.contentFilter.RepeatedMessagesWindow(); window > 0 {
+		repeatedCount, err = tx.GetRecentIdenticalMessagesCount(ctx, chatId, userId, text, window, editedMessageId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	verdict := mc.contentFilter.Check(&services.ContentFilterInput{
+		Text:             text,
+		IsChatAdmin:      dto.IsChatAdminRole(role),
+		ParticipantSince: participantSince.Time,
+		RepeatedCount:    repeatedCount,
+		Blocklist:        blocklist,
+	})
+	if verdict.Action == dto.ContentFilterActionReject {
+		mc.lgr.WithTracing(ctx).Infof("The message of user %v in chat %v was rejected by the content filter: %v", userId, chatId, verdict.Reason)
+		return nil, &contentRejectedError{reason: verdict.Reason}
+	}
+	return verdict, nil
+}
+
+// the posted message is masked in place, the message with the hold verdict is returned with the reason
+func (mc *MessageHandler) filterPostedMessage(ctx context.Context, tx *db.Tx, chatId int64, input *CreateMessageDto, principal *auth.AuthResult) (*services.ContentFilterVerdict, error) {
+	if input.EmbedMessageRequest != nil && input.EmbedMessageRequest.EmbedType == dto.EmbedMessageTypeResend {
+		// the text of the resent message was already checked in its chat
+		return nil, nil
+	}
+	text, err := TrimAmdSanitizeMessage(ctx, mc.lgr, mc.policy, input.Text)
+	if err != nil {
+		return nil, err
+	}
+	verdict, err := mc.filterMessage(ctx, tx, chatId, principal.UserId, 0, text)
+	if err != nil || verdict == nil {
+		return nil, err
+	}
+	if verdict.Action == dto.ContentFilterActionHold && input.Poll != nil {
+		// there is nowhere to keep the poll until the decision
+		return nil, &contentRejectedError{reason: verdict.Reason}
+	}
+	input.Text = verdict.Text
+	return verdict, nil
+}
+
+// performs the same checks as createMessage, so the moderator doesn't review the message which cannot be posted anyway
+func (mc *MessageHandler) holdMessage(ctx context.Context, tx *db.Tx, chatId int64, input *CreateMessageDto, principal *auth.AuthResult, reason string) (int64, error) {
+	if participant, err := tx.IsParticipant(ctx, principal.UserId, chatId); err != nil {
+		return 0, err
+	} else if !participant {
+		return 0, &notParticipantError{}
+	}
+
+	chatBasic, err := tx.GetChatBasic(ctx, chatId)
+	if err != nil {
+		return 0, err
+	}
+
+	role, err := tx.GetParticipantRole(ctx, principal.UserId, chatId)
+	if err != nil {
+		return 0, err
+	}
+
+	if !canWriteMessage(chatBasic, role) {
+		return 0, &cannotWriteMessageError{}
+	}
+
+	creatableMessage, err := convertToCreatableMessage(ctx, mc.lgr, input, principal, chatId, mc.policy)
+	if err != nil {
+		return 0, err
+	}
+
+	err = mc.validateAndSetEmbedFieldsEmbedMessage(ctx, tx, input, creatableMessage)
+	if err != nil {
+		mc.lgr.WithTracing(ctx).Errorf("Error during checking embed %v", err)
+		return 0, err
+	}
+
+	if input.ThreadId != nil {
+		rootMessage, err := tx.GetMessageBasic(ctx, chatId, *input.ThreadId)
+		if err != nil {
+			return 0, err
+		}
+		if rootMessage == nil || rootMessage.DeletedDateTime.Valid || rootMessage.ThreadId != nil {
+			return 0, &wrongThreadError{}
+		}
+	}
+
+	heldMessage := &db.HeldMessage{
+		ChatId:       chatId,
+		OwnerId:      principal.UserId,
+		Text:         creatableMessage.Text,
+		FileItemUuid: input.FileItemUuid,
+		ThreadId:     input.ThreadId,
+		Reason:       reason,
+	}
+	if input.EmbedMessageRequest != nil {
+		heldMessage.EmbedMessageId = &input.EmbedMessageRequest.Id
+		heldMessage.EmbedMessageType = &input.EmbedMessageRequest.EmbedType
+	}
+	mc.lgr.WithTracing(ctx).Infof("The message of user %v in chat %v was held by the content filter: %v", principal.UserId, chatId, reason)
+	return tx.CreateHeldMessage(ctx, heldMessage)
+}
+
+func convertHeldToCreateMessageDto(hm *db.HeldMessage) *CreateMessageDto {
+	ret := &CreateMessageDto{
+		Text:         hm.Text,
+		FileItemUuid: hm.FileItemUuid,
+		ThreadId:     hm.ThreadId,
+	}
+	if hm.EmbedMessageId != nil && hm.EmbedMessageType != nil {
+		ret.EmbedMessageRequest = &dto.EmbedMessageRequest{
+			Id:        *hm.EmbedMessageId,
+			EmbedType: *hm.EmbedMessageType,
+		}
+		if hm.EmbedChatId != nil {
+			ret.EmbedMessageRequest.ChatId = *hm.EmbedChatId
+		}
+	}
+	return ret
+}
+
+func convertToBlocklistEntryDto(e *db.BlocklistEntry) *dto.BlocklistEntryDto {
+	return &dto.BlocklistEntryDto{
+		Id:             e.Id,
+		ChatId:         e.ChatId,
+		Pattern:        e.Pattern,
+		Regex:          e.Regex,
+		Action:         e.Action,
+		CreateDateTime: e.CreateDateTime,
+	}
+}
+
+// the chat blocklist is managed by its moderators, the global one is managed by the admins of the service
+func (mc *MessageHandler) checkBlocklistAccess(c echo.Context, tx *db.Tx, principal *auth.AuthResult, chatId null.Int) (bool, error) {
+	if !chatId.Valid {
+		if !isServiceAdmin(principal) {
+			return false, c.NoContent(http.StatusUnauthorized)
+		}
+		return true, nil
+	}
+	allowed, err := mc.canModerate(c.Request().Context(), tx, principal, chatId.Int64, dto.PermissionModerate)
+	if err != nil {
+		return false, err
+	}
+	if !allowed {
+		return false, c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+	}
+	return true, nil
+}
+
+// the absent path parameter means the global blocklist
+func getBlocklistChatId(c echo.Context) (null.Int, error) {
+	if c.Param("id") == "" {
+		return null.Int{}, nil
+	}
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return null.Int{}, err
+	}
+	return null.IntFrom(chatId), nil
+}
+
+func (mc *MessageHandler) GetBlocklistEntries(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := getBlocklistChatId(c)
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		if allowed, err := mc.checkBlocklistAccess(c, tx, userPrincipalDto, chatId); !allowed {
+			return err
+		}
+
+		entries, err := tx.GetBlocklistEntries(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+
+		entryDtos := make([]*dto.BlocklistEntryDto, 0)
+		for _, e := range entries {
+			entryDtos = append(entryDtos, convertToBlocklistEntryDto(e))
+		}
+		return c.JSON(http.StatusOK, BlocklistEntriesWrapper{Data: entryDtos})
+	})
+}
+
+func (mc *MessageHandler) CreateBlocklistEntry(c echo.Context) error {
+	var bindTo = new(CreateBlocklistEntryDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if !bindTo.Regex {
+		bindTo.Pattern = Trim(bindTo.Pattern)
+	}
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := getBlocklistChatId(c)
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		if allowed, err := mc.checkBlocklistAccess(c, tx, userPrincipalDto, chatId); !allowed {
+			return err
+		}
+
+		entry, err := tx.CreateBlocklistEntry(c.Request().Context(), chatId, bindTo.Pattern, bindTo.Regex, bindTo.Action)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusCreated, convertToBlocklistEntryDto(entry))
+	})
+}
+
+func (mc *MessageHandler) DeleteBlocklistEntry(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := getBlocklistChatId(c)
+	if err != nil {
+		return err
+	}
+
+	entryId, err := GetPathParamAsInt64(c, "entryId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		if allowed, err := mc.checkBlocklistAccess(c, tx, userPrincipalDto, chatId); !allowed {
+			return err
+		}
+
+		deleted, err := tx.DeleteBlocklistEntry(c.Request().Context(), chatId, entryId)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+}
+
+// the queue of the messages which wait for the decision of the moderator
+func (mc *MessageHandler) GetHeldMessages(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		allowed, err := mc.canModerate(c.Request().Context(), tx, userPrincipalDto, chatId, dto.PermissionModerate)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		heldMessages, err := tx.GetHeldMessages(c.Request().Context(), chatId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		var ownerIds = map[int64]bool{}
+		for _, hm := range heldMessages {
+			ownerIds[hm.OwnerId] = true
+		}
+		users := getUsersRemotelyOrEmpty(c.Request().Context(), mc.lgr, ownerIds, mc.restClient)
+
+		heldMessageDtos := make([]*dto.HeldMessageDto, 0)
+		for _, hm := range heldMessages {
+			owner := users[hm.OwnerId]
+			if owner == nil {
+				owner = getDeletedUser(hm.OwnerId)
+			}
+			converted := convertHeldToCreateMessageDto(hm)
+			heldMessageDtos = append(heldMessageDtos, &dto.HeldMessageDto{
+				Id:                  hm.Id,
+				ChatId:              hm.ChatId,
+				Owner:               owner,
+				Text:                hm.Text,
+				FileItemUuid:        hm.FileItemUuid,
+				EmbedMessageRequest: converted.EmbedMessageRequest,
+				ThreadId:            hm.ThreadId,
+				Reason:              hm.Reason,
+				CreateDateTime:      hm.CreateDateTime,
+			})
+		}
+
+		count, err := tx.GetHeldMessagesCount(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, HeldMessagesWrapper{
+			Data:  heldMessageDtos,
+			Count: count,
+		})
+	})
+}
+
+// publishes the held message on behalf of its owner, the content filter isn't applied again
+func (mc *MessageHandler) ApproveHeldMessage(c echo.Context) error {
+	var bindTo = new(ModerationCommentDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	heldMessageId, err := GetPathParamAsInt64(c, "heldMessageId")
+	if err != nil {
+		return err
+	}
+
+	var comment null.String
+	if bindTo.Comment != nil {
+		comment = null.StringFrom(TrimAmdSanitize(mc.policy, *bindTo.Comment))
+	}
+
+	var heldMessage *db.HeldMessage
+	var owner *auth.AuthResult
+	messageId, errOuter := db.TransactWithResult(c.Request().Context(), mc.db, func(tx *db.Tx) (int64, error) {
+		allowed, err := mc.canModerate(c.Request().Context(), tx, userPrincipalDto, chatId, dto.PermissionModerate)
+		if err != nil {
+			return 0, err
+		}
+		if !allowed {
+			return 0, c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		hm, err := tx.TakeHeldMessage(c.Request().Context(), chatId, heldMessageId)
+		if err != nil {
+			return 0, err
+		}
+		if hm == nil {
+			return 0, c.NoContent(http.StatusNotFound)
+		}
+		heldMessage = hm
+		owner = mc.getScheduledMessageOwner(c.Request().Context(), hm.OwnerId)
+
+		messageId, err := mc.createMessage(c.Request().Context(), tx, chatId, convertHeldToCreateMessageDto(hm), owner)
+		if err != nil {
+			return 0, err
+		}
+		err = tx.CreateModerationAction(c.Request().Context(), chatId, null.IntFrom(messageId), null.IntFrom(hm.OwnerId), dto.ModerationActionApproveMessage, userPrincipalDto.UserId, comment)
+		return messageId, err
+	})
+	if errOuter != nil {
+		if handled, err := respondCreateMessageError(c, errOuter); handled {
+			return err
+		}
+
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	if c.Response().Committed {
+		return nil
+	}
+
+	errOuter = mc.notifyAboutCreatedMessage(c.Request().Context(), chatId, heldMessage.ThreadId, messageId, owner)
+	if errOuter != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	mc.fillLinkPreviewAsync(c.Request().Context(), chatId, messageId)
+	return c.JSON(http.StatusOK, &utils.H{"id": messageId})
+}
+
+func (mc *MessageHandler) RejectHeldMessage(c echo.Context) error {
+	var bindTo = new(ModerationCommentDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	heldMessageId, err := GetPathParamAsInt64(c, "heldMessageId")
+	if err != nil {
+		return err
+	}
+
+	var comment null.String
+	if bindTo.Comment != nil {
+		comment = null.StringFrom(TrimAmdSanitize(mc.policy, *bindTo.Comment))
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		allowed, err := mc.canModerate(c.Request().Context(), tx, userPrincipalDto, chatId, dto.PermissionModerate)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		hm, err := tx.TakeHeldMessage(c.Request().Context(), chatId, heldMessageId)
+		if err != nil {
+			return err
+		}
+		if hm == nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		err = tx.CreateModerationAction(c.Request().Context(), chatId, null.Int{}, null.IntFrom(hm.OwnerId), dto.ModerationActionRejectMessage, userPrincipalDto.UserId, comment)
+		if err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"github.com/spf13/viper"
+	"nkonev.name/chat/db"
+)
+
+// the page is fetched after the message is committed, so a slow site doesn't delay sending
+func (mc *MessageHandler) fillLinkPreviewAsync(ctx context.Context, chatId int64, messageId int64) {
+	if !viper.GetBool("linkPreview.enabled") {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := mc.fillLinkPreview(ctx, chatId, messageId); err != nil {
+			mc.lgr.WithTracing(ctx).Errorf("Error during filling the link preview of message %v in chat %v: %v", messageId, chatId, err)
+		}
+	}()
+}
+
+func (mc *MessageHandler) fillLinkPreview(ctx context.Context, chatId int64, messageId int64) error {
+	m, err := mc.db.GetMessageBasic(ctx, chatId, messageId)
+	if err != nil {
+		return err
+	}
+	if m == nil || m.DeletedDateTime.Valid {
+		return nil
+	}
+
+	link := mc.linkPreview.FindFirstUrl(m.Text)
+	if link == m.LinkPreviewUrl.String {
+		// the edit didn't touch the link
+		return nil
+	}
+
+	var lp *db.LinkPreview
+	if link != "" {
+		lp, err = mc.linkPreview.GetPreview(ctx, link)
+		if err != nil {
+			return err
+		}
+	}
+	if lp == nil && !m.LinkPreviewUrl.Valid {
+		return nil
+	}
+
+	changed, err := mc.db.SetMessageLinkPreview(ctx, chatId, messageId, m.Text, lp)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		// the message was edited or deleted meanwhile, the edit fills its own preview
+		return nil
+	}
+
+	return db.Transact(ctx, mc.db, func(tx *db.Tx) error {
+		chatBasic, err := tx.GetChatBasic(ctx, chatId)
+		if err != nil {
+			return err
+		}
+		if chatBasic == nil {
+			return nil
+		}
+
+		message, err := getMessageWithoutPersonalized(ctx, mc.lgr, tx, mc.restClient, chatId, messageId, m.OwnerId) // personal values will be set inside IterateOverChatParticipantIds -> event.go
+		if err != nil {
+			return err
+		}
+		if message == nil {
+			// the author has left the chat
+			return nil
+		}
+
+		return tx.IterateOverChatParticipantIds(ctx, chatId, func(participantIds []int64) error {
+			roles, err := getRolesOfUserIds(ctx, tx, participantIds, chatId)
+			if err != nil {
+				return err
+			}
+			mc.notificator.NotifyAboutEditMessage(ctx, participantIds, chatId, message, chatBasic, roles)
+			return nil
+		})
+	})
+}
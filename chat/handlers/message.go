@@ -9,6 +9,7 @@ import (
 	"github.com/guregu/null"
 	"github.com/labstack/echo/v4"
 	"github.com/spf13/viper"
+	"math"
 	"net/http"
 	"net/url"
 	"nkonev.name/chat/auth"
@@ -31,7 +32,15 @@ const NonExistentUser = -65000
 const DeletedUser = -1
 const AllUsers = -2
 const HereUsers = -3
+const WebhookBotUser = -65001
+
+// the unmapped authors of the imported messages get the ids from here downwards, far below the special users above
+const ImportedUserPlaceholderMax = -1000000
 const badMediaUrl = "BAD_MEDIA_URL"
+const slowMode = "SLOW_MODE"
+const rateLimited = "RATE_LIMITED"
+const contentRejected = "CONTENT_REJECTED"
+const messageHeld = "MESSAGE_HELD"
 
 const maxDisplayableUsers = 10
 
@@ -45,6 +54,8 @@ type CreateMessageDto struct {
 	BlogPost            bool                     `json:"blogPost"`
 	FileItemUuid        *string                  `json:"fileItemUuid"`
 	EmbedMessageRequest *dto.EmbedMessageRequest `json:"embedMessage"`
+	ThreadId            *int64                   `json:"threadId"`
+	Poll                *PollRequestDto          `json:"poll"` // the text is the question of the poll
 }
 
 type MessageHandler struct {
@@ -56,9 +67,13 @@ type MessageHandler struct {
 	restClient         *client.RestClient
 	lgr                *logger.Logger
 	ch                 *ChatHandler
+	linkPreview        *services.LinkPreviewService
+	rateLimiter        *services.RateLimiter
+	webhookSender      *services.OutgoingWebhookSender
+	contentFilter      *services.ContentFilter
 }
 
-func NewMessageHandler(dbR *db.DB, policy *services.SanitizerPolicy, stripSourceContent *services.StripSourcePolicy, stripAllTags *services.StripTagsPolicy, notificator *services.Events, restClient *client.RestClient, lgr *logger.Logger, ch *ChatHandler) *MessageHandler {
+func NewMessageHandler(dbR *db.DB, policy *services.SanitizerPolicy, stripSourceContent *services.StripSourcePolicy, stripAllTags *services.StripTagsPolicy, notificator *services.Events, restClient *client.RestClient, lgr *logger.Logger, ch *ChatHandler, linkPreview *services.LinkPreviewService, rateLimiter *services.RateLimiter, webhookSender *services.OutgoingWebhookSender, contentFilter *services.ContentFilter) *MessageHandler {
 	return &MessageHandler{
 		db:                 dbR,
 		policy:             policy,
@@ -68,6 +83,10 @@ func NewMessageHandler(dbR *db.DB, policy *services.SanitizerPolicy, stripSource
 		restClient:         restClient,
 		lgr:                lgr,
 		ch:                 ch,
+		linkPreview:        linkPreview,
+		rateLimiter:        rateLimiter,
+		webhookSender:      webhookSender,
+		contentFilter:      contentFilter,
 	}
 }
 
@@ -120,6 +139,36 @@ func (mc *MessageHandler) getMessages(ctx context.Context, tx *db.Tx, chatId int
 		return nil, false, err
 	}
 
+	messageDtos, err := mc.convertToMessageDtos(ctx, tx, chatId, userId, messages)
+	if err != nil {
+		return nil, false, err
+	}
+	return messageDtos, false, nil
+}
+
+func (mc *MessageHandler) searchMessagesRanked(ctx context.Context, tx *db.Tx, chatId int64, userId int64, size, offset int, searchString string) ([]*dto.DisplayMessageDto, bool, error) {
+	isParticipant, err := tx.IsParticipant(ctx, userId, chatId)
+	if err != nil {
+		return nil, false, err
+	}
+	if !isParticipant {
+		return nil, true, nil
+	}
+
+	messages, err := tx.SearchMessagesRanked(ctx, chatId, size, offset, searchString)
+	if err != nil {
+		mc.lgr.WithTracing(ctx).Errorf("Error search messages in db %v", err)
+		return nil, false, err
+	}
+
+	messageDtos, err := mc.convertToMessageDtos(ctx, tx, chatId, userId, messages)
+	if err != nil {
+		return nil, false, err
+	}
+	return messageDtos, false, nil
+}
+
+func (mc *MessageHandler) convertToMessageDtos(ctx context.Context, tx *db.Tx, chatId int64, userId int64, messages []*db.Message) ([]*dto.DisplayMessageDto, error) {
 	var ownersSet = map[int64]bool{}
 	var chatsPreSet = map[int64]bool{}
 	for _, message := range messages {
@@ -127,20 +176,20 @@ func (mc *MessageHandler) getMessages(ctx context.Context, tx *db.Tx, chatId int
 	}
 	chatsSet, err := tx.GetChatsBasic(ctx, chatsPreSet, userId)
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 	var users = getUsersRemotelyOrEmpty(ctx, mc.lgr, ownersSet, mc.restClient)
-	areAdminsMap, err := getAreAdmins(ctx, tx, users, chatId)
+	role, err := tx.GetParticipantRole(ctx, userId, chatId)
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 
 	messageDtos := make([]*dto.DisplayMessageDto, 0)
 	for _, mm := range messages {
-		messageDtos = append(messageDtos, convertToMessageDto(ctx, mc.lgr, mm, users, chatsSet, userId, areAdminsMap[userId]))
+		messageDtos = append(messageDtos, convertToMessageDto(ctx, mc.lgr, mm, users, chatsSet, userId, role))
 	}
 
-	return messageDtos, false, nil
+	return messageDtos, nil
 }
 
 type MessagesResponseDto struct {
@@ -176,9 +225,20 @@ func (mc *MessageHandler) GetMessages(c echo.Context) error {
 		return err
 	}
 
+	// rank=true switches from the timeline order to the relevance order, which is paginated by page
+	rank := utils.GetBoolean(c.QueryParam("rank"))
+	page := utils.FixPageString(c.QueryParam("page"))
+
 	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
 
-		messageDtos, notAparticipant, err := mc.getMessages(c.Request().Context(), tx, chatId, userPrincipalDto.UserId, size, startingFromItemId, includeStartingFrom, reverse, searchString)
+		var messageDtos []*dto.DisplayMessageDto
+		var notAparticipant bool
+		var err error
+		if rank && searchString != "" {
+			messageDtos, notAparticipant, err = mc.searchMessagesRanked(c.Request().Context(), tx, chatId, userPrincipalDto.UserId, size, utils.GetOffset(page, size), searchString)
+		} else {
+			messageDtos, notAparticipant, err = mc.getMessages(c.Request().Context(), tx, chatId, userPrincipalDto.UserId, size, startingFromItemId, includeStartingFrom, reverse, searchString)
+		}
 		if err != nil {
 			return err
 		}
@@ -195,7 +255,212 @@ func (mc *MessageHandler) GetMessages(c echo.Context) error {
 	})
 }
 
-func getMessage(c echo.Context, lgr *logger.Logger, co db.CommonOperations, restClient *client.RestClient, chatId int64, messageId int64, behalfUserId int64, behalfUserIsAdminInChat bool) (*dto.DisplayMessageDto, error) {
+func (mc *MessageHandler) GetThreadMessages(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	size := utils.FixSizeString(c.QueryParam("size"))
+	reverse := utils.GetBoolean(c.QueryParam("reverse"))
+	var startingFromItemId *int64
+	startingFromItemIdString := c.QueryParam("startingFromItemId")
+	if startingFromItemIdString != "" {
+		startingFromItemId2, err := utils.ParseInt64(startingFromItemIdString) // exclusive
+		if err != nil {
+			return err
+		}
+		startingFromItemId = &startingFromItemId2
+	}
+	includeStartingFrom := utils.GetBoolean(c.QueryParam("includeStartingFrom"))
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	rootMessageId, err := GetPathParamAsInt64(c, "messageId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if !isParticipant {
+			return c.NoContent(http.StatusNoContent)
+		}
+
+		messages, err := tx.GetThreadMessages(c.Request().Context(), chatId, rootMessageId, size, startingFromItemId, includeStartingFrom, reverse)
+		if err != nil {
+			mc.lgr.WithTracing(c.Request().Context()).Errorf("Error get thread messages from db %v", err)
+			return err
+		}
+
+		messageDtos, err := mc.convertToMessageDtos(c.Request().Context(), tx, chatId, userPrincipalDto.UserId, messages)
+		if err != nil {
+			return err
+		}
+
+		mc.lgr.WithTracing(c.Request().Context()).Debugf("Successfully returning %v thread messages", len(messageDtos))
+		return c.JSON(http.StatusOK, MessagesResponseDto{
+			Items:   messageDtos,
+			HasNext: len(messageDtos) == size,
+		})
+	})
+}
+
+func (mc *MessageHandler) ReadThreadMessage(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	rootMessageId, err := GetPathParamAsInt64(c, "messageId")
+	if err != nil {
+		return err
+	}
+
+	replyId, err := GetPathParamAsInt64(c, "replyId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if !isParticipant {
+			return c.NoContent(http.StatusUnauthorized)
+		}
+
+		thread, err := tx.GetThread(c.Request().Context(), chatId, rootMessageId)
+		if err != nil {
+			return err
+		}
+		if thread == nil {
+			return c.NoContent(http.StatusNoContent)
+		}
+
+		err = tx.MarkThreadAsRead(c.Request().Context(), chatId, rootMessageId, userPrincipalDto.UserId, &replyId)
+		if err != nil {
+			return err
+		}
+
+		unreadReplies, err := tx.GetThreadUnreadRepliesCountBatchByParticipants(c.Request().Context(), []int64{userPrincipalDto.UserId}, chatId, rootMessageId)
+		if err != nil {
+			return err
+		}
+		mc.notificator.NotifyAboutThreadChanged(c.Request().Context(), chatId, convertToThreadDto(thread), []int64{userPrincipalDto.UserId}, unreadReplies)
+		mc.notificator.NotifyRemoveMention(c.Request().Context(), []int64{userPrincipalDto.UserId}, chatId, replyId)
+
+		return c.NoContent(http.StatusAccepted)
+	})
+}
+
+type SearchMessagesGloballyRequestDto struct {
+	StartingFromItemId  *dto.MessageSearchItemId `json:"startingFromItemId"`
+	IncludeStartingFrom bool                     `json:"includeStartingFrom"`
+	Size                int                      `json:"size"`
+	SearchString        string                   `json:"searchString"`
+}
+
+type SearchMessagesGloballyResponseDto struct {
+	Items   []*dto.FoundMessageDto `json:"items"`
+	HasNext bool                   `json:"hasNext"`
+}
+
+func (mc *MessageHandler) SearchMessagesGlobally(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	var bindTo = new(SearchMessagesGloballyRequestDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+
+	size := utils.FixSize(bindTo.Size)
+	searchString := TrimAmdSanitize(mc.policy, bindTo.SearchString)
+	var startingFromItemId *db.MessageSearchItemId
+	if bindTo.StartingFromItemId != nil {
+		startingFromItemId = &db.MessageSearchItemId{
+			CreateDateTime: bindTo.StartingFromItemId.CreateDateTime,
+			ChatId:         bindTo.StartingFromItemId.ChatId,
+			MessageId:      bindTo.StartingFromItemId.MessageId,
+		}
+	}
+
+	foundMessages, err := mc.db.SearchMessagesGlobally(c.Request().Context(), userPrincipalDto.UserId, size, startingFromItemId, bindTo.IncludeStartingFrom, searchString)
+	if err != nil {
+		return err
+	}
+
+	var tetATetChatIds = []int64{}
+	var ownersSet = map[int64]bool{}
+	for _, fm := range foundMessages {
+		ownersSet[fm.OwnerId] = true
+		if fm.ChatTetATet {
+			tetATetChatIds = append(tetATetChatIds, fm.ChatId)
+		}
+	}
+
+	// tet-a-tet chat has no meaningful title, so we show the opposite participant's login
+	tetATetParticipants, err := mc.db.GetParticipantIdsBatch(c.Request().Context(), tetATetChatIds, 2)
+	if err != nil {
+		return err
+	}
+	var tetATetOppositeUserIds = map[int64]int64{}
+	for _, pi := range tetATetParticipants {
+		for _, participantId := range pi.ParticipantIds {
+			if participantId != userPrincipalDto.UserId || len(pi.ParticipantIds) == 1 {
+				tetATetOppositeUserIds[pi.ChatId] = participantId
+				ownersSet[participantId] = true
+			}
+		}
+	}
+
+	var users = getUsersRemotelyOrEmpty(c.Request().Context(), mc.lgr, ownersSet, mc.restClient)
+
+	var foundMessageDtos = make([]*dto.FoundMessageDto, 0)
+	for _, fm := range foundMessages {
+		user := getOwnerOrDeleted(users, fm.OwnerId, fm.ImportedOwnerName)
+		chatName := fm.ChatTitle
+		if fm.ChatTetATet {
+			if oppositeUser, ok := users[tetATetOppositeUserIds[fm.ChatId]]; ok {
+				chatName = oppositeUser.Login
+			}
+		}
+		foundMessageDtos = append(foundMessageDtos, &dto.FoundMessageDto{
+			ChatId:         fm.ChatId,
+			ChatName:       chatName,
+			MessageId:      fm.MessageId,
+			Owner:          user,
+			CreateDateTime: fm.CreateDateTime,
+			Highlight:      fm.Highlight,
+		})
+	}
+
+	mc.lgr.WithTracing(c.Request().Context()).Debugf("Successfully returning %v found messages", len(foundMessageDtos))
+	return c.JSON(http.StatusOK, SearchMessagesGloballyResponseDto{
+		Items:   foundMessageDtos,
+		HasNext: len(foundMessageDtos) == size,
+	})
+}
+
+func getMessage(c echo.Context, lgr *logger.Logger, co db.CommonOperations, restClient *client.RestClient, chatId int64, messageId int64, behalfUserId int64, behalfUserRoleInChat string) (*dto.DisplayMessageDto, error) {
 	message, chatsSet, users, err := prepareDataForMessage(c.Request().Context(), lgr, co, restClient, chatId, messageId, behalfUserId)
 
 	if err != nil {
@@ -207,7 +472,7 @@ func getMessage(c echo.Context, lgr *logger.Logger, co db.CommonOperations, rest
 		return nil, nil
 	}
 
-	return convertToMessageDto(c.Request().Context(), lgr, message, users, chatsSet, behalfUserId, behalfUserIsAdminInChat), nil
+	return convertToMessageDto(c.Request().Context(), lgr, message, users, chatsSet, behalfUserId, behalfUserRoleInChat), nil
 }
 
 func prepareDataForMessage(ctx context.Context, lgr *logger.Logger, co db.CommonOperations, restClient *client.RestClient, chatId int64, messageId int64, behalfUserId int64) (*db.Message, map[int64]*db.BasicChatDtoExtended, map[int64]*dto.User, error) {
@@ -218,20 +483,28 @@ func prepareDataForMessage(ctx context.Context, lgr *logger.Logger, co db.Common
 		if message == nil {
 			return nil, nil, nil, nil
 		}
-		var ownersSet = map[int64]bool{}
-		var chatsPreSet = map[int64]bool{}
-		populateSets(message, ownersSet, chatsPreSet, true)
-
-		chatsSet, err := co.GetChatsBasic(ctx, chatsPreSet, behalfUserId)
+		chatsSet, users, err := prepareDataForLoadedMessage(ctx, lgr, co, restClient, message, behalfUserId)
 		if err != nil {
 			return nil, nil, nil, err
 		}
-
-		var users = getUsersRemotelyOrEmpty(ctx, lgr, ownersSet, restClient)
 		return message, chatsSet, users, nil
 	}
 }
 
+func prepareDataForLoadedMessage(ctx context.Context, lgr *logger.Logger, co db.CommonOperations, restClient *client.RestClient, message *db.Message, behalfUserId int64) (map[int64]*db.BasicChatDtoExtended, map[int64]*dto.User, error) {
+	var ownersSet = map[int64]bool{}
+	var chatsPreSet = map[int64]bool{}
+	populateSets(message, ownersSet, chatsPreSet, true)
+
+	chatsSet, err := co.GetChatsBasic(ctx, chatsPreSet, behalfUserId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var users = getUsersRemotelyOrEmpty(ctx, lgr, ownersSet, restClient)
+	return chatsSet, users, nil
+}
+
 func getMessageWithoutPersonalized(ctx context.Context, lgr *logger.Logger, co db.CommonOperations, restClient *client.RestClient, chatId int64, messageId int64, behalfUserId int64) (*dto.DisplayMessageDto, error) {
 	message, chatsSet, users, err := prepareDataForMessage(ctx, lgr, co, restClient, chatId, messageId, behalfUserId)
 
@@ -247,6 +520,22 @@ func getMessageWithoutPersonalized(ctx context.Context, lgr *logger.Logger, co d
 	return convertToMessageDtoWithoutPersonalized(ctx, lgr, message, users, chatsSet), nil
 }
 
+// renders the message regardless of the participation of the behalf user
+func (mc *MessageHandler) getMessageForModeration(ctx context.Context, tx *db.Tx, chatId int64, messageId int64, behalfUserId int64) (*dto.DisplayMessageDto, error) {
+	message, err := tx.GetMessageForModeration(ctx, chatId, messageId)
+	if err != nil {
+		return nil, err
+	}
+	if message == nil {
+		return nil, nil
+	}
+	chatsSet, users, err := prepareDataForLoadedMessage(ctx, mc.lgr, tx, mc.restClient, message, behalfUserId)
+	if err != nil {
+		return nil, err
+	}
+	return convertToMessageDtoWithoutPersonalized(ctx, mc.lgr, message, users, chatsSet), nil
+}
+
 func populateSets(message *db.Message, ownersSet map[int64]bool, chatsPreSet map[int64]bool, countReactions bool) {
 	ownersSet[message.OwnerId] = true
 	chatsPreSet[message.ChatId] = true
@@ -262,6 +551,7 @@ func populateSets(message *db.Message, ownersSet map[int64]bool, chatsPreSet map
 
 	if countReactions {
 		takeOnAccountReactions(ownersSet, message.Reactions)
+		takeOnAccountPoll(ownersSet, message.Poll)
 	}
 }
 
@@ -318,6 +608,10 @@ func (mc *MessageHandler) ReactionMessage(c echo.Context) error {
 		return err
 	}
 
+	if allowed, retryAfter := mc.rateLimiter.Allow(c.Request().Context(), userPrincipalDto.UserId); !allowed {
+		return respondRateLimited(c, retryAfter)
+	}
+
 	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
 		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
 		if err != nil {
@@ -327,12 +621,30 @@ func (mc *MessageHandler) ReactionMessage(c echo.Context) error {
 			return c.NoContent(http.StatusUnauthorized)
 		}
 
+		m, err := tx.GetMessageBasic(c.Request().Context(), chatId, messageId)
+		if err != nil {
+			mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting chat participants")
+			return err
+		}
+		if m != nil && m.DeletedDateTime.Valid {
+			// tombstones can't be reacted
+			return c.NoContent(http.StatusBadRequest)
+		}
+
 		var bindTo = new(ReactionPut)
 		if err := c.Bind(bindTo); err != nil {
 			mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
 			return err
 		}
 
+		emojiUrl, validReaction, err := mc.checkReaction(c.Request().Context(), tx, chatId, messageId, userPrincipalDto.UserId, bindTo.Reaction)
+		if err != nil {
+			return err
+		}
+		if !validReaction {
+			return c.JSON(http.StatusBadRequest, &utils.H{"message": "Unknown reaction"})
+		}
+
 		wasAdded, err := tx.FlipReaction(c.Request().Context(), userPrincipalDto.UserId, chatId, messageId, bindTo.Reaction)
 		if err != nil {
 			mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during flipping reaction %v", err)
@@ -363,19 +675,13 @@ func (mc *MessageHandler) ReactionMessage(c echo.Context) error {
 			}
 		}
 
-		mc.notificator.SendReactionEvent(c.Request().Context(), wasChanged, chatId, messageId, bindTo.Reaction, reactionUsers, count, tx)
+		mc.notificator.SendReactionEvent(c.Request().Context(), wasChanged, chatId, messageId, bindTo.Reaction, null.NewString(emojiUrl, emojiUrl != ""), reactionUsers, count, tx)
 
 		chatNameForNotification, err := mc.getChatNameForNotification(c.Request().Context(), tx, chatId)
 		if err != nil {
 			return err
 		}
 
-		m, err := tx.GetMessageBasic(c.Request().Context(), chatId, messageId)
-		if err != nil {
-			mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting chat participants")
-			return err
-		}
-
 		// sends notification to the notification microservice
 		if m != nil && m.OwnerId != userPrincipalDto.UserId {
 			mc.notificator.SendReactionOnYourMessage(c.Request().Context(), wasAdded, chatId, messageId, m.OwnerId, bindTo.Reaction, userPrincipalDto.UserId, userPrincipalDto.UserLogin, userPrincipalDto.Avatar, chatNameForNotification)
@@ -403,12 +709,12 @@ func (mc *MessageHandler) GetMessage(c echo.Context) error {
 		return err
 	}
 
-	admin, err := mc.db.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+	role, err := mc.db.GetParticipantRole(c.Request().Context(), userPrincipalDto.UserId, chatId)
 	if err != nil {
 		return err
 	}
 
-	message, err := getMessage(c, mc.lgr, mc.db, mc.restClient, chatId, messageId, userPrincipalDto.UserId, admin)
+	message, err := getMessage(c, mc.lgr, mc.db, mc.restClient, chatId, messageId, userPrincipalDto.UserId, role)
 	if err != nil {
 		return err
 	}
@@ -497,10 +803,25 @@ func (mc *MessageHandler) IsFreshMessagesPage(c echo.Context) error {
 }
 
 func getDeletedUser(id int64) *dto.User {
+	// the bot isn't known to aaa as well
+	if id == WebhookBotUser {
+		return &dto.User{Login: webhookBotLogin, Id: id}
+	}
 	return &dto.User{Login: fmt.Sprintf("deleted_user_%v", id), Id: id}
 }
 
-func convertToMessageDto(ctx context.Context, lgr *logger.Logger, dbMessage *db.Message, users map[int64]*dto.User, chats map[int64]*db.BasicChatDtoExtended, behalfUserId int64, behalfUserIsAdminInChat bool) *dto.DisplayMessageDto {
+// the unmapped author of the imported message isn't known to aaa, but their name from the archive is kept on the message
+func getOwnerOrDeleted(users map[int64]*dto.User, ownerId int64, importedOwnerName null.String) *dto.User {
+	if ownerId <= ImportedUserPlaceholderMax && importedOwnerName.Valid {
+		return &dto.User{Login: importedOwnerName.String, Id: ownerId}
+	}
+	if user := users[ownerId]; user != nil {
+		return user
+	}
+	return getDeletedUser(ownerId)
+}
+
+func convertToMessageDto(ctx context.Context, lgr *logger.Logger, dbMessage *db.Message, users map[int64]*dto.User, chats map[int64]*db.BasicChatDtoExtended, behalfUserId int64, behalfUserRoleInChat string) *dto.DisplayMessageDto {
 
 	ret := convertToMessageDtoWithoutPersonalized(ctx, lgr, dbMessage, users, chats)
 
@@ -508,16 +829,14 @@ func convertToMessageDto(ctx context.Context, lgr *logger.Logger, dbMessage *db.
 	if !ok {
 		lgr.WithTracing(ctx).Errorf("Unable to get message's chat for message id = %v, chat id = %v", dbMessage.Id, dbMessage.ChatId)
 	}
-	ret.SetPersonalizedFields(messageChat.RegularParticipantCanPublishMessage, messageChat.RegularParticipantCanPinMessage, messageChat.RegularParticipantCanWriteMessage, behalfUserIsAdminInChat, behalfUserId)
+	ret.SetPersonalizedFields(messageChat.PermissionSettings(), behalfUserRoleInChat, behalfUserId)
+	ret.SetPersonalizedPoll(ret, messageChat.PermissionSettings(), behalfUserRoleInChat, behalfUserId)
 
 	return ret
 }
 
 func convertToMessageDtoWithoutPersonalized(ctx context.Context, lgr *logger.Logger, dbMessage *db.Message, users map[int64]*dto.User, chats map[int64]*db.BasicChatDtoExtended) *dto.DisplayMessageDto {
-	user := users[dbMessage.OwnerId]
-	if user == nil {
-		user = getDeletedUser(dbMessage.OwnerId)
-	}
+	user := getOwnerOrDeleted(users, dbMessage.OwnerId, dbMessage.ImportedOwnerName)
 	ret := &dto.DisplayMessageDto{
 		Id:             dbMessage.Id,
 		Text:           dbMessage.Text,
@@ -530,7 +849,27 @@ func convertToMessageDtoWithoutPersonalized(ctx context.Context, lgr *logger.Log
 		Pinned:         dbMessage.Pinned,
 		BlogPost:       dbMessage.BlogPost,
 		Published:      dbMessage.Published,
+		Highlight:      dbMessage.SearchHighlight,
+		ThreadId:       dbMessage.ThreadId,
+	}
+	if dbMessage.ThreadReplyCount.Valid && dbMessage.ThreadReplyCount.Int64 > 0 {
+		ret.Thread = &dto.ThreadDto{
+			RootMessageId:     dbMessage.Id,
+			ReplyCount:        dbMessage.ThreadReplyCount.Int64,
+			LastReplyDateTime: dbMessage.ThreadLastReplyDateTime,
+		}
+	}
+
+	if dbMessage.DeletedDateTime.Valid {
+		// a tombstone keeps its place in the timeline, but nothing of its content
+		ret.Text = ""
+		ret.FileItemUuid = nil
+		ret.DeletedDateTime = dbMessage.DeletedDateTime
+		ret.RestorableUntil = null.TimeFrom(dbMessage.DeletedDateTime.Time.Add(viper.GetDuration("message.restoreWindow")))
+		ret.Reactions = make([]dto.Reaction, 0)
+		return ret
 	}
+
 	ret.Text = patchStorageUrlToPreventCachingVideo(ctx, lgr, ret.Text)
 
 	if dbMessage.ResponseEmbeddedMessageReplyOwnerId != nil {
@@ -562,15 +901,22 @@ func convertToMessageDtoWithoutPersonalized(ctx context.Context, lgr *logger.Log
 	}
 
 	ret.Reactions = convertReactions(dbMessage.Reactions, users)
+	ret.Poll = convertPoll(dbMessage.Poll, users)
+	if dbMessage.LinkPreviewUrl.Valid {
+		ret.LinkPreview = &dto.LinkPreviewDto{
+			Url:         dbMessage.LinkPreviewUrl.String,
+			Title:       dbMessage.LinkPreviewTitle,
+			Description: dbMessage.LinkPreviewDescription,
+			ImageUrl:    dbMessage.LinkPreviewImageUrl,
+			SiteName:    dbMessage.LinkPreviewSiteName,
+		}
+	}
 
 	return ret
 }
 
 func convertToPublishedMessageDto(cleanTagsPolicy *services.StripTagsPolicy, dbMessage *db.Message, users map[int64]*dto.User) *dto.PublishedMessageDto {
-	user := users[dbMessage.OwnerId]
-	if user == nil {
-		user = getDeletedUser(dbMessage.OwnerId)
-	}
+	user := getOwnerOrDeleted(users, dbMessage.OwnerId, dbMessage.ImportedOwnerName)
 	ret := &dto.PublishedMessageDto{
 		Id:             dbMessage.Id,
 		Text:           dbMessage.Text,
@@ -586,10 +932,7 @@ func convertToPublishedMessageDto(cleanTagsPolicy *services.StripTagsPolicy, dbM
 }
 
 func convertToPinnedMessageDto(cleanTagsPolicy *services.StripTagsPolicy, dbMessage *db.Message, users map[int64]*dto.User) *dto.PinnedMessageDto {
-	user := users[dbMessage.OwnerId]
-	if user == nil {
-		user = getDeletedUser(dbMessage.OwnerId)
-	}
+	user := getOwnerOrDeleted(users, dbMessage.OwnerId, dbMessage.ImportedOwnerName)
 	ret := &dto.PinnedMessageDto{
 		Id:             dbMessage.Id,
 		Text:           dbMessage.Text,
@@ -639,6 +982,7 @@ func convertReactions(dbReactionsOfMessage []db.Reaction, users map[int64]*dto.U
 				Count:    1,
 				Reaction: dbReaction.Reaction,
 				Users:    usersOfThisReaction,
+				Url:      dbReaction.EmojiUrl,
 			})
 		}
 	}
@@ -649,6 +993,7 @@ func convertReactions(dbReactionsOfMessage []db.Reaction, users map[int64]*dto.U
 func (a *CreateMessageDto) Validate() error {
 	return validation.ValidateStruct(a,
 		validation.Field(&a.Text, validation.Required, validation.Length(minMessageLen, maxMessageLen)),
+		validation.Field(&a.Poll),
 	)
 }
 
@@ -671,6 +1016,20 @@ func (m *cannotWriteMessageError) Error() string {
 	return "You cannot write a message"
 }
 
+type slowModeError struct {
+	retryAfter time.Duration
+}
+
+func (m *slowModeError) Error() string {
+	return "The slow mode is turned on in this chat, please wait"
+}
+
+type wrongThreadError struct{}
+
+func (m *wrongThreadError) Error() string {
+	return "The thread root message is missed or is a reply itself"
+}
+
 func (mc *MessageHandler) PostMessage(c echo.Context) error {
 	var bindTo = new(CreateMessageDto)
 	if err := c.Bind(bindTo); err != nil {
@@ -695,124 +1054,283 @@ func (mc *MessageHandler) PostMessage(c echo.Context) error {
 		return err
 	}
 
-	messageId, errOuter := db.TransactWithResult(c.Request().Context(), mc.db, func(tx *db.Tx) (int64, error) {
-		if participant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId); err != nil {
-			return 0, err
-		} else if !participant {
-			return 0, &notParticipantError{}
-		}
-		creatableMessage, err := convertToCreatableMessage(c.Request().Context(), mc.lgr, bindTo, userPrincipalDto, chatId, mc.policy)
-		if err != nil {
-			return 0, err
+	if allowed, retryAfter := mc.rateLimiter.Allow(c.Request().Context(), userPrincipalDto.UserId); !allowed {
+		return respondRateLimited(c, retryAfter)
+	}
+
+	// before the slash commands, so they can't be used to bypass the slow mode
+	// the scheduled messages are checked at the sending time, see SendScheduledMessage
+	errOuter := db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		return mc.checkSlowMode(c.Request().Context(), tx, chatId, userPrincipalDto.UserId)
+	})
+	if errOuter != nil {
+		if handled, err := respondCreateMessageError(c, errOuter); handled {
+			return err
 		}
 
-		err = mc.validateAndSetEmbedFieldsEmbedMessage(c.Request().Context(), tx, bindTo, creatableMessage)
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+
+	if handled, err := mc.handleSlashCommand(c, chatId, bindTo, userPrincipalDto); handled {
+		return err
+	}
+
+	var heldMessageId int64
+	messageId, errOuter := db.TransactWithResult(c.Request().Context(), mc.db, func(tx *db.Tx) (int64, error) {
+		verdict, err := mc.filterPostedMessage(c.Request().Context(), tx, chatId, bindTo, userPrincipalDto)
 		if err != nil {
-			mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during checking embed %v", err)
 			return 0, err
 		}
-
-		chatBasic, err := tx.GetChatBasic(c.Request().Context(), chatId)
-		if err != nil {
+		if verdict != nil && verdict.Action == dto.ContentFilterActionHold {
+			heldMessageId, err = mc.holdMessage(c.Request().Context(), tx, chatId, bindTo, userPrincipalDto, verdict.Reason)
 			return 0, err
 		}
+		return mc.createMessage(c.Request().Context(), tx, chatId, bindTo, userPrincipalDto)
+	})
+	if errOuter != nil {
+		if handled, err := respondCreateMessageError(c, errOuter); handled {
+			return err
+		}
+
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	if heldMessageId != 0 {
+		_ = mc.clearMessageDraft(c.Request().Context(), chatId, userPrincipalDto.UserId)
+		return c.JSON(http.StatusAccepted, &utils.H{"heldMessageId": heldMessageId, "message": "The message will be published after the review of the moderator", "businessErrorCode": messageHeld})
+	}
+
+	errOuter = mc.notifyAboutCreatedMessage(c.Request().Context(), chatId, bindTo.ThreadId, messageId, userPrincipalDto)
+	if errOuter != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	mc.fillLinkPreviewAsync(c.Request().Context(), chatId, messageId)
+	_ = mc.clearMessageDraft(c.Request().Context(), chatId, userPrincipalDto.UserId)
+	return c.JSON(http.StatusCreated, &utils.H{"id": messageId})
+}
+
+// responds with the business error in case the message cannot be created because of the user's input
+func respondCreateMessageError(c echo.Context, err error) (bool, error) {
+	var mediaError *MediaUrlErr
+	if errors.As(err, &mediaError) {
+		return true, c.JSON(http.StatusBadRequest, &utils.H{"message": mediaError.Error(), "businessErrorCode": badMediaUrl})
+	}
+	var mediaOverflowError *MediaOverflowErr
+	if errors.As(err, &mediaOverflowError) {
+		return true, c.JSON(http.StatusBadRequest, &utils.H{"message": mediaOverflowError.Error()})
+	}
+	var npe *notParticipantError
+	if errors.As(err, &npe) {
+		return true, c.JSON(http.StatusBadRequest, &utils.H{"message": "You are not allowed to write to this chat"})
+	}
+	var cwm *cannotWriteMessageError
+	if errors.As(err, &cwm) {
+		return true, c.NoContent(http.StatusUnauthorized)
+	}
+	var wte *wrongThreadError
+	if errors.As(err, &wte) {
+		return true, c.JSON(http.StatusBadRequest, &utils.H{"message": wte.Error()})
+	}
+	var wpe *wrongPollError
+	if errors.As(err, &wpe) {
+		return true, c.JSON(http.StatusBadRequest, &utils.H{"message": wpe.Error()})
+	}
+	var sme *slowModeError
+	if errors.As(err, &sme) {
+		c.Response().Header().Set(echo.HeaderRetryAfter, utils.Int64ToString(retryAfterSeconds(sme.retryAfter)))
+		return true, c.JSON(http.StatusTooManyRequests, &utils.H{"message": sme.Error(), "businessErrorCode": slowMode, "retryAfterSeconds": retryAfterSeconds(sme.retryAfter)})
+	}
+	var cre *contentRejectedError
+	if errors.As(err, &cre) {
+		return true, c.JSON(http.StatusBadRequest, &utils.H{"message": cre.Error(), "businessErrorCode": contentRejected})
+	}
+	return false, nil
+}
+
+func respondRateLimited(c echo.Context, retryAfter time.Duration) error {
+	c.Response().Header().Set(echo.HeaderRetryAfter, utils.Int64ToString(retryAfterSeconds(retryAfter)))
+	return c.JSON(http.StatusTooManyRequests, &utils.H{"message": "Too many messages and reactions, please wait", "businessErrorCode": rateLimited, "retryAfterSeconds": retryAfterSeconds(retryAfter)})
+}
+
+// rounds up, so the client which waits the returned seconds isn't refused again
+func retryAfterSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
+
+// the owner and the admins aren't limited, the others can post one message per the interval of the chat
+func (mc *MessageHandler) checkSlowMode(ctx context.Context, tx *db.Tx, chatId, userId int64) error {
+	chatBasic, err := tx.GetChatBasic(ctx, chatId)
+	if err != nil {
+		return err
+	}
+	if chatBasic == nil || !chatBasic.SlowModeSeconds.Valid {
+		return nil
+	}
 
-		isChatAdmin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+	role, err := tx.GetParticipantRole(ctx, userId, chatId)
+	if err != nil {
+		return err
+	}
+	if dto.IsChatAdminRole(role) {
+		return nil
+	}
+
+	lastDateTime, err := tx.GetLastMessageCreateDateTime(ctx, chatId, userId)
+	if err != nil {
+		return err
+	}
+	if !lastDateTime.Valid {
+		return nil
+	}
+
+	retryAfter := lastDateTime.Time.Add(time.Duration(chatBasic.SlowModeSeconds.Int64) * time.Second).Sub(time.Now().UTC())
+	if retryAfter > 0 {
+		return &slowModeError{retryAfter: retryAfter}
+	}
+	return nil
+}
+
+// checks the permissions and stores the message, is used both for the posted and for the scheduled messages
+func (mc *MessageHandler) createMessage(ctx context.Context, tx *db.Tx, chatId int64, input *CreateMessageDto, principal *auth.AuthResult) (int64, error) {
+	if participant, err := tx.IsParticipant(ctx, principal.UserId, chatId); err != nil {
+		return 0, err
+	} else if !participant {
+		return 0, &notParticipantError{}
+	}
+	if input.Poll != nil && input.EmbedMessageRequest != nil && input.EmbedMessageRequest.EmbedType == dto.EmbedMessageTypeResend {
+		return 0, &wrongPollError{}
+	}
+	creatableMessage, err := convertToCreatableMessage(ctx, mc.lgr, input, principal, chatId, mc.policy)
+	if err != nil {
+		return 0, err
+	}
+	creatableMessage.Text, err = mc.replaceCustomEmojis(ctx, tx, chatId, creatableMessage.Text)
+	if err != nil {
+		return 0, err
+	}
+
+	err = mc.validateAndSetEmbedFieldsEmbedMessage(ctx, tx, input, creatableMessage)
+	if err != nil {
+		mc.lgr.WithTracing(ctx).Errorf("Error during checking embed %v", err)
+		return 0, err
+	}
+
+	chatBasic, err := tx.GetChatBasic(ctx, chatId)
+	if err != nil {
+		return 0, err
+	}
+
+	role, err := tx.GetParticipantRole(ctx, principal.UserId, chatId)
+	if err != nil {
+		return 0, err
+	}
+
+	if !canWriteMessage(chatBasic, role) {
+		return 0, &cannotWriteMessageError{}
+	}
+
+	if input.ThreadId != nil {
+		rootMessage, err := tx.GetMessageBasic(ctx, chatId, *input.ThreadId)
 		if err != nil {
 			return 0, err
 		}
-
-		if !canWriteMessage(chatBasic, isChatAdmin) {
-			return 0, &cannotWriteMessageError{}
+		if rootMessage == nil || rootMessage.DeletedDateTime.Valid || rootMessage.ThreadId != nil {
+			return 0, &wrongThreadError{}
+		}
+		creatableMessage.ThreadId = input.ThreadId
+	} else if chatBasic.IsBlog {
+		hasMessages, err := tx.HasMessages(ctx, chatId)
+		if err != nil {
+			return 0, err
 		}
 
-		if chatBasic.IsBlog {
-			hasMessages, err := tx.HasMessages(c.Request().Context(), chatId)
-			if err != nil {
-				return 0, err
-			}
-
-			if !hasMessages {
-				creatableMessage.BlogPost = true
-			}
+		if !hasMessages {
+			creatableMessage.BlogPost = true
 		}
+	}
 
-		messageId, _, _, err := tx.CreateMessage(c.Request().Context(), creatableMessage)
+	messageId, _, _, err := tx.CreateMessage(ctx, creatableMessage)
+	if err != nil {
+		return 0, err
+	}
+	if input.Poll != nil {
+		err = mc.createPoll(ctx, tx, chatId, messageId, input.Poll)
 		if err != nil {
 			return 0, err
 		}
-		mp := &messageId
-		err = tx.MarkMessageAsRead(c.Request().Context(), chatId, userPrincipalDto.UserId, mp) // not to send to myself (1/2)
+	}
+	mp := &messageId
+	if creatableMessage.ThreadId != nil {
+		// thread replies don't move the chat in the list and don't touch the main unread counters
+		_, err = tx.RefreshThread(ctx, chatId, *creatableMessage.ThreadId)
 		if err != nil {
 			return 0, err
 		}
-		if tx.UpdateChatLastDatetimeChat(c.Request().Context(), chatId) != nil {
+		err = tx.MarkThreadAsRead(ctx, chatId, *creatableMessage.ThreadId, principal.UserId, mp)
+		if err != nil {
 			return 0, err
 		}
 		return messageId, nil
-	})
-	if errOuter != nil {
-		var mediaError *MediaUrlErr
-		if errors.As(errOuter, &mediaError) {
-			return c.JSON(http.StatusBadRequest, &utils.H{"message": mediaError.Error(), "businessErrorCode": badMediaUrl})
-		}
-		var mediaOverflowError *MediaOverflowErr
-		if errors.As(errOuter, &mediaOverflowError) {
-			return c.JSON(http.StatusBadRequest, &utils.H{"message": mediaOverflowError.Error()})
-		}
-		var npe *notParticipantError
-		if errors.As(errOuter, &npe) {
-			return c.JSON(http.StatusBadRequest, &utils.H{"message": "You are not allowed to write to this chat"})
-		}
-		var cwm *cannotWriteMessageError
-		if errors.As(errOuter, &cwm) {
-			return c.NoContent(http.StatusUnauthorized)
-		}
-
-		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
-		return errOuter
 	}
+	err = tx.MarkMessageAsRead(ctx, chatId, principal.UserId, mp) // not to send to myself (1/2)
+	if err != nil {
+		return 0, err
+	}
+	if tx.UpdateChatLastDatetimeChat(ctx, chatId) != nil {
+		return 0, err
+	}
+	return messageId, nil
+}
 
-	errOuter = db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
-		chatDto, err := mc.ch.getChatWithoutPersonalization(c.Request().Context(), tx, chatId, 0, 0)
+// sends the events about the just created message, is used both for the posted and for the scheduled messages
+func (mc *MessageHandler) notifyAboutCreatedMessage(ctx context.Context, chatId int64, threadId *int64, messageId int64, principal *auth.AuthResult) error {
+	return db.Transact(ctx, mc.db, func(tx *db.Tx) error {
+		if threadId != nil {
+			return mc.notifyAboutNewThreadReply(ctx, tx, chatId, *threadId, messageId, principal)
+		}
+
+		chatDto, err := mc.ch.getChatWithoutPersonalization(ctx, tx, chatId, 0, 0)
 		if err != nil {
 			return err
 		}
 
-		message, err := getMessageWithoutPersonalized(c.Request().Context(), mc.lgr, tx, mc.restClient, chatId, messageId, userPrincipalDto.UserId) // personal values will be set inside IterateOverChatParticipantIds -> event.go
+		message, err := getMessageWithoutPersonalized(ctx, mc.lgr, tx, mc.restClient, chatId, messageId, principal.UserId) // personal values will be set inside IterateOverChatParticipantIds -> event.go
 		if err != nil {
 			return err
 		}
+		mc.notifyOutgoingWebhooksAboutCreatedMessage(ctx, tx, chatId, message, principal)
 
-		chatNameForNotification, err := mc.getChatNameForNotification(c.Request().Context(), tx, chatId)
+		chatNameForNotification, err := mc.getChatNameForNotification(ctx, tx, chatId)
 		if err != nil {
 			return err
 		}
 		var reply, userToSendTo = mc.wasReplyAdded(nil, message, chatId)
-		mc.notificator.NotifyAddReply(c.Request().Context(), reply, userToSendTo, userPrincipalDto.UserId, userPrincipalDto.UserLogin, userPrincipalDto.Avatar, chatNameForNotification)
+		mc.notificator.NotifyAddReply(ctx, reply, userToSendTo, principal.UserId, principal.UserLogin, principal.Avatar, chatNameForNotification)
 
-		messageTextWithoutTags := createMessagePreview(mc.stripAllTags, message.Text, userPrincipalDto.UserLogin)
+		messageTextWithoutTags := createMessagePreview(mc.stripAllTags, message.Text, principal.UserLogin)
 
-		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-			areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
+		err = tx.IterateOverChatParticipantIds(ctx, chatId, func(participantIds []int64) error {
+			roles, err := getRolesOfUserIds(ctx, tx, participantIds, chatId)
 			if err != nil {
 				return err
 			}
 
-			mc.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, areAdmins)
+			mc.notificator.NotifyAboutChangeChat(ctx, chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, roles)
 			// it is an optimisation - instead of actually checking unread messages, we just get the setting "consider_messages_as_unread"
 			// because all the users got the new message and still not 've read it
 			// and only thing is to check if they ignore this chat or not by checking consider_messages_as_unread
-			hasUnreadMessagesMap, err := tx.ShouldSendHasUnreadMessagesCountBatch(c.Request().Context(), chatId, participantIds) // required in order not to send the notification in case "notify about new message" is disabled
+			hasUnreadMessagesMap, err := tx.ShouldSendHasUnreadMessagesCountBatch(ctx, chatId, participantIds) // required in order not to send the notification in case "notify about new message" is disabled
 			if err != nil {
 				return err
 			}
 
 			for participantId, hasUnread := range hasUnreadMessagesMap {
-				if participantId != userPrincipalDto.UserId { // not to send to myself (2/2)
-					mc.notificator.NotifyAboutHasNewMessagesChanged(c.Request().Context(), participantId, hasUnread)
+				if participantId != principal.UserId { // not to send to myself (2/2)
+					mc.notificator.NotifyAboutHasNewMessagesChanged(ctx, participantId, hasUnread)
 
-					meAsUser := dto.User{Id: userPrincipalDto.UserId, Login: userPrincipalDto.UserLogin, Avatar: null.StringFromPtr(userPrincipalDto.Avatar)}
+					meAsUser := dto.User{Id: principal.UserId, Login: principal.UserLogin, Avatar: null.StringFromPtr(principal.Avatar)}
 					var sch dto.ChatDtoWithTetATet = &simpleChat{
 						Id:        chatDto.Id,
 						Name:      chatDto.Name,
@@ -824,17 +1342,17 @@ func (mc *MessageHandler) PostMessage(c echo.Context) error {
 						map[int64]bool{}, // empty because we don't need lastSeen here
 						&meAsUser,
 						participantId,
-						false, // because participantId != userPrincipalDto.UserId above
+						false, // because participantId != principal.UserId above
 					)
-					mc.notificator.NotifyNewMessageBrowserNotification(c.Request().Context(), true, participantId, chatId, sch.GetName(), sch.GetAvatar(), message.Id, messageTextWithoutTags, userPrincipalDto.UserId, userPrincipalDto.UserLogin)
+					mc.notificator.NotifyNewMessageBrowserNotification(ctx, true, participantId, chatId, sch.GetName(), sch.GetAvatar(), message.Id, messageTextWithoutTags, principal.UserId, principal.UserLogin)
 				}
 			}
-			var users = getUsersRemotelyOrEmptyFromSlice(c.Request().Context(), mc.lgr, participantIds, mc.restClient)
-			var userOnlines = getUserOnlinesRemotelyOrEmptyFromSlice(c.Request().Context(), mc.lgr, participantIds, mc.restClient)
+			var users = getUsersRemotelyOrEmptyFromSlice(ctx, mc.lgr, participantIds, mc.restClient)
+			var userOnlines = getUserOnlinesRemotelyOrEmptyFromSlice(ctx, mc.lgr, participantIds, mc.restClient)
 			var addedMentions, strippedText = mc.findMentions(message.Text, true, users, userOnlines)
-			var reallyAddedMentions = excludeMyself(addedMentions, userPrincipalDto)
-			mc.notificator.NotifyAddMention(c.Request().Context(), reallyAddedMentions, chatId, message.Id, strippedText, userPrincipalDto.UserId, userPrincipalDto.UserLogin, userPrincipalDto.Avatar, chatNameForNotification)
-			mc.notificator.NotifyAboutNewMessage(c.Request().Context(), participantIds, chatId, message, toChatBasic(chatDto), areAdmins)
+			var reallyAddedMentions = excludeMyself(addedMentions, principal)
+			mc.notificator.NotifyAddMention(ctx, reallyAddedMentions, chatId, message.Id, strippedText, principal.UserId, principal.UserLogin, principal.Avatar, chatNameForNotification)
+			mc.notificator.NotifyAboutNewMessage(ctx, participantIds, chatId, message, toChatBasic(chatDto), roles)
 			return nil
 		})
 		if err != nil {
@@ -842,12 +1360,76 @@ func (mc *MessageHandler) PostMessage(c echo.Context) error {
 		}
 		//mc.notificator.ChatNotifyMessageCount(participantIds, c, chatId, tx) - it's included in NotifyAboutChangeChat
 
-		return c.JSON(http.StatusCreated, &utils.H{"id": messageId})
+		return nil
 	})
-	if errOuter != nil {
-		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+}
+
+// thread replies are sent only as thread events, they don't change the chat in the list and don't produce the browser notifications
+func (mc *MessageHandler) notifyAboutNewThreadReply(ctx context.Context, tx *db.Tx, chatId int64, rootMessageId int64, messageId int64, userPrincipalDto *auth.AuthResult) error {
+	chatBasic, err := tx.GetChatBasic(ctx, chatId)
+	if err != nil {
+		return err
+	}
+
+	message, err := getMessageWithoutPersonalized(ctx, mc.lgr, tx, mc.restClient, chatId, messageId, userPrincipalDto.UserId)
+	if err != nil {
+		return err
+	}
+	mc.notifyOutgoingWebhooksAboutCreatedMessage(ctx, tx, chatId, message, userPrincipalDto)
+
+	chatNameForNotification, err := mc.getChatNameForNotification(ctx, tx, chatId)
+	if err != nil {
+		return err
+	}
+	var reply, userToSendTo = mc.wasReplyAdded(nil, message, chatId)
+	mc.notificator.NotifyAddReply(ctx, reply, userToSendTo, userPrincipalDto.UserId, userPrincipalDto.UserLogin, userPrincipalDto.Avatar, chatNameForNotification)
+
+	err = tx.IterateOverChatParticipantIds(ctx, chatId, func(participantIds []int64) error {
+		roles, err := getRolesOfUserIds(ctx, tx, participantIds, chatId)
+		if err != nil {
+			return err
+		}
+
+		var users = getUsersRemotelyOrEmptyFromSlice(ctx, mc.lgr, participantIds, mc.restClient)
+		var userOnlines = getUserOnlinesRemotelyOrEmptyFromSlice(ctx, mc.lgr, participantIds, mc.restClient)
+		var addedMentions, strippedText = mc.findMentions(message.Text, true, users, userOnlines)
+		var reallyAddedMentions = excludeMyself(addedMentions, userPrincipalDto)
+		mc.notificator.NotifyAddMention(ctx, reallyAddedMentions, chatId, message.Id, strippedText, userPrincipalDto.UserId, userPrincipalDto.UserLogin, userPrincipalDto.Avatar, chatNameForNotification)
+		mc.notificator.NotifyAboutNewMessage(ctx, participantIds, chatId, message, chatBasic, roles)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return mc.notifyAboutThreadChanged(ctx, tx, chatId, rootMessageId)
+}
+
+func (mc *MessageHandler) notifyAboutThreadChanged(ctx context.Context, tx *db.Tx, chatId int64, rootMessageId int64) error {
+	thread, err := tx.GetThread(ctx, chatId, rootMessageId)
+	if err != nil {
+		return err
+	}
+	if thread == nil {
+		return nil
+	}
+	threadDto := convertToThreadDto(thread)
+	return tx.IterateOverChatParticipantIds(ctx, chatId, func(participantIds []int64) error {
+		unreadReplies, err := tx.GetThreadUnreadRepliesCountBatchByParticipants(ctx, participantIds, chatId, rootMessageId)
+		if err != nil {
+			return err
+		}
+		mc.notificator.NotifyAboutThreadChanged(ctx, chatId, threadDto, participantIds, unreadReplies)
+		return nil
+	})
+}
+
+func convertToThreadDto(thread *db.MessageThread) *dto.ThreadDto {
+	return &dto.ThreadDto{
+		RootMessageId:     thread.RootMessageId,
+		ReplyCount:        thread.ReplyCount,
+		LastReplyDateTime: thread.LastReplyDateTime,
 	}
-	return errOuter
 }
 
 func toChatBasic(chatDto *dto.ChatDto) *db.BasicChatDto {
@@ -864,6 +1446,7 @@ func toChatBasic(chatDto *dto.ChatDto) *db.BasicChatDto {
 			RegularParticipantCanPublishMessage: chatDto.RegularParticipantCanPublishMessage,
 			RegularParticipantCanPinMessage:     chatDto.RegularParticipantCanPinMessage,
 			RegularParticipantCanWriteMessage:   chatDto.RegularParticipantCanWriteMessage,
+			RegularParticipantCanSeeEditHistory: chatDto.RegularParticipantCanSeeEditHistory,
 		}
 	}
 }
@@ -916,7 +1499,7 @@ func (mc *MessageHandler) validateAndSetEmbedFieldsEmbedMessage(ctx context.Cont
 			if err != nil {
 				return err
 			}
-			if m == nil {
+			if m == nil || m.DeletedDateTime.Valid {
 				return errors.New("Missing the message")
 			}
 			receiver.Text = m.Text
@@ -971,17 +1554,33 @@ func (mc *MessageHandler) EditMessage(c echo.Context) error {
 			return err
 		}
 
+		verdict, err := mc.filterMessage(c.Request().Context(), tx, chatId, userPrincipalDto.UserId, editableMessage.Id, editableMessage.Text)
+		if err != nil {
+			return err
+		}
+		if verdict != nil {
+			// the previous version is already published, so the edit cannot wait for the moderator
+			if verdict.Action == dto.ContentFilterActionHold {
+				return &contentRejectedError{reason: verdict.Reason}
+			}
+			editableMessage.Text = verdict.Text
+		}
+		editableMessage.Text, err = mc.replaceCustomEmojis(c.Request().Context(), tx, chatId, editableMessage.Text)
+		if err != nil {
+			return err
+		}
+
 		chatBasic, err := tx.GetChatBasic(c.Request().Context(), chatId)
 		if err != nil {
 			return err
 		}
 
-		isChatAdmin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		role, err := tx.GetParticipantRole(c.Request().Context(), userPrincipalDto.UserId, chatId)
 		if err != nil {
 			return err
 		}
 
-		if !canWriteMessage(chatBasic, isChatAdmin) {
+		if !canWriteMessage(chatBasic, role) {
 			return c.NoContent(http.StatusUnauthorized)
 		}
 
@@ -1024,6 +1623,12 @@ func (mc *MessageHandler) EditMessage(c echo.Context) error {
 
 		var replyAdded, userToSendToAdded = mc.wasReplyAdded(oldMessage, message, chatId)
 		mc.notificator.NotifyAddReply(c.Request().Context(), replyAdded, userToSendToAdded, userPrincipalDto.UserId, userPrincipalDto.UserLogin, userPrincipalDto.Avatar, chatNameForNotification)
+
+		mc.notificator.NotifyOutgoingWebhooks(c.Request().Context(), tx, &dto.OutgoingWebhookEvent{
+			EventType:           dto.OutgoingWebhookMessageEdited,
+			ChatId:              chatId,
+			MessageNotification: message,
+		})
 		var replyRemoved, userToSendRemoved = mc.wasReplyRemoved(oldMessage, message, chatId)
 		mc.notificator.NotifyRemoveReply(c.Request().Context(), replyRemoved, userToSendRemoved)
 
@@ -1043,12 +1648,12 @@ func (mc *MessageHandler) EditMessage(c echo.Context) error {
 		}
 
 		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-			areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
+			roles, err := getRolesOfUserIds(c.Request().Context(), tx, participantIds, chatId)
 			if err != nil {
 				return err
 			}
 
-			mc.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, areAdmins)
+			mc.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, roles)
 
 			var users = getUsersRemotelyOrEmptyFromSlice(c.Request().Context(), mc.lgr, participantIds, mc.restClient)
 			var userOnlines = getUserOnlinesRemotelyOrEmptyFromSlice(c.Request().Context(), mc.lgr, participantIds, mc.restClient)
@@ -1072,17 +1677,17 @@ func (mc *MessageHandler) EditMessage(c echo.Context) error {
 			mc.notificator.NotifyAddMention(c.Request().Context(), reallyAddedMentions, chatId, message.Id, strippedText, userPrincipalDto.UserId, userPrincipalDto.UserLogin, userPrincipalDto.Avatar, chatNameForNotification)
 			mc.notificator.NotifyRemoveMention(c.Request().Context(), userIdsToNotifyAboutMentionDeleted, chatId, message.Id)
 
-			mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, message, chatBasic, areAdmins)
+			mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, message, chatBasic, roles)
 
 			var copiedMsg = convertToPublishedMessageDto(mc.stripAllTags, dbMessage, users0)
 			mc.notificator.NotifyAboutPublishedMessageEdit(c.Request().Context(), chatId, &dto.PublishedMessageEvent{
 				Message:    *copiedMsg,
 				TotalCount: count0,
-			}, participantIds, chatBasic.RegularParticipantCanPublishMessage, areAdmins)
+			}, participantIds, chatBasic.PermissionSettings(), roles)
 
 			converted := convertToPinnedMessageDto(mc.stripAllTags, dbMessage, users0)
 			for _, participantId := range participantIds {
-				converted.CanPin = dto.CanPinMessage(chatBasic.RegularParticipantCanPinMessage, areAdmins[participantId])
+				converted.CanPin = dto.HasChatPermission(roles[participantId], chatBasic.PermissionSettings(), dto.PermissionPin)
 				mc.notificator.NotifyAboutPromotePinnedMessageEdit(c.Request().Context(), chatId, &dto.PinnedMessageEvent{
 					Message:    *converted,
 					TotalCount: count1,
@@ -1108,19 +1713,21 @@ func (mc *MessageHandler) EditMessage(c echo.Context) error {
 			return c.JSON(http.StatusBadRequest, &utils.H{"message": mediaOverflowError.Error()})
 		}
 
+		var cre *contentRejectedError
+		if errors.As(errOuter, &cre) {
+			return c.JSON(http.StatusBadRequest, &utils.H{"message": cre.Error(), "businessErrorCode": contentRejected})
+		}
+
 		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
 		return errOuter
 	}
+	mc.fillLinkPreviewAsync(c.Request().Context(), chatId, bindTo.Id)
 	return errOuter
 }
 
-func canWriteMessage(chatBasic *db.BasicChatDto, isChatAdmin bool) bool {
-	var res = true
+func canWriteMessage(chatBasic *db.BasicChatDto, role string) bool {
 	// see also handlers PostMessage, EditMessage, DeleteMessage
-	if !chatBasic.RegularParticipantCanWriteMessage && !isChatAdmin {
-		res = false
-	}
-	return res
+	return dto.HasChatPermission(role, chatBasic.PermissionSettings(), dto.PermissionWrite)
 }
 
 type MessageFilterDto struct {
@@ -1213,12 +1820,12 @@ func (mc *MessageHandler) SetFileItemUuid(c echo.Context) error {
 		return err
 	}
 
-	isChatAdmin, err := mc.db.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+	role, err := mc.db.GetParticipantRole(c.Request().Context(), userPrincipalDto.UserId, chatId)
 	if err != nil {
 		return err
 	}
 
-	if !canWriteMessage(chatBasic, isChatAdmin) {
+	if !canWriteMessage(chatBasic, role) {
 		return c.NoContent(http.StatusUnauthorized)
 	}
 
@@ -1235,12 +1842,12 @@ func (mc *MessageHandler) SetFileItemUuid(c echo.Context) error {
 
 	// notifying
 	err = mc.db.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-		areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), mc.db, participantIds, chatId)
+		roles, err := getRolesOfUserIds(c.Request().Context(), mc.db, participantIds, chatId)
 		if err != nil {
 			return err
 		}
 
-		mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, message, chatBasic, areAdmins)
+		mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, message, chatBasic, roles)
 		return nil
 	})
 	if err != nil {
@@ -1289,15 +1896,11 @@ func (mc *MessageHandler) DeleteMessage(c echo.Context) error {
 			return err
 		}
 
-		isChatAdmin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		role, err := tx.GetParticipantRole(c.Request().Context(), userPrincipalDto.UserId, chatId)
 		if err != nil {
 			return err
 		}
 
-		if !canWriteMessage(chatBasic, isChatAdmin) {
-			return c.NoContent(http.StatusUnauthorized)
-		}
-
 		messageId, err := GetPathParamAsInt64(c, "messageId")
 		if err != nil {
 			return err
@@ -1306,80 +1909,263 @@ func (mc *MessageHandler) DeleteMessage(c echo.Context) error {
 		if err != nil {
 			return err
 		}
+		if oldMessage == nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		// the moderators can delete the messages of the others
+		if !dto.CanDeleteMessage(chatBasic.PermissionSettings(), role, oldMessage.OwnerId, userPrincipalDto.UserId) {
+			return c.NoContent(http.StatusUnauthorized)
+		}
 
-		if err := tx.DeleteMessage(c.Request().Context(), messageId, userPrincipalDto.UserId, chatId); err != nil {
+		err = mc.deleteMessage(c.Request().Context(), tx, chatId, chatBasic, oldMessage, userPrincipalDto, null.String{})
+		if err != nil {
 			return err
 		}
 
-		count0, err := tx.GetPublishedMessagesCount(c.Request().Context(), chatId)
+		return c.JSON(http.StatusAccepted, &utils.H{"id": messageId})
+	})
+}
+
+// soft-deletes the message and notifies the participants, the permissions are checked by the caller
+// the deletion of the message of the other user is recorded as the moderation action
+func (mc *MessageHandler) deleteMessage(ctx context.Context, tx *db.Tx, chatId int64, chatBasic *db.BasicChatDto, oldMessage *db.Message, principal *auth.AuthResult, comment null.String) error {
+	messageId := oldMessage.Id
+	err := tx.DeleteMessage(ctx, messageId, principal.UserId, chatId)
+	if err != nil {
+		return err
+	}
+
+	if oldMessage.OwnerId != principal.UserId {
+		err = tx.CreateModerationAction(ctx, chatId, null.IntFrom(messageId), null.IntFrom(oldMessage.OwnerId), dto.ModerationActionDeleteMessage, principal.UserId, comment)
 		if err != nil {
 			return err
 		}
+	}
 
-		count1, err := tx.GetPinnedMessagesCount(c.Request().Context(), chatId)
+	// the revisions and the thread of the root are kept until the tombstone is purged, so the message can be restored
+	if oldMessage.ThreadId != nil {
+		_, err = tx.RefreshThread(ctx, chatId, *oldMessage.ThreadId)
 		if err != nil {
 			return err
 		}
+	}
 
-		chatDto, err := mc.ch.getChatWithoutPersonalization(c.Request().Context(), tx, chatId, 0, 0)
+	// the deleter isn't necessarily a participant, the personal fields are set for each participant during notifying
+	tombstone, err := mc.getMessageForModeration(ctx, tx, chatId, messageId, principal.UserId)
+	if err != nil {
+		return err
+	}
+
+	mc.notificator.NotifyOutgoingWebhooks(ctx, tx, &dto.OutgoingWebhookEvent{
+		EventType: dto.OutgoingWebhookMessageDeleted,
+		ChatId:    chatId,
+		MessageDeletedNotification: &dto.MessageDeletedDto{
+			Id:       messageId,
+			ChatId:   chatId,
+			ThreadId: oldMessage.ThreadId,
+		},
+	})
+
+	count0, err := tx.GetPublishedMessagesCount(ctx, chatId)
+	if err != nil {
+		return err
+	}
+
+	count1, err := tx.GetPinnedMessagesCount(ctx, chatId)
+	if err != nil {
+		return err
+	}
+
+	chatDto, err := mc.ch.getChatWithoutPersonalization(ctx, tx, chatId, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	err = tx.IterateOverChatParticipantIds(ctx, chatId, func(participantIds []int64) error {
+		roles, err := getRolesOfUserIds(ctx, tx, participantIds, chatId)
 		if err != nil {
 			return err
 		}
 
-		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-			areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
-			if err != nil {
-				return err
-			}
+		mc.notificator.NotifyAboutChangeChat(ctx, chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, roles)
 
-			mc.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, areAdmins)
+		var users = getUsersRemotelyOrEmptyFromSlice(ctx, mc.lgr, participantIds, mc.restClient)
+		var userOnlines = getUserOnlinesRemotelyOrEmptyFromSlice(ctx, mc.lgr, participantIds, mc.restClient)
 
-			var users = getUsersRemotelyOrEmptyFromSlice(c.Request().Context(), mc.lgr, participantIds, mc.restClient)
-			var userOnlines = getUserOnlinesRemotelyOrEmptyFromSlice(c.Request().Context(), mc.lgr, participantIds, mc.restClient)
+		var oldMentions, _ = mc.findMentions(oldMessage.Text, false, users, userOnlines)
+		mc.notificator.NotifyRemoveMention(ctx, oldMentions, chatId, messageId)
 
-			var oldMentions, _ = mc.findMentions(oldMessage.Text, false, users, userOnlines)
-			mc.notificator.NotifyRemoveMention(c.Request().Context(), oldMentions, chatId, messageId)
+		// clients replace the message with the tombstone in place
+		mc.notificator.NotifyAboutEditMessage(ctx, participantIds, chatId, tombstone, chatBasic, roles)
 
-			cd := &dto.DisplayMessageDto{
-				Id:     messageId,
-				ChatId: chatId,
-			}
-			mc.notificator.NotifyAboutDeleteMessage(c.Request().Context(), participantIds, chatId, cd)
+		mc.notificator.NotifyAboutPublishedMessage(ctx, chatId, &dto.PublishedMessageEvent{
+			Message: dto.PublishedMessageDto{
+				Id:             messageId,
+				ChatId:         chatId,
+				CreateDateTime: oldMessage.CreateDateTime,
+			},
+			TotalCount: count0,
+		}, false, participantIds, dto.ChatPermissionSettings{}, map[int64]string{})
 
-			mc.notificator.NotifyAboutPublishedMessage(c.Request().Context(), chatId, &dto.PublishedMessageEvent{
-				Message: dto.PublishedMessageDto{
+		for _, participantId := range participantIds {
+			mc.notificator.NotifyAboutPromotePinnedMessage(ctx, chatId, &dto.PinnedMessageEvent{
+				Message: dto.PinnedMessageDto{
 					Id:             messageId,
 					ChatId:         chatId,
 					CreateDateTime: oldMessage.CreateDateTime,
 				},
-				TotalCount: count0,
-			}, false, participantIds, false, map[int64]bool{})
+				TotalCount: count1,
+			}, false, participantId)
+		}
 
-			for _, participantId := range participantIds {
-				mc.notificator.NotifyAboutPromotePinnedMessage(c.Request().Context(), chatId, &dto.PinnedMessageEvent{
-					Message: dto.PinnedMessageDto{
-						Id:             messageId,
-						ChatId:         chatId,
-						CreateDateTime: oldMessage.CreateDateTime,
-					},
-					TotalCount: count1,
-				}, false, participantId)
+		for _, participantId := range participantIds {
+			mc.notificator.NotifyNewMessageBrowserNotification(ctx, false, participantId, chatId, "", null.StringFromPtr(nil), messageId, "", principal.UserId, principal.UserLogin)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var replyRemoved, userToSendRemoved = mc.wasReplyRemoved(oldMessage, nil, chatId)
+	mc.notificator.NotifyRemoveReply(ctx, replyRemoved, userToSendRemoved)
+
+	if oldMessage.ThreadId != nil {
+		err = mc.notifyAboutThreadChanged(ctx, tx, chatId, *oldMessage.ThreadId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chat admin can bring a tombstone back within message.restoreWindow after the deletion
+func (mc *MessageHandler) RestoreMessage(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	messageId, err := GetPathParamAsInt64(c, "messageId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		role, err := tx.GetParticipantRole(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+
+		chatBasic, err := tx.GetChatBasic(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+		if chatBasic == nil || !dto.HasChatPermission(role, chatBasic.PermissionSettings(), dto.PermissionDeleteOthersMessages) {
+			return c.NoContent(http.StatusUnauthorized)
+		}
+
+		deletedAfter := time.Now().UTC().Add(-viper.GetDuration("message.restoreWindow"))
+		restored, err := tx.RestoreMessage(c.Request().Context(), chatId, messageId, deletedAfter)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			return c.JSON(http.StatusBadRequest, &utils.H{"message": "The message is not deleted or the restore window is over"})
+		}
+
+		message, err := getMessage(c, mc.lgr, tx, mc.restClient, chatId, messageId, userPrincipalDto.UserId, role)
+		if err != nil {
+			return err
+		}
+
+		if message.ThreadId != nil {
+			_, err = tx.RefreshThread(c.Request().Context(), chatId, *message.ThreadId)
+			if err != nil {
+				return err
 			}
+		}
 
-			for _, participantId := range participantIds {
-				mc.notificator.NotifyNewMessageBrowserNotification(c.Request().Context(), false, participantId, chatId, "", null.StringFromPtr(nil), messageId, "", userPrincipalDto.UserId, userPrincipalDto.UserLogin)
+		// the restored pin is promoted only when there is no other promoted one
+		var promoted bool
+		if message.Pinned {
+			previouslyPromoted, err := tx.GetPinnedPromoted(c.Request().Context(), chatId)
+			if err != nil {
+				return err
+			}
+			if previouslyPromoted == nil {
+				err = tx.PromoteMessage(c.Request().Context(), chatId, messageId)
+				if err != nil {
+					return err
+				}
+				promoted = true
+			}
+		}
+
+		var dbMessage *db.Message
+		var users map[int64]*dto.User
+		var pinnedCount, publishedCount int64
+		if promoted || message.Published {
+			dbMessage, _, users, err = prepareDataForMessage(c.Request().Context(), mc.lgr, tx, mc.restClient, chatId, messageId, userPrincipalDto.UserId)
+			if err != nil {
+				return err
+			}
+			pinnedCount, err = tx.GetPinnedMessagesCount(c.Request().Context(), chatId)
+			if err != nil {
+				return err
+			}
+			publishedCount, err = tx.GetPublishedMessagesCount(c.Request().Context(), chatId)
+			if err != nil {
+				return err
+			}
+		}
+
+		chatDto, err := mc.ch.getChatWithoutPersonalization(c.Request().Context(), tx, chatId, 0, 0)
+		if err != nil {
+			return err
+		}
+
+		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
+			roles, err := getRolesOfUserIds(c.Request().Context(), tx, participantIds, chatId)
+			if err != nil {
+				return err
 			}
 
+			mc.notificator.NotifyAboutChangeChat(c.Request().Context(), chatDto, participantIds, len(chatDto.ParticipantIds) == 1, true, tx, roles)
+
+			mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, message, chatBasic, roles)
+
+			if message.Published {
+				mc.notificator.NotifyAboutPublishedMessage(c.Request().Context(), chatId, &dto.PublishedMessageEvent{
+					Message:    *convertToPublishedMessageDto(mc.stripAllTags, dbMessage, users),
+					TotalCount: publishedCount,
+				}, true, participantIds, chatBasic.PermissionSettings(), roles)
+			}
+			if promoted {
+				return mc.sendPromotePinnedMessageEvent(c.Request().Context(), chatBasic, roles, mc.stripAllTags, dbMessage, users, chatId, participantIds, userPrincipalDto.UserId, true, pinnedCount)
+			}
 			return nil
 		})
 		if err != nil {
 			return err
 		}
 
-		var replyRemoved, userToSendRemoved = mc.wasReplyRemoved(oldMessage, nil, chatId)
-		mc.notificator.NotifyRemoveReply(c.Request().Context(), replyRemoved, userToSendRemoved)
+		if message.ThreadId != nil {
+			err = mc.notifyAboutThreadChanged(c.Request().Context(), tx, chatId, *message.ThreadId)
+			if err != nil {
+				return err
+			}
+		}
 
-		return c.JSON(http.StatusAccepted, &utils.H{"id": messageId})
+		return c.JSON(http.StatusOK, message)
 	})
 }
 
@@ -1598,11 +2384,11 @@ func (mc *MessageHandler) BroadcastMessage(c echo.Context) error {
 		return err
 	}
 
-	if participant, err := mc.db.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId); err != nil {
+	if allowed, err := hasChatPermission(c.Request().Context(), mc.db, userPrincipalDto.UserId, chatId, dto.PermissionManageChat); err != nil {
 		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during checking participant")
 		return err
-	} else if !participant {
-		mc.lgr.WithTracing(c.Request().Context()).Infof("User %v is not participant of chat %v, skipping", userPrincipalDto.UserId, chatId)
+	} else if !allowed {
+		mc.lgr.WithTracing(c.Request().Context()).Infof("User %v is not allowed to broadcast in chat %v, skipping", userPrincipalDto.UserId, chatId)
 		return c.NoContent(http.StatusAccepted)
 	}
 
@@ -1658,12 +2444,12 @@ func (mc *MessageHandler) RemoveFileItem(c echo.Context) error {
 		}
 
 		err = mc.db.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-			areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), mc.db, participantIds, chatId)
+			roles, err := getRolesOfUserIds(c.Request().Context(), mc.db, participantIds, chatId)
 			if err != nil {
 				return err
 			}
 
-			mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, message, chatBasic, areAdmins)
+			mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, message, chatBasic, roles)
 			return nil
 		})
 		if err != nil {
@@ -1707,12 +2493,20 @@ func (mc *MessageHandler) PinMessage(c echo.Context) error {
 			return c.JSON(http.StatusAccepted, &utils.H{"message": msg})
 		}
 
-		isAdmin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		role, err := tx.GetParticipantRole(c.Request().Context(), userPrincipalDto.UserId, chatId)
 		if err != nil {
 			return err
 		}
 
 		if pin {
+			m, err := tx.GetMessageBasic(c.Request().Context(), chatId, messageId)
+			if err != nil {
+				return err
+			}
+			if m == nil || m.DeletedDateTime.Valid {
+				return c.NoContent(http.StatusNoContent)
+			}
+
 			err = tx.PinMessage(c.Request().Context(), chatId, messageId, pin)
 			if err != nil {
 				return err
@@ -1732,7 +2526,7 @@ func (mc *MessageHandler) PinMessage(c echo.Context) error {
 				return err
 			}
 			chatBasic := chatsSet[message.ChatId].BasicChatDto
-			if !dto.CanPinMessage(chatBasic.RegularParticipantCanPinMessage, isAdmin) {
+			if !dto.HasChatPermission(role, chatBasic.PermissionSettings(), dto.PermissionPin) {
 				return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You cannot pin messages in this chat"})
 			}
 
@@ -1744,14 +2538,14 @@ func (mc *MessageHandler) PinMessage(c echo.Context) error {
 			}
 
 			err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-				areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
+				roles, err := getRolesOfUserIds(c.Request().Context(), tx, participantIds, chatId)
 				if err != nil {
 					return err
 				}
-				mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, res, &chatBasic, areAdmins)
+				mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, res, &chatBasic, roles)
 
 				// notify about newly promoted result (promoted can be different)
-				errInternal := mc.sendPromotePinnedMessageEvent(c.Request().Context(), &chatBasic, isAdmin, mc.stripAllTags, message, users, chatId, participantIds, userPrincipalDto.UserId, true, count0)
+				errInternal := mc.sendPromotePinnedMessageEvent(c.Request().Context(), &chatBasic, roles, mc.stripAllTags, message, users, chatId, participantIds, userPrincipalDto.UserId, true, count0)
 				return errInternal
 			})
 			if err != nil {
@@ -1774,7 +2568,7 @@ func (mc *MessageHandler) PinMessage(c echo.Context) error {
 				return err
 			}
 			chatBasic := chatsSet[message.ChatId].BasicChatDto
-			if !dto.CanPinMessage(chatBasic.RegularParticipantCanPinMessage, isAdmin) {
+			if !dto.HasChatPermission(role, chatBasic.PermissionSettings(), dto.PermissionPin) {
 				return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You cannot unpin messages in this chat"})
 			}
 
@@ -1786,15 +2580,15 @@ func (mc *MessageHandler) PinMessage(c echo.Context) error {
 			}
 
 			err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-				areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
+				roles, err := getRolesOfUserIds(c.Request().Context(), tx, participantIds, chatId)
 				if err != nil {
 					return err
 				}
 
-				mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, res, &chatBasic, areAdmins)
+				mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, res, &chatBasic, roles)
 
 				// actually instead of unpromote - remove is better
-				errInternal := mc.sendPromotePinnedMessageEvent(c.Request().Context(), &chatBasic, isAdmin, mc.stripAllTags, message, users, chatId, participantIds, userPrincipalDto.UserId, false, count1)
+				errInternal := mc.sendPromotePinnedMessageEvent(c.Request().Context(), &chatBasic, roles, mc.stripAllTags, message, users, chatId, participantIds, userPrincipalDto.UserId, false, count1)
 				return errInternal
 			})
 			if err != nil {
@@ -1824,7 +2618,11 @@ func (mc *MessageHandler) PinMessage(c echo.Context) error {
 					}
 
 					err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-						errInternal := mc.sendPromotePinnedMessageEvent(c.Request().Context(), &chatBasic, isAdmin, mc.stripAllTags, message2, users2, chatId, participantIds, userPrincipalDto.UserId, true, count2)
+						roles, err := getRolesOfUserIds(c.Request().Context(), tx, participantIds, chatId)
+						if err != nil {
+							return err
+						}
+						errInternal := mc.sendPromotePinnedMessageEvent(c.Request().Context(), &chatBasic, roles, mc.stripAllTags, message2, users2, chatId, participantIds, userPrincipalDto.UserId, true, count2)
 						return errInternal
 					})
 					if err != nil {
@@ -1880,7 +2678,7 @@ func (mc *MessageHandler) PublishMessage(c echo.Context) error {
 			return err
 		}
 
-		isAdmin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		role, err := tx.GetParticipantRole(c.Request().Context(), userPrincipalDto.UserId, chatId)
 		if err != nil {
 			return err
 		}
@@ -1889,11 +2687,11 @@ func (mc *MessageHandler) PublishMessage(c echo.Context) error {
 		if err != nil {
 			return err
 		}
-		if m == nil {
+		if m == nil || m.DeletedDateTime.Valid {
 			return c.NoContent(http.StatusNoContent)
 		}
 
-		if !dto.CanPublishMessage(chatBasic.RegularParticipantCanPublishMessage, isAdmin, m.OwnerId, userPrincipalDto.UserId) {
+		if !dto.CanPublishMessage(chatBasic.PermissionSettings(), role, m.OwnerId, userPrincipalDto.UserId) {
 			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You cannot publish messages in this chat"})
 		}
 
@@ -1917,19 +2715,19 @@ func (mc *MessageHandler) PublishMessage(c echo.Context) error {
 		}
 
 		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-			areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
+			roles, err := getRolesOfUserIds(c.Request().Context(), tx, participantIds, chatId)
 			if err != nil {
 				return err
 			}
 
-			mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, res, chatBasic, areAdmins)
+			mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, res, chatBasic, roles)
 
 			var copiedMsg = convertToPublishedMessageDto(mc.stripAllTags, message, users)
 
 			mc.notificator.NotifyAboutPublishedMessage(c.Request().Context(), chatId, &dto.PublishedMessageEvent{
 				Message:    *copiedMsg,
 				TotalCount: count0,
-			}, publish, participantIds, chatBasic.RegularParticipantCanPublishMessage, areAdmins)
+			}, publish, participantIds, chatBasic.PermissionSettings(), roles)
 			return nil
 		})
 		if err != nil {
@@ -1963,7 +2761,7 @@ func (mc *MessageHandler) MakeBlogPost(c echo.Context) error {
 			return err
 		}
 
-		if m == nil {
+		if m == nil || m.DeletedDateTime.Valid {
 			return c.NoContent(http.StatusNoContent)
 		}
 
@@ -2007,14 +2805,14 @@ func (mc *MessageHandler) MakeBlogPost(c echo.Context) error {
 		}
 
 		err = tx.IterateOverChatParticipantIds(c.Request().Context(), chatId, func(participantIds []int64) error {
-			areAdmins, err := getAreAdminsOfUserIds(c.Request().Context(), tx, participantIds, chatId)
+			roles, err := getRolesOfUserIds(c.Request().Context(), tx, participantIds, chatId)
 			if err != nil {
 				return err
 			}
 			if res0 != nil {
-				mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, res0, chatBasic, areAdmins)
+				mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, res0, chatBasic, roles)
 			}
-			mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, res, chatBasic, areAdmins)
+			mc.notificator.NotifyAboutEditMessage(c.Request().Context(), participantIds, chatId, res, chatBasic, roles)
 			return nil
 		})
 		if err != nil {
@@ -2057,7 +2855,7 @@ func (mc *MessageHandler) GetPinnedMessages(c echo.Context) error {
 			return c.NoContent(http.StatusUnauthorized)
 		}
 
-		isAdmin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		role, err := tx.GetParticipantRole(c.Request().Context(), userPrincipalDto.UserId, chatId)
 		if err != nil {
 			return err
 		}
@@ -2081,7 +2879,7 @@ func (mc *MessageHandler) GetPinnedMessages(c echo.Context) error {
 		for _, message := range messages {
 
 			converted := convertToPinnedMessageDto(mc.stripAllTags, message, owners)
-			converted.CanPin = dto.CanPinMessage(chatBasic.RegularParticipantCanPinMessage, isAdmin)
+			converted.CanPin = dto.HasChatPermission(role, chatBasic.PermissionSettings(), dto.PermissionPin)
 
 			messageDtos = append(messageDtos, converted)
 		}
@@ -2178,7 +2976,7 @@ func (mc *MessageHandler) GetPublishedMessages(c echo.Context) error {
 			return c.NoContent(http.StatusUnauthorized)
 		}
 
-		admin, err := tx.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		role, err := tx.GetParticipantRole(c.Request().Context(), userPrincipalDto.UserId, chatId)
 		if err != nil {
 			return err
 		}
@@ -2203,7 +3001,7 @@ func (mc *MessageHandler) GetPublishedMessages(c echo.Context) error {
 		for _, message := range messages {
 
 			converted := convertToPublishedMessageDto(mc.stripAllTags, message, owners) // the actual personal values don't needed here
-			converted.CanPublish = dto.CanPublishMessage(chatBasic.RegularParticipantCanPublishMessage, admin, message.OwnerId, userPrincipalDto.UserId)
+			converted.CanPublish = dto.CanPublishMessage(chatBasic.PermissionSettings(), role, message.OwnerId, userPrincipalDto.UserId)
 
 			messageDtos = append(messageDtos, converted)
 		}
@@ -2220,27 +3018,17 @@ func (mc *MessageHandler) GetPublishedMessages(c echo.Context) error {
 	})
 }
 
-func getAreAdmins(ctx context.Context, co db.CommonOperations, owners map[int64]*dto.User, chatId int64) (map[int64]bool, error) {
-
-	ownerIds := make([]int64, 0)
-	for ownerId, _ := range owners {
-		ownerIds = append(ownerIds, ownerId)
-	}
-
-	return getAreAdminsOfUserIds(ctx, co, ownerIds, chatId)
-}
-
-func getAreAdminsOfUserIds(ctx context.Context, co db.CommonOperations, userIds []int64, chatId int64) (map[int64]bool, error) {
-	areAdminsMap := map[int64]bool{}
+func getRolesOfUserIds(ctx context.Context, co db.CommonOperations, userIds []int64, chatId int64) (map[int64]string, error) {
+	rolesMap := map[int64]string{}
 
-	areAdmins, err := co.IsAdminBatchByParticipants(ctx, userIds, chatId)
+	roles, err := co.GetRolesBatchByParticipants(ctx, userIds, chatId)
 	if err != nil {
-		return areAdminsMap, err
+		return rolesMap, err
 	}
-	for _, areAdmin := range areAdmins {
-		areAdminsMap[areAdmin.UserId] = areAdmin.Admin
+	for _, role := range roles {
+		rolesMap[role.UserId] = role.Role
 	}
-	return areAdminsMap, nil
+	return rolesMap, nil
 }
 
 type PublishedMessageWrapper struct {
@@ -2338,12 +3126,12 @@ func patchForViewAndSetPromoted(cleanTagsPolicy *services.StripTagsPolicy, messa
 	message.PinnedPromoted = &promote
 }
 
-func (mc *MessageHandler) sendPromotePinnedMessageEvent(ctx context.Context, chatBasic *db.BasicChatDto, isAdmin bool, cleanTagsPolicy *services.StripTagsPolicy, dbMessage *db.Message, users map[int64]*dto.User, chatId int64, participantIds []int64, behalfUserId int64, promote bool, count int64) error {
+func (mc *MessageHandler) sendPromotePinnedMessageEvent(ctx context.Context, chatBasic *db.BasicChatDto, roles map[int64]string, cleanTagsPolicy *services.StripTagsPolicy, dbMessage *db.Message, users map[int64]*dto.User, chatId int64, participantIds []int64, behalfUserId int64, promote bool, count int64) error {
 
 	messageDto := convertToPinnedMessageDto(cleanTagsPolicy, dbMessage, users)
 
 	for _, participantId := range participantIds {
-		messageDto.CanPin = dto.CanPinMessage(chatBasic.RegularParticipantCanPinMessage, isAdmin)
+		messageDto.CanPin = dto.HasChatPermission(roles[participantId], chatBasic.PermissionSettings(), dto.PermissionPin)
 
 		// notify about promote to the pinned
 		mc.notificator.NotifyAboutPromotePinnedMessage(ctx, chatId, &dto.PinnedMessageEvent{
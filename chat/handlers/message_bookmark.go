@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+const maxBookmarkNoteLen = 1024
+const maxBookmarkTags = 10
+const maxBookmarkTagLen = 32
+
+// a tag can't contain a comma because the tags are joined on reading
+var bookmarkTagRegexp = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+
+type PutMessageBookmarkDto struct {
+	Note *string  `json:"note"`
+	Tags []string `json:"tags"`
+}
+
+func (a *PutMessageBookmarkDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Note, validation.Length(0, maxBookmarkNoteLen)),
+		validation.Field(&a.Tags, validation.Length(0, maxBookmarkTags), validation.Each(validation.Length(1, maxBookmarkTagLen), validation.Match(bookmarkTagRegexp))),
+	)
+}
+
+// the tags are case-insensitive, the repeated ones are dropped
+func (a *PutMessageBookmarkDto) normalize() {
+	var tags = make([]string, 0, len(a.Tags))
+	var seen = map[string]bool{}
+	for _, tag := range a.Tags {
+		tag = strings.ToLower(Trim(tag))
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	a.Tags = tags
+
+	if a.Note != nil && Trim(*a.Note) == "" {
+		a.Note = nil
+	}
+}
+
+type MessageBookmarksWrapper struct {
+	Data  []*dto.MessageBookmarkDto `json:"items"`
+	Count int64                     `json:"count"` // total bookmarks number of the user
+}
+
+func convertToMessageBookmarkDto(b *db.MessageBookmark) *dto.MessageBookmarkDto {
+	return &dto.MessageBookmarkDto{
+		Id:             b.Id,
+		ChatId:         b.ChatId,
+		MessageId:      b.MessageId,
+		Note:           b.Note,
+		Tags:           b.Tags,
+		CreateDateTime: b.CreateDateTime,
+	}
+}
+
+func (mc *MessageHandler) PutMessageBookmark(c echo.Context) error {
+	var bindTo = new(PutMessageBookmarkDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	bindTo.normalize()
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	messageId, err := GetPathParamAsInt64(c, "messageId")
+	if err != nil {
+		return err
+	}
+
+	var note *string
+	if bindTo.Note != nil {
+		sanitized := TrimAmdSanitize(mc.policy, *bindTo.Note)
+		note = &sanitized
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if !isParticipant {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		message, err := tx.GetMessage(c.Request().Context(), chatId, userPrincipalDto.UserId, messageId)
+		if err != nil {
+			return err
+		}
+		if message == nil || message.DeletedDateTime.Valid {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		bookmark, err := tx.PutMessageBookmark(c.Request().Context(), userPrincipalDto.UserId, chatId, messageId, note, bindTo.Tags)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, convertToMessageBookmarkDto(bookmark))
+	})
+}
+
+func (mc *MessageHandler) DeleteMessageBookmark(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	messageId, err := GetPathParamAsInt64(c, "messageId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		deleted, err := tx.DeleteMessageBookmark(c.Request().Context(), userPrincipalDto.UserId, chatId, messageId)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+}
+
+// the saved messages of the user across all the chats, the newest bookmark goes first
+func (mc *MessageHandler) GetMessageBookmarks(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+	tag := strings.ToLower(Trim(c.QueryParam("tag")))
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		bookmarks, err := tx.GetMessageBookmarks(c.Request().Context(), userPrincipalDto.UserId, tag, size, offset)
+		if err != nil {
+			return err
+		}
+
+		var chatIds = map[int64]bool{}
+		for _, b := range bookmarks {
+			chatIds[b.ChatId] = true
+		}
+		chats, err := tx.GetChatsBasic(c.Request().Context(), chatIds, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+
+		var roles = map[int64]string{}
+		bookmarkDtos := make([]*dto.MessageBookmarkDto, 0)
+		for _, b := range bookmarks {
+			role, cached := roles[b.ChatId]
+			if !cached {
+				role, err = tx.GetParticipantRole(c.Request().Context(), userPrincipalDto.UserId, b.ChatId)
+				if err != nil {
+					return err
+				}
+				roles[b.ChatId] = role
+			}
+
+			message, err := getMessage(c, mc.lgr, tx, mc.restClient, b.ChatId, b.MessageId, userPrincipalDto.UserId, role)
+			if err != nil {
+				return err
+			}
+			if message == nil {
+				mc.lgr.WithTracing(c.Request().Context()).Infof("Skipping the bookmark %v of the absent message %v in chat %v", b.Id, b.MessageId, b.ChatId)
+				continue
+			}
+
+			bookmarkDto := convertToMessageBookmarkDto(b)
+			bookmarkDto.Message = message
+			if chat, ok := chats[b.ChatId]; ok {
+				bookmarkDto.ChatTitle = chat.Title
+			}
+			bookmarkDtos = append(bookmarkDtos, bookmarkDto)
+		}
+
+		count, err := tx.GetMessageBookmarksCount(c.Request().Context(), userPrincipalDto.UserId, tag)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, MessageBookmarksWrapper{
+			Data:  bookmarkDtos,
+			Count: count,
+		})
+	})
+}
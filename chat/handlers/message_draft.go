@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+// is sent by the client debounced, the empty draft removes the existing one
+type PutMessageDraftDto struct {
+	Text                string                   `json:"text"`
+	ThreadId            *int64                   `json:"threadId"`
+	EmbedMessageRequest *dto.EmbedMessageRequest `json:"embedMessage"` // the reply or the resend target
+}
+
+func (a *PutMessageDraftDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Text, validation.Length(0, maxMessageLen)),
+		validation.Field(&a.EmbedMessageRequest, validation.By(validateDraftEmbedMessageRequest)),
+	)
+}
+
+func validateDraftEmbedMessageRequest(value interface{}) error {
+	r, _ := value.(*dto.EmbedMessageRequest)
+	if r == nil {
+		return nil
+	}
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Id, validation.Required),
+		validation.Field(&r.EmbedType, validation.Required, validation.In(dto.EmbedMessageTypeReply, dto.EmbedMessageTypeResend)),
+	)
+}
+
+func (a *PutMessageDraftDto) isEmpty() bool {
+	return Trim(a.Text) == "" && a.EmbedMessageRequest == nil
+}
+
+func convertToMessageDraftDto(d *db.MessageDraft) *dto.MessageDraftDto {
+	if d == nil {
+		return nil
+	}
+	ret := &dto.MessageDraftDto{
+		ChatId:       d.ChatId,
+		Text:         d.Text,
+		ThreadId:     d.ThreadId,
+		EditDateTime: d.EditDateTime,
+	}
+	if d.EmbedMessageId != nil && d.EmbedMessageType != nil {
+		ret.EmbedMessageRequest = &dto.EmbedMessageRequest{
+			Id:        *d.EmbedMessageId,
+			EmbedType: *d.EmbedMessageType,
+		}
+		if d.EmbedChatId != nil {
+			ret.EmbedMessageRequest.ChatId = *d.EmbedChatId
+		}
+	}
+	return ret
+}
+
+func (mc *MessageHandler) GetMessageDraft(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if !isParticipant {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		draft, err := tx.GetMessageDraft(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if draft == nil {
+			return c.NoContent(http.StatusNoContent)
+		}
+		return c.JSON(http.StatusOK, convertToMessageDraftDto(draft))
+	})
+}
+
+func (mc *MessageHandler) PutMessageDraft(c echo.Context) error {
+	var bindTo = new(PutMessageDraftDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var text string
+	if !bindTo.isEmpty() {
+		text, err = TrimAmdSanitizeMessage(c.Request().Context(), mc.lgr, mc.policy, bindTo.Text)
+		if err != nil {
+			if handled, respondErr := respondCreateMessageError(c, err); handled {
+				return respondErr
+			}
+			return err
+		}
+	}
+
+	var draft *db.MessageDraft
+	var changed bool
+	errOuter := db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if !isParticipant {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		if bindTo.isEmpty() {
+			changed, err = tx.DeleteMessageDraft(c.Request().Context(), userPrincipalDto.UserId, chatId)
+			return err
+		}
+
+		creatable := &db.MessageDraft{
+			UserId:   userPrincipalDto.UserId,
+			ChatId:   chatId,
+			Text:     text,
+			ThreadId: bindTo.ThreadId,
+		}
+		if bindTo.EmbedMessageRequest != nil {
+			creatable.EmbedMessageId = &bindTo.EmbedMessageRequest.Id
+			creatable.EmbedMessageType = &bindTo.EmbedMessageRequest.EmbedType
+			if bindTo.EmbedMessageRequest.EmbedType == dto.EmbedMessageTypeResend {
+				creatable.EmbedChatId = &bindTo.EmbedMessageRequest.ChatId
+			}
+		}
+		draft, err = tx.PutMessageDraft(c.Request().Context(), creatable)
+		changed = true
+		return err
+	})
+	if errOuter != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	if c.Response().Committed {
+		return nil
+	}
+
+	draftDto := convertToMessageDraftDto(draft)
+	if changed {
+		mc.notificator.NotifyAboutMessageDraft(c.Request().Context(), userPrincipalDto.UserId, chatId, draftDto)
+	}
+	if draftDto == nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+	return c.JSON(http.StatusOK, draftDto)
+}
+
+func (mc *MessageHandler) DeleteMessageDraft(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	err = mc.clearMessageDraft(c.Request().Context(), chatId, userPrincipalDto.UserId)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// is called after the message is posted, the other sessions of the user clear their input
+func (mc *MessageHandler) clearMessageDraft(ctx context.Context, chatId, userId int64) error {
+	deleted, err := mc.db.DeleteMessageDraft(ctx, userId, chatId)
+	if err != nil {
+		mc.lgr.WithTracing(ctx).Errorf("Error during removing the draft of user %v in chat %v: %v", userId, chatId, err)
+		return err
+	}
+	if deleted {
+		mc.notificator.NotifyAboutMessageDraft(ctx, userId, chatId, nil)
+	}
+	return nil
+}
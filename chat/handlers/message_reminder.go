@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/guregu/null"
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+// either the absolute time or the delay from now, e.g. "3h"
+type MessageReminderTimeDto struct {
+	RemindDateTime *time.Time `json:"remindDateTime"`
+	RemindIn       *string    `json:"remindIn"`
+}
+
+func (a *MessageReminderTimeDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.RemindDateTime, validation.Required.When(a.RemindIn == nil), validation.Nil.When(a.RemindIn != nil), validation.By(validateRemindDateTime)),
+		validation.Field(&a.RemindIn, validation.By(validateRemindIn)),
+	)
+}
+
+func validateRemindIn(value interface{}) error {
+	s, _ := value.(*string)
+	if s == nil {
+		return nil
+	}
+	remindIn, err := time.ParseDuration(*s)
+	if err != nil {
+		return errors.New("must be a duration like 1h30m")
+	}
+	if remindIn <= 0 || remindIn > maxRemindIn {
+		return errors.New("is out of the allowed range")
+	}
+	return nil
+}
+
+func validateRemindDateTime(value interface{}) error {
+	t, _ := value.(*time.Time)
+	if t == nil {
+		return nil
+	}
+	now := time.Now()
+	if !t.After(now) || t.After(now.Add(maxRemindIn)) {
+		return errors.New("must be in the future and not later than a year")
+	}
+	return nil
+}
+
+// is called after the validation
+func (a *MessageReminderTimeDto) resolve() time.Time {
+	if a.RemindIn != nil {
+		remindIn, _ := time.ParseDuration(*a.RemindIn)
+		return time.Now().UTC().Add(remindIn)
+	}
+	return a.RemindDateTime.UTC()
+}
+
+type MessageRemindersWrapper struct {
+	Data  []*dto.MessageReminderDto `json:"items"`
+	Count int64                     `json:"count"` // total reminders number of the user
+}
+
+func convertToMessageReminderDto(r *db.MessageReminder) *dto.MessageReminderDto {
+	return &dto.MessageReminderDto{
+		Id:             r.Id,
+		ChatId:         r.ChatId,
+		MessageId:      r.MessageId,
+		RemindDateTime: r.RemindDateTime,
+		Fired:          r.Fired,
+		CreateDateTime: r.CreateDateTime,
+	}
+}
+
+func (mc *MessageHandler) CreateMessageReminder(c echo.Context) error {
+	var bindTo = new(MessageReminderTimeDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	messageId, err := GetPathParamAsInt64(c, "messageId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if !isParticipant {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		message, err := tx.GetMessage(c.Request().Context(), chatId, userPrincipalDto.UserId, messageId)
+		if err != nil {
+			return err
+		}
+		if message == nil || message.DeletedDateTime.Valid {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		reminder, err := tx.CreateMessageReminder(c.Request().Context(), userPrincipalDto.UserId, chatId, messageId, bindTo.resolve())
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusCreated, convertToMessageReminderDto(reminder))
+	})
+}
+
+func (mc *MessageHandler) GetMessageReminders(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		reminders, err := tx.GetMessageReminders(c.Request().Context(), userPrincipalDto.UserId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		reminderDtos := make([]*dto.MessageReminderDto, 0)
+		for _, r := range reminders {
+			reminderDtos = append(reminderDtos, convertToMessageReminderDto(r))
+		}
+
+		count, err := tx.GetMessageRemindersCount(c.Request().Context(), userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, MessageRemindersWrapper{
+			Data:  reminderDtos,
+			Count: count,
+		})
+	})
+}
+
+// sets the new time of both the pending and the already fired reminder
+func (mc *MessageHandler) SnoozeMessageReminder(c echo.Context) error {
+	var bindTo = new(MessageReminderTimeDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	reminderId, err := GetPathParamAsInt64(c, "reminderId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		reminder, err := tx.SnoozeMessageReminder(c.Request().Context(), userPrincipalDto.UserId, reminderId, bindTo.resolve())
+		if err != nil {
+			return err
+		}
+		if reminder == nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return c.JSON(http.StatusOK, convertToMessageReminderDto(reminder))
+	})
+}
+
+func (mc *MessageHandler) DeleteMessageReminder(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	reminderId, err := GetPathParamAsInt64(c, "reminderId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		deleted, err := tx.DeleteMessageReminder(c.Request().Context(), userPrincipalDto.UserId, reminderId)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+}
+
+// is called by the scheduler, the reminder about the message which the user can't see anymore fires silently
+func (mc *MessageHandler) SendMessageReminder(ctx context.Context, reminderId int64) error {
+	var reminder *db.MessageReminder
+	var message *dto.DisplayMessageDto
+	var chatName string
+	err := db.Transact(ctx, mc.db, func(tx *db.Tx) error {
+		r, err := tx.FireMessageReminder(ctx, reminderId)
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			// was cancelled or snoozed in the meantime
+			return nil
+		}
+		reminder = r
+
+		message, err = getMessageWithoutPersonalized(ctx, mc.lgr, tx, mc.restClient, r.ChatId, r.MessageId, r.UserId)
+		if err != nil {
+			return err
+		}
+		if message == nil || message.DeletedDateTime.Valid {
+			return nil
+		}
+
+		chatName, err = mc.getChatNameForNotification(ctx, tx, r.ChatId)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if reminder == nil {
+		return nil
+	}
+	if message == nil || message.DeletedDateTime.Valid {
+		mc.lgr.WithTracing(ctx).Infof("Skipping the reminder %v of user %v because the message %v in chat %v isn't available", reminder.Id, reminder.UserId, reminder.MessageId, reminder.ChatId)
+		return nil
+	}
+
+	var ownerLogin string
+	var ownerAvatar null.String
+	if message.Owner != nil {
+		ownerLogin = message.Owner.Login
+		ownerAvatar = message.Owner.Avatar
+	}
+
+	mc.notificator.SendMessageReminder(ctx, reminder.UserId, reminder.ChatId, reminder.MessageId, createMessagePreviewWithoutLogin(mc.stripAllTags, message.Text), message.OwnerId, ownerLogin, ownerAvatar.Ptr(), chatName)
+	mc.notificator.NotifyReminderBrowserNotification(ctx, reminder.UserId, reminder.ChatId, chatName, null.StringFromPtr(nil), reminder.MessageId, createMessagePreview(mc.stripAllTags, message.Text, ownerLogin), message.OwnerId, ownerLogin)
+	return nil
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+type MessageRevisionsWrapper struct {
+	Data  []*dto.MessageRevisionDto `json:"items"`
+	Count int64                     `json:"count"` // total revisions number of the message
+}
+
+type MessageRevisionWrapper struct {
+	Revision         *dto.MessageRevisionDto `json:"revision"`
+	PreviousRevision *dto.MessageRevisionDto `json:"previousRevision"` // to show the difference, null for the original text
+}
+
+func (mc *MessageHandler) GetMessageRevisions(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	messageId, err := GetPathParamAsInt64(c, "messageId")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		canSee, err := mc.canSeeEditHistory(c.Request().Context(), tx, chatId, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+		if !canSee {
+			return c.NoContent(http.StatusUnauthorized)
+		}
+
+		revisions, err := tx.GetMessageRevisions(c.Request().Context(), chatId, messageId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		var editorsSet = map[int64]bool{}
+		for _, revision := range revisions {
+			editorsSet[revision.EditorId] = true
+		}
+		var editors = getUsersRemotelyOrEmpty(c.Request().Context(), mc.lgr, editorsSet, mc.restClient)
+
+		revisionDtos := make([]*dto.MessageRevisionDto, 0)
+		for _, revision := range revisions {
+			revisionDtos = append(revisionDtos, convertToMessageRevisionDto(revision, editors))
+		}
+
+		count, err := tx.GetMessageRevisionsCount(c.Request().Context(), chatId, messageId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, MessageRevisionsWrapper{
+			Data:  revisionDtos,
+			Count: count,
+		})
+	})
+}
+
+func (mc *MessageHandler) GetMessageRevision(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	messageId, err := GetPathParamAsInt64(c, "messageId")
+	if err != nil {
+		return err
+	}
+
+	revisionId, err := GetPathParamAsInt64(c, "revisionId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		canSee, err := mc.canSeeEditHistory(c.Request().Context(), tx, chatId, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+		if !canSee {
+			return c.NoContent(http.StatusUnauthorized)
+		}
+
+		revision, err := tx.GetMessageRevision(c.Request().Context(), chatId, messageId, revisionId)
+		if err != nil {
+			return err
+		}
+		if revision == nil {
+			return c.NoContent(http.StatusNoContent)
+		}
+
+		previousRevision, err := tx.GetPreviousMessageRevision(c.Request().Context(), chatId, messageId, revisionId)
+		if err != nil {
+			return err
+		}
+
+		var editorsSet = map[int64]bool{revision.EditorId: true}
+		if previousRevision != nil {
+			editorsSet[previousRevision.EditorId] = true
+		}
+		var editors = getUsersRemotelyOrEmpty(c.Request().Context(), mc.lgr, editorsSet, mc.restClient)
+
+		response := MessageRevisionWrapper{
+			Revision: convertToMessageRevisionDto(revision, editors),
+		}
+		if previousRevision != nil {
+			response.PreviousRevision = convertToMessageRevisionDto(previousRevision, editors)
+		}
+		return c.JSON(http.StatusOK, response)
+	})
+}
+
+func (mc *MessageHandler) canSeeEditHistory(ctx context.Context, tx *db.Tx, chatId int64, userId int64) (bool, error) {
+	isParticipant, err := tx.IsParticipant(ctx, userId, chatId)
+	if err != nil {
+		return false, err
+	}
+	if !isParticipant {
+		return false, nil
+	}
+
+	chatBasic, err := tx.GetChatBasic(ctx, chatId)
+	if err != nil {
+		return false, err
+	}
+	if chatBasic == nil {
+		return false, nil
+	}
+
+	role, err := tx.GetParticipantRole(ctx, userId, chatId)
+	if err != nil {
+		return false, err
+	}
+
+	return dto.HasChatPermission(role, chatBasic.PermissionSettings(), dto.PermissionSeeEditHistory), nil
+}
+
+func convertToMessageRevisionDto(revision *db.MessageRevision, users map[int64]*dto.User) *dto.MessageRevisionDto {
+	editor := users[revision.EditorId]
+	if editor == nil {
+		editor = getDeletedUser(revision.EditorId)
+	}
+	return &dto.MessageRevisionDto{
+		Id:             revision.Id,
+		MessageId:      revision.MessageId,
+		Text:           revision.Text,
+		EditorId:       revision.EditorId,
+		Editor:         editor,
+		CreateDateTime: revision.CreateDateTime,
+	}
+}
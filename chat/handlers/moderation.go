@@ -0,0 +1,455 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/guregu/null"
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+const minReportReasonLength = 1
+const maxReportReasonLength = 1024
+const maxModerationCommentLength = 1024
+
+// the admin of the service can moderate any chat
+const serviceAdminRole = "ROLE_ADMIN"
+
+type CreateMessageReportDto struct {
+	Reason string `json:"reason"`
+}
+
+func (a *CreateMessageReportDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Reason, validation.Required, validation.Length(minReportReasonLength, maxReportReasonLength)),
+	)
+}
+
+type ResolveMessageReportDto struct {
+	Action            string     `json:"action"`
+	Comment           *string    `json:"comment"`
+	BanExpireDateTime *time.Time `json:"banExpireDateTime"` // is applicable to the ban, nil means the ban is permanent
+}
+
+func (a *ResolveMessageReportDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Action, validation.Required, validation.In(dto.ModerationResolutions...)),
+		validation.Field(&a.Comment, validation.Length(0, maxModerationCommentLength)),
+		validation.Field(&a.BanExpireDateTime, validation.Min(time.Now().UTC())),
+	)
+}
+
+type MessageReportsWrapper struct {
+	Data  []*dto.MessageReportDto `json:"items"`
+	Count int64                   `json:"count"` // total open reports number
+}
+
+type ModerationActionsWrapper struct {
+	Data  []*dto.ModerationActionDto `json:"items"`
+	Count int64                      `json:"count"` // total actions number in this chat
+}
+
+func isServiceAdmin(principal *auth.AuthResult) bool {
+	return principal != nil && principal.HasRole(serviceAdminRole)
+}
+
+func (mc *MessageHandler) canModerate(ctx context.Context, tx *db.Tx, principal *auth.AuthResult, chatId int64, permission string) (bool, error) {
+	if isServiceAdmin(principal) {
+		return true, nil
+	}
+	return hasChatPermission(ctx, tx, principal.UserId, chatId, permission)
+}
+
+func (mc *MessageHandler) convertToMessageReportDtos(ctx context.Context, tx *db.Tx, reports []*db.MessageReport, behalfUserId int64) ([]*dto.MessageReportDto, error) {
+	var userIds = map[int64]bool{}
+	var chatIds = map[int64]bool{}
+	for _, r := range reports {
+		userIds[r.MessageOwnerId] = true
+		userIds[r.ReporterId] = true
+		chatIds[r.ChatId] = true
+	}
+	users := getUsersRemotelyOrEmpty(ctx, mc.lgr, userIds, mc.restClient)
+	chats, err := tx.GetChatsBasic(ctx, chatIds, behalfUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*dto.MessageReportDto, 0)
+	for _, r := range reports {
+		owner := users[r.MessageOwnerId]
+		if owner == nil {
+			owner = getDeletedUser(r.MessageOwnerId)
+		}
+		reporter := users[r.ReporterId]
+		if reporter == nil {
+			reporter = getDeletedUser(r.ReporterId)
+		}
+		reportDto := &dto.MessageReportDto{
+			Id:             r.Id,
+			ChatId:         r.ChatId,
+			MessageId:      r.MessageId,
+			MessageOwner:   owner,
+			MessageText:    r.MessageText,
+			Reporter:       reporter,
+			Reason:         r.Reason,
+			CreateDateTime: r.CreateDateTime,
+		}
+		if chat, ok := chats[r.ChatId]; ok {
+			reportDto.ChatTitle = chat.Title
+		}
+		ret = append(ret, reportDto)
+	}
+	return ret, nil
+}
+
+func (mc *MessageHandler) ReportMessage(c echo.Context) error {
+	var bindTo = new(CreateMessageReportDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	bindTo.Reason = TrimAmdSanitize(mc.policy, bindTo.Reason)
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	messageId, err := GetPathParamAsInt64(c, "messageId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		message, err := tx.GetMessage(c.Request().Context(), chatId, userPrincipalDto.UserId, messageId)
+		if err != nil {
+			return err
+		}
+		if message == nil || message.DeletedDateTime.Valid {
+			return c.NoContent(http.StatusNotFound)
+		}
+		if message.OwnerId == userPrincipalDto.UserId {
+			return c.JSON(http.StatusBadRequest, &utils.H{"message": "You cannot report your own message"})
+		}
+
+		report, err := tx.CreateMessageReport(c.Request().Context(), chatId, messageId, message.OwnerId, message.Text, userPrincipalDto.UserId, bindTo.Reason)
+		if err != nil {
+			return err
+		}
+		if report == nil {
+			return c.JSON(http.StatusConflict, &utils.H{"message": "You have already reported this message"})
+		}
+		return c.JSON(http.StatusCreated, &utils.H{"id": report.Id})
+	})
+}
+
+// the queue of the chat for its moderators
+func (mc *MessageHandler) GetChatMessageReports(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		allowed, err := mc.canModerate(c.Request().Context(), tx, userPrincipalDto, chatId, dto.PermissionModerate)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		reports, err := tx.GetOpenMessageReports(c.Request().Context(), chatId, size, offset)
+		if err != nil {
+			return err
+		}
+		reportDtos, err := mc.convertToMessageReportDtos(c.Request().Context(), tx, reports, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+
+		count, err := tx.GetOpenMessageReportsCount(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, MessageReportsWrapper{
+			Data:  reportDtos,
+			Count: count,
+		})
+	})
+}
+
+// the queue of all the chats for the admins of the service
+func (mc *MessageHandler) GetAllMessageReports(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	if !isServiceAdmin(userPrincipalDto) {
+		return c.NoContent(http.StatusUnauthorized)
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		reports, err := tx.GetAllOpenMessageReports(c.Request().Context(), size, offset)
+		if err != nil {
+			return err
+		}
+		reportDtos, err := mc.convertToMessageReportDtos(c.Request().Context(), tx, reports, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+
+		count, err := tx.GetAllOpenMessageReportsCount(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, MessageReportsWrapper{
+			Data:  reportDtos,
+			Count: count,
+		})
+	})
+}
+
+// the decision closes all the open reports about the same message
+func (mc *MessageHandler) ResolveMessageReport(c echo.Context) error {
+	var bindTo = new(ResolveMessageReportDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	reportId, err := GetPathParamAsInt64(c, "reportId")
+	if err != nil {
+		return err
+	}
+
+	var comment null.String
+	if bindTo.Comment != nil {
+		comment = null.StringFrom(TrimAmdSanitize(mc.policy, *bindTo.Comment))
+	}
+
+	var removedUserId *int64
+	errOuter := db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		// the dismissal is available to the moderators, the other decisions require the corresponding permissions
+		var permission = dto.PermissionModerate
+		switch bindTo.Action {
+		case dto.ModerationActionDeleteMessage:
+			permission = dto.PermissionDeleteOthersMessages
+		case dto.ModerationActionRemoveUser, dto.ModerationActionBanUser:
+			permission = dto.PermissionManageParticipants
+		}
+		allowed, err := mc.canModerate(c.Request().Context(), tx, userPrincipalDto, chatId, permission)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		report, err := tx.GetMessageReport(c.Request().Context(), chatId, reportId)
+		if err != nil {
+			return err
+		}
+		if report == nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+		if report.Resolution.Valid {
+			return c.JSON(http.StatusConflict, &utils.H{"message": "The report is already resolved"})
+		}
+
+		switch bindTo.Action {
+		case dto.ModerationActionDismiss:
+			err = tx.CreateModerationAction(c.Request().Context(), chatId, null.IntFrom(report.MessageId), null.IntFrom(report.MessageOwnerId), dto.ModerationActionDismiss, userPrincipalDto.UserId, comment)
+		case dto.ModerationActionDeleteMessage:
+			err = mc.deleteReportedMessage(c.Request().Context(), tx, chatId, report.MessageId, userPrincipalDto, comment)
+		case dto.ModerationActionRemoveUser, dto.ModerationActionBanUser:
+			role, err := tx.GetParticipantRole(c.Request().Context(), report.MessageOwnerId, chatId)
+			if err != nil {
+				return err
+			}
+			if role == dto.ChatRoleOwner {
+				return c.JSON(http.StatusBadRequest, &utils.H{"message": "The owner cannot be removed"})
+			}
+			if report.MessageOwnerId == userPrincipalDto.UserId {
+				return c.JSON(http.StatusBadRequest, &utils.H{"message": "You cannot remove yourself"})
+			}
+			if bindTo.Action == dto.ModerationActionBanUser {
+				_, err = mc.ch.banParticipant(c.Request().Context(), tx, chatId, report.MessageOwnerId, role, userPrincipalDto.UserId, comment, null.TimeFromPtr(bindTo.BanExpireDateTime))
+				if err != nil {
+					return err
+				}
+			} else if role != "" {
+				err = mc.ch.removeParticipant(c.Request().Context(), tx, chatId, report.MessageOwnerId, userPrincipalDto.UserId, comment)
+				if err != nil {
+					return err
+				}
+			}
+			if role != "" {
+				removedUserId = &report.MessageOwnerId
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		return tx.ResolveMessageReports(c.Request().Context(), chatId, report.MessageId, bindTo.Action, userPrincipalDto.UserId)
+	})
+	if errOuter != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	if c.Response().Committed {
+		return nil
+	}
+
+	if removedUserId != nil {
+		errOuter = db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+			return mc.ch.notifyAboutDeletedParticipant(c.Request().Context(), tx, chatId, *removedUserId)
+		})
+		if errOuter != nil {
+			mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+			return errOuter
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// the message which was already deleted by its owner or by another moderator is left as is
+func (mc *MessageHandler) deleteReportedMessage(ctx context.Context, tx *db.Tx, chatId, messageId int64, principal *auth.AuthResult, comment null.String) error {
+	message, err := tx.GetMessageForModeration(ctx, chatId, messageId)
+	if err != nil {
+		return err
+	}
+	if message == nil || message.DeletedDateTime.Valid {
+		return nil
+	}
+	chatBasic, err := tx.GetChatBasic(ctx, chatId)
+	if err != nil {
+		return err
+	}
+	return mc.deleteMessage(ctx, tx, chatId, chatBasic, message, principal, comment)
+}
+
+// the trail of the moderation of the chat
+func (mc *MessageHandler) GetModerationActions(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		allowed, err := mc.canModerate(c.Request().Context(), tx, userPrincipalDto, chatId, dto.PermissionModerate)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		actions, err := tx.GetModerationActions(c.Request().Context(), chatId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		var userIds = map[int64]bool{}
+		for _, a := range actions {
+			userIds[a.ActorId] = true
+			if a.TargetUserId.Valid {
+				userIds[a.TargetUserId.Int64] = true
+			}
+		}
+		users := getUsersRemotelyOrEmpty(c.Request().Context(), mc.lgr, userIds, mc.restClient)
+
+		actionDtos := make([]*dto.ModerationActionDto, 0)
+		for _, a := range actions {
+			actor := users[a.ActorId]
+			if actor == nil {
+				actor = getDeletedUser(a.ActorId)
+			}
+			actionDto := &dto.ModerationActionDto{
+				Id:             a.Id,
+				ChatId:         a.ChatId,
+				MessageId:      a.MessageId,
+				Action:         a.Action,
+				Actor:          actor,
+				Comment:        a.Comment,
+				CreateDateTime: a.CreateDateTime,
+			}
+			if a.TargetUserId.Valid {
+				actionDto.TargetUser = users[a.TargetUserId.Int64]
+				if actionDto.TargetUser == nil {
+					actionDto.TargetUser = getDeletedUser(a.TargetUserId.Int64)
+				}
+			}
+			actionDtos = append(actionDtos, actionDto)
+		}
+
+		count, err := tx.GetModerationActionsCount(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, ModerationActionsWrapper{
+			Data:  actionDtos,
+			Count: count,
+		})
+	})
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+const minPollOptions = 2
+const maxPollOptions = 20
+const maxPollOptionLen = 256
+
+type PollRequestDto struct {
+	Options        []string `json:"options"`
+	MultipleChoice bool     `json:"multipleChoice"`
+	Anonymous      bool     `json:"anonymous"`
+}
+
+func (a *PollRequestDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Options, validation.Required, validation.Length(minPollOptions, maxPollOptions), validation.Each(validation.Required, validation.Length(1, maxPollOptionLen))),
+	)
+}
+
+type PollVoteDto struct {
+	OptionIds []int64 `json:"optionIds"` // the complete choice of the user, empty retracts the vote
+}
+
+type wrongPollError struct{}
+
+func (m *wrongPollError) Error() string {
+	return "The poll cannot be attached to the resent message"
+}
+
+func (mc *MessageHandler) createPoll(ctx context.Context, tx *db.Tx, chatId int64, messageId int64, input *PollRequestDto) error {
+	options := make([]string, 0, len(input.Options))
+	for _, option := range input.Options {
+		options = append(options, TrimAmdSanitizeChatTitle(mc.stripAllTags, option))
+	}
+	return tx.CreatePoll(ctx, chatId, messageId, input.MultipleChoice, input.Anonymous, options)
+}
+
+// adds the displayable voters of the public poll, in the same manner as takeOnAccountReactions
+func takeOnAccountPoll(ownersSet map[int64]bool, poll *db.Poll) {
+	if poll == nil || poll.Anonymous {
+		return
+	}
+	for _, o := range poll.Options {
+		for _, voterId := range getOnlyMaxDisplayableUsers(o.VoterIds) {
+			ownersSet[voterId] = true
+		}
+	}
+}
+
+func convertPoll(dbPoll *db.Poll, users map[int64]*dto.User) *dto.PollDto {
+	if dbPoll == nil {
+		return nil
+	}
+	ret := &dto.PollDto{
+		MultipleChoice: dbPoll.MultipleChoice,
+		Anonymous:      dbPoll.Anonymous,
+		Closed:         dbPoll.ClosedDateTime.Valid,
+		ClosedDateTime: dbPoll.ClosedDateTime,
+		Options:        make([]*dto.PollOptionDto, 0, len(dbPoll.Options)),
+	}
+	var voters = map[int64]bool{}
+	for _, o := range dbPoll.Options {
+		option := &dto.PollOptionDto{
+			Id:    o.Id,
+			Text:  o.Text,
+			Count: int64(len(o.VoterIds)),
+			Users: make([]*dto.User, 0),
+		}
+		option.SetVoterIds(o.VoterIds)
+		if !dbPoll.Anonymous {
+			for _, voterId := range getOnlyMaxDisplayableUsers(o.VoterIds) {
+				user := users[voterId]
+				if user == nil {
+					user = getDeletedUser(voterId)
+				}
+				option.Users = append(option.Users, user)
+			}
+		}
+		for _, voterId := range o.VoterIds {
+			voters[voterId] = true
+		}
+		ret.Options = append(ret.Options, option)
+	}
+	ret.TotalVoters = int64(len(voters))
+	return ret
+}
+
+// checks that the behalf user is able to touch the poll, responds in case they aren't
+func (mc *MessageHandler) getPollForChange(c echo.Context, tx *db.Tx, chatId int64, messageId int64, behalfUserId int64) (*db.MessageBasic, *db.Poll, bool, error) {
+	isParticipant, err := tx.IsParticipant(c.Request().Context(), behalfUserId, chatId)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if !isParticipant {
+		return nil, nil, false, c.NoContent(http.StatusUnauthorized)
+	}
+
+	m, err := tx.GetMessageBasic(c.Request().Context(), chatId, messageId)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if m == nil {
+		return nil, nil, false, c.NoContent(http.StatusNotFound)
+	}
+	if m.DeletedDateTime.Valid {
+		// the tombstone's poll is hidden as well as its text
+		return nil, nil, false, c.NoContent(http.StatusBadRequest)
+	}
+
+	poll, err := tx.GetPoll(c.Request().Context(), chatId, messageId)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if poll == nil {
+		return nil, nil, false, c.NoContent(http.StatusNotFound)
+	}
+	if poll.ClosedDateTime.Valid {
+		return nil, nil, false, c.JSON(http.StatusBadRequest, &utils.H{"message": "The poll is closed"})
+	}
+	return m, poll, true, nil
+}
+
+func (mc *MessageHandler) sendPollChanged(ctx context.Context, tx *db.Tx, chatId int64, messageId int64, messageOwnerId int64) error {
+	poll, err := tx.GetPoll(ctx, chatId, messageId)
+	if err != nil {
+		return err
+	}
+	var votersSet = map[int64]bool{}
+	takeOnAccountPoll(votersSet, poll)
+	var users = getUsersRemotelyOrEmpty(ctx, mc.lgr, votersSet, mc.restClient)
+
+	mc.notificator.SendPollEvent(ctx, chatId, messageId, messageOwnerId, convertPoll(poll, users), tx)
+	return nil
+}
+
+func (mc *MessageHandler) VotePoll(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	messageId, err := GetPathParamAsInt64(c, "messageId")
+	if err != nil {
+		return err
+	}
+
+	var bindTo = new(PollVoteDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		m, poll, proceed, err := mc.getPollForChange(c, tx, chatId, messageId, userPrincipalDto.UserId)
+		if !proceed {
+			return err
+		}
+
+		if !poll.MultipleChoice && len(bindTo.OptionIds) > 1 {
+			return c.JSON(http.StatusBadRequest, &utils.H{"message": "Only one option can be chosen"})
+		}
+		for _, optionId := range bindTo.OptionIds {
+			if !poll.HasOption(optionId) {
+				return c.JSON(http.StatusBadRequest, &utils.H{"message": "Wrong option"})
+			}
+		}
+
+		err = tx.VotePoll(c.Request().Context(), chatId, messageId, userPrincipalDto.UserId, bindTo.OptionIds)
+		if err != nil {
+			return err
+		}
+
+		err = mc.sendPollChanged(c.Request().Context(), tx, chatId, messageId, m.OwnerId)
+		if err != nil {
+			return err
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+}
+
+func (mc *MessageHandler) ClosePoll(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	messageId, err := GetPathParamAsInt64(c, "messageId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		m, _, proceed, err := mc.getPollForChange(c, tx, chatId, messageId, userPrincipalDto.UserId)
+		if !proceed {
+			return err
+		}
+
+		if m.OwnerId != userPrincipalDto.UserId {
+			allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionDeleteOthersMessages)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return c.NoContent(http.StatusUnauthorized)
+			}
+		}
+
+		err = tx.ClosePoll(c.Request().Context(), chatId, messageId)
+		if err != nil {
+			return err
+		}
+
+		err = mc.sendPollChanged(c.Request().Context(), tx, chatId, messageId, m.OwnerId)
+		if err != nil {
+			return err
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+}
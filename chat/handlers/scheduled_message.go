@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+	"time"
+)
+
+type CreateScheduledMessageDto struct {
+	CreateMessageDto
+	SendDateTime time.Time `json:"sendDateTime"`
+}
+
+type EditScheduledMessageDto struct {
+	Id int64 `json:"id"`
+	CreateScheduledMessageDto
+}
+
+type ScheduledMessagesWrapper struct {
+	Data  []*dto.ScheduledMessageDto `json:"items"`
+	Count int64                      `json:"count"` // total scheduled messages number of the user in this chat
+}
+
+func (a *CreateScheduledMessageDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.SendDateTime, validation.Required, validation.Min(time.Now().UTC())),
+	)
+}
+
+func (a *EditScheduledMessageDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Id, validation.Required),
+	)
+}
+
+func (mc *MessageHandler) GetScheduledMessages(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		messages, err := tx.GetScheduledMessages(c.Request().Context(), chatId, userPrincipalDto.UserId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		messageDtos := make([]*dto.ScheduledMessageDto, 0)
+		for _, message := range messages {
+			messageDtos = append(messageDtos, convertToScheduledMessageDto(message))
+		}
+
+		count, err := tx.GetScheduledMessagesCount(c.Request().Context(), chatId, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, ScheduledMessagesWrapper{
+			Data:  messageDtos,
+			Count: count,
+		})
+	})
+}
+
+func (mc *MessageHandler) PostScheduledMessage(c echo.Context) error {
+	var bindTo = new(CreateScheduledMessageDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+
+	if valid, err := mc.validateScheduledMessage(c, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	scheduledMessageId, errOuter := db.TransactWithResult(c.Request().Context(), mc.db, func(tx *db.Tx) (int64, error) {
+		scheduledMessage, err := mc.convertToScheduledMessage(c.Request().Context(), tx, chatId, bindTo, userPrincipalDto)
+		if err != nil {
+			return 0, err
+		}
+		return tx.CreateScheduledMessage(c.Request().Context(), scheduledMessage)
+	})
+	if errOuter != nil {
+		if handled, err := respondCreateMessageError(c, errOuter); handled {
+			return err
+		}
+
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+
+	return c.JSON(http.StatusCreated, &utils.H{"id": scheduledMessageId})
+}
+
+func (mc *MessageHandler) EditScheduledMessage(c echo.Context) error {
+	var bindTo = new(EditScheduledMessageDto)
+	if err := c.Bind(bindTo); err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+
+	if valid, err := ValidateAndRespondError(c, mc.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+	if valid, err := mc.validateScheduledMessage(c, &bindTo.CreateScheduledMessageDto); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	errOuter := db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		existing, err := tx.GetScheduledMessage(c.Request().Context(), chatId, bindTo.Id, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		scheduledMessage, err := mc.convertToScheduledMessage(c.Request().Context(), tx, chatId, &bindTo.CreateScheduledMessageDto, userPrincipalDto)
+		if err != nil {
+			return err
+		}
+		scheduledMessage.Id = bindTo.Id
+
+		err = tx.EditScheduledMessage(c.Request().Context(), scheduledMessage)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, &utils.H{"id": bindTo.Id})
+	})
+	if errOuter != nil {
+		if handled, err := respondCreateMessageError(c, errOuter); handled {
+			return err
+		}
+
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", errOuter)
+		return errOuter
+	}
+	return nil
+}
+
+func (mc *MessageHandler) DeleteScheduledMessage(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	scheduledMessageId, err := GetPathParamAsInt64(c, "scheduledMessageId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), mc.db, func(tx *db.Tx) error {
+		deleted, err := tx.DeleteScheduledMessage(c.Request().Context(), chatId, scheduledMessageId, userPrincipalDto.UserId)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return c.JSON(http.StatusAccepted, &utils.H{"id": scheduledMessageId})
+	})
+}
+
+// SendScheduledMessage is invoked by the scheduler, it creates the message on behalf of its owner and sends the same events as PostMessage does
+func (mc *MessageHandler) SendScheduledMessage(ctx context.Context, scheduledMessageId int64) error {
+	var scheduledMessage *db.ScheduledMessage
+	var principal *auth.AuthResult
+	messageId, err := db.TransactWithResult(ctx, mc.db, func(tx *db.Tx) (int64, error) {
+		sm, err := tx.TakeScheduledMessage(ctx, scheduledMessageId)
+		if err != nil {
+			return 0, err
+		}
+		if sm == nil {
+			// was cancelled in the meantime
+			return 0, nil
+		}
+		scheduledMessage = sm
+		principal = mc.getScheduledMessageOwner(ctx, sm.OwnerId)
+
+		err = mc.checkSlowMode(ctx, tx, sm.ChatId, sm.OwnerId)
+		if err != nil {
+			return 0, err
+		}
+		return mc.createMessage(ctx, tx, sm.ChatId, convertScheduledToCreateMessageDto(sm), principal)
+	})
+	if err != nil {
+		var sme *slowModeError
+		if errors.As(err, &sme) {
+			// the transaction is rolled back, so the message is still there and is sent as soon as the slow mode allows
+			sendDateTime := time.Now().UTC().Add(sme.retryAfter)
+			mc.lgr.WithTracing(ctx).Infof("Postponing the scheduled message %v till %v because of the slow mode", scheduledMessageId, sendDateTime.Format(time.RFC3339))
+			return db.Transact(ctx, mc.db, func(tx *db.Tx) error {
+				return tx.PostponeScheduledMessage(ctx, scheduledMessageId, sendDateTime)
+			})
+		}
+		if isCreateMessageBusinessError(err) {
+			// the owner has left the chat or has lost the permissions - there is no sense to retry
+			mc.lgr.WithTracing(ctx).Warnf("Dropping the scheduled message %v because it cannot be sent: %v", scheduledMessageId, err)
+			return db.Transact(ctx, mc.db, func(tx *db.Tx) error {
+				_, err := tx.TakeScheduledMessage(ctx, scheduledMessageId)
+				return err
+			})
+		}
+		return err
+	}
+	if scheduledMessage == nil {
+		return nil
+	}
+
+	mc.lgr.WithTracing(ctx).Infof("The scheduled message %v was sent as message %v to chat %v", scheduledMessageId, messageId, scheduledMessage.ChatId)
+	err = mc.notifyAboutCreatedMessage(ctx, scheduledMessage.ChatId, scheduledMessage.ThreadId, messageId, principal)
+	if err != nil {
+		return err
+	}
+	mc.fillLinkPreviewAsync(ctx, scheduledMessage.ChatId, messageId)
+	return nil
+}
+
+func (mc *MessageHandler) validateScheduledMessage(c echo.Context, bindTo *CreateScheduledMessageDto) (bool, error) {
+	if bindTo.EmbedMessageRequest == nil || (bindTo.EmbedMessageRequest != nil && bindTo.EmbedMessageRequest.EmbedType == dto.EmbedMessageTypeReply) {
+		if valid, err := ValidateAndRespondError(c, mc.lgr, &bindTo.CreateMessageDto); err != nil || !valid {
+			return valid, err
+		}
+	}
+	return ValidateAndRespondError(c, mc.lgr, bindTo)
+}
+
+// performs the same checks as createMessage in order to reject the wrong message beforehand, not at the sending time
+func (mc *MessageHandler) convertToScheduledMessage(ctx context.Context, tx *db.Tx, chatId int64, input *CreateScheduledMessageDto, principal *auth.AuthResult) (*db.ScheduledMessage, error) {
+	if participant, err := tx.IsParticipant(ctx, principal.UserId, chatId); err != nil {
+		return nil, err
+	} else if !participant {
+		return nil, &notParticipantError{}
+	}
+
+	chatBasic, err := tx.GetChatBasic(ctx, chatId)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := tx.GetParticipantRole(ctx, principal.UserId, chatId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canWriteMessage(chatBasic, role) {
+		return nil, &cannotWriteMessageError{}
+	}
+
+	// the message is checked now because its author expects the answer, the masked text is kept
+	verdict, err := mc.filterPostedMessage(ctx, tx, chatId, &input.CreateMessageDto, principal)
+	if err != nil {
+		return nil, err
+	}
+	if verdict != nil && verdict.Action == dto.ContentFilterActionHold {
+		// the moderator's queue is for the messages which are being posted right now
+		return nil, &contentRejectedError{reason: verdict.Reason}
+	}
+
+	creatableMessage, err := convertToCreatableMessage(ctx, mc.lgr, &input.CreateMessageDto, principal, chatId, mc.policy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = mc.validateAndSetEmbedFieldsEmbedMessage(ctx, tx, &input.CreateMessageDto, creatableMessage)
+	if err != nil {
+		mc.lgr.WithTracing(ctx).Errorf("Error during checking embed %v", err)
+		return nil, err
+	}
+
+	if input.ThreadId != nil {
+		rootMessage, err := tx.GetMessageBasic(ctx, chatId, *input.ThreadId)
+		if err != nil {
+			return nil, err
+		}
+		if rootMessage == nil || rootMessage.DeletedDateTime.Valid || rootMessage.ThreadId != nil {
+			return nil, &wrongThreadError{}
+		}
+	}
+
+	ret := &db.ScheduledMessage{
+		ChatId:       chatId,
+		OwnerId:      principal.UserId,
+		Text:         creatableMessage.Text,
+		FileItemUuid: input.FileItemUuid,
+		ThreadId:     input.ThreadId,
+		SendDateTime: input.SendDateTime.UTC(),
+	}
+	if input.EmbedMessageRequest != nil {
+		ret.EmbedMessageId = &input.EmbedMessageRequest.Id
+		ret.EmbedMessageType = &input.EmbedMessageRequest.EmbedType
+		if input.EmbedMessageRequest.EmbedType == dto.EmbedMessageTypeResend {
+			ret.EmbedChatId = &input.EmbedMessageRequest.ChatId
+		}
+	}
+	return ret, nil
+}
+
+func (mc *MessageHandler) getScheduledMessageOwner(ctx context.Context, ownerId int64) *auth.AuthResult {
+	principal := &auth.AuthResult{
+		UserId: ownerId,
+	}
+	users := getUsersRemotelyOrEmptyFromSlice(ctx, mc.lgr, []int64{ownerId}, mc.restClient)
+	if user, ok := users[ownerId]; ok {
+		principal.UserLogin = user.Login
+		principal.Avatar = user.Avatar.Ptr()
+	}
+	return principal
+}
+
+func isCreateMessageBusinessError(err error) bool {
+	var mediaError *MediaUrlErr
+	var mediaOverflowError *MediaOverflowErr
+	var npe *notParticipantError
+	var cwm *cannotWriteMessageError
+	var wte *wrongThreadError
+	return errors.As(err, &mediaError) || errors.As(err, &mediaOverflowError) || errors.As(err, &npe) || errors.As(err, &cwm) || errors.As(err, &wte)
+}
+
+func convertScheduledToCreateMessageDto(sm *db.ScheduledMessage) *CreateMessageDto {
+	ret := &CreateMessageDto{
+		Text:         sm.Text,
+		FileItemUuid: sm.FileItemUuid,
+		ThreadId:     sm.ThreadId,
+	}
+	if sm.EmbedMessageId != nil && sm.EmbedMessageType != nil {
+		ret.EmbedMessageRequest = &dto.EmbedMessageRequest{
+			Id:        *sm.EmbedMessageId,
+			EmbedType: *sm.EmbedMessageType,
+		}
+		if sm.EmbedChatId != nil {
+			ret.EmbedMessageRequest.ChatId = *sm.EmbedChatId
+		}
+	}
+	return ret
+}
+
+func convertToScheduledMessageDto(sm *db.ScheduledMessage) *dto.ScheduledMessageDto {
+	ret := &dto.ScheduledMessageDto{
+		Id:             sm.Id,
+		ChatId:         sm.ChatId,
+		Text:           sm.Text,
+		FileItemUuid:   sm.FileItemUuid,
+		ThreadId:       sm.ThreadId,
+		SendDateTime:   sm.SendDateTime,
+		CreateDateTime: sm.CreateDateTime,
+		EditDateTime:   sm.EditDateTime,
+	}
+	if sm.EmbedMessageId != nil && sm.EmbedMessageType != nil {
+		ret.EmbedMessageRequest = &dto.EmbedMessageRequest{
+			Id:        *sm.EmbedMessageId,
+			EmbedType: *sm.EmbedMessageType,
+		}
+		if sm.EmbedChatId != nil {
+			ret.EmbedMessageRequest.ChatId = *sm.EmbedChatId
+		}
+	}
+	return ret
+}
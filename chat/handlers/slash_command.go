@@ -0,0 +1,675 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/labstack/echo/v4"
+	"nkonev.name/chat/auth"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/utils"
+)
+
+const maxSlashCommandDescriptionLen = 256
+const maxRemindIn = 365 * 24 * time.Hour
+const slashCommandEventType = "slash_command"
+const shrug = `¯\_(ツ)_/¯`
+
+var slashCommandNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+
+// the command is the first word of the message, the rest is its arguments
+var slashCommandRegexp = regexp.MustCompile(`^/([a-z][a-z0-9_-]{0,31})(?:\s+([\s\S]*))?$`)
+
+var paragraphsReplacer = strings.NewReplacer("</p>", "</p>\n", "<br>", "\n", "<br/>", "\n", "<br />", "\n")
+
+type ChatSlashCommandRequestDto struct {
+	Name        string `json:"name"` // without the leading slash
+	Description string `json:"description"`
+	Url         string `json:"url"`
+}
+
+func (a *ChatSlashCommandRequestDto) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Name, validation.Required, validation.Match(slashCommandNameRegexp), validation.NotIn(builtInSlashCommandNames()...)),
+		validation.Field(&a.Description, validation.Length(0, maxSlashCommandDescriptionLen)),
+		validation.Field(&a.Url, validation.Required, is.URL, validation.Match(webhookUrlRegexp)),
+	)
+}
+
+type ChatSlashCommandsWrapper struct {
+	Data  []*dto.ChatSlashCommandDto `json:"items"`
+	Count int64                      `json:"count"` // total registered commands number in this chat
+}
+
+type AvailableSlashCommandsWrapper struct {
+	Data []*dto.AvailableSlashCommandDto `json:"items"`
+}
+
+type slashCommandInvocation struct {
+	name      string
+	args      string
+	chatId    int64
+	threadId  *int64
+	principal *auth.AuthResult
+}
+
+// the nil fields mean there is nothing to do
+type slashCommandResult struct {
+	message    *CreateMessageDto // is posted on behalf of the invoker the regular way
+	botMessage *string           // is posted on behalf of the bot
+	ephemeral  *string           // is shown only to the invoker
+}
+
+type builtInSlashCommand struct {
+	name        string
+	description string
+	usage       string
+	invoke      func(mc *MessageHandler, ctx context.Context, cmd *builtInSlashCommand, invocation *slashCommandInvocation) (*slashCommandResult, error)
+}
+
+var builtInSlashCommands = []*builtInSlashCommand{
+	{
+		name:        "me",
+		description: "Posts the action on your behalf",
+		usage:       "/me <action>",
+		invoke:      (*MessageHandler).invokeMeCommand,
+	},
+	{
+		name:        "shrug",
+		description: "Appends " + shrug + " to the message",
+		usage:       "/shrug [message]",
+		invoke:      (*MessageHandler).invokeShrugCommand,
+	},
+	{
+		name:        "poll",
+		description: "Creates the poll",
+		usage:       "/poll <question> | <option> | <option> ...",
+		invoke:      (*MessageHandler).invokePollCommand,
+	},
+	{
+		name:        "remind",
+		description: "Posts the message to this chat after the given time",
+		usage:       "/remind <duration, e.g. 1h30m> <message>",
+		invoke:      (*MessageHandler).invokeRemindCommand,
+	},
+	{
+		name:        "topic",
+		description: "Changes the title of the chat",
+		usage:       "/topic <title>",
+		invoke:      (*MessageHandler).invokeTopicCommand,
+	},
+}
+
+func builtInSlashCommandNames() []interface{} {
+	ret := make([]interface{}, 0, len(builtInSlashCommands))
+	for _, cmd := range builtInSlashCommands {
+		ret = append(ret, cmd.name)
+	}
+	return ret
+}
+
+func findBuiltInSlashCommand(name string) *builtInSlashCommand {
+	for _, cmd := range builtInSlashCommands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// returns the name and the arguments of the command, the text is the html of the message
+func parseSlashCommand(policy interface{ Sanitize(string) string }, text string) (string, string, bool) {
+	plain := html.UnescapeString(policy.Sanitize(paragraphsReplacer.Replace(text)))
+	plain = strings.TrimSpace(strings.ReplaceAll(plain, "\u00a0", " "))
+	matches := slashCommandRegexp.FindStringSubmatch(plain)
+	if matches == nil {
+		return "", "", false
+	}
+	return matches[1], strings.TrimSpace(matches[2]), true
+}
+
+// only the plain text messages are treated as the commands
+func (mc *MessageHandler) parseSlashCommandInvocation(input *CreateMessageDto, chatId int64, principal *auth.AuthResult) *slashCommandInvocation {
+	if input.EmbedMessageRequest != nil || input.Poll != nil || input.FileItemUuid != nil || input.BlogPost {
+		return nil
+	}
+	name, args, ok := parseSlashCommand(mc.stripAllTags, input.Text)
+	if !ok {
+		return nil
+	}
+	return &slashCommandInvocation{
+		name:      name,
+		args:      args,
+		chatId:    chatId,
+		threadId:  input.ThreadId,
+		principal: principal,
+	}
+}
+
+func toHtmlParagraph(text string) string {
+	return "<p>" + html.EscapeString(text) + "</p>"
+}
+
+func ephemeralReply(text string) *slashCommandResult {
+	reply := toHtmlParagraph(text)
+	return &slashCommandResult{ephemeral: &reply}
+}
+
+func usageReply(cmd *builtInSlashCommand) *slashCommandResult {
+	return ephemeralReply("Usage: " + cmd.usage)
+}
+
+// returns nil if there is no such command, in this case the text is posted as the regular message
+func (mc *MessageHandler) invokeSlashCommand(ctx context.Context, invocation *slashCommandInvocation) (*slashCommandResult, error) {
+	var registered *db.ChatSlashCommand
+	err := db.Transact(ctx, mc.db, func(tx *db.Tx) error {
+		if participant, err := tx.IsParticipant(ctx, invocation.principal.UserId, invocation.chatId); err != nil {
+			return err
+		} else if !participant {
+			return &notParticipantError{}
+		}
+
+		chatBasic, err := tx.GetChatBasic(ctx, invocation.chatId)
+		if err != nil {
+			return err
+		}
+		role, err := tx.GetParticipantRole(ctx, invocation.principal.UserId, invocation.chatId)
+		if err != nil {
+			return err
+		}
+		if !canWriteMessage(chatBasic, role) {
+			return &cannotWriteMessageError{}
+		}
+
+		if findBuiltInSlashCommand(invocation.name) != nil {
+			return nil
+		}
+		registered, err = tx.GetChatSlashCommandByName(ctx, invocation.chatId, invocation.name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if builtIn := findBuiltInSlashCommand(invocation.name); builtIn != nil {
+		return builtIn.invoke(mc, ctx, builtIn, invocation)
+	}
+	if registered != nil {
+		return mc.invokeRegisteredSlashCommand(ctx, invocation, registered), nil
+	}
+	return nil, nil
+}
+
+func (mc *MessageHandler) invokeMeCommand(ctx context.Context, cmd *builtInSlashCommand, invocation *slashCommandInvocation) (*slashCommandResult, error) {
+	if invocation.args == "" {
+		return usageReply(cmd), nil
+	}
+	return &slashCommandResult{
+		message: &CreateMessageDto{
+			Text:     "<p><i>" + html.EscapeString(invocation.principal.UserLogin+" "+invocation.args) + "</i></p>",
+			ThreadId: invocation.threadId,
+		},
+	}, nil
+}
+
+func (mc *MessageHandler) invokeShrugCommand(ctx context.Context, cmd *builtInSlashCommand, invocation *slashCommandInvocation) (*slashCommandResult, error) {
+	return &slashCommandResult{
+		message: &CreateMessageDto{
+			Text:     toHtmlParagraph(strings.TrimSpace(invocation.args + " " + shrug)),
+			ThreadId: invocation.threadId,
+		},
+	}, nil
+}
+
+func (mc *MessageHandler) invokePollCommand(ctx context.Context, cmd *builtInSlashCommand, invocation *slashCommandInvocation) (*slashCommandResult, error) {
+	parts := strings.Split(invocation.args, "|")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	if len(parts) < 1+minPollOptions || parts[0] == "" {
+		return usageReply(cmd), nil
+	}
+
+	poll := &PollRequestDto{
+		Options: parts[1:],
+	}
+	if err := poll.Validate(); err != nil {
+		return ephemeralReply(fmt.Sprintf("The poll is wrong: %v", err)), nil
+	}
+	return &slashCommandResult{
+		message: &CreateMessageDto{
+			Text:     toHtmlParagraph(parts[0]),
+			ThreadId: invocation.threadId,
+			Poll:     poll,
+		},
+	}, nil
+}
+
+// creates the scheduled message of the invoker, it can be edited or cancelled among the other scheduled messages
+func (mc *MessageHandler) invokeRemindCommand(ctx context.Context, cmd *builtInSlashCommand, invocation *slashCommandInvocation) (*slashCommandResult, error) {
+	fields := strings.SplitN(invocation.args, " ", 2)
+	if len(fields) != 2 || strings.TrimSpace(fields[1]) == "" {
+		return usageReply(cmd), nil
+	}
+	remindIn, err := time.ParseDuration(fields[0])
+	if err != nil || remindIn <= 0 || remindIn > maxRemindIn {
+		return usageReply(cmd), nil
+	}
+
+	input := &CreateScheduledMessageDto{
+		CreateMessageDto: CreateMessageDto{
+			Text:     toHtmlParagraph(strings.TrimSpace(fields[1])),
+			ThreadId: invocation.threadId,
+		},
+		SendDateTime: time.Now().UTC().Add(remindIn),
+	}
+	err = db.Transact(ctx, mc.db, func(tx *db.Tx) error {
+		scheduledMessage, err := mc.convertToScheduledMessage(ctx, tx, invocation.chatId, input, invocation.principal)
+		if err != nil {
+			return err
+		}
+		_, err = tx.CreateScheduledMessage(ctx, scheduledMessage)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ephemeralReply(fmt.Sprintf("The message will be posted at %v", input.SendDateTime.Format(time.RFC3339))), nil
+}
+
+func (mc *MessageHandler) invokeTopicCommand(ctx context.Context, cmd *builtInSlashCommand, invocation *slashCommandInvocation) (*slashCommandResult, error) {
+	title := TrimAmdSanitizeChatTitle(mc.ch.stripTagsPolicy, invocation.args)
+	if err := lateValidateChatTitle(title); err != nil || utf8.RuneCountInString(title) > maxChatNameLen {
+		return usageReply(cmd), nil
+	}
+
+	allowed, err := db.TransactWithResult(ctx, mc.db, func(tx *db.Tx) (bool, error) {
+		return mc.ch.editChatTitle(ctx, tx, invocation.chatId, invocation.principal.UserId, title)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return ephemeralReply("You are not allowed to change the title of this chat"), nil
+	}
+	return ephemeralReply("The title of the chat was changed"), nil
+}
+
+// the failure of the external endpoint is reported only to the invoker
+func (mc *MessageHandler) invokeRegisteredSlashCommand(ctx context.Context, invocation *slashCommandInvocation, cmd *db.ChatSlashCommand) *slashCommandResult {
+	payload, err := json.Marshal(&dto.SlashCommandInvocation{
+		Command:   cmd.Name,
+		Text:      invocation.args,
+		ChatId:    invocation.chatId,
+		ThreadId:  invocation.threadId,
+		UserId:    invocation.principal.UserId,
+		UserLogin: invocation.principal.UserLogin,
+	})
+	if err != nil {
+		mc.lgr.WithTracing(ctx).Errorf("Error during marshalling the invocation of command %v: %v", cmd.Id, err)
+		return ephemeralReply(fmt.Sprintf("The command /%v has failed", cmd.Name))
+	}
+
+	body, err := mc.webhookSender.Call(ctx, cmd.Url, cmd.Secret, slashCommandEventType, payload)
+	if err != nil {
+		mc.lgr.WithTracing(ctx).Warnf("Error during invoking command %v of chat %v: %v", cmd.Id, cmd.ChatId, err)
+		return ephemeralReply(fmt.Sprintf("The command /%v has failed", cmd.Name))
+	}
+
+	response := dto.SlashCommandResponse{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &response); err != nil {
+			mc.lgr.WithTracing(ctx).Warnf("Unable to parse the response of command %v of chat %v: %v", cmd.Id, cmd.ChatId, err)
+			return ephemeralReply(fmt.Sprintf("The command /%v has responded with the wrong body", cmd.Name))
+		}
+	}
+
+	if strings.TrimSpace(response.Text) == "" {
+		return &slashCommandResult{}
+	}
+	if response.ResponseType == dto.SlashCommandResponseVisible {
+		return &slashCommandResult{botMessage: &response.Text}
+	}
+	text, err := TrimAmdSanitizeMessage(ctx, mc.lgr, mc.policy, response.Text)
+	if err != nil {
+		mc.lgr.WithTracing(ctx).Warnf("Unable to sanitize the response of command %v of chat %v: %v", cmd.Id, cmd.ChatId, err)
+		return ephemeralReply(fmt.Sprintf("The command /%v has responded with the wrong body", cmd.Name))
+	}
+	return &slashCommandResult{ephemeral: &text}
+}
+
+// is called from PostMessage, returns false in case the message should be posted the regular way
+// the input is replaced in case the command is turned into the regular message of the invoker
+func (mc *MessageHandler) handleSlashCommand(c echo.Context, chatId int64, input *CreateMessageDto, principal *auth.AuthResult) (bool, error) {
+	invocation := mc.parseSlashCommandInvocation(input, chatId, principal)
+	if invocation == nil {
+		return false, nil
+	}
+
+	result, err := mc.invokeSlashCommand(c.Request().Context(), invocation)
+	if err != nil {
+		if handled, err := respondCreateMessageError(c, err); handled {
+			return true, err
+		}
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during invoking command %v: %v", invocation.name, err)
+		return true, err
+	}
+	if result == nil {
+		return false, nil
+	}
+
+	if result.ephemeral != nil {
+		mc.notificator.NotifyAboutEphemeralMessage(c.Request().Context(), chatId, principal.UserId, &dto.EphemeralMessageNotification{
+			Command:  invocation.name,
+			Text:     *result.ephemeral,
+			ThreadId: invocation.threadId,
+		})
+	}
+
+	if result.message != nil {
+		*input = *result.message
+		return false, nil
+	}
+
+	// the command was consumed, so the draft it was typed in isn't needed anymore
+	_ = mc.clearMessageDraft(c.Request().Context(), chatId, principal.UserId)
+
+	if result.botMessage != nil {
+		return true, mc.postSlashCommandBotMessage(c, invocation, *result.botMessage)
+	}
+
+	return true, c.JSON(http.StatusOK, &utils.H{"command": invocation.name})
+}
+
+// the name of the command is used as the login in the notifications
+func (mc *MessageHandler) postSlashCommandBotMessage(c echo.Context, invocation *slashCommandInvocation, text string) error {
+	messageId, err := db.TransactWithResult(c.Request().Context(), mc.db, func(tx *db.Tx) (int64, error) {
+		return mc.createBotMessage(c.Request().Context(), tx, invocation.chatId, invocation.threadId, text)
+	})
+	if err != nil {
+		if handled, err := respondCreateMessageError(c, err); handled {
+			return err
+		}
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", err)
+		return err
+	}
+
+	botPrincipal := &auth.AuthResult{
+		UserId:    WebhookBotUser,
+		UserLogin: invocation.name,
+	}
+	err = mc.notifyAboutCreatedMessage(c.Request().Context(), invocation.chatId, invocation.threadId, messageId, botPrincipal)
+	if err != nil {
+		mc.lgr.WithTracing(c.Request().Context()).Errorf("Error during act transaction %v", err)
+		return err
+	}
+	mc.fillLinkPreviewAsync(c.Request().Context(), invocation.chatId, messageId)
+	return c.JSON(http.StatusCreated, &utils.H{"id": messageId})
+}
+
+func convertToChatSlashCommandDto(cmd *db.ChatSlashCommand) *dto.ChatSlashCommandDto {
+	return &dto.ChatSlashCommandDto{
+		Id:             cmd.Id,
+		ChatId:         cmd.ChatId,
+		Name:           cmd.Name,
+		Description:    cmd.Description,
+		Url:            cmd.Url,
+		Secret:         cmd.Secret,
+		OwnerId:        cmd.OwnerId,
+		CreateDateTime: cmd.CreateDateTime,
+	}
+}
+
+func (ch *ChatHandler) CreateChatSlashCommand(c echo.Context) error {
+	var bindTo = new(ChatSlashCommandRequestDto)
+	if err := c.Bind(bindTo); err != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, ch.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	secret, err := generateWebhookToken()
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		existing, err := tx.GetChatSlashCommandByName(c.Request().Context(), chatId, bindTo.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return c.JSON(http.StatusConflict, &utils.H{"message": "The command with the same name already exists"})
+		}
+
+		cmd, err := tx.CreateChatSlashCommand(c.Request().Context(), chatId, userPrincipalDto.UserId, bindTo.Name, bindTo.Description, bindTo.Url, secret)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, convertToChatSlashCommandDto(cmd))
+	})
+}
+
+// the secret stays the same, in order to change it the command should be recreated
+func (ch *ChatHandler) EditChatSlashCommand(c echo.Context) error {
+	var bindTo = new(ChatSlashCommandRequestDto)
+	if err := c.Bind(bindTo); err != nil {
+		ch.lgr.WithTracing(c.Request().Context()).Warnf("Error during binding to dto %v", err)
+		return err
+	}
+	if valid, err := ValidateAndRespondError(c, ch.lgr, bindTo); err != nil || !valid {
+		return err
+	}
+
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	commandId, err := GetPathParamAsInt64(c, "commandId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		existing, err := tx.GetChatSlashCommandByName(c.Request().Context(), chatId, bindTo.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.Id != commandId {
+			return c.JSON(http.StatusConflict, &utils.H{"message": "The command with the same name already exists"})
+		}
+
+		cmd, err := tx.EditChatSlashCommand(c.Request().Context(), chatId, commandId, bindTo.Name, bindTo.Description, bindTo.Url)
+		if err != nil {
+			return err
+		}
+		if cmd == nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		return c.JSON(http.StatusOK, convertToChatSlashCommandDto(cmd))
+	})
+}
+
+func (ch *ChatHandler) GetChatSlashCommands(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	page := utils.FixPageString(c.QueryParam("page"))
+	size := utils.FixSizeString(c.QueryParam("size"))
+	offset := utils.GetOffset(page, size)
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		commands, err := tx.GetChatSlashCommands(c.Request().Context(), chatId, size, offset)
+		if err != nil {
+			return err
+		}
+
+		commandDtos := make([]*dto.ChatSlashCommandDto, 0)
+		for _, cmd := range commands {
+			commandDtos = append(commandDtos, convertToChatSlashCommandDto(cmd))
+		}
+
+		count, err := tx.GetChatSlashCommandsCount(c.Request().Context(), chatId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, ChatSlashCommandsWrapper{
+			Data:  commandDtos,
+			Count: count,
+		})
+	})
+}
+
+func (ch *ChatHandler) DeleteChatSlashCommand(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	commandId, err := GetPathParamAsInt64(c, "commandId")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		allowed, err := hasChatPermission(c.Request().Context(), tx, userPrincipalDto.UserId, chatId, dto.PermissionManageChat)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		deleted, err := tx.DeleteChatSlashCommand(c.Request().Context(), chatId, commandId)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+}
+
+// the built-in and the registered commands for the autocompletion, the endpoints of the latter aren't exposed
+func (ch *ChatHandler) GetAvailableSlashCommands(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		ch.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := GetPathParamAsInt64(c, "id")
+	if err != nil {
+		return err
+	}
+
+	return db.Transact(c.Request().Context(), ch.db, func(tx *db.Tx) error {
+		isParticipant, err := tx.IsParticipant(c.Request().Context(), userPrincipalDto.UserId, chatId)
+		if err != nil {
+			return err
+		}
+		if !isParticipant {
+			return c.JSON(http.StatusUnauthorized, &utils.H{"message": "You have no access to this chat"})
+		}
+
+		commandDtos := make([]*dto.AvailableSlashCommandDto, 0)
+		for _, cmd := range builtInSlashCommands {
+			commandDtos = append(commandDtos, &dto.AvailableSlashCommandDto{
+				Name:        cmd.name,
+				Description: cmd.description,
+				Usage:       cmd.usage,
+				BuiltIn:     true,
+			})
+		}
+
+		commands, err := tx.GetChatSlashCommands(c.Request().Context(), chatId, utils.DefaultSize, 0)
+		if err != nil {
+			return err
+		}
+		for _, cmd := range commands {
+			commandDtos = append(commandDtos, &dto.AvailableSlashCommandDto{
+				Name:        cmd.Name,
+				Description: cmd.Description,
+				Usage:       "/" + cmd.Name + " [text]",
+			})
+		}
+
+		return c.JSON(http.StatusOK, AvailableSlashCommandsWrapper{
+			Data: commandDtos,
+		})
+	})
+}
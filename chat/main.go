@@ -66,7 +66,25 @@ func main() {
 			tasks.Scheduler,
 			tasks.CleanChatsOfDeletedUserScheduler,
 			tasks.NewCleanChatsOfDeletedUserService,
+			tasks.SendScheduledMessagesScheduler,
+			tasks.NewSendScheduledMessagesService,
+			tasks.PurgeDeletedMessagesScheduler,
+			tasks.NewPurgeDeletedMessagesService,
+			tasks.ExportChatsScheduler,
+			tasks.NewExportChatsService,
+			tasks.MessageRetentionScheduler,
+			tasks.NewMessageRetentionService,
+			tasks.CleanLinkPreviewCacheScheduler,
+			tasks.NewCleanLinkPreviewCacheService,
+			tasks.SendOutgoingWebhooksScheduler,
+			tasks.NewSendOutgoingWebhooksService,
+			tasks.SendMessageRemindersScheduler,
+			tasks.NewSendMessageRemindersService,
 			services.NewEvents,
+			services.NewLinkPreviewService,
+			services.NewRateLimiter,
+			services.NewContentFilter,
+			services.NewOutgoingWebhookSender,
 			producer.NewRabbitEventsPublisher,
 			producer.NewRabbitNotificationsPublisher,
 			listener.CreateAaaUserProfileUpdateListener,
@@ -198,6 +216,13 @@ func configureEcho(
 	e.GET("/api/chat/:id", ch.GetChat)
 	e.POST("/api/chat/fresh", ch.IsFreshChatsPage)
 	e.POST("/api/chat", ch.CreateChat)
+	e.POST("/api/chat/import", ch.ImportChat)
+	e.GET("/api/chat/import/user-mapping", ch.GetChatImportUserMappings)
+	e.PUT("/api/chat/import/user-mapping", ch.PutChatImportUserMapping)
+	e.DELETE("/api/chat/import/user-mapping", ch.DeleteChatImportUserMapping)
+	e.GET("/api/chat/import/user-mapping/incoming", ch.GetIncomingChatImportUserMappings)
+	e.PUT("/api/chat/import/user-mapping/incoming", ch.ConfirmChatImportUserMapping)
+	e.DELETE("/api/chat/import/user-mapping/incoming", ch.DeclineChatImportUserMapping)
 	e.DELETE("/api/chat/:id", ch.DeleteChat)
 	e.PUT("/api/chat", ch.EditChat)
 	e.PUT("/api/chat/:id/leave", ch.LeaveChat)
@@ -212,12 +237,37 @@ func configureEcho(
 	e.DELETE("/internal/delete-all-participants", ch.RemoveAllParticipants)
 	e.GET("/internal/does-participant-belong-to-chat", ch.DoesParticipantBelongToChat)
 	e.GET("/api/chat/:id/mention/suggest", ch.SearchForUsersToMention)
+	e.POST("/api/chat/:id/export", ch.ExportChat)
+	e.GET("/api/chat/:id/export", ch.GetChatExports)
+	e.POST("/api/chat/:id/invite-link", ch.CreateChatInviteLink)
+	e.GET("/api/chat/:id/invite-link", ch.GetChatInviteLinks)
+	e.DELETE("/api/chat/:id/invite-link/:linkId", ch.RevokeChatInviteLink)
+	e.PUT("/api/chat/invite/:token/join", ch.JoinChatByInviteLink)
+	e.PUT("/api/chat/:id/join-request", ch.RequestToJoinChat)
+	e.GET("/api/chat/:id/join-request", ch.GetChatJoinRequests)
+	e.PUT("/api/chat/:id/join-request/:requestId/approve", ch.ApproveChatJoinRequest)
+	e.PUT("/api/chat/:id/join-request/:requestId/reject", ch.RejectChatJoinRequest)
+	e.PUT("/api/chat/:id/ban/:participantId", ch.BanParticipant)
+	e.GET("/api/chat/:id/ban", ch.GetChatBans)
+	e.DELETE("/api/chat/:id/ban/:participantId", ch.UnbanParticipant)
+	e.POST("/api/chat/:id/webhook/incoming", ch.CreateChatIncomingWebhook)
+	e.GET("/api/chat/:id/webhook/incoming", ch.GetChatIncomingWebhooks)
+	e.DELETE("/api/chat/:id/webhook/incoming/:webhookId", ch.DeleteChatIncomingWebhook)
+	e.POST("/api/chat/:id/webhook/outgoing", ch.CreateChatOutgoingWebhook)
+	e.GET("/api/chat/:id/webhook/outgoing", ch.GetChatOutgoingWebhooks)
+	e.PUT("/api/chat/:id/webhook/outgoing/:webhookId", ch.EditChatOutgoingWebhook)
+	e.DELETE("/api/chat/:id/webhook/outgoing/:webhookId", ch.DeleteChatOutgoingWebhook)
+	e.POST("/api/chat/:id/command", ch.CreateChatSlashCommand)
+	e.GET("/api/chat/:id/command", ch.GetChatSlashCommands)
+	e.GET("/api/chat/:id/command/available", ch.GetAvailableSlashCommands)
+	e.PUT("/api/chat/:id/command/:commandId", ch.EditChatSlashCommand)
+	e.DELETE("/api/chat/:id/command/:commandId", ch.DeleteChatSlashCommand)
 	e.GET("/api/chat/can-create-blog", ch.CanCreateBlog)
 	e.PUT("/api/chat/tet-a-tet/:participantId", ch.TetATet)
 	e.PUT("/api/chat/public/preview-without-html", ch.CreatePreview)
 	e.GET("/internal/access", ch.CheckAccess)
 	e.GET("/internal/participant-ids", ch.GetChatParticipants)
-	e.GET("/internal/is-admin", ch.IsAdmin)
+	e.GET("/internal/has-permission", ch.HasPermission)
 	e.GET("/internal/does-chats-exist", ch.IsExists)
 	e.GET("/internal/name-for-invite", ch.GetNameForInvite)
 	e.GET("/internal/basic/:id", ch.GetBasicInfo)
@@ -226,17 +276,56 @@ func configureEcho(
 	e.GET("/api/chat/:id/notification", ch.GetUserChatNotificationSettings)
 
 	e.GET("/api/chat/:id/message/search", mc.GetMessages)
+	e.POST("/api/chat/message/search", mc.SearchMessagesGlobally)
 	e.GET("/api/chat/:id/message/:messageId", mc.GetMessage)
 	e.POST("/api/chat/:id/message/fresh", mc.IsFreshMessagesPage)
 	e.PUT("/api/chat/:id/message/:messageId/reaction", mc.ReactionMessage)
+	e.PUT("/api/chat/:id/message/:messageId/poll/vote", mc.VotePoll)
+	e.PUT("/api/chat/:id/message/:messageId/poll/close", mc.ClosePoll)
 	e.POST("/api/chat/:id/message", mc.PostMessage)
 	e.PUT("/api/chat/:id/message", mc.EditMessage)
 	e.POST("/api/chat/:id/message/filter", mc.Filter)
 	e.PUT("/api/chat/:id/message/file-item-uuid", mc.SetFileItemUuid)
 	e.DELETE("/api/chat/:id/message/:messageId", mc.DeleteMessage)
+	e.PUT("/api/chat/:id/message/:messageId/restore", mc.RestoreMessage)
 	e.PUT("/api/chat/:id/message/read/:messageId", mc.ReadMessage)
 	e.GET("/api/chat/:id/message/read/:messageId", mc.GetReadMessageUsers)
 	e.GET("/api/chat/:id/message/find-by-file-item-uuid/:fileItemUuid", mc.FindMessageByFileItemUuid)
+	e.GET("/api/chat/:id/message/scheduled", mc.GetScheduledMessages)
+	e.POST("/api/chat/:id/message/scheduled", mc.PostScheduledMessage)
+	e.PUT("/api/chat/:id/message/scheduled", mc.EditScheduledMessage)
+	e.DELETE("/api/chat/:id/message/scheduled/:scheduledMessageId", mc.DeleteScheduledMessage)
+	e.GET("/api/chat/:id/message/draft", mc.GetMessageDraft)
+	e.PUT("/api/chat/:id/message/draft", mc.PutMessageDraft)
+	e.DELETE("/api/chat/:id/message/draft", mc.DeleteMessageDraft)
+	e.GET("/api/chat/:id/message/:messageId/thread", mc.GetThreadMessages)
+	e.PUT("/api/chat/:id/message/:messageId/thread/read/:replyId", mc.ReadThreadMessage)
+	e.GET("/api/chat/:id/message/:messageId/revision", mc.GetMessageRevisions)
+	e.GET("/api/chat/:id/message/:messageId/revision/:revisionId", mc.GetMessageRevision)
+	e.PUT("/api/chat/:id/message/:messageId/bookmark", mc.PutMessageBookmark)
+	e.DELETE("/api/chat/:id/message/:messageId/bookmark", mc.DeleteMessageBookmark)
+	e.GET("/api/chat/message/bookmark", mc.GetMessageBookmarks)
+	e.POST("/api/chat/:id/message/:messageId/reminder", mc.CreateMessageReminder)
+	e.GET("/api/chat/message/reminder", mc.GetMessageReminders)
+	e.PUT("/api/chat/message/reminder/:reminderId/snooze", mc.SnoozeMessageReminder)
+	e.DELETE("/api/chat/message/reminder/:reminderId", mc.DeleteMessageReminder)
+	e.POST("/api/chat/:id/message/:messageId/report", mc.ReportMessage)
+	e.GET("/api/chat/:id/report", mc.GetChatMessageReports)
+	e.GET("/api/chat/report", mc.GetAllMessageReports)
+	e.PUT("/api/chat/:id/report/:reportId/resolve", mc.ResolveMessageReport)
+	e.GET("/api/chat/:id/moderation-action", mc.GetModerationActions)
+	e.GET("/api/chat/:id/blocklist", mc.GetBlocklistEntries)
+	e.POST("/api/chat/:id/blocklist", mc.CreateBlocklistEntry)
+	e.DELETE("/api/chat/:id/blocklist/:entryId", mc.DeleteBlocklistEntry)
+	e.GET("/api/chat/blocklist", mc.GetBlocklistEntries)
+	e.POST("/api/chat/blocklist", mc.CreateBlocklistEntry)
+	e.DELETE("/api/chat/blocklist/:entryId", mc.DeleteBlocklistEntry)
+	e.GET("/api/chat/:id/held-message", mc.GetHeldMessages)
+	e.PUT("/api/chat/:id/held-message/:heldMessageId/approve", mc.ApproveHeldMessage)
+	e.PUT("/api/chat/:id/held-message/:heldMessageId/reject", mc.RejectHeldMessage)
+	e.GET("/api/chat/:id/emoji", mc.GetChatEmojis)
+	e.POST("/api/chat/:id/emoji", mc.CreateChatEmoji)
+	e.DELETE("/api/chat/:id/emoji/:emojiId", mc.DeleteChatEmoji)
 
 	e.PUT("/api/chat/:id/typing", mc.TypeMessage)
 	e.PUT("/api/chat/:id/broadcast", mc.BroadcastMessage)
@@ -249,6 +338,7 @@ func configureEcho(
 	e.PUT("/api/chat/:id/message/:messageId/publish", mc.PublishMessage)
 	e.GET("/api/chat/:id/message/publish", mc.GetPublishedMessages)
 	e.GET("/api/chat/public/:id/message/:messageId", mc.GetPublishedMessage)
+	e.POST("/api/chat/public/webhook/:token", mc.PostMessageByIncomingWebhook)
 
 	e.PUT("/api/chat/:id/read", ch.MarkAsRead)
 	e.PUT("/api/chat/read", ch.MarkAsReadAll)
@@ -258,6 +348,10 @@ func configureEcho(
 	e.GET("/internal/blog/seo", bh.GetAllBlogPostsForSeo)
 	e.GET("/api/blog/:id", bh.GetBlogPost)
 	e.GET("/api/blog/:id/comment", bh.GetBlogPostComments)
+	e.GET("/api/blog/feed/atom", bh.GetBlogPostsAtomFeed)
+	e.GET("/api/blog/feed/rss", bh.GetBlogPostsRssFeed)
+	e.GET("/api/blog/:id/comment/feed/atom", bh.GetBlogPostCommentsAtomFeed)
+	e.GET("/api/blog/:id/comment/feed/rss", bh.GetBlogPostCommentsRssFeed)
 
 	lc.Append(fx.Hook{
 		OnStop: func(ctx context.Context) error {
@@ -335,19 +429,28 @@ func runScheduler(
 	lgr *logger.Logger,
 	scheduler *dcron.Cron,
 	ct *tasks.CleanChatsOfDeletedUserTask,
+	sst *tasks.SendScheduledMessagesTask,
+	pdt *tasks.PurgeDeletedMessagesTask,
+	ect *tasks.ExportChatsTask,
+	mrt *tasks.MessageRetentionTask,
+	clpt *tasks.CleanLinkPreviewCacheTask,
+	owt *tasks.SendOutgoingWebhooksTask,
+	smrt *tasks.SendMessageRemindersTask,
 	lc fx.Lifecycle,
 ) error {
 	scheduler.Start()
 	lgr.Infof("Scheduler started")
 
-	if viper.GetBool("schedulers." + ct.Key() + ".enabled") {
-		lgr.Infof("Adding task " + ct.Key() + " to scheduler")
-		err := scheduler.AddJobs(ct)
-		if err != nil {
-			return err
+	for _, task := range []dcron.Job{ct, sst, pdt, ect, mrt, clpt, owt, smrt} {
+		if viper.GetBool("schedulers." + task.Key() + ".enabled") {
+			lgr.Infof("Adding task " + task.Key() + " to scheduler")
+			err := scheduler.AddJobs(task)
+			if err != nil {
+				return err
+			}
+		} else {
+			lgr.Infof("Task " + task.Key() + " is disabled")
 		}
-	} else {
-		lgr.Infof("Task " + ct.Key() + " is disabled")
 	}
 
 	lc.Append(fx.Hook{
@@ -10,6 +10,7 @@ import (
 	"github.com/guregu/null"
 	"github.com/labstack/echo/v4"
 	"github.com/oliveagle/jsonpath"
+	redisV9 "github.com/redis/go-redis/v9"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/fx"
@@ -17,6 +18,7 @@ import (
 	"go.uber.org/fx/fxtest"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	test "net/http/httptest"
 	"net/url"
@@ -29,6 +31,7 @@ import (
 	"nkonev.name/chat/producer"
 	myRabbitmq "nkonev.name/chat/rabbitmq"
 	"nkonev.name/chat/services"
+	"nkonev.name/chat/tasks"
 	"nkonev.name/chat/utils"
 	"os"
 	"strings"
@@ -234,6 +237,11 @@ func runTest(t *testing.T, testFunc interface{}) *fxtest.App {
 			configureTestMigrations,
 			db.ConfigureDb,
 			services.NewEvents,
+			services.NewLinkPreviewService,
+			services.NewRateLimiter,
+			services.NewContentFilter,
+			services.NewOutgoingWebhookSender,
+			tasks.RedisV9,
 			producer.NewRabbitEventsPublisher,
 			producer.NewRabbitNotificationsPublisher,
 			myRabbitmq.CreateRabbitMqConnection,
@@ -273,6 +281,11 @@ func startAppFull(t *testing.T) (*fxtest.App, fx.Shutdowner) {
 			configureTestMigrations,
 			db.ConfigureDb,
 			services.NewEvents,
+			services.NewLinkPreviewService,
+			services.NewRateLimiter,
+			services.NewContentFilter,
+			services.NewOutgoingWebhookSender,
+			tasks.RedisV9,
 			producer.NewRabbitEventsPublisher,
 			producer.NewRabbitNotificationsPublisher,
 			myRabbitmq.CreateRabbitMqConnection,
@@ -673,6 +686,88 @@ func TestGetMessagesPaginatedSearch(t *testing.T) {
 	})
 }
 
+func TestGetMessagesRankedSearch(t *testing.T) {
+	runTest(t, func(e *echo.Echo) {
+		c, b, _ := request("POST", "/api/chat", strings.NewReader(`{"name": "Ranked search chat"}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		for _, text := range []string{"Just one pineapple here", "Pineapple, pineapple and once more pineapple", "Nothing relevant"} {
+			c1, _, _ := request("POST", "/api/chat/"+chatIdString+"/message", strings.NewReader(`{"text": "`+text+`"}`), e)
+			assert.Equal(t, http.StatusCreated, c1)
+		}
+
+		httpResult, body, _ := request("GET", "/api/chat/"+chatIdString+"/message/search?rank=true&size=10&searchString=pineap", nil, e)
+		assert.Equal(t, http.StatusOK, httpResult)
+
+		resultWrapper := new(handlers.MessagesResponseDto)
+		err := json.Unmarshal([]byte(body), resultWrapper)
+		assert.NoError(t, err)
+		result := resultWrapper.Items
+
+		// the most relevant goes first regardless of the creation order
+		assert.Equal(t, 2, len(result))
+		assert.Equal(t, "Pineapple, pineapple and once more pineapple", result[0].Text)
+		assert.Equal(t, "Just one pineapple here", result[1].Text)
+		for _, item := range result {
+			assert.NotNil(t, item.Highlight)
+			assert.Contains(t, *item.Highlight, "<mark>")
+		}
+	})
+}
+
+func TestSearchMessagesGlobally(t *testing.T) {
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo) {
+		var chatIds []string
+		for _, name := range []string{"Global search chat one", "Global search chat two"} {
+			c, b, _ := request("POST", "/api/chat", strings.NewReader(`{"name": "`+name+`"}`), e)
+			assert.Equal(t, http.StatusCreated, c)
+			chatIds = append(chatIds, utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{})))
+		}
+		for _, chatIdString := range chatIds {
+			c1, _, _ := request("POST", "/api/chat/"+chatIdString+"/message", strings.NewReader(`{"text": "Somewhere is a watermelon"}`), e)
+			assert.Equal(t, http.StatusCreated, c1)
+		}
+
+		// the chat of the other user isn't searched
+		c2, b2, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Foreign chat"}`), e)
+		assert.Equal(t, http.StatusCreated, c2)
+		foreignChatIdString := utils.InterfaceToString(getJsonPathResult(t, b2, "$.id").(interface{}))
+		c3, _, _ := requestWithHeader("POST", "/api/chat/"+foreignChatIdString+"/message", h2, strings.NewReader(`{"text": "The foreign watermelon"}`), e)
+		assert.Equal(t, http.StatusCreated, c3)
+
+		httpResult, body, _ := request("POST", "/api/chat/message/search", strings.NewReader(`{"searchString": "watermel", "size": 10}`), e)
+		assert.Equal(t, http.StatusOK, httpResult)
+
+		resultWrapper := new(handlers.SearchMessagesGloballyResponseDto)
+		err := json.Unmarshal([]byte(body), resultWrapper)
+		assert.NoError(t, err)
+		result := resultWrapper.Items
+
+		// the newest goes first
+		assert.Equal(t, 2, len(result))
+		assert.Equal(t, chatIds[1], utils.Int64ToString(result[0].ChatId))
+		assert.Equal(t, chatIds[0], utils.Int64ToString(result[1].ChatId))
+		assert.Contains(t, result[0].Highlight, "<mark>")
+
+		// the second page starts after the first item
+		httpResult2, body2, _ := request("POST", "/api/chat/message/search", strings.NewReader(`{"searchString": "watermel", "size": 10, "startingFromItemId": {"createDateTime": "`+result[0].CreateDateTime.Format(time.RFC3339Nano)+`", "chatId": `+chatIds[1]+`, "messageId": `+utils.Int64ToString(result[0].MessageId)+`}}`), e)
+		assert.Equal(t, http.StatusOK, httpResult2)
+		resultWrapper2 := new(handlers.SearchMessagesGloballyResponseDto)
+		err = json.Unmarshal([]byte(body2), resultWrapper2)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(resultWrapper2.Items))
+		assert.Equal(t, chatIds[0], utils.Int64ToString(resultWrapper2.Items[0].ChatId))
+	})
+}
+
 func TestMessageValidation(t *testing.T) {
 	runTest(t, func(e *echo.Echo, db *db.DB) {
 		c, b, _ := request("POST", "/api/chat/1/message", strings.NewReader(`{"text": ""}`), e)
@@ -721,6 +816,130 @@ func TestMessageCrud(t *testing.T) {
 		assert.Equal(t, http.StatusAccepted, c1)
 		messagesAfterDelete, _ := db.CountMessages(context.Background())
 		assert.Equal(t, messagesBefore, messagesAfterDelete)
+
+		// the tombstone is left
+		c6, b6, _ := request("GET", "/api/chat/1/message/"+idString, nil, e)
+		assert.Equal(t, http.StatusOK, c6)
+		assert.NotEmpty(t, utils.InterfaceToString(getJsonPathResult(t, b6, "$.deletedDateTime").(interface{})))
+	})
+}
+
+func TestReplyToDeletedThreadRoot(t *testing.T) {
+	runTest(t, func(e *echo.Echo) {
+		c, b, _ := request("POST", "/api/chat/1/message", strings.NewReader(`{"text": "The root of the thread"}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		rootIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c1, _, _ := request("POST", "/api/chat/1/message", strings.NewReader(`{"text": "The first reply", "threadId": `+rootIdString+`}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+
+		c2, _, _ := request("DELETE", "/api/chat/1/message/"+rootIdString, nil, e)
+		assert.Equal(t, http.StatusAccepted, c2)
+
+		// the tombstone can't take the new replies
+		c3, _, _ := request("POST", "/api/chat/1/message", strings.NewReader(`{"text": "The late reply", "threadId": `+rootIdString+`}`), e)
+		assert.Equal(t, http.StatusBadRequest, c3)
+	})
+}
+
+func TestRestoreMessageKeepsPinAndPublication(t *testing.T) {
+	runTest(t, func(e *echo.Echo) {
+		c, b, _ := request("POST", "/api/chat", strings.NewReader(`{"name": "Chat with restored message"}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c1, b1, _ := request("POST", "/api/chat/"+chatIdString+"/message", strings.NewReader(`{"text": "The important message"}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+		idString := utils.InterfaceToString(getJsonPathResult(t, b1, "$.id").(interface{}))
+
+		c2, _, _ := request("PUT", "/api/chat/"+chatIdString+"/message/"+idString+"/pin?pin=true", nil, e)
+		assert.Equal(t, http.StatusOK, c2)
+		c3, _, _ := request("PUT", "/api/chat/"+chatIdString+"/message/"+idString+"/publish?publish=true", nil, e)
+		assert.Equal(t, http.StatusOK, c3)
+
+		c4, _, _ := request("DELETE", "/api/chat/"+chatIdString+"/message/"+idString, nil, e)
+		assert.Equal(t, http.StatusAccepted, c4)
+		c5, b5, _ := request("GET", "/api/chat/"+chatIdString+"/message/"+idString, nil, e)
+		assert.Equal(t, http.StatusOK, c5)
+		assert.False(t, getJsonPathResult(t, b5, "$.pinned").(bool))
+		assert.False(t, getJsonPathResult(t, b5, "$.published").(bool))
+
+		c6, b6, _ := request("PUT", "/api/chat/"+chatIdString+"/message/"+idString+"/restore", nil, e)
+		assert.Equal(t, http.StatusOK, c6)
+		assert.True(t, getJsonPathResult(t, b6, "$.pinned").(bool))
+		assert.True(t, getJsonPathResult(t, b6, "$.published").(bool))
+	})
+}
+
+func TestChatImportWithConfirmedMapping(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo) {
+		// Bob is mapped onto tester2 who has to confirm it
+		c1, b1, _ := requestWithHeader("PUT", "/api/chat/import/user-mapping", h1, strings.NewReader(`{"source": "telegram", "externalUserId": "user11", "userId": 2}`), e)
+		assert.Equal(t, http.StatusOK, c1)
+		assert.False(t, getJsonPathResult(t, b1, "$.confirmed").(bool))
+		c2, b2, _ := requestWithHeader("PUT", "/api/chat/import/user-mapping", h1, strings.NewReader(`{"source": "telegram", "externalUserId": "user10"}`), e)
+		assert.Equal(t, http.StatusOK, c2)
+		assert.True(t, getJsonPathResult(t, b2, "$.confirmed").(bool))
+
+		c3, b3, _ := requestWithHeader("GET", "/api/chat/import/user-mapping/incoming", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c3)
+		assert.Equal(t, "user11", getJsonPathResult(t, b3, "$.items[0].externalUserId").(string))
+		c4, _, _ := requestWithHeader("PUT", "/api/chat/import/user-mapping/incoming", h2, strings.NewReader(`{"ownerId": 1, "source": "telegram", "externalUserId": "user11"}`), e)
+		assert.Equal(t, http.StatusOK, c4)
+
+		archive := `{"name": "Imported team", "id": 4242, "messages": [
+			{"id": 1, "type": "message", "date_unixtime": "1672567200", "from": "Alice", "from_id": "user10", "text_entities": [{"type": "plain", "text": "From Alice"}]},
+			{"id": 2, "type": "message", "date_unixtime": "1672567260", "from": "Bob", "from_id": "user11", "text_entities": [{"type": "plain", "text": "From Bob"}]},
+			{"id": 3, "type": "message", "date_unixtime": "1672567320", "from": "Carol", "from_id": "user12", "text_entities": [{"type": "plain", "text": "From Carol"}]}
+		]}`
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "result.json")
+		assert.NoError(t, err)
+		_, err = part.Write([]byte(archive))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+		hMultipart := map[string][]string{
+			echo.HeaderContentType: {writer.FormDataContentType()},
+			"X-Auth-Expiresin":     {"1590022342295000"},
+			"X-Auth-Username":      {userTester}, // tester
+			"X-Auth-Userid":        {"1"},
+		}
+		c5, b5, _ := requestWithHeader("POST", "/api/chat/import", hMultipart, body, e)
+		assert.Equal(t, http.StatusCreated, c5)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b5, "$.chatId").(interface{}))
+		// the placeholder doesn't clash with the special users
+		assert.Equal(t, "Carol", getJsonPathResult(t, b5, "$.unmappedAuthors[0].name").(string))
+		assert.True(t, getJsonPathResult(t, b5, "$.unmappedAuthors[0].placeholderId").(float64) <= handlers.ImportedUserPlaceholderMax)
+
+		// tester2 has joined together with the messages of Bob
+		c6, b6, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/message?size=10", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c6)
+		messagesWrapper := new(handlers.MessagesResponseDto)
+		assert.NoError(t, json.Unmarshal([]byte(b6), messagesWrapper))
+		var owners = map[string]int64{}
+		var logins = map[string]string{}
+		for _, m := range messagesWrapper.Items {
+			owners[m.Text] = m.OwnerId
+			logins[m.Text] = m.Owner.Login
+		}
+		assert.Equal(t, int64(1), owners["<p>From Alice</p>"])
+		assert.Equal(t, int64(2), owners["<p>From Bob</p>"])
+		assert.True(t, owners["<p>From Carol</p>"] <= handlers.ImportedUserPlaceholderMax)
+		assert.Equal(t, "Carol", logins["<p>From Carol</p>"])
 	})
 }
 
@@ -740,6 +959,37 @@ func TestMessageIsSanitized(t *testing.T) {
 	})
 }
 
+func TestMessagePoll(t *testing.T) {
+	runTest(t, func(e *echo.Echo, db *db.DB) {
+		c, b, _ := request("POST", "/api/chat/1/message", strings.NewReader(`{"text": "Where do we go?", "poll": {"options": ["Cinema", "Park"]}}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		idString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c1, b1, _ := request("GET", "/api/chat/1/message/"+idString, nil, e)
+		assert.Equal(t, http.StatusOK, c1)
+		firstOptionId := utils.InterfaceToString(getJsonPathResult(t, b1, "$.poll.options[0].id").(interface{}))
+		secondOptionId := utils.InterfaceToString(getJsonPathResult(t, b1, "$.poll.options[1].id").(interface{}))
+
+		c2, _, _ := request("PUT", "/api/chat/1/message/"+idString+"/poll/vote", strings.NewReader(`{"optionIds": [`+firstOptionId+`, `+secondOptionId+`]}`), e)
+		assert.Equal(t, http.StatusBadRequest, c2)
+
+		c3, _, _ := request("PUT", "/api/chat/1/message/"+idString+"/poll/vote", strings.NewReader(`{"optionIds": [`+secondOptionId+`]}`), e)
+		assert.Equal(t, http.StatusOK, c3)
+
+		c4, b4, _ := request("GET", "/api/chat/1/message/"+idString, nil, e)
+		assert.Equal(t, http.StatusOK, c4)
+		assert.Equal(t, "1", utils.InterfaceToString(getJsonPathResult(t, b4, "$.poll.options[1].count").(interface{})))
+		assert.Equal(t, true, getJsonPathResult(t, b4, "$.poll.options[1].voted").(interface{}))
+		assert.Equal(t, "1", utils.InterfaceToString(getJsonPathResult(t, b4, "$.poll.totalVoters").(interface{})))
+
+		c5, _, _ := request("PUT", "/api/chat/1/message/"+idString+"/poll/close", nil, e)
+		assert.Equal(t, http.StatusOK, c5)
+
+		c6, _, _ := request("PUT", "/api/chat/1/message/"+idString+"/poll/vote", strings.NewReader(`{"optionIds": [`+firstOptionId+`]}`), e)
+		assert.Equal(t, http.StatusBadRequest, c6)
+	})
+}
+
 func TestNotPossibleToWriteAMessageWithNotAllowedMediaUrl(t *testing.T) {
 	runTest(t, func(e *echo.Echo, db *db.DB) {
 		c, b, _ := request("POST", "/api/chat/1/message", strings.NewReader(`{"text": "<img src=\"http://malicious.example.com/virus.jpg\"> Lorem ipsum"}`), e)
@@ -799,6 +1049,737 @@ func TestItIsNotPossibleToWriteToForeignChat(t *testing.T) {
 	})
 }
 
+func TestChatInviteLink(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo, db *db.DB) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Chat with invite link"}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		// not an admin
+		c0, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/invite-link", h1, strings.NewReader(`{}`), e)
+		assert.Equal(t, http.StatusUnauthorized, c0)
+
+		c1, b1, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/invite-link", h2, strings.NewReader(`{"maxUses": 1}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+		token := utils.InterfaceToString(getJsonPathResult(t, b1, "$.token").(interface{}))
+		linkIdString := utils.InterfaceToString(getJsonPathResult(t, b1, "$.id").(interface{}))
+
+		c2, b2, _ := requestWithHeader("PUT", "/api/chat/invite/"+token+"/join", h1, nil, e)
+		assert.Equal(t, http.StatusOK, c2)
+		assert.Equal(t, chatIdString, utils.InterfaceToString(getJsonPathResult(t, b2, "$.chatId").(interface{})))
+
+		// the participant joins again without consuming the link
+		c3, _, _ := requestWithHeader("PUT", "/api/chat/invite/"+token+"/join", h1, nil, e)
+		assert.Equal(t, http.StatusOK, c3)
+
+		c4, b4, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/invite-link", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c4)
+		assert.Equal(t, "1", utils.InterfaceToString(getJsonPathResult(t, b4, "$.items[0].uses").(interface{})))
+		assert.Equal(t, false, getJsonPathResult(t, b4, "$.items[0].usable").(interface{}))
+
+		c5, _, _ := requestWithHeader("DELETE", "/api/chat/"+chatIdString+"/invite-link/"+linkIdString, h2, nil, e)
+		assert.Equal(t, http.StatusOK, c5)
+
+		c6, _, _ := requestWithHeader("PUT", "/api/chat/invite/unknown/join", h1, nil, e)
+		assert.Equal(t, http.StatusNotFound, c6)
+	})
+}
+
+func TestChatJoinRequest(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo, db *db.DB) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Community chat", "availableToSearch": true, "joinByRequest": true}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c1, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/join", h1, nil, e)
+		assert.Equal(t, http.StatusUnauthorized, c1)
+
+		c2, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/join-request", h1, nil, e)
+		assert.Equal(t, http.StatusAccepted, c2)
+
+		// not an admin
+		c3, _, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/join-request", h1, nil, e)
+		assert.Equal(t, http.StatusUnauthorized, c3)
+
+		c4, b4, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/join-request", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c4)
+		assert.Equal(t, "1", utils.InterfaceToString(getJsonPathResult(t, b4, "$.count").(interface{})))
+		assert.Equal(t, "1", utils.InterfaceToString(getJsonPathResult(t, b4, "$.items[0].user.id").(interface{})))
+		requestIdString := utils.InterfaceToString(getJsonPathResult(t, b4, "$.items[0].id").(interface{}))
+
+		c5, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/join-request/"+requestIdString+"/approve", h2, nil, e)
+		assert.Equal(t, http.StatusAccepted, c5)
+
+		c6, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/join-request/"+requestIdString+"/reject", h2, nil, e)
+		assert.Equal(t, http.StatusNotFound, c6)
+
+		c7, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/join-request", h1, nil, e)
+		assert.Equal(t, http.StatusConflict, c7)
+	})
+}
+
+func TestChatParticipantRoles(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo, db *db.DB) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Moderated chat", "participantIds": [1], "regularParticipantCanWriteMessage": true}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c1, b1, _ := requestWithHeader("GET", "/api/chat/"+chatIdString, h2, nil, e)
+		assert.Equal(t, http.StatusOK, c1)
+		assert.Equal(t, "owner", getJsonPathResult(t, b1, "$.role").(string))
+
+		c2, b2, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h2, strings.NewReader(`{"text": "a message of the owner"}`), e)
+		assert.Equal(t, http.StatusCreated, c2)
+		messageIdString := utils.InterfaceToString(getJsonPathResult(t, b2, "$.id").(interface{}))
+
+		// a member cannot delete the message of another user
+		c3, _, _ := requestWithHeader("DELETE", "/api/chat/"+chatIdString+"/message/"+messageIdString, h1, nil, e)
+		assert.Equal(t, http.StatusUnauthorized, c3)
+
+		c4, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/participant/1?role=superuser", h2, nil, e)
+		assert.Equal(t, http.StatusBadRequest, c4)
+
+		c5, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/participant/1?role=moderator", h2, nil, e)
+		assert.Equal(t, http.StatusAccepted, c5)
+
+		// a moderator doesn't manage the participants
+		c6, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/participant/2?role=member", h1, nil, e)
+		assert.Equal(t, http.StatusUnauthorized, c6)
+
+		c7, _, _ := requestWithHeader("DELETE", "/api/chat/"+chatIdString+"/message/"+messageIdString, h1, nil, e)
+		assert.Equal(t, http.StatusAccepted, c7)
+	})
+}
+
+func TestChatBan(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo, db *db.DB) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Searchable chat", "availableToSearch": true, "participantIds": [1]}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		// not an admin
+		c1, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/ban/2", h1, strings.NewReader(`{}`), e)
+		assert.Equal(t, http.StatusUnauthorized, c1)
+
+		c2, b2, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/ban/1", h2, strings.NewReader(`{"reason": "spam"}`), e)
+		assert.Equal(t, http.StatusOK, c2)
+		assert.Equal(t, "spam", getJsonPathResult(t, b2, "$.reason").(string))
+
+		c3, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/join", h1, nil, e)
+		assert.Equal(t, http.StatusUnauthorized, c3)
+
+		c4, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/participant", h2, strings.NewReader(`{"addParticipantIds": [1]}`), e)
+		assert.Equal(t, http.StatusConflict, c4)
+
+		c5, b5, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/ban", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c5)
+		assert.Equal(t, "1", utils.InterfaceToString(getJsonPathResult(t, b5, "$.count").(interface{})))
+		assert.Equal(t, "1", utils.InterfaceToString(getJsonPathResult(t, b5, "$.items[0].user.id").(interface{})))
+
+		c6, _, _ := requestWithHeader("DELETE", "/api/chat/"+chatIdString+"/ban/1", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c6)
+
+		c7, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/join", h1, nil, e)
+		assert.Equal(t, http.StatusAccepted, c7)
+	})
+}
+
+func TestMessageReport(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Moderated chat", "participantIds": [1]}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c1, b1, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>Buy my stuff</p>"}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+		messageIdString := utils.InterfaceToString(getJsonPathResult(t, b1, "$.id").(interface{}))
+
+		c2, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message/"+messageIdString+"/report", h1, strings.NewReader(`{"reason": "mine"}`), e)
+		assert.Equal(t, http.StatusBadRequest, c2)
+
+		c3, b3, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message/"+messageIdString+"/report", h2, strings.NewReader(`{"reason": "spam"}`), e)
+		assert.Equal(t, http.StatusCreated, c3)
+		reportIdString := utils.InterfaceToString(getJsonPathResult(t, b3, "$.id").(interface{}))
+
+		c4, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message/"+messageIdString+"/report", h2, strings.NewReader(`{"reason": "spam again"}`), e)
+		assert.Equal(t, http.StatusConflict, c4)
+
+		// the member doesn't see the queue
+		c5, _, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/report", h1, nil, e)
+		assert.Equal(t, http.StatusUnauthorized, c5)
+
+		c6, b6, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/report", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c6)
+		assert.Equal(t, "1", utils.InterfaceToString(getJsonPathResult(t, b6, "$.count").(interface{})))
+		assert.Equal(t, "<p>Buy my stuff</p>", getJsonPathResult(t, b6, "$.items[0].messageText").(string))
+
+		c7, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/report/"+reportIdString+"/resolve", h2, strings.NewReader(`{"action": "delete_message", "comment": "advertising"}`), e)
+		assert.Equal(t, http.StatusNoContent, c7)
+
+		c8, b8, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/report", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c8)
+		assert.Equal(t, "0", utils.InterfaceToString(getJsonPathResult(t, b8, "$.count").(interface{})))
+
+		c9, b9, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/moderation-action", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c9)
+		assert.Equal(t, "delete_message", getJsonPathResult(t, b9, "$.items[0].action").(string))
+		assert.Equal(t, "advertising", getJsonPathResult(t, b9, "$.items[0].comment").(string))
+	})
+}
+
+func TestContentFilter(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Filtered chat", "participantIds": [1]}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c0, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/blocklist", h1, strings.NewReader(`{"pattern": "darn", "action": "mask"}`), e)
+		assert.Equal(t, http.StatusUnauthorized, c0)
+		c0, _, _ = requestWithHeader("POST", "/api/chat/blocklist", h2, strings.NewReader(`{"pattern": "darn", "action": "mask"}`), e)
+		assert.Equal(t, http.StatusUnauthorized, c0)
+		c0, _, _ = requestWithHeader("POST", "/api/chat/"+chatIdString+"/blocklist", h2, strings.NewReader(`{"pattern": "(", "regex": true, "action": "mask"}`), e)
+		assert.Equal(t, http.StatusBadRequest, c0)
+
+		c1, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/blocklist", h2, strings.NewReader(`{"pattern": "darn", "action": "mask"}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+		c1, _, _ = requestWithHeader("POST", "/api/chat/"+chatIdString+"/blocklist", h2, strings.NewReader(`{"pattern": "c[a@]sino", "regex": true, "action": "hold"}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+
+		c2, b2, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>darn it</p>"}`), e)
+		assert.Equal(t, http.StatusCreated, c2)
+		messageIdString := utils.InterfaceToString(getJsonPathResult(t, b2, "$.id").(interface{}))
+		c3, b3, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/message/"+messageIdString, h1, nil, e)
+		assert.Equal(t, http.StatusOK, c3)
+		assert.Equal(t, "<p>**** it</p>", getJsonPathResult(t, b3, "$.text").(string))
+
+		// the new participant can't post the links without the review
+		c4, b4, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>see https://example.com</p>"}`), e)
+		assert.Equal(t, http.StatusAccepted, c4)
+		assert.Equal(t, "MESSAGE_HELD", getJsonPathResult(t, b4, "$.businessErrorCode").(string))
+		heldIdString := utils.InterfaceToString(getJsonPathResult(t, b4, "$.heldMessageId").(interface{}))
+
+		c5, b5, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>best C@sino</p>"}`), e)
+		assert.Equal(t, http.StatusAccepted, c5)
+		heldIdString2 := utils.InterfaceToString(getJsonPathResult(t, b5, "$.heldMessageId").(interface{}))
+
+		c6, _, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/held-message", h1, nil, e)
+		assert.Equal(t, http.StatusUnauthorized, c6)
+		c6, b6, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/held-message", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c6)
+		assert.Equal(t, "2", utils.InterfaceToString(getJsonPathResult(t, b6, "$.count").(interface{})))
+
+		c7, b7, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/held-message/"+heldIdString+"/approve", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c7)
+		approvedIdString := utils.InterfaceToString(getJsonPathResult(t, b7, "$.id").(interface{}))
+		c8, b8, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/message/"+approvedIdString, h1, nil, e)
+		assert.Equal(t, http.StatusOK, c8)
+		assert.Equal(t, float64(1), getJsonPathResult(t, b8, "$.ownerId").(float64))
+
+		c9, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/held-message/"+heldIdString2+"/reject", h2, strings.NewReader(`{"comment": "advertising"}`), e)
+		assert.Equal(t, http.StatusNoContent, c9)
+		c9, _, _ = requestWithHeader("PUT", "/api/chat/"+chatIdString+"/held-message/"+heldIdString2+"/reject", h2, nil, e)
+		assert.Equal(t, http.StatusNotFound, c9)
+
+		c10, b10, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/moderation-action", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c10)
+		assert.Equal(t, "reject_message", getJsonPathResult(t, b10, "$.items[0].action").(string))
+		assert.Equal(t, "approve_message", getJsonPathResult(t, b10, "$.items[1].action").(string))
+
+		for i := 0; i < 3; i++ {
+			c11, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>hello</p>"}`), e)
+			assert.Equal(t, http.StatusCreated, c11)
+		}
+		c12, b12, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>hello</p>"}`), e)
+		assert.Equal(t, http.StatusBadRequest, c12)
+		assert.Equal(t, "CONTENT_REJECTED", getJsonPathResult(t, b12, "$.businessErrorCode").(string))
+
+		// the chat admin isn't subject to the heuristics
+		c13, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h2, strings.NewReader(`{"text": "<p>see https://example.com</p>"}`), e)
+		assert.Equal(t, http.StatusCreated, c13)
+
+		// the scheduled message can't wait for the moderator
+		sendDateTime := time.Now().UTC().Add(time.Hour).Format(time.RFC3339)
+		c14, b14, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message/scheduled", h1, strings.NewReader(`{"text": "<p>best casino</p>", "sendDateTime": "`+sendDateTime+`"}`), e)
+		assert.Equal(t, http.StatusBadRequest, c14)
+		assert.Equal(t, "CONTENT_REJECTED", getJsonPathResult(t, b14, "$.businessErrorCode").(string))
+	})
+}
+
+func TestChatEmoji(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Emoji chat", "participantIds": [1]}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+		emojiUrl := "/api/storage/public/chat/emoji/" + chatIdString + "_abc.png"
+
+		c0, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/emoji", h1, strings.NewReader(`{"shortcode": "party", "url": "`+emojiUrl+`"}`), e)
+		assert.Equal(t, http.StatusUnauthorized, c0)
+		c0, _, _ = requestWithHeader("POST", "/api/chat/"+chatIdString+"/emoji", h2, strings.NewReader(`{"shortcode": "party", "url": "/api/storage/public/chat/emoji/100500_abc.png"}`), e)
+		assert.Equal(t, http.StatusBadRequest, c0)
+		c0, _, _ = requestWithHeader("POST", "/api/chat/"+chatIdString+"/emoji", h2, strings.NewReader(`{"shortcode": "Party!", "url": "`+emojiUrl+`"}`), e)
+		assert.Equal(t, http.StatusBadRequest, c0)
+
+		c1, b1, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/emoji", h2, strings.NewReader(`{"shortcode": ":party:", "url": "`+emojiUrl+`"}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+		emojiIdString := utils.InterfaceToString(getJsonPathResult(t, b1, "$.id").(interface{}))
+		c1, _, _ = requestWithHeader("POST", "/api/chat/"+chatIdString+"/emoji", h2, strings.NewReader(`{"shortcode": "party", "url": "`+emojiUrl+`"}`), e)
+		assert.Equal(t, http.StatusConflict, c1)
+
+		c2, b2, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/emoji", h1, nil, e)
+		assert.Equal(t, http.StatusOK, c2)
+		assert.Equal(t, "party", getJsonPathResult(t, b2, "$.items[0].shortcode").(string))
+
+		c3, b3, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h2, strings.NewReader(`{"text": "<p>let us :party:</p>"}`), e)
+		assert.Equal(t, http.StatusCreated, c3)
+		messageIdString := utils.InterfaceToString(getJsonPathResult(t, b3, "$.id").(interface{}))
+
+		c4, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/message/"+messageIdString+"/reaction", h1, strings.NewReader(`{"reaction": ":party:"}`), e)
+		assert.Equal(t, http.StatusOK, c4)
+		c4, _, _ = requestWithHeader("PUT", "/api/chat/"+chatIdString+"/message/"+messageIdString+"/reaction", h1, strings.NewReader(`{"reaction": ":unknown:"}`), e)
+		assert.Equal(t, http.StatusBadRequest, c4)
+
+		c5, b5, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/message/"+messageIdString, h1, nil, e)
+		assert.Equal(t, http.StatusOK, c5)
+		assert.Equal(t, `<p>let us <img class="custom-emoji" src="`+emojiUrl+`" alt=":party:"/></p>`, getJsonPathResult(t, b5, "$.text").(string))
+		assert.Equal(t, ":party:", getJsonPathResult(t, b5, "$.reactions[0].reaction").(string))
+		assert.Equal(t, emojiUrl, getJsonPathResult(t, b5, "$.reactions[0].url").(string))
+
+		c6, _, _ := requestWithHeader("DELETE", "/api/chat/"+chatIdString+"/emoji/"+emojiIdString, h2, nil, e)
+		assert.Equal(t, http.StatusNoContent, c6)
+
+		// the reaction with the removed emoji can still be taken back
+		c7, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/message/"+messageIdString+"/reaction", h1, strings.NewReader(`{"reaction": ":party:"}`), e)
+		assert.Equal(t, http.StatusOK, c7)
+		c7, _, _ = requestWithHeader("PUT", "/api/chat/"+chatIdString+"/message/"+messageIdString+"/reaction", h1, strings.NewReader(`{"reaction": ":party:"}`), e)
+		assert.Equal(t, http.StatusBadRequest, c7)
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	enabled, capacity, refillPerSecond := viper.Get("rateLimit.enabled"), viper.Get("rateLimit.capacity"), viper.Get("rateLimit.refillPerSecond")
+	viper.Set("rateLimit.enabled", true)
+	viper.Set("rateLimit.capacity", 2)
+	viper.Set("rateLimit.refillPerSecond", 0.01)
+	defer func() {
+		viper.Set("rateLimit.enabled", enabled)
+		viper.Set("rateLimit.capacity", capacity)
+		viper.Set("rateLimit.refillPerSecond", refillPerSecond)
+	}()
+
+	runTest(t, func(e *echo.Echo, redisClient *redisV9.Client) {
+		// the bucket can remain from the previous run
+		assert.NoError(t, redisClient.Del(context.Background(), "chat:rate-limit:1").Err())
+
+		c, b, _ := request("POST", "/api/chat", strings.NewReader(`{"name": "Rate limited chat"}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c1, _, _ := request("POST", "/api/chat/"+chatIdString+"/message", strings.NewReader(`{"text": "The first message"}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+		c2, _, _ := request("POST", "/api/chat/"+chatIdString+"/message", strings.NewReader(`{"text": "The second message"}`), e)
+		assert.Equal(t, http.StatusCreated, c2)
+
+		c3, b3, _ := request("POST", "/api/chat/"+chatIdString+"/message", strings.NewReader(`{"text": "The third message"}`), e)
+		assert.Equal(t, http.StatusTooManyRequests, c3)
+		assert.Equal(t, "RATE_LIMITED", getJsonPathResult(t, b3, "$.businessErrorCode").(string))
+		assert.True(t, getJsonPathResult(t, b3, "$.retryAfterSeconds").(float64) > 0)
+	})
+}
+
+func TestChatSlowMode(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Slow chat", "slowModeSeconds": 600, "participantIds": [1]}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c1, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "The first message"}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+
+		c2, b2, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "The second message"}`), e)
+		assert.Equal(t, http.StatusTooManyRequests, c2)
+		assert.Equal(t, "SLOW_MODE", getJsonPathResult(t, b2, "$.businessErrorCode").(string))
+		assert.True(t, getJsonPathResult(t, b2, "$.retryAfterSeconds").(float64) > 0)
+
+		// the slash command isn't a way around
+		c21, b21, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>/me waves</p>"}`), e)
+		assert.Equal(t, http.StatusTooManyRequests, c21)
+		assert.Equal(t, "SLOW_MODE", getJsonPathResult(t, b21, "$.businessErrorCode").(string))
+
+		// the owner isn't limited
+		c3, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h2, strings.NewReader(`{"text": "The first message of the owner"}`), e)
+		assert.Equal(t, http.StatusCreated, c3)
+		c4, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h2, strings.NewReader(`{"text": "The second message of the owner"}`), e)
+		assert.Equal(t, http.StatusCreated, c4)
+	})
+}
+
+func TestChatSlowModeScheduledMessage(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo, dbR *db.DB, mh *handlers.MessageHandler) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Slow chat with scheduled", "slowModeSeconds": 600, "participantIds": [1]}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+		chatId, err := utils.ParseInt64(chatIdString)
+		assert.NoError(t, err)
+
+		c1, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "The first message"}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+
+		// the due message, the api doesn't allow to schedule in the past
+		ctx := context.Background()
+		sendDateTime := time.Now().UTC().Add(-time.Second)
+		scheduledId, err := db.TransactWithResult(ctx, dbR, func(tx *db.Tx) (int64, error) {
+			return tx.CreateScheduledMessage(ctx, &db.ScheduledMessage{ChatId: chatId, OwnerId: 1, Text: "The scheduled message", SendDateTime: sendDateTime})
+		})
+		assert.NoError(t, err)
+
+		messagesBefore, err := dbR.CountMessages(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, mh.SendScheduledMessage(ctx, scheduledId))
+		messagesAfter, err := dbR.CountMessages(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, messagesBefore, messagesAfter)
+
+		// is postponed instead of being sent or dropped
+		sm, err := dbR.GetScheduledMessage(ctx, chatId, scheduledId, 1)
+		assert.NoError(t, err)
+		assert.NotNil(t, sm)
+		assert.True(t, sm.SendDateTime.After(time.Now().UTC()))
+	})
+}
+
+func TestChatIncomingWebhook(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+	hPublic := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+	}
+
+	runTest(t, func(e *echo.Echo) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Chat with webhook", "participantIds": [1]}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		// the regular participant can't create webhooks
+		c1, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/webhook/incoming", h1, strings.NewReader(`{"name": "CI"}`), e)
+		assert.Equal(t, http.StatusUnauthorized, c1)
+
+		c2, b2, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/webhook/incoming", h2, strings.NewReader(`{"name": "CI"}`), e)
+		assert.Equal(t, http.StatusCreated, c2)
+		token := getJsonPathResult(t, b2, "$.token").(string)
+		assert.NotEmpty(t, token)
+
+		c3, _, _ := requestWithHeader("POST", "/api/chat/public/webhook/"+token, hPublic, strings.NewReader(`{"text": "Build passed"}`), e)
+		assert.Equal(t, http.StatusCreated, c3)
+
+		c4, _, _ := requestWithHeader("POST", "/api/chat/public/webhook/unknown"+token, hPublic, strings.NewReader(`{"text": "Build passed"}`), e)
+		assert.Equal(t, http.StatusNotFound, c4)
+
+		// the token is shown only once
+		c5, b5, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/webhook/incoming", h2, nil, e)
+		assert.Equal(t, http.StatusOK, c5)
+		assert.Equal(t, "CI", getJsonPathResult(t, b5, "$.items[0].name").(string))
+		assert.NotContains(t, b5, token)
+
+		// the bot is subject to the content filter
+		c6, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/blocklist", h2, strings.NewReader(`{"pattern": "deploywrecked", "action": "reject"}`), e)
+		assert.Equal(t, http.StatusCreated, c6)
+		c7, b7, _ := requestWithHeader("POST", "/api/chat/public/webhook/"+token, hPublic, strings.NewReader(`{"text": "Build deploywrecked"}`), e)
+		assert.Equal(t, http.StatusBadRequest, c7)
+		assert.Equal(t, "CONTENT_REJECTED", getJsonPathResult(t, b7, "$.businessErrorCode").(string))
+	})
+}
+
+func TestSlashCommands(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+	h2 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester2}, // tester2
+		"X-Auth-Userid":        {"2"},
+	}
+
+	runTest(t, func(e *echo.Echo, db *db.DB) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h2, strings.NewReader(`{"name": "Chat with commands", "participantIds": [1]}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+		chatId, _ := utils.ParseInt64(chatIdString)
+
+		c1, b1, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>/me waves</p>"}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+		messageId := getJsonPathResult(t, b1, "$.id").(float64)
+		row := db.QueryRow("SELECT text FROM message WHERE chat_id = $1 AND id = $2", chatId, int64(messageId))
+		var text string
+		assert.Nil(t, row.Scan(&text))
+		assert.Equal(t, "<p><i>tester waves</i></p>", text)
+
+		// the regular participant isn't allowed to change the title, the reply is ephemeral
+		c2, b2, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>/topic The new title</p>"}`), e)
+		assert.Equal(t, http.StatusOK, c2)
+		assert.Equal(t, "topic", getJsonPathResult(t, b2, "$.command").(string))
+
+		c3, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h2, strings.NewReader(`{"text": "<p>/topic The new title</p>"}`), e)
+		assert.Equal(t, http.StatusOK, c3)
+		row = db.QueryRow("SELECT title FROM chat WHERE id = $1", chatId)
+		var title string
+		assert.Nil(t, row.Scan(&title))
+		assert.Equal(t, "The new title", title)
+
+		// the unknown command is posted as is
+		c4, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>/etc/hosts is the file</p>"}`), e)
+		assert.Equal(t, http.StatusCreated, c4)
+
+		c5, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/command", h2, strings.NewReader(`{"name": "me", "url": "https://example.com/command"}`), e)
+		assert.Equal(t, http.StatusBadRequest, c5)
+
+		c6, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/command", h2, strings.NewReader(`{"name": "deploy", "description": "Deploys the build", "url": "https://example.com/command"}`), e)
+		assert.Equal(t, http.StatusCreated, c6)
+
+		c7, b7, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/command/available", h1, nil, e)
+		assert.Equal(t, http.StatusOK, c7)
+		assert.Equal(t, "deploy", getJsonPathResult(t, b7, "$.items[5].name").(string))
+	})
+}
+
+func TestMessageDraft(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+
+	runTest(t, func(e *echo.Echo) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h1, strings.NewReader(`{"name": "Chat with draft"}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c1, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/message/draft", h1, strings.NewReader(`{"text": "<p>Half-written</p>"}`), e)
+		assert.Equal(t, http.StatusOK, c1)
+
+		c2, b2, _ := requestWithHeader("GET", "/api/chat/"+chatIdString, h1, nil, e)
+		assert.Equal(t, http.StatusOK, c2)
+		assert.Equal(t, "<p>Half-written</p>", getJsonPathResult(t, b2, "$.draft.text").(string))
+
+		c3, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>Fully written</p>"}`), e)
+		assert.Equal(t, http.StatusCreated, c3)
+
+		// is cleared after posting
+		c4, _, _ := requestWithHeader("GET", "/api/chat/"+chatIdString+"/message/draft", h1, nil, e)
+		assert.Equal(t, http.StatusNoContent, c4)
+	})
+}
+
+func TestMessageBookmark(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+
+	runTest(t, func(e *echo.Echo) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h1, strings.NewReader(`{"name": "Chat with bookmarks"}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c1, b1, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>Worth saving</p>"}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+		messageIdString := utils.InterfaceToString(getJsonPathResult(t, b1, "$.id").(interface{}))
+
+		c2, _, _ := requestWithHeader("PUT", "/api/chat/"+chatIdString+"/message/"+messageIdString+"/bookmark", h1, strings.NewReader(`{"note": "read later", "tags": ["Work", "work", "todo"]}`), e)
+		assert.Equal(t, http.StatusOK, c2)
+
+		c3, b3, _ := requestWithHeader("GET", "/api/chat/message/bookmark?tag=work", h1, nil, e)
+		assert.Equal(t, http.StatusOK, c3)
+		assert.Equal(t, float64(1), getJsonPathResult(t, b3, "$.count").(float64))
+		assert.Equal(t, "Chat with bookmarks", getJsonPathResult(t, b3, "$.items[0].chatTitle").(string))
+		assert.Equal(t, "read later", getJsonPathResult(t, b3, "$.items[0].note").(string))
+		assert.Equal(t, "<p>Worth saving</p>", getJsonPathResult(t, b3, "$.items[0].message.text").(string))
+		assert.Equal(t, 2, len(getJsonPathResult(t, b3, "$.items[0].tags").([]interface{})))
+
+		// the bookmark goes away together with the message
+		c4, _, _ := requestWithHeader("DELETE", "/api/chat/"+chatIdString+"/message/"+messageIdString, h1, nil, e)
+		assert.Equal(t, http.StatusAccepted, c4)
+
+		c5, b5, _ := requestWithHeader("GET", "/api/chat/message/bookmark", h1, nil, e)
+		assert.Equal(t, http.StatusOK, c5)
+		assert.Equal(t, float64(0), getJsonPathResult(t, b5, "$.count").(float64))
+	})
+}
+
+func TestMessageReminder(t *testing.T) {
+	h1 := map[string][]string{
+		echo.HeaderContentType: {"application/json"},
+		"X-Auth-Expiresin":     {"1590022342295000"},
+		"X-Auth-Username":      {userTester}, // tester
+		"X-Auth-Userid":        {"1"},
+	}
+
+	runTest(t, func(e *echo.Echo) {
+		c, b, _ := requestWithHeader("POST", "/api/chat", h1, strings.NewReader(`{"name": "Chat with reminders"}`), e)
+		assert.Equal(t, http.StatusCreated, c)
+		chatIdString := utils.InterfaceToString(getJsonPathResult(t, b, "$.id").(interface{}))
+
+		c1, b1, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message", h1, strings.NewReader(`{"text": "<p>Don't forget</p>"}`), e)
+		assert.Equal(t, http.StatusCreated, c1)
+		messageIdString := utils.InterfaceToString(getJsonPathResult(t, b1, "$.id").(interface{}))
+
+		c2, _, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message/"+messageIdString+"/reminder", h1, strings.NewReader(`{"remindIn": "3h", "remindDateTime": "2030-01-01T00:00:00Z"}`), e)
+		assert.Equal(t, http.StatusBadRequest, c2)
+
+		c3, b3, _ := requestWithHeader("POST", "/api/chat/"+chatIdString+"/message/"+messageIdString+"/reminder", h1, strings.NewReader(`{"remindIn": "3h"}`), e)
+		assert.Equal(t, http.StatusCreated, c3)
+		reminderIdString := utils.InterfaceToString(getJsonPathResult(t, b3, "$.id").(interface{}))
+
+		c4, _, _ := requestWithHeader("PUT", "/api/chat/message/reminder/"+reminderIdString+"/snooze", h1, strings.NewReader(`{"remindIn": "24h"}`), e)
+		assert.Equal(t, http.StatusOK, c4)
+
+		c5, b5, _ := requestWithHeader("GET", "/api/chat/message/reminder", h1, nil, e)
+		assert.Equal(t, http.StatusOK, c5)
+		assert.Equal(t, float64(1), getJsonPathResult(t, b5, "$.count").(float64))
+		assert.Equal(t, false, getJsonPathResult(t, b5, "$.items[0].fired").(bool))
+
+		c6, _, _ := requestWithHeader("DELETE", "/api/chat/message/reminder/"+reminderIdString, h1, nil, e)
+		assert.Equal(t, http.StatusNoContent, c6)
+
+		c7, b7, _ := requestWithHeader("GET", "/api/chat/message/reminder", h1, nil, e)
+		assert.Equal(t, http.StatusOK, c7)
+		assert.Equal(t, float64(0), getJsonPathResult(t, b7, "$.count").(float64))
+	})
+}
+
 func TestGetBlogsPaginated(t *testing.T) {
 	runTest(t, func(e *echo.Echo) {
 		httpFirstPage, bodyFirstPage, _ := request("GET", "/api/blog?page=2&size=3", nil, e)
@@ -821,6 +1802,26 @@ func TestGetBlogsPaginated(t *testing.T) {
 	})
 }
 
+func TestBlogFeed(t *testing.T) {
+	runTest(t, func(e *echo.Echo) {
+		c1, b1, h1 := request("GET", "/api/blog/feed/atom?size=3", nil, e)
+		assert.Equal(t, http.StatusOK, c1)
+		assert.Contains(t, b1, `<feed xmlns="http://www.w3.org/2005/Atom"`)
+		etag := h1.Get("ETag")
+		assert.NotEmpty(t, etag)
+		assert.NotEmpty(t, h1.Get(echo.HeaderLastModified))
+
+		c2, _, _ := requestWithHeader("GET", "/api/blog/feed/atom?size=3", map[string][]string{"If-None-Match": {etag}}, nil, e)
+		assert.Equal(t, http.StatusNotModified, c2)
+		c2, _, _ = requestWithHeader("GET", "/api/blog/feed/atom?size=3", map[string][]string{echo.HeaderIfModifiedSince: {h1.Get(echo.HeaderLastModified)}}, nil, e)
+		assert.Equal(t, http.StatusNotModified, c2)
+
+		c3, b3, _ := request("GET", "/api/blog/feed/rss?size=3", nil, e)
+		assert.Equal(t, http.StatusOK, c3)
+		assert.Contains(t, b3, `<rss version="2.0"`)
+	})
+}
+
 func TestGetBlogsPaginatedSearch(t *testing.T) {
 	runTest(t, func(e *echo.Echo) {
 		httpFirstPage, bodyFirstPage, _ := request("GET", "/api/blog?size=3&searchString=generated_chat994", nil, e)
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	AtomContentType = "application/atom+xml; charset=utf-8"
+	RssContentType  = "application/rss+xml; charset=utf-8"
+)
+
+// the format-agnostic feed, it is rendered either as Atom or as RSS 2.0
+type Feed struct {
+	Id          string
+	Title       string
+	Description string
+	Link        string // the html page
+	SelfLink    string // the feed itself
+	Updated     time.Time
+	Entries     []*FeedEntry
+}
+
+type FeedEntry struct {
+	Id        string
+	Title     string
+	Link      string
+	Summary   string // the plain text
+	ImageUrl  string
+	Author    string
+	Published time.Time
+	Updated   time.Time
+}
+
+// the tag changes when any entry is added, removed or edited, Atom and RSS are the different representations
+func (f *Feed) ETag(contentType string) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%v\n%v\n", contentType, f.Title)
+	for _, e := range f.Entries {
+		fmt.Fprintf(h, "%v %v\n", e.Id, e.Updated.UnixNano())
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)) + `"`
+}
+
+// the latest modification among the entries, the feed without entries keeps the given default
+func LatestFeedUpdate(entries []*FeedEntry, def time.Time) time.Time {
+	ret := def
+	for _, e := range entries {
+		if e.Updated.After(ret) {
+			ret = e.Updated
+		}
+	}
+	return ret
+}
+
+const (
+	atomNamespace       = "http://www.w3.org/2005/Atom"
+	mediaRssNamespace   = "http://search.yahoo.com/mrss/"
+	dublinCoreNamespace = "http://purl.org/dc/elements/1.1/"
+)
+
+type mediaThumbnail struct {
+	Url string `xml:"url,attr"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title     string          `xml:"title"`
+	Id        string          `xml:"id"`
+	Link      atomLink        `xml:"link"`
+	Published string          `xml:"published"`
+	Updated   string          `xml:"updated"`
+	Author    *atomAuthor     `xml:"author,omitempty"`
+	Summary   atomText        `xml:"summary"`
+	Thumbnail *mediaThumbnail `xml:"media:thumbnail,omitempty"`
+}
+
+type atomFeed struct {
+	XMLName    xml.Name     `xml:"feed"`
+	Xmlns      string       `xml:"xmlns,attr"`
+	XmlnsMedia string       `xml:"xmlns:media,attr"`
+	Title      string       `xml:"title"`
+	Subtitle   string       `xml:"subtitle,omitempty"`
+	Id         string       `xml:"id"`
+	Updated    string       `xml:"updated"`
+	Links      []atomLink   `xml:"link"`
+	Entries    []*atomEntry `xml:"entry"`
+}
+
+func RenderAtom(f *Feed) ([]byte, error) {
+	af := atomFeed{
+		Xmlns:      atomNamespace,
+		XmlnsMedia: mediaRssNamespace,
+		Title:      f.Title,
+		Subtitle:   f.Description,
+		Id:         f.Id,
+		Updated:    f.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Rel: "self", Type: strings.Split(AtomContentType, ";")[0], Href: f.SelfLink},
+			{Rel: "alternate", Type: "text/html", Href: f.Link},
+		},
+		Entries: make([]*atomEntry, 0),
+	}
+	for _, e := range f.Entries {
+		ae := &atomEntry{
+			Title:     e.Title,
+			Id:        e.Id,
+			Link:      atomLink{Rel: "alternate", Type: "text/html", Href: e.Link},
+			Published: e.Published.UTC().Format(time.RFC3339),
+			Updated:   e.Updated.UTC().Format(time.RFC3339),
+			Summary:   atomText{Type: "text", Value: e.Summary},
+		}
+		if e.Author != "" {
+			ae.Author = &atomAuthor{Name: e.Author}
+		}
+		if e.ImageUrl != "" {
+			ae.Thumbnail = &mediaThumbnail{Url: e.ImageUrl}
+		}
+		af.Entries = append(af.Entries, ae)
+	}
+	return marshalFeed(af)
+}
+
+type rssGuid struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string          `xml:"title"`
+	Link        string          `xml:"link"`
+	Guid        rssGuid         `xml:"guid"`
+	Description string          `xml:"description"`
+	Creator     string          `xml:"dc:creator,omitempty"`
+	PubDate     string          `xml:"pubDate"`
+	Thumbnail   *mediaThumbnail `xml:"media:thumbnail,omitempty"`
+}
+
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	LastBuildDate string     `xml:"lastBuildDate"`
+	SelfLink      atomLink   `xml:"atom:link"`
+	Items         []*rssItem `xml:"item"`
+}
+
+type rssFeed struct {
+	XMLName    xml.Name   `xml:"rss"`
+	Version    string     `xml:"version,attr"`
+	XmlnsAtom  string     `xml:"xmlns:atom,attr"`
+	XmlnsMedia string     `xml:"xmlns:media,attr"`
+	XmlnsDc    string     `xml:"xmlns:dc,attr"`
+	Channel    rssChannel `xml:"channel"`
+}
+
+func RenderRss(f *Feed) ([]byte, error) {
+	rf := rssFeed{
+		Version:    "2.0",
+		XmlnsAtom:  atomNamespace,
+		XmlnsMedia: mediaRssNamespace,
+		XmlnsDc:    dublinCoreNamespace,
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Description,
+			LastBuildDate: f.Updated.UTC().Format(time.RFC1123Z),
+			SelfLink:      atomLink{Rel: "self", Type: strings.Split(RssContentType, ";")[0], Href: f.SelfLink},
+			Items:         make([]*rssItem, 0),
+		},
+	}
+	if rf.Channel.Description == "" {
+		// it is required by the specification
+		rf.Channel.Description = f.Title
+	}
+	for _, e := range f.Entries {
+		ri := &rssItem{
+			Title:       e.Title,
+			Link:        e.Link,
+			Guid:        rssGuid{IsPermaLink: e.Id == e.Link, Value: e.Id},
+			Description: e.Summary,
+			Creator:     e.Author,
+			PubDate:     e.Published.UTC().Format(time.RFC1123Z),
+		}
+		if e.ImageUrl != "" {
+			ri.Thumbnail = &mediaThumbnail{Url: e.ImageUrl}
+		}
+		rf.Channel.Items = append(rf.Channel.Items, ri)
+	}
+	return marshalFeed(rf)
+}
+
+func marshalFeed(v any) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
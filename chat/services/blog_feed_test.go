@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderFeeds(t *testing.T) {
+	published := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	feed := &Feed{
+		Id:       "http://localhost:8081/public/blog",
+		Title:    "Blog",
+		Link:     "http://localhost:8081/public/blog",
+		SelfLink: "http://localhost:8081/api/blog/feed/atom",
+		Updated:  published,
+		Entries: []*FeedEntry{{
+			Id:        "http://localhost:8081/public/blog/post/1",
+			Title:     "Cats & dogs",
+			Link:      "http://localhost:8081/public/blog/post/1",
+			Summary:   "About <pets>",
+			ImageUrl:  "http://localhost:8081/api/storage/public/download/embed/preview?file=a.jpg",
+			Author:    "tester",
+			Published: published,
+			Updated:   published,
+		}},
+	}
+
+	atom, err := RenderAtom(feed)
+	assert.Nil(t, err)
+	assert.Contains(t, string(atom), `<updated>2024-01-02T03:04:05Z</updated>`)
+	assert.Contains(t, string(atom), `<title>Cats &amp; dogs</title>`)
+	assert.Contains(t, string(atom), `<summary type="text">About &lt;pets&gt;</summary>`)
+	assert.Contains(t, string(atom), `<name>tester</name>`)
+	assert.Contains(t, string(atom), `<media:thumbnail url="http://localhost:8081/api/storage/public/download/embed/preview?file=a.jpg"></media:thumbnail>`)
+
+	rss, err := RenderRss(feed)
+	assert.Nil(t, err)
+	assert.Contains(t, string(rss), `<pubDate>Tue, 02 Jan 2024 03:04:05 +0000</pubDate>`)
+	assert.Contains(t, string(rss), `<guid isPermaLink="true">http://localhost:8081/public/blog/post/1</guid>`)
+	assert.Contains(t, string(rss), `<dc:creator>tester</dc:creator>`)
+	assert.Contains(t, string(rss), `<description>Blog</description>`)
+}
+
+func TestFeedETag(t *testing.T) {
+	published := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	entry := &FeedEntry{Id: "1", Published: published, Updated: published}
+	feed := &Feed{Title: "Blog", Entries: []*FeedEntry{entry}}
+
+	etag := feed.ETag(AtomContentType)
+	assert.Equal(t, etag, feed.ETag(AtomContentType))
+	assert.NotEqual(t, etag, feed.ETag(RssContentType))
+
+	entry.Updated = published.Add(time.Minute)
+	assert.NotEqual(t, etag, feed.ETag(AtomContentType))
+	assert.Equal(t, entry.Updated, LatestFeedUpdate(feed.Entries, published))
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/guregu/null"
+	"nkonev.name/chat/dto"
+)
+
+const ChatImportSourceVideochat = "videochat"
+const ChatImportSourceTelegram = "telegram"
+
+const chatImportArchiveJsonFilename = "messages.json"
+
+// the reaction column holds up to 4 characters, the longer emoji sequences (ZWJ, skin tones) don't fit
+const maxImportedReactionLength = 4
+
+// the files of the videochat archive belong to the storage of the exported chat, they aren't copied
+const importedAttachmentsNote = "<p>[the attached files are not imported]</p>"
+
+// the source-agnostic representation of the archive being imported
+type ImportedChat struct {
+	Source     string
+	ExternalId string
+	Title      string
+	Messages   []*ImportedMessage
+}
+
+type ImportedReaction struct {
+	Reaction        string
+	ExternalUserIds []string
+	UserNames       []string
+}
+
+type ImportedMessage struct {
+	ExternalId        string
+	ExternalOwnerId   string
+	OwnerName         string
+	Text              string // html
+	CreateDateTime    time.Time
+	EditDateTime      null.Time
+	ReplyToExternalId *string
+	ThreadExternalId  *string
+	Pinned            bool
+	Published         bool
+	BlogPost          bool
+	Reactions         []ImportedReaction
+}
+
+// accepts either the zip produced by the chat export, its messages.json or the Telegram desktop result.json of a single chat
+// maxSize limits the unpacked json, so the small zip bomb can't exhaust the memory
+func ParseChatImportArchive(content []byte, maxSize int64) (*ImportedChat, error) {
+	if bytes.HasPrefix(content, []byte("PK")) {
+		zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			return nil, err
+		}
+		jsonFile, err := zipReader.Open(chatImportArchiveJsonFilename)
+		if err != nil {
+			return nil, fmt.Errorf("the archive should contain %v: %w", chatImportArchiveJsonFilename, err)
+		}
+		defer jsonFile.Close()
+		// the header is checked first, but it's written by the uploader, so the reading is limited as well
+		if info, err := jsonFile.Stat(); err == nil {
+			if header, ok := info.Sys().(*zip.FileHeader); ok && header.UncompressedSize64 > uint64(maxSize) {
+				return nil, fmt.Errorf("%v is larger than %v bytes", chatImportArchiveJsonFilename, maxSize)
+			}
+		}
+		content, err = io.ReadAll(io.LimitReader(jsonFile, maxSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(content)) > maxSize {
+			return nil, fmt.Errorf("%v is larger than %v bytes", chatImportArchiveJsonFilename, maxSize)
+		}
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(content, &probe); err != nil {
+		return nil, err
+	}
+	if _, ok := probe["chat"]; ok {
+		return parseVideochatExport(content)
+	}
+	if _, ok := probe["messages"]; ok {
+		return parseTelegramExport(content)
+	}
+	return nil, errors.New("unknown archive format")
+}
+
+// the reaction which can't be stored is skipped, otherwise it would fail the whole batch on every attempt
+// the custom emoji of the exported chat doesn't exist in the imported one
+func isImportableReaction(reaction string) bool {
+	if _, isCustom := ParseEmojiReference(reaction); isCustom {
+		return false
+	}
+	length := utf8.RuneCountInString(reaction)
+	return length > 0 && length <= maxImportedReactionLength
+}
+
+type videochatExport struct {
+	Chat     dto.ExportedChatDto      `json:"chat"`
+	Messages []dto.ExportedMessageDto `json:"messages"`
+}
+
+func parseVideochatExport(content []byte) (*ImportedChat, error) {
+	var export videochatExport
+	if err := json.Unmarshal(content, &export); err != nil {
+		return nil, err
+	}
+
+	ret := &ImportedChat{
+		Source:     ChatImportSourceVideochat,
+		ExternalId: strconv.FormatInt(export.Chat.Id, 10),
+		Title:      export.Chat.Title,
+		Messages:   make([]*ImportedMessage, 0, len(export.Messages)),
+	}
+	for _, m := range export.Messages {
+		if m.Deleted {
+			continue
+		}
+		im := &ImportedMessage{
+			ExternalId:      strconv.FormatInt(m.Id, 10),
+			ExternalOwnerId: strconv.FormatInt(m.OwnerId, 10),
+			OwnerName:       m.OwnerLogin,
+			Text:            m.Text,
+			CreateDateTime:  m.CreateDateTime,
+			EditDateTime:    m.EditDateTime,
+			Pinned:          m.Pinned,
+			Published:       m.Published,
+			BlogPost:        m.BlogPost,
+			Reactions:       make([]ImportedReaction, 0, len(m.Reactions)),
+		}
+		if m.FileItemUuid != nil {
+			im.Text += importedAttachmentsNote
+		}
+		if m.ThreadId != nil {
+			threadId := strconv.FormatInt(*m.ThreadId, 10)
+			im.ThreadExternalId = &threadId
+		}
+		if m.EmbedMessage != nil {
+			if m.EmbedMessage.EmbedType == dto.EmbedMessageTypeReply {
+				replyTo := strconv.FormatInt(m.EmbedMessage.Id, 10)
+				im.ReplyToExternalId = &replyTo
+			} else if m.EmbedMessage.EmbedType == dto.EmbedMessageTypeResend {
+				// the original chat may not exist here, so the resent message becomes the regular one
+				im.Text = m.EmbedMessage.Text
+			}
+		}
+		for _, r := range m.Reactions {
+			if !isImportableReaction(r.Reaction) {
+				continue
+			}
+			userIds := make([]string, 0, len(r.UserIds))
+			for _, userId := range r.UserIds {
+				userIds = append(userIds, strconv.FormatInt(userId, 10))
+			}
+			im.Reactions = append(im.Reactions, ImportedReaction{Reaction: r.Reaction, ExternalUserIds: userIds, UserNames: r.Logins})
+		}
+		ret.Messages = append(ret.Messages, im)
+	}
+	return ret, nil
+}
+
+type telegramTextEntity struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+	Href string `json:"href"`
+}
+
+type telegramReaction struct {
+	Type   string `json:"type"`
+	Emoji  string `json:"emoji"`
+	Recent []struct {
+		From   string `json:"from"`
+		FromId string `json:"from_id"`
+	} `json:"recent"`
+}
+
+type telegramMessage struct {
+	Id               int64                `json:"id"`
+	Type             string               `json:"type"`
+	DateUnixtime     string               `json:"date_unixtime"`
+	EditedUnixtime   string               `json:"edited_unixtime"`
+	From             string               `json:"from"`
+	FromId           string               `json:"from_id"`
+	ReplyToMessageId *int64               `json:"reply_to_message_id"`
+	TextEntities     []telegramTextEntity `json:"text_entities"`
+	Photo            string               `json:"photo"`
+	File             string               `json:"file"`
+	ForwardedFrom    string               `json:"forwarded_from"`
+	Reactions        []telegramReaction   `json:"reactions"`
+}
+
+type telegramExport struct {
+	Id       int64             `json:"id"`
+	Name     string            `json:"name"`
+	Messages []telegramMessage `json:"messages"`
+}
+
+func parseTelegramUnixtime(s string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+func telegramEntitiesToHtml(entities []telegramTextEntity) string {
+	var sb strings.Builder
+	for _, e := range entities {
+		text := html.EscapeString(e.Text)
+		switch e.Type {
+		case "bold":
+			sb.WriteString("<b>" + text + "</b>")
+		case "italic":
+			sb.WriteString("<i>" + text + "</i>")
+		case "underline":
+			sb.WriteString("<u>" + text + "</u>")
+		case "strikethrough":
+			sb.WriteString("<s>" + text + "</s>")
+		case "code":
+			sb.WriteString("<code>" + text + "</code>")
+		case "pre":
+			sb.WriteString("<pre>" + text + "</pre>")
+		case "link":
+			sb.WriteString(`<a href="` + text + `">` + text + "</a>")
+		case "text_link":
+			sb.WriteString(`<a href="` + html.EscapeString(e.Href) + `">` + text + "</a>")
+		default:
+			sb.WriteString(text)
+		}
+	}
+	return strings.ReplaceAll(sb.String(), "\n", "<br>")
+}
+
+func parseTelegramExport(content []byte) (*ImportedChat, error) {
+	var export telegramExport
+	if err := json.Unmarshal(content, &export); err != nil {
+		return nil, err
+	}
+
+	ret := &ImportedChat{
+		Source:     ChatImportSourceTelegram,
+		ExternalId: strconv.FormatInt(export.Id, 10),
+		Title:      export.Name,
+		Messages:   make([]*ImportedMessage, 0, len(export.Messages)),
+	}
+	for _, m := range export.Messages {
+		// joins, pins, calls and so on
+		if m.Type != "message" {
+			continue
+		}
+		createDateTime, err := parseTelegramUnixtime(m.DateUnixtime)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the date of the message %v: %w", m.Id, err)
+		}
+
+		text := telegramEntitiesToHtml(m.TextEntities)
+		if m.ForwardedFrom != "" {
+			text = "<i>" + html.EscapeString(m.ForwardedFrom) + ":</i><br>" + text
+		}
+		// the media files aren't a part of the json so only their names are kept
+		for _, attachment := range []string{m.Photo, m.File} {
+			if attachment != "" {
+				if text != "" {
+					text += "<br>"
+				}
+				text += "[" + html.EscapeString(attachment) + "]"
+			}
+		}
+		if text == "" {
+			continue
+		}
+
+		im := &ImportedMessage{
+			ExternalId:      strconv.FormatInt(m.Id, 10),
+			ExternalOwnerId: m.FromId,
+			OwnerName:       m.From,
+			Text:            "<p>" + text + "</p>",
+			CreateDateTime:  createDateTime,
+			Reactions:       make([]ImportedReaction, 0, len(m.Reactions)),
+		}
+		if m.EditedUnixtime != "" {
+			editDateTime, err := parseTelegramUnixtime(m.EditedUnixtime)
+			if err == nil {
+				im.EditDateTime = null.TimeFrom(editDateTime)
+			}
+		}
+		if m.ReplyToMessageId != nil {
+			replyTo := strconv.FormatInt(*m.ReplyToMessageId, 10)
+			im.ReplyToExternalId = &replyTo
+		}
+		for _, r := range m.Reactions {
+			// the custom emoji reactions don't have a textual representation
+			if r.Type != "emoji" || !isImportableReaction(r.Emoji) {
+				continue
+			}
+			userIds := make([]string, 0, len(r.Recent))
+			userNames := make([]string, 0, len(r.Recent))
+			for _, recent := range r.Recent {
+				userIds = append(userIds, recent.FromId)
+				userNames = append(userNames, recent.From)
+			}
+			im.Reactions = append(im.Reactions, ImportedReaction{Reaction: r.Emoji, ExternalUserIds: userIds, UserNames: userNames})
+		}
+		ret.Messages = append(ret.Messages, im)
+	}
+	return ret, nil
+}
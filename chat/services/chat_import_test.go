@@ -0,0 +1,116 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+const testChatImportMaxSize = 1024 * 1024
+
+func TestParseTelegramExport(t *testing.T) {
+	imported, err := ParseChatImportArchive([]byte(`{
+		"name": "Project",
+		"type": "private_group",
+		"id": 42,
+		"messages": [
+			{"id": 1, "type": "service", "date_unixtime": "1672567200", "action": "create_group"},
+			{"id": 2, "type": "message", "date_unixtime": "1672567260", "from": "Alice", "from_id": "user10",
+				"text_entities": [{"type": "plain", "text": "Hello "}, {"type": "bold", "text": "<world>"}],
+				"reactions": [{"type": "emoji", "count": 1, "emoji": "👍", "recent": [{"from": "Bob", "from_id": "user11"}]}]},
+			{"id": 3, "type": "message", "date_unixtime": "1672567320", "edited_unixtime": "1672567380", "from": "Bob", "from_id": "user11",
+				"reply_to_message_id": 2, "text_entities": [{"type": "plain", "text": "Hi"}]}
+		]
+	}`), testChatImportMaxSize)
+	assert.Nil(t, err)
+	assert.Equal(t, ChatImportSourceTelegram, imported.Source)
+	assert.Equal(t, "42", imported.ExternalId)
+	assert.Equal(t, "Project", imported.Title)
+	assert.Equal(t, 2, len(imported.Messages))
+
+	first := imported.Messages[0]
+	assert.Equal(t, "2", first.ExternalId)
+	assert.Equal(t, "user10", first.ExternalOwnerId)
+	assert.Equal(t, "<p>Hello <b>&lt;world&gt;</b></p>", first.Text)
+	assert.Equal(t, int64(1672567260), first.CreateDateTime.Unix())
+	assert.Equal(t, []ImportedReaction{{Reaction: "👍", ExternalUserIds: []string{"user11"}, UserNames: []string{"Bob"}}}, first.Reactions)
+
+	second := imported.Messages[1]
+	assert.Equal(t, "2", *second.ReplyToExternalId)
+	assert.True(t, second.EditDateTime.Valid)
+}
+
+func TestParseVideochatExport(t *testing.T) {
+	imported, err := ParseChatImportArchive([]byte(`{
+		"chat": {"id": 5, "title": "Team"},
+		"participants": [{"id": 1, "login": "admin"}],
+		"messages": [
+			{"id": 10, "ownerId": 1, "text": "<p>root</p>", "createDateTime": "2023-01-01T10:00:00Z", "pinned": true, "fileItemUuid": "a7c5c2d2-0b4c-4a4b-9d1e-1d6b2e0f7b11",
+				"reactions": [{"reaction": "🔥", "userIds": [1], "logins": ["admin"]}, {"reaction": ":party:", "userIds": [1], "logins": ["admin"]}]},
+			{"id": 11, "ownerId": 1, "text": "", "createDateTime": "2023-01-01T10:01:00Z", "deleted": true},
+			{"id": 12, "ownerId": 2, "text": "", "createDateTime": "2023-01-01T10:02:00Z", "threadId": 10,
+				"embedMessage": {"id": 3, "chatId": 7, "embedType": "resend", "ownerId": 3, "text": "<p>resent</p>"}}
+		]
+	}`), testChatImportMaxSize)
+	assert.Nil(t, err)
+	assert.Equal(t, ChatImportSourceVideochat, imported.Source)
+	assert.Equal(t, "5", imported.ExternalId)
+	assert.Equal(t, 2, len(imported.Messages))
+
+	assert.True(t, imported.Messages[0].Pinned)
+	assert.Equal(t, "<p>root</p>"+importedAttachmentsNote, imported.Messages[0].Text)
+	assert.Equal(t, 1, len(imported.Messages[0].Reactions))
+	assert.Equal(t, []string{"1"}, imported.Messages[0].Reactions[0].ExternalUserIds)
+	assert.Equal(t, []string{"admin"}, imported.Messages[0].Reactions[0].UserNames)
+
+	assert.Equal(t, "<p>resent</p>", imported.Messages[1].Text)
+	assert.Equal(t, "10", *imported.Messages[1].ThreadExternalId)
+	assert.Nil(t, imported.Messages[1].ReplyToExternalId)
+}
+
+func TestParseTelegramExportSkipsLongReactions(t *testing.T) {
+	imported, err := ParseChatImportArchive([]byte(`{
+		"name": "Project",
+		"id": 42,
+		"messages": [
+			{"id": 2, "type": "message", "date_unixtime": "1672567260", "from": "Alice", "from_id": "user10",
+				"text_entities": [{"type": "plain", "text": "Hello"}],
+				"reactions": [
+					{"type": "emoji", "emoji": "👨‍👩‍👧‍👦", "recent": [{"from": "Bob", "from_id": "user11"}]},
+					{"type": "emoji", "emoji": "👍🏽", "recent": [{"from": "Bob", "from_id": "user11"}]},
+					{"type": "emoji", "emoji": "❤️", "recent": [{"from": "Bob", "from_id": "user11"}]}
+				]}
+		]
+	}`), testChatImportMaxSize)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(imported.Messages))
+	// the family emoji consists of 7 code points
+	assert.Equal(t, []ImportedReaction{
+		{Reaction: "👍🏽", ExternalUserIds: []string{"user11"}, UserNames: []string{"Bob"}},
+		{Reaction: "❤️", ExternalUserIds: []string{"user11"}, UserNames: []string{"Bob"}},
+	}, imported.Messages[0].Reactions)
+}
+
+func TestParseUnknownArchive(t *testing.T) {
+	_, err := ParseChatImportArchive([]byte(`{"foo": "bar"}`), testChatImportMaxSize)
+	assert.NotNil(t, err)
+}
+
+func TestParseChatImportArchiveLimitsUnpackedSize(t *testing.T) {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	w, err := zipWriter.Create("messages.json")
+	assert.Nil(t, err)
+	_, err = w.Write([]byte(`{"chat": {"id": 5, "title": "` + strings.Repeat("a", 2048) + `"}, "messages": []}`))
+	assert.Nil(t, err)
+	assert.Nil(t, zipWriter.Close())
+
+	_, err = ParseChatImportArchive(buf.Bytes(), 1024)
+	assert.ErrorContains(t, err, "larger than 1024 bytes")
+
+	imported, err := ParseChatImportArchive(buf.Bytes(), testChatImportMaxSize)
+	assert.Nil(t, err)
+	assert.Equal(t, "5", imported.ExternalId)
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/spf13/viper"
+	"golang.org/x/net/html"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/logger"
+)
+
+type BlocklistPattern struct {
+	Pattern string
+	Regex   bool // otherwise the pattern is the whole word
+	Action  string
+}
+
+// the data which is gathered by the caller, the rules themselves don't touch the database
+type ContentFilterInput struct {
+	Text             string // the sanitized html
+	IsChatAdmin      bool   // the admins aren't subject to the spam heuristics
+	ParticipantSince time.Time
+	RepeatedCount    int64 // the identical messages of the user in the chat within the window
+	Blocklist        []*BlocklistPattern
+
+	links int
+}
+
+type ContentFilterVerdict struct {
+	Action string // empty means the message is passed as is
+	Text   string // the text with the masked words
+	Reason string
+}
+
+// the stronger action wins, the masking is applied anyway
+var contentFilterActionWeights = map[string]int{
+	dto.ContentFilterActionMask:   1,
+	dto.ContentFilterActionHold:   2,
+	dto.ContentFilterActionReject: 3,
+}
+
+func (v *ContentFilterVerdict) apply(action, reason string) {
+	if contentFilterActionWeights[action] > contentFilterActionWeights[v.Action] {
+		v.Action = action
+		v.Reason = reason
+	}
+}
+
+type ContentFilterRule interface {
+	Check(now time.Time, input *ContentFilterInput, verdict *ContentFilterVerdict)
+}
+
+type blocklistRule struct{}
+
+func (r *blocklistRule) Check(now time.Time, input *ContentFilterInput, verdict *ContentFilterVerdict) {
+	for _, p := range input.Blocklist {
+		re, err := CompileBlocklistPattern(p.Pattern, p.Regex)
+		if err != nil {
+			// was validated on creating
+			continue
+		}
+		masked, found := maskBlocklisted(verdict.Text, re, !p.Regex)
+		if !found {
+			continue
+		}
+		if p.Action == dto.ContentFilterActionMask {
+			verdict.Text = masked
+		}
+		verdict.apply(p.Action, "The message contains a forbidden word")
+	}
+}
+
+type linksRule struct {
+	maxLinks int
+	action   string
+}
+
+func (r *linksRule) Check(now time.Time, input *ContentFilterInput, verdict *ContentFilterVerdict) {
+	if !input.IsChatAdmin && input.links > r.maxLinks {
+		verdict.apply(r.action, fmt.Sprintf("The message contains more than %v links", r.maxLinks))
+	}
+}
+
+type repeatedMessagesRule struct {
+	maxCount int64
+	action   string
+}
+
+func (r *repeatedMessagesRule) Check(now time.Time, input *ContentFilterInput, verdict *ContentFilterVerdict) {
+	if !input.IsChatAdmin && input.RepeatedCount >= r.maxCount {
+		verdict.apply(r.action, "The same message was posted too many times")
+	}
+}
+
+type newParticipantRule struct {
+	period   time.Duration
+	maxLinks int
+	action   string
+}
+
+func (r *newParticipantRule) Check(now time.Time, input *ContentFilterInput, verdict *ContentFilterVerdict) {
+	if !input.IsChatAdmin && now.Sub(input.ParticipantSince) < r.period && input.links > r.maxLinks {
+		verdict.apply(r.action, "The new participant cannot post links yet")
+	}
+}
+
+// runs the rules against the posted or the edited message
+type ContentFilter struct {
+	lgr            *logger.Logger
+	rules          []ContentFilterRule
+	repeatedWindow time.Duration
+}
+
+func NewContentFilter(lgr *logger.Logger) *ContentFilter {
+	f := &ContentFilter{
+		lgr:            lgr,
+		repeatedWindow: viper.GetDuration("contentFilter.repeatedMessages.window"),
+	}
+	f.rules = append(f.rules, &blocklistRule{})
+	if viper.IsSet("contentFilter.maxLinks") {
+		f.rules = append(f.rules, &linksRule{
+			maxLinks: viper.GetInt("contentFilter.maxLinks"),
+			action:   f.getAction("contentFilter.linksAction"),
+		})
+	}
+	if f.repeatedWindow > 0 {
+		f.rules = append(f.rules, &repeatedMessagesRule{
+			maxCount: viper.GetInt64("contentFilter.repeatedMessages.maxCount"),
+			action:   f.getAction("contentFilter.repeatedMessages.action"),
+		})
+	}
+	if period := viper.GetDuration("contentFilter.newParticipant.period"); period > 0 {
+		f.rules = append(f.rules, &newParticipantRule{
+			period:   period,
+			maxLinks: viper.GetInt("contentFilter.newParticipant.maxLinks"),
+			action:   f.getAction("contentFilter.newParticipant.action"),
+		})
+	}
+	return f
+}
+
+// the masking is meaningful only for the blocklist, so the other rules reject by default
+func (f *ContentFilter) getAction(key string) string {
+	action := viper.GetString(key)
+	if action != dto.ContentFilterActionReject && action != dto.ContentFilterActionHold {
+		f.lgr.Warnf("Unsupported action %q of %v, the message is going to be rejected", action, key)
+		return dto.ContentFilterActionReject
+	}
+	return action
+}
+
+func (f *ContentFilter) Enabled() bool {
+	return viper.GetBool("contentFilter.enabled")
+}
+
+// the caller counts the identical messages within this window
+func (f *ContentFilter) RepeatedMessagesWindow() time.Duration {
+	return f.repeatedWindow
+}
+
+func (f *ContentFilter) Check(input *ContentFilterInput) *ContentFilterVerdict {
+	return checkContent(time.Now().UTC(), f.rules, input)
+}
+
+func checkContent(now time.Time, rules []ContentFilterRule, input *ContentFilterInput) *ContentFilterVerdict {
+	input.links = len(findUrls(input.Text))
+	verdict := &ContentFilterVerdict{Text: input.Text}
+	for _, rule := range rules {
+		rule.Check(now, input, verdict)
+	}
+	return verdict
+}
+
+const maxBlocklistPatternLength = 256
+
+var blocklistPatternCache sync.Map
+
+// the patterns are case-insensitive, the compiled ones are cached because the same entries are checked on every message
+func CompileBlocklistPattern(pattern string, regex bool) (*regexp.Regexp, error) {
+	key := fmt.Sprintf("%v:%v", regex, pattern)
+	if cached, ok := blocklistPatternCache.Load(key); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	if utf8.RuneCountInString(pattern) > maxBlocklistPatternLength {
+		return nil, fmt.Errorf("the pattern is longer than %v", maxBlocklistPatternLength)
+	}
+	expr := pattern
+	if !regex {
+		expr = regexp.QuoteMeta(strings.TrimSpace(pattern))
+	}
+	re, err := regexp.Compile("(?i)" + expr)
+	if err != nil {
+		return nil, err
+	}
+	if re.MatchString("") {
+		return nil, fmt.Errorf("the pattern matches the empty string")
+	}
+	blocklistPatternCache.Store(key, re)
+	return re, nil
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_'
+}
+
+// \b of RE2 doesn't know about the non-latin letters, so the boundaries of the whole word are checked here
+func isWholeWord(s string, start, end int) bool {
+	if start > 0 {
+		if r, _ := utf8.DecodeLastRuneInString(s[:start]); isWordRune(r) {
+			return false
+		}
+	}
+	if end < len(s) {
+		if r, _ := utf8.DecodeRuneInString(s[end:]); isWordRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func maskText(s string, re *regexp.Regexp, wholeWord bool) (string, bool) {
+	var sb strings.Builder
+	var found bool
+	var last int
+	for _, loc := range re.FindAllStringIndex(s, -1) {
+		if loc[0] == loc[1] || (wholeWord && !isWholeWord(s, loc[0], loc[1])) {
+			continue
+		}
+		found = true
+		sb.WriteString(s[last:loc[0]])
+		sb.WriteString(strings.Repeat("*", utf8.RuneCountInString(s[loc[0]:loc[1]])))
+		last = loc[1]
+	}
+	if !found {
+		return s, false
+	}
+	sb.WriteString(s[last:])
+	return sb.String(), true
+}
+
+// only the text is checked, not the tags and their attributes
+func maskBlocklisted(text string, re *regexp.Regexp, wholeWord bool) (string, bool) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(text))
+	if err != nil {
+		return text, false
+	}
+	var found bool
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			if masked, ok := maskText(n.Data, re, wholeWord); ok {
+				n.Data = masked
+				found = true
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	for _, n := range doc.Find("body").Nodes {
+		walk(n)
+	}
+	if !found {
+		return text, false
+	}
+	masked, err := doc.Find("body").Html()
+	if err != nil {
+		return text, false
+	}
+	return masked, true
+}
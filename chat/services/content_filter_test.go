@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"nkonev.name/chat/dto"
+)
+
+func TestMaskBlocklistedWholeWord(t *testing.T) {
+	re, err := CompileBlocklistPattern("spam", false)
+	assert.Nil(t, err)
+
+	masked, found := maskBlocklisted(`<p>Spam and spammer, <a href="https://spam.example">spam</a></p>`, re, true)
+	assert.True(t, found)
+	assert.Equal(t, `<p>**** and spammer, <a href="https://spam.example">****</a></p>`, masked)
+
+	_, found = maskBlocklisted(`<p>antispam</p>`, re, true)
+	assert.False(t, found)
+
+	cyrillic, err := CompileBlocklistPattern("слово", false)
+	assert.Nil(t, err)
+	masked, found = maskBlocklisted(`<p>Слово, словом</p>`, cyrillic, true)
+	assert.True(t, found)
+	assert.Equal(t, `<p>*****, словом</p>`, masked)
+}
+
+func TestCompileBlocklistPattern(t *testing.T) {
+	re, err := CompileBlocklistPattern(`c[a@]sino`, true)
+	assert.Nil(t, err)
+	masked, found := maskBlocklisted(`<p>best C@sinos</p>`, re, false)
+	assert.True(t, found)
+	assert.Equal(t, `<p>best ******s</p>`, masked)
+
+	_, err = CompileBlocklistPattern(`(`, true)
+	assert.NotNil(t, err)
+	_, err = CompileBlocklistPattern(`a*`, true)
+	assert.NotNil(t, err)
+}
+
+func TestCheckContent(t *testing.T) {
+	now := time.Now().UTC()
+	rules := []ContentFilterRule{
+		&blocklistRule{},
+		&linksRule{maxLinks: 2, action: dto.ContentFilterActionReject},
+		&repeatedMessagesRule{maxCount: 2, action: dto.ContentFilterActionReject},
+		&newParticipantRule{period: time.Hour, maxLinks: 0, action: dto.ContentFilterActionHold},
+	}
+	blocklist := []*BlocklistPattern{
+		{Pattern: "darn", Action: dto.ContentFilterActionMask},
+		{Pattern: "casino", Action: dto.ContentFilterActionHold},
+	}
+
+	verdict := checkContent(now, rules, &ContentFilterInput{Text: "<p>darn it</p>", ParticipantSince: now.Add(-2 * time.Hour), Blocklist: blocklist})
+	assert.Equal(t, dto.ContentFilterActionMask, verdict.Action)
+	assert.Equal(t, "<p>**** it</p>", verdict.Text)
+
+	verdict = checkContent(now, rules, &ContentFilterInput{Text: "<p>darn casino</p>", ParticipantSince: now.Add(-2 * time.Hour), Blocklist: blocklist})
+	assert.Equal(t, dto.ContentFilterActionHold, verdict.Action)
+	assert.Equal(t, "<p>**** casino</p>", verdict.Text)
+
+	verdict = checkContent(now, rules, &ContentFilterInput{Text: "<p>https://a.example https://b.example https://c.example</p>", ParticipantSince: now.Add(-2 * time.Hour)})
+	assert.Equal(t, dto.ContentFilterActionReject, verdict.Action)
+
+	verdict = checkContent(now, rules, &ContentFilterInput{Text: "<p>https://a.example https://b.example https://c.example</p>", IsChatAdmin: true, ParticipantSince: now})
+	assert.Equal(t, "", verdict.Action)
+
+	verdict = checkContent(now, rules, &ContentFilterInput{Text: "<p>hello</p>", ParticipantSince: now.Add(-2 * time.Hour), RepeatedCount: 2})
+	assert.Equal(t, dto.ContentFilterActionReject, verdict.Action)
+
+	verdict = checkContent(now, rules, &ContentFilterInput{Text: "<p>see https://a.example</p>", ParticipantSince: now.Add(-time.Minute)})
+	assert.Equal(t, dto.ContentFilterActionHold, verdict.Action)
+
+	verdict = checkContent(now, rules, &ContentFilterInput{Text: "<p>hello</p>", ParticipantSince: now.Add(-time.Minute)})
+	assert.Equal(t, "", verdict.Action)
+	assert.Equal(t, "<p>hello</p>", verdict.Text)
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const CustomEmojiClass = "custom-emoji"
+
+var emojiShortcodeRegexp = regexp.MustCompile(`^[a-z0-9_+-]{2,32}$`)
+
+var emojiReferenceRegexp = regexp.MustCompile(`:([a-z0-9_+-]{2,32}):`)
+
+func IsValidEmojiShortcode(shortcode string) bool {
+	return emojiShortcodeRegexp.MatchString(shortcode)
+}
+
+// the reaction ":shortcode:" references the custom emoji of the chat
+func ParseEmojiReference(reaction string) (string, bool) {
+	if len(reaction) < 2 || !strings.HasPrefix(reaction, ":") || !strings.HasSuffix(reaction, ":") {
+		return "", false
+	}
+	shortcode := reaction[1 : len(reaction)-1]
+	if !IsValidEmojiShortcode(shortcode) {
+		return "", false
+	}
+	return shortcode, true
+}
+
+// the emojis of the chat are fetched only if the text mentions some shortcode
+func HasEmojiReferences(text string) bool {
+	return emojiReferenceRegexp.MatchString(text)
+}
+
+func newCustomEmojiNode(shortcode, url string) *html.Node {
+	return &html.Node{
+		Type:     html.ElementNode,
+		DataAtom: atom.Img,
+		Data:     "img",
+		Attr: []html.Attribute{
+			{Key: "class", Val: CustomEmojiClass},
+			{Key: "src", Val: url},
+			{Key: "alt", Val: ":" + shortcode + ":"},
+		},
+	}
+}
+
+// splits the text node by the known shortcodes, returns false if there is nothing to replace
+func replaceEmojisInTextNode(n *html.Node, emojis map[string]string) bool {
+	var replaced bool
+	var last int
+	s := n.Data
+	for _, loc := range emojiReferenceRegexp.FindAllStringSubmatchIndex(s, -1) {
+		url, ok := emojis[s[loc[2]:loc[3]]]
+		if !ok {
+			continue
+		}
+		replaced = true
+		if loc[0] > last {
+			n.Parent.InsertBefore(&html.Node{Type: html.TextNode, Data: s[last:loc[0]]}, n)
+		}
+		n.Parent.InsertBefore(newCustomEmojiNode(s[loc[2]:loc[3]], url), n)
+		last = loc[1]
+	}
+	if !replaced {
+		return false
+	}
+	if last < len(s) {
+		n.Data = s[last:]
+	} else {
+		n.Parent.RemoveChild(n)
+	}
+	return true
+}
+
+// replaces :shortcode: with the image of the custom emoji, the code and the links are left as is
+func ReplaceCustomEmojis(text string, emojis map[string]string) string {
+	if len(emojis) == 0 || !HasEmojiReferences(text) {
+		return text
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(text))
+	if err != nil {
+		return text
+	}
+	var replaced bool
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && (n.DataAtom == atom.Pre || n.DataAtom == atom.Code || n.DataAtom == atom.A) {
+			return
+		}
+		for child := n.FirstChild; child != nil; {
+			next := child.NextSibling
+			if child.Type == html.TextNode {
+				if replaceEmojisInTextNode(child, emojis) {
+					replaced = true
+				}
+			} else {
+				walk(child)
+			}
+			child = next
+		}
+	}
+	for _, n := range doc.Find("body").Nodes {
+		walk(n)
+	}
+	if !replaced {
+		return text
+	}
+	ret, err := doc.Find("body").Html()
+	if err != nil {
+		return text
+	}
+	return ret
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceCustomEmojis(t *testing.T) {
+	emojis := map[string]string{
+		"party": "/api/storage/public/chat/emoji/1_a.png",
+		"ok":    "/api/storage/public/chat/emoji/1_b.png",
+	}
+
+	assert.Equal(t,
+		`<p>let&#39;s <img class="custom-emoji" src="/api/storage/public/chat/emoji/1_a.png" alt=":party:"/>!<img class="custom-emoji" src="/api/storage/public/chat/emoji/1_b.png" alt=":ok:"/> :unknown:</p>`,
+		ReplaceCustomEmojis(`<p>let's :party:!:ok: :unknown:</p>`, emojis),
+	)
+
+	assert.Equal(t,
+		`<p><code>:party:</code> <a href="https://example.com">:ok:</a> <img class="custom-emoji" src="/api/storage/public/chat/emoji/1_b.png" alt=":ok:"/></p>`,
+		ReplaceCustomEmojis(`<p><code>:party:</code> <a href="https://example.com">:ok:</a> :ok:</p>`, emojis),
+	)
+
+	assert.Equal(t, `<p>no emojis: here</p>`, ReplaceCustomEmojis(`<p>no emojis: here</p>`, emojis))
+}
+
+func TestParseEmojiReference(t *testing.T) {
+	shortcode, ok := ParseEmojiReference(":party_parrot:")
+	assert.True(t, ok)
+	assert.Equal(t, "party_parrot", shortcode)
+
+	_, ok = ParseEmojiReference("👍")
+	assert.False(t, ok)
+	_, ok = ParseEmojiReference(":Party:")
+	assert.False(t, ok)
+	_, ok = ParseEmojiReference(":")
+	assert.False(t, ok)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/getlantern/deepcopy"
 	"github.com/guregu/null"
@@ -43,16 +44,16 @@ type DisplayMessageDtoNotification struct {
 
 const NoPagePlaceholder = -1
 
-func (not *Events) NotifyAboutNewChat(ctx context.Context, newChatDto *dto.ChatDto, userIds []int64, isSingleTetATetParticipant bool, overrideIsParticipant bool, tx *db.Tx, areAdminsMap map[int64]bool) {
-	not.chatNotifyCommon(ctx, userIds, newChatDto, "chat_created", isSingleTetATetParticipant, overrideIsParticipant, tx, areAdminsMap)
+func (not *Events) NotifyAboutNewChat(ctx context.Context, newChatDto *dto.ChatDto, userIds []int64, isSingleTetATetParticipant bool, overrideIsParticipant bool, tx *db.Tx, roles map[int64]string) {
+	not.chatNotifyCommon(ctx, userIds, newChatDto, "chat_created", isSingleTetATetParticipant, overrideIsParticipant, tx, roles)
 }
 
-func (not *Events) NotifyAboutChangeChat(ctx context.Context, chatDto *dto.ChatDto, userIds []int64, isSingleTetATetParticipant bool, overrideIsParticipant bool, tx *db.Tx, areAdminsMap map[int64]bool) {
-	not.chatNotifyCommon(ctx, userIds, chatDto, "chat_edited", isSingleTetATetParticipant, overrideIsParticipant, tx, areAdminsMap)
+func (not *Events) NotifyAboutChangeChat(ctx context.Context, chatDto *dto.ChatDto, userIds []int64, isSingleTetATetParticipant bool, overrideIsParticipant bool, tx *db.Tx, roles map[int64]string) {
+	not.chatNotifyCommon(ctx, userIds, chatDto, "chat_edited", isSingleTetATetParticipant, overrideIsParticipant, tx, roles)
 }
 
-func (not *Events) NotifyAboutRedrawLeftChat(ctx context.Context, chatDto *dto.ChatDto, userId int64, isSingleTetATetParticipant bool, overrideIsParticipant bool, tx *db.Tx, areAdminsMap map[int64]bool) {
-	not.chatNotifyCommon(ctx, []int64{userId}, chatDto, "chat_redraw", isSingleTetATetParticipant, overrideIsParticipant, tx, areAdminsMap)
+func (not *Events) NotifyAboutRedrawLeftChat(ctx context.Context, chatDto *dto.ChatDto, userId int64, isSingleTetATetParticipant bool, overrideIsParticipant bool, tx *db.Tx, roles map[int64]string) {
+	not.chatNotifyCommon(ctx, []int64{userId}, chatDto, "chat_redraw", isSingleTetATetParticipant, overrideIsParticipant, tx, roles)
 }
 
 func (not *Events) NotifyAboutDeleteChat(ctx context.Context, chatId int64, userIds []int64, tx *db.Tx) {
@@ -67,7 +68,7 @@ func (not *Events) NotifyAboutDeleteChat(ctx context.Context, chatId int64, user
 /**
  * isSingleParticipant should be taken from responseDto or count. using len(participants) where participants are a portion from Iterate...() is incorrect because we can get only one user in the last iteration
  */
-func (not *Events) chatNotifyCommon(ctx context.Context, userIds []int64, newChatDto *dto.ChatDto, eventType string, isSingleTetATetParticipant bool, overrideIsParticipant bool, tx *db.Tx, areAdminsMap map[int64]bool) {
+func (not *Events) chatNotifyCommon(ctx context.Context, userIds []int64, newChatDto *dto.ChatDto, eventType string, isSingleTetATetParticipant bool, overrideIsParticipant bool, tx *db.Tx, roles map[int64]string) {
 	not.lgr.WithTracing(ctx).Debugf("Sending notification about %v the chat to participants: %v", eventType, userIds)
 
 	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("chat.%s", eventType))
@@ -112,7 +113,7 @@ func (not *Events) chatNotifyCommon(ctx context.Context, userIds []int64, newCha
 
 			// see also handlers/chat.go:199 convertToDto()
 			// override pinned personally for participantId
-			copied.SetPersonalizedFields(areAdminsMap[participantId], unreadMessages[participantId], overrideIsParticipant, isChatPinnedMap[participantId])
+			copied.SetPersonalizedFields(roles[participantId], unreadMessages[participantId], overrideIsParticipant, isChatPinnedMap[participantId])
 
 			// set chat name and avatar for tet-a-tet
 			for _, participant := range copied.Participants {
@@ -208,17 +209,24 @@ func (not *Events) NotifyAboutHasNewMessagesChanged(ctx context.Context, partici
 	}
 }
 
-func (not *Events) messageNotifyCommon(ctx context.Context, userIds []int64, chatId int64, message *dto.DisplayMessageDto, eventType string, chatBasic *db.BasicChatDto, chatAdmins map[int64]bool) {
+func (not *Events) messageNotifyCommon(ctx context.Context, userIds []int64, chatId int64, message *dto.DisplayMessageDto, eventType string, chatBasic *db.BasicChatDto, chatRoles map[int64]string) {
+	isDeleted := eventType == "message_deleted"
+	// thread replies don't go to the main timeline, so we distinguish them by the event type
+	if message.ThreadId != nil {
+		eventType = "thread_" + eventType
+	}
+
 	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("message.%s", eventType))
 	defer messageSpan.End()
 
 	for _, participantId := range userIds {
-		if eventType == "message_deleted" {
+		if isDeleted {
 			err := not.rabbitEventPublisher.Publish(ctx, dto.ChatEvent{
 				EventType: eventType,
 				MessageDeletedNotification: &dto.MessageDeletedDto{
-					Id:     message.Id,
-					ChatId: message.ChatId,
+					Id:       message.Id,
+					ChatId:   message.ChatId,
+					ThreadId: message.ThreadId,
 				},
 				UserId: participantId,
 				ChatId: chatId,
@@ -233,7 +241,8 @@ func (not *Events) messageNotifyCommon(ctx context.Context, userIds []int64, cha
 				continue
 			}
 
-			copied.SetPersonalizedFields(chatBasic.RegularParticipantCanPublishMessage, chatBasic.RegularParticipantCanPinMessage, chatBasic.RegularParticipantCanWriteMessage, chatAdmins[participantId], participantId)
+			copied.SetPersonalizedFields(chatBasic.PermissionSettings(), chatRoles[participantId], participantId)
+			copied.SetPersonalizedPoll(message, chatBasic.PermissionSettings(), chatRoles[participantId], participantId)
 
 			err := not.rabbitEventPublisher.Publish(ctx, dto.ChatEvent{
 				EventType:           eventType,
@@ -248,16 +257,37 @@ func (not *Events) messageNotifyCommon(ctx context.Context, userIds []int64, cha
 	}
 }
 
-func (not *Events) NotifyAboutNewMessage(ctx context.Context, userIds []int64, chatId int64, message *dto.DisplayMessageDto, chatBasic *db.BasicChatDto, chatAdmins map[int64]bool) {
-	not.messageNotifyCommon(ctx, userIds, chatId, message, "message_created", chatBasic, chatAdmins)
+func (not *Events) NotifyAboutNewMessage(ctx context.Context, userIds []int64, chatId int64, message *dto.DisplayMessageDto, chatBasic *db.BasicChatDto, chatRoles map[int64]string) {
+	not.messageNotifyCommon(ctx, userIds, chatId, message, "message_created", chatBasic, chatRoles)
 }
 
 func (not *Events) NotifyAboutDeleteMessage(ctx context.Context, userIds []int64, chatId int64, message *dto.DisplayMessageDto) {
 	not.messageNotifyCommon(ctx, userIds, chatId, message, "message_deleted", nil, nil)
 }
 
-func (not *Events) NotifyAboutEditMessage(ctx context.Context, userIds []int64, chatId int64, message *dto.DisplayMessageDto, chatBasic *db.BasicChatDto, chatAdmins map[int64]bool) {
-	not.messageNotifyCommon(ctx, userIds, chatId, message, "message_edited", chatBasic, chatAdmins)
+func (not *Events) NotifyAboutEditMessage(ctx context.Context, userIds []int64, chatId int64, message *dto.DisplayMessageDto, chatBasic *db.BasicChatDto, chatRoles map[int64]string) {
+	not.messageNotifyCommon(ctx, userIds, chatId, message, "message_edited", chatBasic, chatRoles)
+}
+
+func (not *Events) NotifyAboutThreadChanged(ctx context.Context, chatId int64, thread *dto.ThreadDto, participantIds []int64, unreadReplies map[int64]int64) {
+	eventType := "thread_changed"
+	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("message.%s", eventType))
+	defer messageSpan.End()
+
+	for _, participantId := range participantIds {
+		err := not.rabbitEventPublisher.Publish(ctx, dto.ChatEvent{
+			EventType: eventType,
+			ThreadChangedEvent: &dto.ThreadChangedEvent{
+				Thread:        *thread,
+				UnreadReplies: unreadReplies[participantId],
+			},
+			UserId: participantId,
+			ChatId: chatId,
+		})
+		if err != nil {
+			not.lgr.WithTracing(ctx).Errorf("Error during sending to rabbitmq : %s", err)
+		}
+	}
 }
 
 func (not *Events) NotifyAboutMessageTyping(ctx context.Context, chatId int64, user *dto.User, co db.CommonOperations) {
@@ -544,7 +574,7 @@ func (not *Events) NotifyAboutPromotePinnedMessageEdit(ctx context.Context, chat
 	}
 }
 
-func (not *Events) NotifyAboutPublishedMessage(ctx context.Context, chatId int64, msg *dto.PublishedMessageEvent, publish bool, participantIds []int64, regularParticipantCanPublishMessage bool, areAdmins map[int64]bool) {
+func (not *Events) NotifyAboutPublishedMessage(ctx context.Context, chatId int64, msg *dto.PublishedMessageEvent, publish bool, participantIds []int64, permissionSettings dto.ChatPermissionSettings, roles map[int64]string) {
 
 	var eventType = ""
 	if publish {
@@ -564,7 +594,7 @@ func (not *Events) NotifyAboutPublishedMessage(ctx context.Context, chatId int64
 			continue
 		}
 
-		copied.Message.CanPublish = dto.CanPublishMessage(regularParticipantCanPublishMessage, areAdmins[participantId], copied.Message.OwnerId, participantId)
+		copied.Message.CanPublish = dto.CanPublishMessage(permissionSettings, roles[participantId], copied.Message.OwnerId, participantId)
 
 		err := not.rabbitEventPublisher.Publish(ctx, dto.ChatEvent{
 			EventType:                    eventType,
@@ -578,7 +608,7 @@ func (not *Events) NotifyAboutPublishedMessage(ctx context.Context, chatId int64
 	}
 }
 
-func (not *Events) NotifyAboutPublishedMessageEdit(ctx context.Context, chatId int64, msg *dto.PublishedMessageEvent, participantIds []int64, regularParticipantCanPublishMessage bool, areAdmins map[int64]bool) {
+func (not *Events) NotifyAboutPublishedMessageEdit(ctx context.Context, chatId int64, msg *dto.PublishedMessageEvent, participantIds []int64, permissionSettings dto.ChatPermissionSettings, roles map[int64]string) {
 
 	var eventType = "published_message_edit"
 
@@ -593,7 +623,7 @@ func (not *Events) NotifyAboutPublishedMessageEdit(ctx context.Context, chatId i
 			continue
 		}
 
-		copied.Message.CanPublish = dto.CanPublishMessage(regularParticipantCanPublishMessage, areAdmins[participantId], copied.Message.OwnerId, participantId)
+		copied.Message.CanPublish = dto.CanPublishMessage(permissionSettings, roles[participantId], copied.Message.OwnerId, participantId)
 
 		err := not.rabbitEventPublisher.Publish(ctx, dto.ChatEvent{
 			EventType:                    eventType,
@@ -607,7 +637,7 @@ func (not *Events) NotifyAboutPublishedMessageEdit(ctx context.Context, chatId i
 	}
 }
 
-func (not *Events) SendReactionEvent(ctx context.Context, wasChanged bool, chatId, messageId int64, reaction string, reactionUsers []*dto.User, count int, tx *db.Tx) {
+func (not *Events) SendReactionEvent(ctx context.Context, wasChanged bool, chatId, messageId int64, reaction string, url null.String, reactionUsers []*dto.User, count int, tx *db.Tx) {
 	var eventType string
 	if wasChanged {
 		eventType = "reaction_changed"
@@ -622,6 +652,7 @@ func (not *Events) SendReactionEvent(ctx context.Context, wasChanged bool, chatI
 		Count:    int64(count),
 		Reaction: reaction,
 		Users:    reactionUsers,
+		Url:      url,
 	}
 
 	reactionChangedEvent := dto.ReactionChangedEvent{
@@ -649,6 +680,44 @@ func (not *Events) SendReactionEvent(ctx context.Context, wasChanged bool, chatI
 	}
 }
 
+func (not *Events) SendPollEvent(ctx context.Context, chatId, messageId, messageOwnerId int64, poll *dto.PollDto, tx *db.Tx) {
+	eventType := "poll_changed"
+
+	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("notification.%s", eventType))
+	defer messageSpan.End()
+
+	err := tx.IterateOverChatParticipantIds(ctx, chatId, func(participantIds []int64) error {
+		roles, err := tx.GetRolesBatchByParticipants(ctx, participantIds, chatId)
+		if err != nil {
+			return err
+		}
+		rolesMap := map[int64]string{}
+		for _, role := range roles {
+			rolesMap[role.UserId] = role.Role
+		}
+
+		for _, participantId := range participantIds {
+			err := not.rabbitEventPublisher.Publish(ctx, dto.ChatEvent{
+				EventType: eventType,
+				PollChangedEvent: &dto.PollChangedEvent{
+					MessageId: messageId,
+					Poll:      poll.Personalized(messageOwnerId, dto.HasChatPermission(rolesMap[participantId], dto.ChatPermissionSettings{}, dto.PermissionDeleteOthersMessages), participantId),
+				},
+				UserId: participantId,
+				ChatId: chatId,
+			})
+			if err != nil {
+				not.lgr.WithTracing(ctx).Errorf("Error during sending to rabbitmq : %s", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		not.lgr.WithTracing(ctx).Errorf("Error during getting chat participants")
+		return
+	}
+}
+
 func (not *Events) SendReactionOnYourMessage(ctx context.Context, wasAdded bool, chatId, messageId, messageOwnerId int64, reaction string, behalfUserId int64, behalfLogin string, behalfAvatar *string, chatTitle string) {
 	var eventType string
 	if wasAdded {
@@ -682,6 +751,80 @@ func (not *Events) SendReactionOnYourMessage(ctx context.Context, wasAdded bool,
 
 }
 
+func (not *Events) SendChatExportReady(ctx context.Context, chatId int64, userId int64, chatTitle string, exportId int64, filename, url string) {
+	eventType := "chat_export_ready"
+
+	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("notification.%s", eventType))
+	defer messageSpan.End()
+
+	err := not.rabbitNotificationPublisher.Publish(ctx, dto.NotificationEvent{
+		EventType: eventType,
+		UserId:    userId,
+		ChatId:    chatId,
+		ByUserId:  userId,
+		ChatTitle: chatTitle,
+		ChatExportNotification: &dto.ChatExportNotification{
+			ExportId: exportId,
+			Filename: filename,
+			Url:      url,
+		},
+	})
+	if err != nil {
+		not.lgr.WithTracing(ctx).Errorf("Error during sending to rabbitmq : %s", err)
+	}
+}
+
+// asks the admins of the chat to approve or reject the request
+func (not *Events) SendJoinRequest(ctx context.Context, adminIds []int64, chatId, requestId int64, behalfUserId int64, behalfLogin string, behalfAvatar *string, chatTitle string) {
+	eventType := "join_request_added"
+
+	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("notification.%s", eventType))
+	defer messageSpan.End()
+
+	for _, adminId := range adminIds {
+		err := not.rabbitNotificationPublisher.Publish(ctx, dto.NotificationEvent{
+			EventType: eventType,
+			UserId:    adminId,
+			ChatId:    chatId,
+			ByUserId:  behalfUserId,
+			ByLogin:   behalfLogin,
+			ByAvatar:  behalfAvatar,
+			ChatTitle: chatTitle,
+			JoinRequestNotification: &dto.JoinRequestNotification{
+				RequestId: requestId,
+			},
+		})
+		if err != nil {
+			not.lgr.WithTracing(ctx).Errorf("Error during sending to rabbitmq : %s", err)
+		}
+	}
+}
+
+// the reminder is sent on behalf of the owner of the message, so the notification looks like the one about the mention
+func (not *Events) SendMessageReminder(ctx context.Context, userId, chatId, messageId int64, text string, ownerId int64, ownerLogin string, ownerAvatar *string, chatTitle string) {
+	eventType := "reminder"
+
+	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("notification.%s", eventType))
+	defer messageSpan.End()
+
+	err := not.rabbitNotificationPublisher.Publish(ctx, dto.NotificationEvent{
+		EventType: eventType,
+		UserId:    userId,
+		ChatId:    chatId,
+		ByUserId:  ownerId,
+		ByLogin:   ownerLogin,
+		ByAvatar:  ownerAvatar,
+		ChatTitle: chatTitle,
+		ReminderNotification: &dto.ReminderNotification{
+			MessageId: messageId,
+			Text:      text,
+		},
+	})
+	if err != nil {
+		not.lgr.WithTracing(ctx).Errorf("Error during sending to rabbitmq : %s", err)
+	}
+}
+
 func (not *Events) NotifyMessagesReloadCommand(ctx context.Context, chatId int64, participantIds []int64) {
 	eventType := "messages_reload"
 	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("chat.%s", eventType))
@@ -700,6 +843,45 @@ func (not *Events) NotifyMessagesReloadCommand(ctx context.Context, chatId int64
 
 }
 
+// is sent to all the sessions of the user, the nil draft means it was removed
+func (not *Events) NotifyAboutMessageDraft(ctx context.Context, userId, chatId int64, draft *dto.MessageDraftDto) {
+	eventType := "draft_changed"
+	if draft == nil {
+		eventType = "draft_deleted"
+		draft = &dto.MessageDraftDto{
+			ChatId: chatId,
+		}
+	}
+	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("chat.%s", eventType))
+	defer messageSpan.End()
+
+	err := not.rabbitEventPublisher.Publish(ctx, dto.GlobalUserEvent{
+		EventType:                eventType,
+		UserId:                   userId,
+		MessageDraftNotification: draft,
+	})
+	if err != nil {
+		not.lgr.WithTracing(ctx).Errorf("Error during sending to rabbitmq : %s", err)
+	}
+}
+
+// is sent only to the user who invoked the slash command
+func (not *Events) NotifyAboutEphemeralMessage(ctx context.Context, chatId, userId int64, message *dto.EphemeralMessageNotification) {
+	eventType := "ephemeral_message"
+	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("chat.%s", eventType))
+	defer messageSpan.End()
+
+	err := not.rabbitEventPublisher.Publish(ctx, dto.ChatEvent{
+		EventType:                    eventType,
+		UserId:                       userId,
+		ChatId:                       chatId,
+		EphemeralMessageNotification: message,
+	})
+	if err != nil {
+		not.lgr.WithTracing(ctx).Errorf("Error during sending to rabbitmq : %s", err)
+	}
+}
+
 func (not *Events) NotifyNewMessageBrowserNotification(ctx context.Context, add bool, participantId int64, chatId int64, chatName string, chatAvatar null.String, messageId int64, messageText string, ownerId int64, ownerLogin string) {
 	eventType := "browser_notification_add_message"
 	if !add {
@@ -727,3 +909,44 @@ func (not *Events) NotifyNewMessageBrowserNotification(ctx context.Context, add
 	}
 
 }
+
+func (not *Events) NotifyReminderBrowserNotification(ctx context.Context, userId int64, chatId int64, chatName string, chatAvatar null.String, messageId int64, messageText string, ownerId int64, ownerLogin string) {
+	eventType := "browser_notification_reminder"
+
+	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("notification.%s", eventType))
+	defer messageSpan.End()
+
+	err := not.rabbitEventPublisher.Publish(ctx, dto.GlobalUserEvent{
+		UserId:    userId,
+		EventType: eventType,
+		BrowserNotification: &dto.BrowserNotification{
+			ChatId:      chatId,
+			ChatName:    chatName,
+			ChatAvatar:  chatAvatar.Ptr(),
+			MessageId:   messageId,
+			MessageText: messageText,
+			OwnerId:     ownerId,
+			OwnerLogin:  ownerLogin,
+		},
+	})
+	if err != nil {
+		not.lgr.WithTracing(ctx).Errorf("Error during sending to rabbitmq : %s", err)
+	}
+}
+
+// enqueues the event for the outgoing webhooks of the chat, it's called once per event, not per portion of the participants
+func (not *Events) NotifyOutgoingWebhooks(ctx context.Context, tx *db.Tx, event *dto.OutgoingWebhookEvent) {
+	ctx, messageSpan := not.tr.Start(ctx, fmt.Sprintf("webhook.%s", event.EventType))
+	defer messageSpan.End()
+
+	event.CreateDateTime = time.Now().UTC()
+	payload, err := json.Marshal(event)
+	if err != nil {
+		not.lgr.WithTracing(ctx).Errorf("Error during marshalling the webhook event : %s", err)
+		return
+	}
+	err = tx.CreateOutgoingWebhookDeliveries(ctx, event.ChatId, event.EventType, payload)
+	if err != nil {
+		not.lgr.WithTracing(ctx).Errorf("Error during enqueueing the webhook event : %s", err)
+	}
+}
@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/guregu/null"
+	"github.com/spf13/viper"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/logger"
+	"nkonev.name/chat/utils"
+)
+
+const maxLinkPreviewTitleLen = 256
+const maxLinkPreviewDescriptionLen = 1024
+
+var errLinkPreviewForbiddenAddress = errors.New("the address is forbidden for the link preview")
+
+// the ranges which aren't covered by net.IP's IsPrivate(), IsLoopback() and so on
+var forbiddenLinkPreviewPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+var plainUrlRegexp = regexp.MustCompile(`https?://[^\s<>"']+`)
+
+type LinkPreviewService struct {
+	client      *http.Client
+	dbR         *db.DB
+	lgr         *logger.Logger
+	allow       []regexp.Regexp
+	deny        []regexp.Regexp
+	maxBodySize int64
+	cacheTtl    time.Duration
+	userAgent   string
+}
+
+func NewLinkPreviewService(lgr *logger.Logger, dbR *db.DB) *LinkPreviewService {
+	timeout := viper.GetDuration("linkPreview.timeout")
+	maxRedirects := viper.GetInt("linkPreview.maxRedirects")
+
+	srv := &LinkPreviewService{
+		dbR:         dbR,
+		lgr:         lgr,
+		allow:       utils.StringsToRegexpArray(viper.GetStringSlice("linkPreview.allow")),
+		deny:        utils.StringsToRegexpArray(viper.GetStringSlice("linkPreview.deny")),
+		maxBodySize: viper.GetInt64("linkPreview.maxBodySize"),
+		cacheTtl:    viper.GetDuration("linkPreview.cacheTtl"),
+		userAgent:   viper.GetString("linkPreview.userAgent"),
+	}
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: forbidInternalAddresses,
+	}
+	srv.client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+			MaxIdleConns:          viper.GetInt("http.maxIdleConns"),
+			IdleConnTimeout:       viper.GetDuration("http.idleConnTimeout"),
+		},
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %v redirects", maxRedirects)
+			}
+			if !srv.isUrlAllowed(req.URL) {
+				return fmt.Errorf("the redirect to %v isn't allowed", req.URL)
+			}
+			return nil
+		},
+	}
+	return srv
+}
+
+// the check is made against the resolved address, so neither a dns record nor a redirect can lead to the internal network
+// is used as the Control of the dialers of the urls which come from the users
+func forbidInternalAddresses(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if isForbiddenLinkPreviewAddress(ip) {
+		return errLinkPreviewForbiddenAddress
+	}
+	return nil
+}
+
+func isForbiddenLinkPreviewAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	for _, prefix := range forbiddenLinkPreviewPrefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (srv *LinkPreviewService) isUrlAllowed(u *url.URL) bool {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return false
+	}
+	str := u.String()
+	for _, r := range srv.deny {
+		if r.MatchString(str) {
+			return false
+		}
+	}
+	if len(srv.allow) == 0 {
+		return true
+	}
+	for _, r := range srv.allow {
+		if r.MatchString(str) {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the first link of the message's html which can have a preview, or an empty string
+func (srv *LinkPreviewService) FindFirstUrl(text string) string {
+	for _, candidate := range findUrls(text) {
+		u, err := url.Parse(candidate)
+		if err != nil {
+			continue
+		}
+		if srv.isUrlAllowed(u) {
+			return u.String()
+		}
+	}
+	return ""
+}
+
+func findUrls(text string) []string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(text))
+	if err != nil {
+		return nil
+	}
+	ret := make([]string, 0)
+	doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		ret = append(ret, strings.TrimSpace(s.AttrOr("href", "")))
+	})
+	// the links which weren't turned into <a> by the editor
+	doc.Find("pre, code").Remove()
+	ret = append(ret, plainUrlRegexp.FindAllString(doc.Text(), -1)...)
+	return ret
+}
+
+// returns the cached preview or fetches the page, nil means there is nothing to show
+func (srv *LinkPreviewService) GetPreview(ctx context.Context, rawUrl string) (*db.LinkPreview, error) {
+	cached, found, err := srv.dbR.GetCachedLinkPreview(ctx, rawUrl, time.Now().UTC().Add(-srv.cacheTtl))
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return cached, nil
+	}
+
+	lp, err := srv.fetch(ctx, rawUrl)
+	if err != nil {
+		// the unavailable page is cached as well, not to knock on it on every message
+		srv.lgr.WithTracing(ctx).Infof("Unable to get the link preview of %v: %v", rawUrl, err)
+	}
+	err = srv.dbR.PutCachedLinkPreview(ctx, rawUrl, lp)
+	if err != nil {
+		return nil, err
+	}
+	return lp, nil
+}
+
+func (srv *LinkPreviewService) get(ctx context.Context, u *url.URL, accept string) (*http.Response, error) {
+	if !srv.isUrlAllowed(u) {
+		return nil, fmt.Errorf("the url %v isn't allowed", u)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if srv.userAgent != "" {
+		req.Header.Set("User-Agent", srv.userAgent)
+	}
+	resp, err := srv.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %v", resp.StatusCode)
+	}
+	return resp, nil
+}
+
+func (srv *LinkPreviewService) fetch(ctx context.Context, rawUrl string) (*db.LinkPreview, error) {
+	pageUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := srv.get(ctx, pageUrl, "text/html")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("unsupported content type %v", mediaType)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(io.LimitReader(resp.Body, srv.maxBodySize))
+	if err != nil {
+		return nil, err
+	}
+
+	// relative urls are resolved against the page after the redirects
+	md, oembedUrl := extractLinkPreview(doc, resp.Request.URL)
+	if oembedUrl != nil && (md.Title == "" || md.ImageUrl == "") {
+		if o, err := srv.fetchOembed(ctx, oembedUrl); err != nil {
+			srv.lgr.WithTracing(ctx).Infof("Unable to get the oEmbed of %v: %v", rawUrl, err)
+		} else {
+			md.fillFromOembed(o)
+		}
+	}
+	return md.toLinkPreview(rawUrl, resp.Request.URL), nil
+}
+
+type oembedResponse struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailUrl string `json:"thumbnail_url"`
+}
+
+func (srv *LinkPreviewService) fetchOembed(ctx context.Context, u *url.URL) (*oembedResponse, error) {
+	resp, err := srv.get(ctx, u, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var o oembedResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, srv.maxBodySize)).Decode(&o); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// the metadata found on the page, the empty strings are the absent values
+type pageMetadata struct {
+	Title       string
+	TitleTag    string // is used when neither the meta tags nor oEmbed have the title
+	Description string
+	ImageUrl    string
+	SiteName    string
+}
+
+func metaContent(doc *goquery.Document, names ...string) string {
+	for _, name := range names {
+		// OpenGraph uses "property", Twitter cards and the rest use "name"
+		content := doc.Find(fmt.Sprintf(`meta[property=%q], meta[name=%q]`, name, name)).First().AttrOr("content", "")
+		if content = strings.TrimSpace(content); content != "" {
+			return content
+		}
+	}
+	return ""
+}
+
+func extractLinkPreview(doc *goquery.Document, pageUrl *url.URL) (*pageMetadata, *url.URL) {
+	md := &pageMetadata{
+		Title:       metaContent(doc, "og:title", "twitter:title"),
+		Description: metaContent(doc, "og:description", "twitter:description", "description"),
+		ImageUrl:    metaContent(doc, "og:image", "og:image:url", "og:image:secure_url", "twitter:image", "twitter:image:src"),
+		SiteName:    metaContent(doc, "og:site_name", "application-name"),
+	}
+
+	var oembedUrl *url.URL
+	if href, ok := doc.Find(`link[type="application/json+oembed"]`).First().Attr("href"); ok {
+		if u, err := pageUrl.Parse(strings.TrimSpace(href)); err == nil {
+			oembedUrl = u
+		}
+	}
+
+	md.TitleTag = strings.TrimSpace(doc.Find("title").First().Text())
+	return md, oembedUrl
+}
+
+func (md *pageMetadata) fillFromOembed(o *oembedResponse) {
+	if md.Title == "" {
+		md.Title = strings.TrimSpace(o.Title)
+	}
+	if md.Description == "" {
+		md.Description = strings.TrimSpace(o.AuthorName)
+	}
+	if md.ImageUrl == "" {
+		md.ImageUrl = strings.TrimSpace(o.ThumbnailUrl)
+	}
+	if md.SiteName == "" {
+		md.SiteName = strings.TrimSpace(o.ProviderName)
+	}
+}
+
+// returns nil in case there is nothing but the site name
+func (md *pageMetadata) toLinkPreview(rawUrl string, pageUrl *url.URL) *db.LinkPreview {
+	var imageUrl string
+	if md.ImageUrl != "" {
+		if u, err := pageUrl.Parse(md.ImageUrl); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+			imageUrl = u.String()
+		}
+	}
+	title := md.Title
+	if title == "" {
+		title = md.TitleTag
+	}
+	if title == "" && md.Description == "" && imageUrl == "" {
+		return nil
+	}
+	siteName := md.SiteName
+	if siteName == "" {
+		siteName = pageUrl.Hostname()
+	}
+	return &db.LinkPreview{
+		Url:         rawUrl,
+		Title:       null.NewString(cutRunes(title, maxLinkPreviewTitleLen), title != ""),
+		Description: null.NewString(cutRunes(md.Description, maxLinkPreviewDescriptionLen), md.Description != ""),
+		ImageUrl:    null.NewString(imageUrl, imageUrl != ""),
+		SiteName:    null.NewString(cutRunes(siteName, maxLinkPreviewTitleLen), siteName != ""),
+	}
+}
+
+func cutRunes(s string, size int) string {
+	runes := []rune(s)
+	if len(runes) <= size {
+		return s
+	}
+	return string(runes[:size]) + "..."
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestForbiddenLinkPreviewAddress(t *testing.T) {
+	for _, forbidden := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fd00::1", "fe80::1", "::ffff:127.0.0.1"} {
+		assert.True(t, isForbiddenLinkPreviewAddress(netip.MustParseAddr(forbidden)), forbidden)
+	}
+	for _, allowed := range []string{"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"} {
+		assert.False(t, isForbiddenLinkPreviewAddress(netip.MustParseAddr(allowed)), allowed)
+	}
+}
+
+func TestFindUrls(t *testing.T) {
+	urls := findUrls(`<p>see <a href="https://example.com/a">this</a> and https://example.org/b</p><pre>https://example.net/code</pre>`)
+	assert.Equal(t, []string{"https://example.com/a", "https://example.org/b"}, urls)
+}
+
+func TestExtractLinkPreview(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html><head>
+		<title>Page title</title>
+		<meta property="og:title" content="OpenGraph title">
+		<meta name="twitter:description" content="Twitter description">
+		<meta property="og:image" content="/img/cover.png">
+		<link rel="alternate" type="application/json+oembed" href="/oembed?url=x">
+	</head></html>`))
+	assert.Nil(t, err)
+	pageUrl, _ := url.Parse("https://example.com/articles/1")
+
+	md, oembedUrl := extractLinkPreview(doc, pageUrl)
+	assert.Equal(t, "https://example.com/oembed?url=x", oembedUrl.String())
+
+	lp := md.toLinkPreview("https://example.com/articles/1", pageUrl)
+	assert.Equal(t, "OpenGraph title", lp.Title.String)
+	assert.Equal(t, "Twitter description", lp.Description.String)
+	assert.Equal(t, "https://example.com/img/cover.png", lp.ImageUrl.String)
+	assert.Equal(t, "example.com", lp.SiteName.String)
+}
+
+func TestExtractLinkPreviewWithoutMetadata(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html><body>nothing</body></html>`))
+	assert.Nil(t, err)
+	pageUrl, _ := url.Parse("https://example.com")
+
+	md, oembedUrl := extractLinkPreview(doc, pageUrl)
+	assert.Nil(t, oembedUrl)
+	assert.Nil(t, md.toLinkPreview("https://example.com", pageUrl))
+}
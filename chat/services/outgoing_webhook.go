@@ -0,0 +1,130 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/logger"
+)
+
+const OutgoingWebhookSignatureHeader = "X-Webhook-Signature"
+const OutgoingWebhookTimestampHeader = "X-Webhook-Timestamp"
+const OutgoingWebhookEventHeader = "X-Webhook-Event"
+const OutgoingWebhookDeliveryHeader = "X-Webhook-Delivery"
+
+const maxOutgoingWebhookResponseSize = 64 * 1024
+
+// the receiver computes the same value with the secret of the webhook, the timestamp protects against the replay
+func SignOutgoingWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// the delay before the next attempt is doubled after each failed one, attempts counts the failed attempts
+func OutgoingWebhookBackoff(attempts int, initial, max time.Duration) time.Duration {
+	delay := initial
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+type OutgoingWebhookSender struct {
+	client *http.Client
+	lgr    *logger.Logger
+}
+
+func NewOutgoingWebhookSender(lgr *logger.Logger) *OutgoingWebhookSender {
+	timeout := viper.GetDuration("outgoingWebhook.timeout")
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+	}
+	// the url is set by the chat admin, so they shouldn't be able to reach the internal network by default
+	if !viper.GetBool("outgoingWebhook.allowInternalAddresses") {
+		dialer.Control = forbidInternalAddresses
+	}
+
+	return &OutgoingWebhookSender{
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy:                 nil,
+				DialContext:           dialer.DialContext,
+				TLSHandshakeTimeout:   timeout,
+				ResponseHeaderTimeout: timeout,
+				MaxIdleConns:          viper.GetInt("http.maxIdleConns"),
+				IdleConnTimeout:       viper.GetDuration("http.idleConnTimeout"),
+			},
+			Timeout: timeout,
+			// the redirect could lead the signed payload to the other receiver
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		lgr: lgr,
+	}
+}
+
+// the delivery is considered successful only on 2xx
+func (s *OutgoingWebhookSender) Send(ctx context.Context, delivery *db.OutgoingWebhookDelivery) error {
+	_, err := s.post(ctx, delivery.Url, delivery.Secret, delivery.EventType, delivery.Payload, func(req *http.Request) {
+		req.Header.Set(OutgoingWebhookDeliveryHeader, strconv.FormatInt(delivery.Id, 10))
+	})
+	return err
+}
+
+// posts the payload the same way as the webhook is posted and returns the body of the response, is used by the slash commands
+func (s *OutgoingWebhookSender) Call(ctx context.Context, url, secret, eventType string, payload []byte) ([]byte, error) {
+	return s.post(ctx, url, secret, eventType, payload, nil)
+}
+
+func (s *OutgoingWebhookSender) post(ctx context.Context, url, secret, eventType string, payload []byte, customize func(req *http.Request)) ([]byte, error) {
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(OutgoingWebhookEventHeader, eventType)
+	req.Header.Set(OutgoingWebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(OutgoingWebhookSignatureHeader, SignOutgoingWebhookPayload(secret, timestamp, payload))
+	if customize != nil {
+		customize(req)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOutgoingWebhookResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("the receiver responded with the status %v", resp.StatusCode)
+	}
+	return body, nil
+}
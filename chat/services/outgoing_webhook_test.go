@@ -0,0 +1,19 @@
+package services
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSignOutgoingWebhookPayload(t *testing.T) {
+	// echo -n '1700000000.{"eventType":"message_created"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=68713b07a740c383d59a963c95b4e3c2688ce8de9985e496733d9f9548639fc1", SignOutgoingWebhookPayload("secret", 1700000000, []byte(`{"eventType":"message_created"}`)))
+}
+
+func TestOutgoingWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, OutgoingWebhookBackoff(1, 10*time.Second, time.Hour))
+	assert.Equal(t, 20*time.Second, OutgoingWebhookBackoff(2, 10*time.Second, time.Hour))
+	assert.Equal(t, 80*time.Second, OutgoingWebhookBackoff(4, 10*time.Second, time.Hour))
+	assert.Equal(t, time.Hour, OutgoingWebhookBackoff(20, 10*time.Second, time.Hour))
+}
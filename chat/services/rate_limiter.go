@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+	"nkonev.name/chat/logger"
+)
+
+// the token bucket, the redis time is used, so all the instances of the service share the same clock
+var tokenBucketScript = redisV9.NewScript(`
+local capacity = tonumber(ARGV[1])
+local refillPerSecond = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * refillPerSecond / 1000)
+
+local allowed = 0
+local waitMs = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	waitMs = math.ceil((1 - tokens) * 1000 / refillPerSecond)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity * 1000 / refillPerSecond) + 1000)
+return {allowed, waitMs}
+`)
+
+// limits the actions of the user across all the chats, such as the posting of messages and the reactions
+type RateLimiter struct {
+	redisClient     *redisV9.Client
+	lgr             *logger.Logger
+	capacity        int64
+	refillPerSecond float64
+}
+
+func NewRateLimiter(redisClient *redisV9.Client, lgr *logger.Logger) *RateLimiter {
+	return &RateLimiter{
+		redisClient:     redisClient,
+		lgr:             lgr,
+		capacity:        viper.GetInt64("rateLimit.capacity"),
+		refillPerSecond: viper.GetFloat64("rateLimit.refillPerSecond"),
+	}
+}
+
+func rateLimitKey(userId int64) string {
+	return fmt.Sprintf("chat:rate-limit:%v", userId)
+}
+
+func incomingWebhookRateLimitKey(webhookId int64) string {
+	return fmt.Sprintf("chat:rate-limit:incoming-webhook:%v", webhookId)
+}
+
+// takes a token of the user's bucket, returns false and the time to wait when the bucket is empty
+func (r *RateLimiter) Allow(ctx context.Context, userId int64) (bool, time.Duration) {
+	return r.allow(ctx, rateLimitKey(userId), fmt.Sprintf("user %v", userId))
+}
+
+// the incoming webhook has its own bucket of the same size, all of them post on behalf of the same bot user
+func (r *RateLimiter) AllowIncomingWebhook(ctx context.Context, webhookId int64) (bool, time.Duration) {
+	return r.allow(ctx, incomingWebhookRateLimitKey(webhookId), fmt.Sprintf("incoming webhook %v", webhookId))
+}
+
+// the action is allowed when redis isn't available, the limit isn't worth the outage
+func (r *RateLimiter) allow(ctx context.Context, key, subject string) (bool, time.Duration) {
+	if !viper.GetBool("rateLimit.enabled") || r.capacity <= 0 || r.refillPerSecond <= 0 {
+		return true, 0
+	}
+
+	res, err := tokenBucketScript.Run(ctx, r.redisClient, []string{key}, r.capacity, r.refillPerSecond).Int64Slice()
+	if err != nil {
+		r.lgr.WithTracing(ctx).Errorf("Unable to check the rate limit of %v: %v", subject, err)
+		return true, 0
+	}
+	if len(res) != 2 {
+		r.lgr.WithTracing(ctx).Errorf("Unexpected response of the rate limit script for %v: %v", subject, res)
+		return true, 0
+	}
+
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond
+}
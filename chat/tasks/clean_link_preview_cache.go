@@ -0,0 +1,64 @@
+package tasks
+
+import (
+	"context"
+	"github.com/nkonev/dcron"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/logger"
+	"time"
+)
+
+type CleanLinkPreviewCacheTask struct {
+	dcron.Job
+}
+
+func CleanLinkPreviewCacheScheduler(
+	lgr *logger.Logger,
+	service *CleanLinkPreviewCacheService,
+) *CleanLinkPreviewCacheTask {
+	const key = "cleanLinkPreviewCacheTask"
+	var str = viper.GetString("schedulers." + key + ".cron")
+	lgr.Infof("Created CleanLinkPreviewCacheScheduler with cron %v", str)
+
+	job := dcron.NewJob(key, str, func(ctx context.Context) error {
+		service.doJob()
+		return nil
+	})
+
+	return &CleanLinkPreviewCacheTask{job}
+}
+
+type CleanLinkPreviewCacheService struct {
+	tracer trace.Tracer
+	dbR    *db.DB
+	lgr    *logger.Logger
+}
+
+func (srv *CleanLinkPreviewCacheService) doJob() {
+	ctx, span := srv.tracer.Start(context.Background(), "scheduler.cleanLinkPreviewCache")
+	defer span.End()
+
+	srv.lgr.WithTracing(ctx).Infof("Starting cleaning link preview cache job")
+
+	// the expired entries aren't used anyway, the previews of the messages are stored with them
+	olderThan := time.Now().UTC().Add(-viper.GetDuration("linkPreview.cacheTtl"))
+	deleted, err := srv.dbR.DeleteCachedLinkPreviews(ctx, olderThan)
+	if err != nil {
+		srv.lgr.WithTracing(ctx).Errorf("Got error during cleaning link preview cache: %v", err)
+		return
+	}
+
+	srv.lgr.WithTracing(ctx).Infof("End of cleaning link preview cache job, deleted %v", deleted)
+}
+
+func NewCleanLinkPreviewCacheService(lgr *logger.Logger, dbR *db.DB) *CleanLinkPreviewCacheService {
+	trcr := otel.Tracer("scheduler/clean-link-preview-cache")
+	return &CleanLinkPreviewCacheService{
+		tracer: trcr,
+		dbR:    dbR,
+		lgr:    lgr,
+	}
+}
@@ -0,0 +1,77 @@
+package tasks
+
+import (
+	"context"
+	"github.com/nkonev/dcron"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/handlers"
+	"nkonev.name/chat/logger"
+)
+
+type ExportChatsTask struct {
+	dcron.Job
+}
+
+func ExportChatsScheduler(
+	lgr *logger.Logger,
+	service *ExportChatsService,
+) *ExportChatsTask {
+	const key = "exportChatsTask"
+	var str = viper.GetString("schedulers." + key + ".cron")
+	lgr.Infof("Created ExportChatsScheduler with cron %v", str)
+
+	job := dcron.NewJob(key, str, func(ctx context.Context) error {
+		service.doJob()
+		return nil
+	})
+
+	return &ExportChatsTask{job}
+}
+
+type ExportChatsService struct {
+	chatHandler *handlers.ChatHandler
+	tracer      trace.Tracer
+	dbR         *db.DB
+	lgr         *logger.Logger
+}
+
+func (srv *ExportChatsService) doJob() {
+	ctx, span := srv.tracer.Start(context.Background(), "scheduler.exportChats")
+	defer span.End()
+	srv.processExports(ctx)
+}
+
+func (srv *ExportChatsService) processExports(c context.Context) {
+	batchExports := viper.GetInt("schedulers.exportChatsTask.batchExports")
+
+	exportIds, err := srv.dbR.GetPendingChatExportIds(c, batchExports)
+	if err != nil {
+		srv.lgr.WithTracing(c).Errorf("Got error GetPendingChatExportIds, error %v", err)
+		return
+	}
+	if len(exportIds) == 0 {
+		return
+	}
+
+	srv.lgr.WithTracing(c).Infof("Starting processing %v chat exports", len(exportIds))
+	for _, exportId := range exportIds {
+		err = srv.chatHandler.ProcessChatExport(c, exportId)
+		if err != nil {
+			srv.lgr.WithTracing(c).Errorf("Got error during processing chat export %v, error %v", exportId, err)
+		}
+	}
+	srv.lgr.WithTracing(c).Infof("End of processing chat exports")
+}
+
+func NewExportChatsService(lgr *logger.Logger, chatHandler *handlers.ChatHandler, dbR *db.DB) *ExportChatsService {
+	trcr := otel.Tracer("scheduler/export-chats")
+	return &ExportChatsService{
+		chatHandler: chatHandler,
+		tracer:      trcr,
+		dbR:         dbR,
+		lgr:         lgr,
+	}
+}
@@ -0,0 +1,185 @@
+package tasks
+
+import (
+	"context"
+	"github.com/nkonev/dcron"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"nkonev.name/chat/client"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/logger"
+	"nkonev.name/chat/services"
+	"nkonev.name/chat/utils"
+	"time"
+)
+
+type MessageRetentionTask struct {
+	dcron.Job
+}
+
+func MessageRetentionScheduler(
+	lgr *logger.Logger,
+	service *MessageRetentionService,
+) *MessageRetentionTask {
+	const key = "messageRetentionTask"
+	var str = viper.GetString("schedulers." + key + ".cron")
+	lgr.Infof("Created MessageRetentionScheduler with cron %v", str)
+
+	job := dcron.NewJob(key, str, func(ctx context.Context) error {
+		service.doJob()
+		return nil
+	})
+
+	return &MessageRetentionTask{job}
+}
+
+type MessageRetentionService struct {
+	notificator *services.Events
+	restClient  *client.RestClient
+	tracer      trace.Tracer
+	dbR         *db.DB
+	lgr         *logger.Logger
+}
+
+func (srv *MessageRetentionService) doJob() {
+	ctx, span := srv.tracer.Start(context.Background(), "scheduler.messageRetention")
+	defer span.End()
+	srv.processChats(ctx)
+}
+
+func (srv *MessageRetentionService) processChats(c context.Context) {
+	srv.lgr.WithTracing(c).Infof("Starting message retention job")
+
+	batchMessages := viper.GetInt("schedulers.messageRetentionTask.batchMessages")
+
+	var hasMoreChats = true
+	for chatPage := 0; hasMoreChats; chatPage++ {
+		chats, err := srv.dbR.GetChatsWithMessageRetention(c, utils.DefaultSize, utils.GetOffset(chatPage, utils.DefaultSize))
+		if err != nil {
+			srv.lgr.WithTracing(c).Errorf("Got error GetChatsWithMessageRetention, chatPage %v, error %v", chatPage, err)
+			return
+		}
+		hasMoreChats = len(chats) == utils.DefaultSize
+
+		for _, chat := range chats {
+			createdBefore := time.Now().UTC().Add(-time.Duration(chat.MessageRetentionSeconds) * time.Second)
+			srv.expireChat(c, chat.ChatId, createdBefore, batchMessages)
+		}
+	}
+
+	srv.lgr.WithTracing(c).Infof("End of message retention job")
+}
+
+func (srv *MessageRetentionService) expireChat(c context.Context, chatId int64, createdBefore time.Time, batchMessages int) {
+	var hasMoreMessages = true
+	for hasMoreMessages {
+		var fileItemUuids = map[string]bool{}
+		err := db.Transact(c, srv.dbR, func(tx *db.Tx) error {
+			expired, err := tx.DeleteExpiredMessages(c, chatId, createdBefore, batchMessages)
+			if err != nil {
+				return err
+			}
+			hasMoreMessages = len(expired) == batchMessages
+			if len(expired) == 0 {
+				return nil
+			}
+
+			var deleted = make([]db.ExpiredMessage, 0, len(expired))
+			var touchedThreads = map[int64]bool{}
+			for _, em := range expired {
+				err = tx.DeleteMessageRevisions(c, chatId, em.Id)
+				if err != nil {
+					return err
+				}
+				err = tx.DeletePoll(c, chatId, em.Id)
+				if err != nil {
+					return err
+				}
+				err = tx.DeleteMessageBookmarks(c, chatId, em.Id)
+				if err != nil {
+					return err
+				}
+				deleted = append(deleted, em)
+				if em.ThreadId == nil {
+					// a thread without its root can't be shown, so the replies go away before their own expiration
+					replies, err := tx.DeleteThreadReplies(c, chatId, em.Id)
+					if err != nil {
+						return err
+					}
+					deleted = append(deleted, replies...)
+					err = tx.DeleteThread(c, chatId, em.Id)
+					if err != nil {
+						return err
+					}
+				} else {
+					touchedThreads[*em.ThreadId] = true
+				}
+			}
+			for _, em := range deleted {
+				if em.ThreadId == nil {
+					// the thread of the deleted root is already gone
+					delete(touchedThreads, em.Id)
+				}
+				if em.FileItemUuid != nil {
+					fileItemUuids[*em.FileItemUuid] = true
+				}
+			}
+			for rootMessageId := range touchedThreads {
+				if _, err = tx.RefreshThread(c, chatId, rootMessageId); err != nil {
+					return err
+				}
+			}
+			srv.lgr.WithTracing(c).Infof("Deleted %v expired messages in chat %v", len(deleted), chatId)
+
+			return tx.IterateOverChatParticipantIds(c, chatId, func(participantIds []int64) error {
+				for _, em := range deleted {
+					srv.notificator.NotifyAboutDeleteMessage(c, participantIds, chatId, &dto.DisplayMessageDto{
+						Id:       em.Id,
+						ChatId:   chatId,
+						ThreadId: em.ThreadId,
+					})
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			srv.lgr.WithTracing(c).Errorf("Got error during deleting expired messages in chat %v, error %v", chatId, err)
+			return
+		}
+
+		srv.removeUnreferencedFiles(c, chatId, fileItemUuids)
+	}
+}
+
+// the same file item can be attached to several messages, so it's removed from the storage only when the last one is gone
+func (srv *MessageRetentionService) removeUnreferencedFiles(c context.Context, chatId int64, fileItemUuids map[string]bool) {
+	for fileItemUuid := range fileItemUuids {
+		referenced, err := db.TransactWithResult(c, srv.dbR, func(tx *db.Tx) (bool, error) {
+			return tx.IsFileItemUuidReferenced(c, chatId, fileItemUuid)
+		})
+		if err != nil {
+			srv.lgr.WithTracing(c).Errorf("Got error during checking file item %v in chat %v, error %v", fileItemUuid, chatId, err)
+			continue
+		}
+		if referenced {
+			continue
+		}
+		err = srv.restClient.DeleteFileItem(c, chatId, fileItemUuid)
+		if err != nil {
+			srv.lgr.WithTracing(c).Errorf("Got error during removing file item %v in chat %v, error %v", fileItemUuid, chatId, err)
+		}
+	}
+}
+
+func NewMessageRetentionService(lgr *logger.Logger, notificator *services.Events, restClient *client.RestClient, dbR *db.DB) *MessageRetentionService {
+	trcr := otel.Tracer("scheduler/message-retention")
+	return &MessageRetentionService{
+		notificator: notificator,
+		restClient:  restClient,
+		tracer:      trcr,
+		dbR:         dbR,
+		lgr:         lgr,
+	}
+}
@@ -0,0 +1,136 @@
+package tasks
+
+import (
+	"context"
+	"github.com/nkonev/dcron"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/dto"
+	"nkonev.name/chat/logger"
+	"nkonev.name/chat/services"
+	"nkonev.name/chat/utils"
+	"time"
+)
+
+type PurgeDeletedMessagesTask struct {
+	dcron.Job
+}
+
+func PurgeDeletedMessagesScheduler(
+	lgr *logger.Logger,
+	service *PurgeDeletedMessagesService,
+) *PurgeDeletedMessagesTask {
+	const key = "purgeDeletedMessagesTask"
+	var str = viper.GetString("schedulers." + key + ".cron")
+	lgr.Infof("Created PurgeDeletedMessagesScheduler with cron %v", str)
+
+	job := dcron.NewJob(key, str, func(ctx context.Context) error {
+		service.doJob()
+		return nil
+	})
+
+	return &PurgeDeletedMessagesTask{job}
+}
+
+type PurgeDeletedMessagesService struct {
+	notificator *services.Events
+	tracer      trace.Tracer
+	dbR         *db.DB
+	lgr         *logger.Logger
+}
+
+func (srv *PurgeDeletedMessagesService) doJob() {
+	ctx, span := srv.tracer.Start(context.Background(), "scheduler.purgeDeletedMessages")
+	defer span.End()
+	srv.processChats(ctx)
+}
+
+func (srv *PurgeDeletedMessagesService) processChats(c context.Context) {
+	srv.lgr.WithTracing(c).Infof("Starting purging deleted messages job")
+
+	batchMessages := viper.GetInt("schedulers.purgeDeletedMessagesTask.batchMessages")
+	deletedBefore := time.Now().UTC().Add(-viper.GetDuration("schedulers.purgeDeletedMessagesTask.retention"))
+
+	var hasMoreChats = true
+	for chatPage := 0; hasMoreChats; chatPage++ {
+		var chatIds []int64
+		err := db.Transact(c, srv.dbR, func(tx *db.Tx) error {
+			var err error
+			chatIds, err = tx.GetChatIds(c, utils.DefaultSize, utils.GetOffset(chatPage, utils.DefaultSize))
+			return err
+		})
+		if err != nil {
+			srv.lgr.WithTracing(c).Errorf("Got error GetChatIds, chatPage %v, error %v", chatPage, err)
+			return
+		}
+		hasMoreChats = len(chatIds) == utils.DefaultSize
+
+		for _, chatId := range chatIds {
+			srv.purgeChat(c, chatId, deletedBefore, batchMessages)
+		}
+	}
+
+	srv.lgr.WithTracing(c).Infof("End of purging deleted messages job")
+}
+
+func (srv *PurgeDeletedMessagesService) purgeChat(c context.Context, chatId int64, deletedBefore time.Time, batchMessages int) {
+	var hasMoreMessages = true
+	for hasMoreMessages {
+		err := db.Transact(c, srv.dbR, func(tx *db.Tx) error {
+			purged, err := tx.PurgeDeletedMessages(c, chatId, deletedBefore, batchMessages)
+			if err != nil {
+				return err
+			}
+			hasMoreMessages = len(purged) == batchMessages
+			if len(purged) == 0 {
+				return nil
+			}
+
+			for _, pm := range purged {
+				err = tx.DeleteMessageRevisions(c, chatId, pm.Id)
+				if err != nil {
+					return err
+				}
+				err = tx.DeletePoll(c, chatId, pm.Id)
+				if err != nil {
+					return err
+				}
+				if pm.ThreadId == nil {
+					// the replies go away together with their root
+					err = tx.DeleteThread(c, chatId, pm.Id)
+					if err != nil {
+						return err
+					}
+				}
+			}
+			srv.lgr.WithTracing(c).Infof("Purged %v deleted messages in chat %v", len(purged), chatId)
+
+			return tx.IterateOverChatParticipantIds(c, chatId, func(participantIds []int64) error {
+				for _, pm := range purged {
+					srv.notificator.NotifyAboutDeleteMessage(c, participantIds, chatId, &dto.DisplayMessageDto{
+						Id:       pm.Id,
+						ChatId:   chatId,
+						ThreadId: pm.ThreadId,
+					})
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			srv.lgr.WithTracing(c).Errorf("Got error during purging deleted messages in chat %v, error %v", chatId, err)
+			return
+		}
+	}
+}
+
+func NewPurgeDeletedMessagesService(lgr *logger.Logger, notificator *services.Events, dbR *db.DB) *PurgeDeletedMessagesService {
+	trcr := otel.Tracer("scheduler/purge-deleted-messages")
+	return &PurgeDeletedMessagesService{
+		notificator: notificator,
+		tracer:      trcr,
+		dbR:         dbR,
+		lgr:         lgr,
+	}
+}
@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"context"
+	"github.com/nkonev/dcron"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/handlers"
+	"nkonev.name/chat/logger"
+)
+
+type SendMessageRemindersTask struct {
+	dcron.Job
+}
+
+func SendMessageRemindersScheduler(
+	lgr *logger.Logger,
+	service *SendMessageRemindersService,
+) *SendMessageRemindersTask {
+	const key = "sendMessageRemindersTask"
+	var str = viper.GetString("schedulers." + key + ".cron")
+	lgr.Infof("Created SendMessageRemindersScheduler with cron %v", str)
+
+	job := dcron.NewJob(key, str, func(ctx context.Context) error {
+		service.doJob()
+		return nil
+	})
+
+	return &SendMessageRemindersTask{job}
+}
+
+type SendMessageRemindersService struct {
+	messageHandler *handlers.MessageHandler
+	tracer         trace.Tracer
+	dbR            *db.DB
+	lgr            *logger.Logger
+}
+
+func (srv *SendMessageRemindersService) doJob() {
+	ctx, span := srv.tracer.Start(context.Background(), "scheduler.sendMessageReminders")
+	defer span.End()
+	srv.processReminders(ctx)
+	srv.deleteFiredReminders(ctx)
+}
+
+func (srv *SendMessageRemindersService) processReminders(c context.Context) {
+	srv.lgr.WithTracing(c).Debugf("Starting sending message reminders job")
+
+	batchReminders := viper.GetInt("schedulers.sendMessageRemindersTask.batchReminders")
+
+	var hasMoreReminders = true
+	for hasMoreReminders {
+		ids, err := srv.dbR.GetDueMessageReminderIds(c, batchReminders)
+		if err != nil {
+			srv.lgr.WithTracing(c).Errorf("Got error GetDueMessageReminderIds %v", err)
+			return
+		}
+		hasMoreReminders = len(ids) == batchReminders
+
+		for _, id := range ids {
+			err = srv.messageHandler.SendMessageReminder(c, id)
+			if err != nil {
+				srv.lgr.WithTracing(c).Errorf("Got error during sending the message reminder %v, error %v", id, err)
+				// the reminder isn't marked as fired, so we stop here not to take it again in the next portion, it will be retried on the next run
+				hasMoreReminders = false
+			}
+		}
+	}
+
+	srv.lgr.WithTracing(c).Debugf("End of sending message reminders job")
+}
+
+func (srv *SendMessageRemindersService) deleteFiredReminders(c context.Context) {
+	retention := viper.GetDuration("schedulers.sendMessageRemindersTask.firedRetention")
+	deleted, err := srv.dbR.DeleteFiredMessageReminders(c, retention)
+	if err != nil {
+		srv.lgr.WithTracing(c).Errorf("Got error DeleteFiredMessageReminders %v", err)
+		return
+	}
+	if deleted > 0 {
+		srv.lgr.WithTracing(c).Infof("Removed %v fired message reminders", deleted)
+	}
+}
+
+func NewSendMessageRemindersService(lgr *logger.Logger, messageHandler *handlers.MessageHandler, dbR *db.DB) *SendMessageRemindersService {
+	trcr := otel.Tracer("scheduler/send-message-reminders")
+	return &SendMessageRemindersService{
+		messageHandler: messageHandler,
+		tracer:         trcr,
+		dbR:            dbR,
+		lgr:            lgr,
+	}
+}
@@ -0,0 +1,101 @@
+package tasks
+
+import (
+	"context"
+	"github.com/nkonev/dcron"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/logger"
+	"nkonev.name/chat/services"
+)
+
+type SendOutgoingWebhooksTask struct {
+	dcron.Job
+}
+
+func SendOutgoingWebhooksScheduler(
+	lgr *logger.Logger,
+	service *SendOutgoingWebhooksService,
+) *SendOutgoingWebhooksTask {
+	const key = "sendOutgoingWebhooksTask"
+	var str = viper.GetString("schedulers." + key + ".cron")
+	lgr.Infof("Created SendOutgoingWebhooksScheduler with cron %v", str)
+
+	job := dcron.NewJob(key, str, func(ctx context.Context) error {
+		service.doJob()
+		return nil
+	})
+
+	return &SendOutgoingWebhooksTask{job}
+}
+
+type SendOutgoingWebhooksService struct {
+	sender *services.OutgoingWebhookSender
+	tracer trace.Tracer
+	dbR    *db.DB
+	lgr    *logger.Logger
+}
+
+func (srv *SendOutgoingWebhooksService) doJob() {
+	ctx, span := srv.tracer.Start(context.Background(), "scheduler.sendOutgoingWebhooks")
+	defer span.End()
+	srv.processDeliveries(ctx)
+}
+
+func (srv *SendOutgoingWebhooksService) processDeliveries(c context.Context) {
+	srv.lgr.WithTracing(c).Debugf("Starting sending outgoing webhooks job")
+
+	batchDeliveries := viper.GetInt("schedulers.sendOutgoingWebhooksTask.batchDeliveries")
+	maxAttempts := viper.GetInt("outgoingWebhook.maxAttempts")
+	initialBackoff := viper.GetDuration("outgoingWebhook.initialBackoff")
+	maxBackoff := viper.GetDuration("outgoingWebhook.maxBackoff")
+
+	var hasMoreDeliveries = true
+	for hasMoreDeliveries {
+		deliveries, err := srv.dbR.GetDueOutgoingWebhookDeliveries(c, batchDeliveries)
+		if err != nil {
+			srv.lgr.WithTracing(c).Errorf("Got error GetDueOutgoingWebhookDeliveries %v", err)
+			return
+		}
+		hasMoreDeliveries = len(deliveries) == batchDeliveries
+
+		for _, delivery := range deliveries {
+			err = srv.sender.Send(c, delivery)
+			if err == nil {
+				err = srv.dbR.DeleteOutgoingWebhookDelivery(c, delivery.Id)
+				if err != nil {
+					srv.lgr.WithTracing(c).Errorf("Got error during removing the sent delivery %v, error %v", delivery.Id, err)
+					return
+				}
+				continue
+			}
+
+			attempts := delivery.Attempts + 1
+			if attempts >= maxAttempts {
+				srv.lgr.WithTracing(c).Warnf("Giving up the delivery %v of webhook %v after %v attempts, the last error %v", delivery.Id, delivery.WebhookId, attempts, err)
+				err = srv.dbR.DeleteOutgoingWebhookDelivery(c, delivery.Id)
+			} else {
+				srv.lgr.WithTracing(c).Infof("Postponing the delivery %v of webhook %v after %v attempts, error %v", delivery.Id, delivery.WebhookId, attempts, err)
+				err = srv.dbR.PostponeOutgoingWebhookDelivery(c, delivery.Id, attempts, services.OutgoingWebhookBackoff(attempts, initialBackoff, maxBackoff))
+			}
+			if err != nil {
+				srv.lgr.WithTracing(c).Errorf("Got error during updating the failed delivery %v, error %v", delivery.Id, err)
+				return
+			}
+		}
+	}
+
+	srv.lgr.WithTracing(c).Debugf("End of sending outgoing webhooks job")
+}
+
+func NewSendOutgoingWebhooksService(lgr *logger.Logger, sender *services.OutgoingWebhookSender, dbR *db.DB) *SendOutgoingWebhooksService {
+	trcr := otel.Tracer("scheduler/send-outgoing-webhooks")
+	return &SendOutgoingWebhooksService{
+		sender: sender,
+		tracer: trcr,
+		dbR:    dbR,
+		lgr:    lgr,
+	}
+}
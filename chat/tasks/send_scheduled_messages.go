@@ -0,0 +1,82 @@
+package tasks
+
+import (
+	"context"
+	"github.com/nkonev/dcron"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"nkonev.name/chat/db"
+	"nkonev.name/chat/handlers"
+	"nkonev.name/chat/logger"
+)
+
+type SendScheduledMessagesTask struct {
+	dcron.Job
+}
+
+func SendScheduledMessagesScheduler(
+	lgr *logger.Logger,
+	service *SendScheduledMessagesService,
+) *SendScheduledMessagesTask {
+	const key = "sendScheduledMessagesTask"
+	var str = viper.GetString("schedulers." + key + ".cron")
+	lgr.Infof("Created SendScheduledMessagesScheduler with cron %v", str)
+
+	job := dcron.NewJob(key, str, func(ctx context.Context) error {
+		service.doJob()
+		return nil
+	})
+
+	return &SendScheduledMessagesTask{job}
+}
+
+type SendScheduledMessagesService struct {
+	messageHandler *handlers.MessageHandler
+	tracer         trace.Tracer
+	dbR            *db.DB
+	lgr            *logger.Logger
+}
+
+func (srv *SendScheduledMessagesService) doJob() {
+	ctx, span := srv.tracer.Start(context.Background(), "scheduler.sendScheduledMessages")
+	defer span.End()
+	srv.processMessages(ctx)
+}
+
+func (srv *SendScheduledMessagesService) processMessages(c context.Context) {
+	srv.lgr.WithTracing(c).Debugf("Starting sending scheduled messages job")
+
+	batchMessages := viper.GetInt("schedulers.sendScheduledMessagesTask.batchMessages")
+
+	var hasMoreMessages = true
+	for hasMoreMessages {
+		ids, err := srv.dbR.GetDueScheduledMessageIds(c, batchMessages)
+		if err != nil {
+			srv.lgr.WithTracing(c).Errorf("Got error GetDueScheduledMessageIds %v", err)
+			return
+		}
+		hasMoreMessages = len(ids) == batchMessages
+
+		for _, id := range ids {
+			err = srv.messageHandler.SendScheduledMessage(c, id)
+			if err != nil {
+				srv.lgr.WithTracing(c).Errorf("Got error during sending the scheduled message %v, error %v", id, err)
+				// the message is left in the table, so we stop here not to take it again in the next portion, it will be retried on the next run
+				hasMoreMessages = false
+			}
+		}
+	}
+
+	srv.lgr.WithTracing(c).Debugf("End of sending scheduled messages job")
+}
+
+func NewSendScheduledMessagesService(lgr *logger.Logger, messageHandler *handlers.MessageHandler, dbR *db.DB) *SendScheduledMessagesService {
+	trcr := otel.Tracer("scheduler/send-scheduled-messages")
+	return &SendScheduledMessagesService{
+		messageHandler: messageHandler,
+		tracer:         trcr,
+		dbR:            dbR,
+		lgr:            lgr,
+	}
+}
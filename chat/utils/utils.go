@@ -258,6 +258,7 @@ func GetInt64BoolMap(ids []int64) map[int64]bool {
 const FileParam = "file"
 const UrlStoragePublicGetFile = "/api/storage/public/download"
 const UrlStorageEmbedPreview = "/embed/preview"
+const UrlStoragePublicGetChatEmoji = "/api/storage/public/chat/emoji"
 
 func SetImagePreviewExtension(key string) string {
 	return SetExtension(key, "jpg")
@@ -35,6 +35,13 @@ type BaseChatDto struct {
 	BlogAbout                           bool        `json:"blogAbout"`
 	RegularParticipantCanWriteMessage   bool        `json:"regularParticipantCanWriteMessage"`
 	CanWriteMessage                     bool        `json:"canWriteMessage"`
+	RegularParticipantCanSeeEditHistory bool        `json:"regularParticipantCanSeeEditHistory"`
+	CanSeeEditHistory                   bool        `json:"canSeeEditHistory"`
+	MessageRetentionSeconds             null.Int    `json:"messageRetentionSeconds"`
+	JoinByRequest                       bool        `json:"joinByRequest"`
+	SlowModeSeconds                     null.Int    `json:"slowModeSeconds"`
+	Role                                string      `json:"role"`
+	Permissions                         []string    `json:"permissions"`
 }
 
 type ChatDeletedDto struct {
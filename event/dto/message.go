@@ -37,35 +37,77 @@ type PinnedMessageDto struct {
 }
 
 type Reaction struct {
-	Count    int64   `json:"count"`
-	Users    []*User `json:"users"`
-	Reaction string  `json:"reaction"`
+	Count    int64       `json:"count"`
+	Users    []*User     `json:"users"`
+	Reaction string      `json:"reaction"`
+	Url      null.String `json:"url"` // the image of the custom emoji
 }
 
 type DisplayMessageDto struct {
-	Id             int64                 `json:"id"`
-	Text           string                `json:"text"`
-	ChatId         int64                 `json:"chatId"`
-	OwnerId        int64                 `json:"ownerId"`
-	CreateDateTime time.Time             `json:"createDateTime"`
-	EditDateTime   null.Time             `json:"editDateTime"`
-	Owner          *User                 `json:"owner"`
-	CanEdit        bool                  `json:"canEdit"`
-	CanDelete      bool                  `json:"canDelete"`
-	FileItemUuid   *string               `json:"fileItemUuid"`
-	EmbedMessage   *EmbedMessageResponse `json:"embedMessage"`
-	Pinned         bool                  `json:"pinned"`
-	BlogPost       bool                  `json:"blogPost"`
-	PinnedPromoted *bool                 `json:"pinnedPromoted"`
-	Reactions      []Reaction            `json:"reactions"`
-	Published      bool                  `json:"published"`
-	CanPublish     bool                  `json:"canPublish"`
-	CanPin         bool                  `json:"canPin"`
+	Id              int64                 `json:"id"`
+	Text            string                `json:"text"`
+	ChatId          int64                 `json:"chatId"`
+	OwnerId         int64                 `json:"ownerId"`
+	CreateDateTime  time.Time             `json:"createDateTime"`
+	EditDateTime    null.Time             `json:"editDateTime"`
+	Owner           *User                 `json:"owner"`
+	CanEdit         bool                  `json:"canEdit"`
+	CanDelete       bool                  `json:"canDelete"`
+	FileItemUuid    *string               `json:"fileItemUuid"`
+	EmbedMessage    *EmbedMessageResponse `json:"embedMessage"`
+	Pinned          bool                  `json:"pinned"`
+	BlogPost        bool                  `json:"blogPost"`
+	PinnedPromoted  *bool                 `json:"pinnedPromoted"`
+	Reactions       []Reaction            `json:"reactions"`
+	Published       bool                  `json:"published"`
+	CanPublish      bool                  `json:"canPublish"`
+	CanPin          bool                  `json:"canPin"`
+	ThreadId        *int64                `json:"threadId"`
+	Thread          *ThreadDto            `json:"thread"`
+	DeletedDateTime null.Time             `json:"deletedDateTime"`
+	RestorableUntil null.Time             `json:"restorableUntil"`
+	CanRestore      bool                  `json:"canRestore"`
+	Poll            *PollDto              `json:"poll"`
+	LinkPreview     *LinkPreviewDto       `json:"linkPreview"`
+}
+
+type LinkPreviewDto struct {
+	Url         string      `json:"url"`
+	Title       null.String `json:"title"`
+	Description null.String `json:"description"`
+	ImageUrl    null.String `json:"imageUrl"`
+	SiteName    null.String `json:"siteName"`
+}
+
+type PollOptionDto struct {
+	Id    int64   `json:"id"`
+	Text  string  `json:"text"`
+	Count int64   `json:"count"`
+	Users []*User `json:"users"`
+	Voted bool    `json:"voted"`
+}
+
+type PollDto struct {
+	MultipleChoice bool             `json:"multipleChoice"`
+	Anonymous      bool             `json:"anonymous"`
+	Closed         bool             `json:"closed"`
+	ClosedDateTime null.Time        `json:"closedDateTime"`
+	Options        []*PollOptionDto `json:"options"`
+	TotalVoters    int64            `json:"totalVoters"`
+	CanVote        bool             `json:"canVote"`
+	CanClose       bool             `json:"canClose"`
+}
+
+type ThreadDto struct {
+	RootMessageId     int64     `json:"rootMessageId"`
+	ReplyCount        int64     `json:"replyCount"`
+	LastReplyDateTime null.Time `json:"lastReplyDateTime"`
 }
 
 type MessageDeletedDto struct {
-	Id     int64 `json:"id"`
-	ChatId int64 `json:"chatId"`
+	Id       int64  `json:"id"`
+	ChatId   int64  `json:"chatId"`
+	ThreadId *int64 `json:"threadId"`
 }
 
 type UserTypingNotification struct {
@@ -80,6 +122,26 @@ type MessageBroadcastNotification struct {
 	Text   string `json:"text"`
 }
 
+type MessageDraftEmbed struct {
+	Id        int64  `json:"id"`
+	ChatId    int64  `json:"chatId"`
+	EmbedType string `json:"embedType"`
+}
+
+type MessageDraftDto struct {
+	ChatId              int64              `json:"chatId"`
+	Text                string             `json:"text"`
+	ThreadId            *int64             `json:"threadId"`
+	EmbedMessageRequest *MessageDraftEmbed `json:"embedMessage"`
+	EditDateTime        time.Time          `json:"editDateTime"`
+}
+
+type EphemeralMessageNotification struct {
+	Command  string `json:"command"`
+	Text     string `json:"text"`
+	ThreadId *int64 `json:"threadId"`
+}
+
 type AllUnreadMessages struct {
 	MessagesCount int64 `json:"allUnreadMessages"`
 }
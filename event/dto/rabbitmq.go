@@ -37,6 +37,16 @@ type ReactionChangedEvent struct {
 	Reaction  Reaction `json:"reaction"`
 }
 
+type PollChangedEvent struct {
+	MessageId int64    `json:"messageId"`
+	Poll      *PollDto `json:"poll"`
+}
+
+type ThreadChangedEvent struct {
+	Thread        ThreadDto `json:"thread"`
+	UnreadReplies int64     `json:"unreadReplies"`
+}
+
 type ChatEvent struct {
 	TraceString                  string                        `json:"-"`
 	EventType                    string                        `json:"eventType"`
@@ -51,6 +61,9 @@ type ChatEvent struct {
 	FileEvent                    *WrappedFileInfoDto           `json:"fileEvent"`
 	PublishedMessageNotification *PublishedMessageEvent        `json:"publishedMessageEvent"`
 	ReactionChangedEvent         *ReactionChangedEvent         `json:"reactionChangedEvent"`
+	ThreadChangedEvent           *ThreadChangedEvent           `json:"threadChangedEvent"`
+	PollChangedEvent             *PollChangedEvent             `json:"pollChangedEvent"`
+	EphemeralMessageNotification *EphemeralMessageNotification `json:"ephemeralMessageNotification"`
 }
 
 func (ChatEvent) Name() eventbus.EventName {
@@ -107,6 +120,7 @@ type GlobalUserEvent struct {
 	HasUnreadMessagesChanged         *HasUnreadMessagesChanged       `json:"hasUnreadMessagesChanged"`
 	BrowserNotification              *BrowserNotification            `json:"browserNotification"`
 	UserTypingNotification           *UserTypingNotification         `json:"userTypingNotification"`
+	MessageDraftNotification         *MessageDraftDto                `json:"messageDraftNotification"`
 }
 
 func (GlobalUserEvent) Name() eventbus.EventName {
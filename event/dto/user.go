@@ -22,5 +22,6 @@ type UserAccountEvent struct {
 
 type UserWithAdmin struct {
 	User
-	Admin bool `json:"admin"`
+	Admin bool   `json:"admin"`
+	Role  string `json:"role"`
 }
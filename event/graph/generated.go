@@ -80,38 +80,48 @@ type ComplexityRoot struct {
 		CanEdit                             func(childComplexity int) int
 		CanLeave                            func(childComplexity int) int
 		CanResend                           func(childComplexity int) int
+		CanSeeEditHistory                   func(childComplexity int) int
 		CanVideoKick                        func(childComplexity int) int
 		CanWriteMessage                     func(childComplexity int) int
 		ID                                  func(childComplexity int) int
 		IsResultFromSearch                  func(childComplexity int) int
+		JoinByRequest                       func(childComplexity int) int
 		LastMessagePreview                  func(childComplexity int) int
 		LastSeenDateTime                    func(childComplexity int) int
 		LastUpdateDateTime                  func(childComplexity int) int
 		LoginColor                          func(childComplexity int) int
+		MessageRetentionSeconds             func(childComplexity int) int
 		Name                                func(childComplexity int) int
 		ParticipantIds                      func(childComplexity int) int
 		Participants                        func(childComplexity int) int
 		ParticipantsCount                   func(childComplexity int) int
+		Permissions                         func(childComplexity int) int
 		Pinned                              func(childComplexity int) int
 		RegularParticipantCanPinMessage     func(childComplexity int) int
 		RegularParticipantCanPublishMessage func(childComplexity int) int
+		RegularParticipantCanSeeEditHistory func(childComplexity int) int
 		RegularParticipantCanWriteMessage   func(childComplexity int) int
+		Role                                func(childComplexity int) int
 		ShortInfo                           func(childComplexity int) int
+		SlowModeSeconds                     func(childComplexity int) int
 		TetATet                             func(childComplexity int) int
 		UnreadMessages                      func(childComplexity int) int
 	}
 
 	ChatEvent struct {
+		EphemeralMessageEvent func(childComplexity int) int
 		EventType             func(childComplexity int) int
 		FileEvent             func(childComplexity int) int
 		MessageBroadcastEvent func(childComplexity int) int
 		MessageDeletedEvent   func(childComplexity int) int
 		MessageEvent          func(childComplexity int) int
 		ParticipantsEvent     func(childComplexity int) int
+		PollChangedEvent      func(childComplexity int) int
 		PreviewCreatedEvent   func(childComplexity int) int
 		PromoteMessageEvent   func(childComplexity int) int
 		PublishedMessageEvent func(childComplexity int) int
 		ReactionChangedEvent  func(childComplexity int) int
+		ThreadChangedEvent    func(childComplexity int) int
 	}
 
 	ChatUnreadMessageChanged struct {
@@ -129,24 +139,31 @@ type ComplexityRoot struct {
 	}
 
 	DisplayMessageDto struct {
-		BlogPost       func(childComplexity int) int
-		CanDelete      func(childComplexity int) int
-		CanEdit        func(childComplexity int) int
-		CanPin         func(childComplexity int) int
-		CanPublish     func(childComplexity int) int
-		ChatID         func(childComplexity int) int
-		CreateDateTime func(childComplexity int) int
-		EditDateTime   func(childComplexity int) int
-		EmbedMessage   func(childComplexity int) int
-		FileItemUUID   func(childComplexity int) int
-		ID             func(childComplexity int) int
-		Owner          func(childComplexity int) int
-		OwnerID        func(childComplexity int) int
-		Pinned         func(childComplexity int) int
-		PinnedPromoted func(childComplexity int) int
-		Published      func(childComplexity int) int
-		Reactions      func(childComplexity int) int
-		Text           func(childComplexity int) int
+		BlogPost        func(childComplexity int) int
+		CanDelete       func(childComplexity int) int
+		CanEdit         func(childComplexity int) int
+		CanPin          func(childComplexity int) int
+		CanPublish      func(childComplexity int) int
+		CanRestore      func(childComplexity int) int
+		ChatID          func(childComplexity int) int
+		CreateDateTime  func(childComplexity int) int
+		DeletedDateTime func(childComplexity int) int
+		EditDateTime    func(childComplexity int) int
+		EmbedMessage    func(childComplexity int) int
+		FileItemUUID    func(childComplexity int) int
+		ID              func(childComplexity int) int
+		LinkPreview     func(childComplexity int) int
+		Owner           func(childComplexity int) int
+		OwnerID         func(childComplexity int) int
+		Pinned          func(childComplexity int) int
+		PinnedPromoted  func(childComplexity int) int
+		Poll            func(childComplexity int) int
+		Published       func(childComplexity int) int
+		Reactions       func(childComplexity int) int
+		RestorableUntil func(childComplexity int) int
+		Text            func(childComplexity int) int
+		Thread          func(childComplexity int) int
+		ThreadID        func(childComplexity int) int
 	}
 
 	EmbedMessageResponse struct {
@@ -159,6 +176,12 @@ type ComplexityRoot struct {
 		Text          func(childComplexity int) int
 	}
 
+	EphemeralMessageNotification struct {
+		Command  func(childComplexity int) int
+		Text     func(childComplexity int) int
+		ThreadID func(childComplexity int) int
+	}
+
 	FileInfoDto struct {
 		CanDelete      func(childComplexity int) int
 		CanEdit        func(childComplexity int) int
@@ -192,6 +215,7 @@ type ComplexityRoot struct {
 		EventType                      func(childComplexity int) int
 		ForceLogout                    func(childComplexity int) int
 		HasUnreadMessagesChanged       func(childComplexity int) int
+		MessageDraftEvent              func(childComplexity int) int
 		NotificationEvent              func(childComplexity int) int
 		UnreadMessagesNotification     func(childComplexity int) int
 		UserTypingEvent                func(childComplexity int) int
@@ -206,6 +230,14 @@ type ComplexityRoot struct {
 		HasUnreadMessages func(childComplexity int) int
 	}
 
+	LinkPreviewDto struct {
+		Description func(childComplexity int) int
+		ImageURL    func(childComplexity int) int
+		SiteName    func(childComplexity int) int
+		Title       func(childComplexity int) int
+		URL         func(childComplexity int) int
+	}
+
 	MessageBroadcastNotification struct {
 		Login  func(childComplexity int) int
 		Text   func(childComplexity int) int
@@ -213,8 +245,23 @@ type ComplexityRoot struct {
 	}
 
 	MessageDeletedDto struct {
-		ChatID func(childComplexity int) int
-		ID     func(childComplexity int) int
+		ChatID   func(childComplexity int) int
+		ID       func(childComplexity int) int
+		ThreadID func(childComplexity int) int
+	}
+
+	MessageDraftDto struct {
+		ChatID       func(childComplexity int) int
+		EditDateTime func(childComplexity int) int
+		EmbedMessage func(childComplexity int) int
+		Text         func(childComplexity int) int
+		ThreadID     func(childComplexity int) int
+	}
+
+	MessageDraftEmbedDto struct {
+		ChatID    func(childComplexity int) int
+		EmbedType func(childComplexity int) int
+		ID        func(childComplexity int) int
 	}
 
 	NotificationDto struct {
@@ -251,6 +298,7 @@ type ComplexityRoot struct {
 		ID         func(childComplexity int) int
 		Login      func(childComplexity int) int
 		LoginColor func(childComplexity int) int
+		Role       func(childComplexity int) int
 		ShortInfo  func(childComplexity int) int
 	}
 
@@ -270,6 +318,30 @@ type ComplexityRoot struct {
 		Message func(childComplexity int) int
 	}
 
+	PollChangedEvent struct {
+		MessageID func(childComplexity int) int
+		Poll      func(childComplexity int) int
+	}
+
+	PollDto struct {
+		Anonymous      func(childComplexity int) int
+		CanClose       func(childComplexity int) int
+		CanVote        func(childComplexity int) int
+		Closed         func(childComplexity int) int
+		ClosedDateTime func(childComplexity int) int
+		MultipleChoice func(childComplexity int) int
+		Options        func(childComplexity int) int
+		TotalVoters    func(childComplexity int) int
+	}
+
+	PollOptionDto struct {
+		Count func(childComplexity int) int
+		ID    func(childComplexity int) int
+		Text  func(childComplexity int) int
+		Users func(childComplexity int) int
+		Voted func(childComplexity int) int
+	}
+
 	PreviewCreatedEvent struct {
 		AType         func(childComplexity int) int
 		CorrelationID func(childComplexity int) int
@@ -300,6 +372,7 @@ type ComplexityRoot struct {
 	Reaction struct {
 		Count    func(childComplexity int) int
 		Reaction func(childComplexity int) int
+		URL      func(childComplexity int) int
 		Users    func(childComplexity int) int
 	}
 
@@ -315,6 +388,17 @@ type ComplexityRoot struct {
 		UserStatusEvents  func(childComplexity int, userIds []int64) int
 	}
 
+	ThreadChangedEvent struct {
+		Thread        func(childComplexity int) int
+		UnreadReplies func(childComplexity int) int
+	}
+
+	ThreadDto struct {
+		LastReplyDateTime func(childComplexity int) int
+		ReplyCount        func(childComplexity int) int
+		RootMessageID     func(childComplexity int) int
+	}
+
 	UserAccountDto struct {
 		Avatar            func(childComplexity int) int
 		AvatarBig         func(childComplexity int) int
@@ -590,6 +674,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ChatDto.CanResend(childComplexity), true
 
+	case "ChatDto.canSeeEditHistory":
+		if e.complexity.ChatDto.CanSeeEditHistory == nil {
+			break
+		}
+
+		return e.complexity.ChatDto.CanSeeEditHistory(childComplexity), true
+
 	case "ChatDto.canVideoKick":
 		if e.complexity.ChatDto.CanVideoKick == nil {
 			break
@@ -618,6 +709,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ChatDto.IsResultFromSearch(childComplexity), true
 
+	case "ChatDto.joinByRequest":
+		if e.complexity.ChatDto.JoinByRequest == nil {
+			break
+		}
+
+		return e.complexity.ChatDto.JoinByRequest(childComplexity), true
+
 	case "ChatDto.lastMessagePreview":
 		if e.complexity.ChatDto.LastMessagePreview == nil {
 			break
@@ -646,6 +744,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ChatDto.LoginColor(childComplexity), true
 
+	case "ChatDto.messageRetentionSeconds":
+		if e.complexity.ChatDto.MessageRetentionSeconds == nil {
+			break
+		}
+
+		return e.complexity.ChatDto.MessageRetentionSeconds(childComplexity), true
+
 	case "ChatDto.name":
 		if e.complexity.ChatDto.Name == nil {
 			break
@@ -674,6 +779,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ChatDto.ParticipantsCount(childComplexity), true
 
+	case "ChatDto.permissions":
+		if e.complexity.ChatDto.Permissions == nil {
+			break
+		}
+
+		return e.complexity.ChatDto.Permissions(childComplexity), true
+
 	case "ChatDto.pinned":
 		if e.complexity.ChatDto.Pinned == nil {
 			break
@@ -695,6 +807,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ChatDto.RegularParticipantCanPublishMessage(childComplexity), true
 
+	case "ChatDto.regularParticipantCanSeeEditHistory":
+		if e.complexity.ChatDto.RegularParticipantCanSeeEditHistory == nil {
+			break
+		}
+
+		return e.complexity.ChatDto.RegularParticipantCanSeeEditHistory(childComplexity), true
+
 	case "ChatDto.regularParticipantCanWriteMessage":
 		if e.complexity.ChatDto.RegularParticipantCanWriteMessage == nil {
 			break
@@ -702,6 +821,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ChatDto.RegularParticipantCanWriteMessage(childComplexity), true
 
+	case "ChatDto.role":
+		if e.complexity.ChatDto.Role == nil {
+			break
+		}
+
+		return e.complexity.ChatDto.Role(childComplexity), true
+
 	case "ChatDto.shortInfo":
 		if e.complexity.ChatDto.ShortInfo == nil {
 			break
@@ -709,6 +835,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ChatDto.ShortInfo(childComplexity), true
 
+	case "ChatDto.slowModeSeconds":
+		if e.complexity.ChatDto.SlowModeSeconds == nil {
+			break
+		}
+
+		return e.complexity.ChatDto.SlowModeSeconds(childComplexity), true
+
 	case "ChatDto.tetATet":
 		if e.complexity.ChatDto.TetATet == nil {
 			break
@@ -723,6 +856,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ChatDto.UnreadMessages(childComplexity), true
 
+	case "ChatEvent.ephemeralMessageEvent":
+		if e.complexity.ChatEvent.EphemeralMessageEvent == nil {
+			break
+		}
+
+		return e.complexity.ChatEvent.EphemeralMessageEvent(childComplexity), true
+
 	case "ChatEvent.eventType":
 		if e.complexity.ChatEvent.EventType == nil {
 			break
@@ -765,6 +905,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ChatEvent.ParticipantsEvent(childComplexity), true
 
+	case "ChatEvent.pollChangedEvent":
+		if e.complexity.ChatEvent.PollChangedEvent == nil {
+			break
+		}
+
+		return e.complexity.ChatEvent.PollChangedEvent(childComplexity), true
+
 	case "ChatEvent.previewCreatedEvent":
 		if e.complexity.ChatEvent.PreviewCreatedEvent == nil {
 			break
@@ -793,6 +940,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ChatEvent.ReactionChangedEvent(childComplexity), true
 
+	case "ChatEvent.threadChangedEvent":
+		if e.complexity.ChatEvent.ThreadChangedEvent == nil {
+			break
+		}
+
+		return e.complexity.ChatEvent.ThreadChangedEvent(childComplexity), true
+
 	case "ChatUnreadMessageChanged.chatId":
 		if e.complexity.ChatUnreadMessageChanged.ChatID == nil {
 			break
@@ -884,6 +1038,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DisplayMessageDto.CanPublish(childComplexity), true
 
+	case "DisplayMessageDto.canRestore":
+		if e.complexity.DisplayMessageDto.CanRestore == nil {
+			break
+		}
+
+		return e.complexity.DisplayMessageDto.CanRestore(childComplexity), true
+
 	case "DisplayMessageDto.chatId":
 		if e.complexity.DisplayMessageDto.ChatID == nil {
 			break
@@ -898,6 +1059,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DisplayMessageDto.CreateDateTime(childComplexity), true
 
+	case "DisplayMessageDto.deletedDateTime":
+		if e.complexity.DisplayMessageDto.DeletedDateTime == nil {
+			break
+		}
+
+		return e.complexity.DisplayMessageDto.DeletedDateTime(childComplexity), true
+
 	case "DisplayMessageDto.editDateTime":
 		if e.complexity.DisplayMessageDto.EditDateTime == nil {
 			break
@@ -926,6 +1094,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DisplayMessageDto.ID(childComplexity), true
 
+	case "DisplayMessageDto.linkPreview":
+		if e.complexity.DisplayMessageDto.LinkPreview == nil {
+			break
+		}
+
+		return e.complexity.DisplayMessageDto.LinkPreview(childComplexity), true
+
 	case "DisplayMessageDto.owner":
 		if e.complexity.DisplayMessageDto.Owner == nil {
 			break
@@ -954,6 +1129,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DisplayMessageDto.PinnedPromoted(childComplexity), true
 
+	case "DisplayMessageDto.poll":
+		if e.complexity.DisplayMessageDto.Poll == nil {
+			break
+		}
+
+		return e.complexity.DisplayMessageDto.Poll(childComplexity), true
+
 	case "DisplayMessageDto.published":
 		if e.complexity.DisplayMessageDto.Published == nil {
 			break
@@ -968,6 +1150,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DisplayMessageDto.Reactions(childComplexity), true
 
+	case "DisplayMessageDto.restorableUntil":
+		if e.complexity.DisplayMessageDto.RestorableUntil == nil {
+			break
+		}
+
+		return e.complexity.DisplayMessageDto.RestorableUntil(childComplexity), true
+
 	case "DisplayMessageDto.text":
 		if e.complexity.DisplayMessageDto.Text == nil {
 			break
@@ -975,6 +1164,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DisplayMessageDto.Text(childComplexity), true
 
+	case "DisplayMessageDto.thread":
+		if e.complexity.DisplayMessageDto.Thread == nil {
+			break
+		}
+
+		return e.complexity.DisplayMessageDto.Thread(childComplexity), true
+
+	case "DisplayMessageDto.threadId":
+		if e.complexity.DisplayMessageDto.ThreadID == nil {
+			break
+		}
+
+		return e.complexity.DisplayMessageDto.ThreadID(childComplexity), true
+
 	case "EmbedMessageResponse.chatId":
 		if e.complexity.EmbedMessageResponse.ChatID == nil {
 			break
@@ -1024,6 +1227,27 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.EmbedMessageResponse.Text(childComplexity), true
 
+	case "EphemeralMessageNotification.command":
+		if e.complexity.EphemeralMessageNotification.Command == nil {
+			break
+		}
+
+		return e.complexity.EphemeralMessageNotification.Command(childComplexity), true
+
+	case "EphemeralMessageNotification.text":
+		if e.complexity.EphemeralMessageNotification.Text == nil {
+			break
+		}
+
+		return e.complexity.EphemeralMessageNotification.Text(childComplexity), true
+
+	case "EphemeralMessageNotification.threadId":
+		if e.complexity.EphemeralMessageNotification.ThreadID == nil {
+			break
+		}
+
+		return e.complexity.EphemeralMessageNotification.ThreadID(childComplexity), true
+
 	case "FileInfoDto.canDelete":
 		if e.complexity.FileInfoDto.CanDelete == nil {
 			break
@@ -1206,6 +1430,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.GlobalEvent.HasUnreadMessagesChanged(childComplexity), true
 
+	case "GlobalEvent.messageDraftEvent":
+		if e.complexity.GlobalEvent.MessageDraftEvent == nil {
+			break
+		}
+
+		return e.complexity.GlobalEvent.MessageDraftEvent(childComplexity), true
+
 	case "GlobalEvent.notificationEvent":
 		if e.complexity.GlobalEvent.NotificationEvent == nil {
 			break
@@ -1269,6 +1500,41 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.HasUnreadMessagesChangedEvent.HasUnreadMessages(childComplexity), true
 
+	case "LinkPreviewDto.description":
+		if e.complexity.LinkPreviewDto.Description == nil {
+			break
+		}
+
+		return e.complexity.LinkPreviewDto.Description(childComplexity), true
+
+	case "LinkPreviewDto.imageUrl":
+		if e.complexity.LinkPreviewDto.ImageURL == nil {
+			break
+		}
+
+		return e.complexity.LinkPreviewDto.ImageURL(childComplexity), true
+
+	case "LinkPreviewDto.siteName":
+		if e.complexity.LinkPreviewDto.SiteName == nil {
+			break
+		}
+
+		return e.complexity.LinkPreviewDto.SiteName(childComplexity), true
+
+	case "LinkPreviewDto.title":
+		if e.complexity.LinkPreviewDto.Title == nil {
+			break
+		}
+
+		return e.complexity.LinkPreviewDto.Title(childComplexity), true
+
+	case "LinkPreviewDto.url":
+		if e.complexity.LinkPreviewDto.URL == nil {
+			break
+		}
+
+		return e.complexity.LinkPreviewDto.URL(childComplexity), true
+
 	case "MessageBroadcastNotification.login":
 		if e.complexity.MessageBroadcastNotification.Login == nil {
 			break
@@ -1304,6 +1570,69 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.MessageDeletedDto.ID(childComplexity), true
 
+	case "MessageDeletedDto.threadId":
+		if e.complexity.MessageDeletedDto.ThreadID == nil {
+			break
+		}
+
+		return e.complexity.MessageDeletedDto.ThreadID(childComplexity), true
+
+	case "MessageDraftDto.chatId":
+		if e.complexity.MessageDraftDto.ChatID == nil {
+			break
+		}
+
+		return e.complexity.MessageDraftDto.ChatID(childComplexity), true
+
+	case "MessageDraftDto.editDateTime":
+		if e.complexity.MessageDraftDto.EditDateTime == nil {
+			break
+		}
+
+		return e.complexity.MessageDraftDto.EditDateTime(childComplexity), true
+
+	case "MessageDraftDto.embedMessage":
+		if e.complexity.MessageDraftDto.EmbedMessage == nil {
+			break
+		}
+
+		return e.complexity.MessageDraftDto.EmbedMessage(childComplexity), true
+
+	case "MessageDraftDto.text":
+		if e.complexity.MessageDraftDto.Text == nil {
+			break
+		}
+
+		return e.complexity.MessageDraftDto.Text(childComplexity), true
+
+	case "MessageDraftDto.threadId":
+		if e.complexity.MessageDraftDto.ThreadID == nil {
+			break
+		}
+
+		return e.complexity.MessageDraftDto.ThreadID(childComplexity), true
+
+	case "MessageDraftEmbedDto.chatId":
+		if e.complexity.MessageDraftEmbedDto.ChatID == nil {
+			break
+		}
+
+		return e.complexity.MessageDraftEmbedDto.ChatID(childComplexity), true
+
+	case "MessageDraftEmbedDto.embedType":
+		if e.complexity.MessageDraftEmbedDto.EmbedType == nil {
+			break
+		}
+
+		return e.complexity.MessageDraftEmbedDto.EmbedType(childComplexity), true
+
+	case "MessageDraftEmbedDto.id":
+		if e.complexity.MessageDraftEmbedDto.ID == nil {
+			break
+		}
+
+		return e.complexity.MessageDraftEmbedDto.ID(childComplexity), true
+
 	case "NotificationDto.byAvatar":
 		if e.complexity.NotificationDto.ByAvatar == nil {
 			break
@@ -1472,6 +1801,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ParticipantWithAdmin.LoginColor(childComplexity), true
 
+	case "ParticipantWithAdmin.role":
+		if e.complexity.ParticipantWithAdmin.Role == nil {
+			break
+		}
+
+		return e.complexity.ParticipantWithAdmin.Role(childComplexity), true
+
 	case "ParticipantWithAdmin.shortInfo":
 		if e.complexity.ParticipantWithAdmin.ShortInfo == nil {
 			break
@@ -1549,6 +1885,111 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PinnedMessageEvent.Message(childComplexity), true
 
+	case "PollChangedEvent.messageId":
+		if e.complexity.PollChangedEvent.MessageID == nil {
+			break
+		}
+
+		return e.complexity.PollChangedEvent.MessageID(childComplexity), true
+
+	case "PollChangedEvent.poll":
+		if e.complexity.PollChangedEvent.Poll == nil {
+			break
+		}
+
+		return e.complexity.PollChangedEvent.Poll(childComplexity), true
+
+	case "PollDto.anonymous":
+		if e.complexity.PollDto.Anonymous == nil {
+			break
+		}
+
+		return e.complexity.PollDto.Anonymous(childComplexity), true
+
+	case "PollDto.canClose":
+		if e.complexity.PollDto.CanClose == nil {
+			break
+		}
+
+		return e.complexity.PollDto.CanClose(childComplexity), true
+
+	case "PollDto.canVote":
+		if e.complexity.PollDto.CanVote == nil {
+			break
+		}
+
+		return e.complexity.PollDto.CanVote(childComplexity), true
+
+	case "PollDto.closed":
+		if e.complexity.PollDto.Closed == nil {
+			break
+		}
+
+		return e.complexity.PollDto.Closed(childComplexity), true
+
+	case "PollDto.closedDateTime":
+		if e.complexity.PollDto.ClosedDateTime == nil {
+			break
+		}
+
+		return e.complexity.PollDto.ClosedDateTime(childComplexity), true
+
+	case "PollDto.multipleChoice":
+		if e.complexity.PollDto.MultipleChoice == nil {
+			break
+		}
+
+		return e.complexity.PollDto.MultipleChoice(childComplexity), true
+
+	case "PollDto.options":
+		if e.complexity.PollDto.Options == nil {
+			break
+		}
+
+		return e.complexity.PollDto.Options(childComplexity), true
+
+	case "PollDto.totalVoters":
+		if e.complexity.PollDto.TotalVoters == nil {
+			break
+		}
+
+		return e.complexity.PollDto.TotalVoters(childComplexity), true
+
+	case "PollOptionDto.count":
+		if e.complexity.PollOptionDto.Count == nil {
+			break
+		}
+
+		return e.complexity.PollOptionDto.Count(childComplexity), true
+
+	case "PollOptionDto.id":
+		if e.complexity.PollOptionDto.ID == nil {
+			break
+		}
+
+		return e.complexity.PollOptionDto.ID(childComplexity), true
+
+	case "PollOptionDto.text":
+		if e.complexity.PollOptionDto.Text == nil {
+			break
+		}
+
+		return e.complexity.PollOptionDto.Text(childComplexity), true
+
+	case "PollOptionDto.users":
+		if e.complexity.PollOptionDto.Users == nil {
+			break
+		}
+
+		return e.complexity.PollOptionDto.Users(childComplexity), true
+
+	case "PollOptionDto.voted":
+		if e.complexity.PollOptionDto.Voted == nil {
+			break
+		}
+
+		return e.complexity.PollOptionDto.Voted(childComplexity), true
+
 	case "PreviewCreatedEvent.aType":
 		if e.complexity.PreviewCreatedEvent.AType == nil {
 			break
@@ -1668,6 +2109,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Reaction.Reaction(childComplexity), true
 
+	case "Reaction.url":
+		if e.complexity.Reaction.URL == nil {
+			break
+		}
+
+		return e.complexity.Reaction.URL(childComplexity), true
+
 	case "Reaction.users":
 		if e.complexity.Reaction.Users == nil {
 			break
@@ -1732,36 +2180,71 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Subscription.UserStatusEvents(childComplexity, args["userIds"].([]int64)), true
 
-	case "UserAccountDto.avatar":
-		if e.complexity.UserAccountDto.Avatar == nil {
+	case "ThreadChangedEvent.thread":
+		if e.complexity.ThreadChangedEvent.Thread == nil {
 			break
 		}
 
-		return e.complexity.UserAccountDto.Avatar(childComplexity), true
+		return e.complexity.ThreadChangedEvent.Thread(childComplexity), true
 
-	case "UserAccountDto.avatarBig":
-		if e.complexity.UserAccountDto.AvatarBig == nil {
+	case "ThreadChangedEvent.unreadReplies":
+		if e.complexity.ThreadChangedEvent.UnreadReplies == nil {
 			break
 		}
 
-		return e.complexity.UserAccountDto.AvatarBig(childComplexity), true
+		return e.complexity.ThreadChangedEvent.UnreadReplies(childComplexity), true
 
-	case "UserAccountDto.id":
-		if e.complexity.UserAccountDto.ID == nil {
+	case "ThreadDto.lastReplyDateTime":
+		if e.complexity.ThreadDto.LastReplyDateTime == nil {
 			break
 		}
 
-		return e.complexity.UserAccountDto.ID(childComplexity), true
+		return e.complexity.ThreadDto.LastReplyDateTime(childComplexity), true
 
-	case "UserAccountDto.lastSeenDateTime":
-		if e.complexity.UserAccountDto.LastSeenDateTime == nil {
+	case "ThreadDto.replyCount":
+		if e.complexity.ThreadDto.ReplyCount == nil {
 			break
 		}
 
-		return e.complexity.UserAccountDto.LastSeenDateTime(childComplexity), true
+		return e.complexity.ThreadDto.ReplyCount(childComplexity), true
 
-	case "UserAccountDto.ldap":
-		if e.complexity.UserAccountDto.Ldap == nil {
+	case "ThreadDto.rootMessageId":
+		if e.complexity.ThreadDto.RootMessageID == nil {
+			break
+		}
+
+		return e.complexity.ThreadDto.RootMessageID(childComplexity), true
+
+	case "UserAccountDto.avatar":
+		if e.complexity.UserAccountDto.Avatar == nil {
+			break
+		}
+
+		return e.complexity.UserAccountDto.Avatar(childComplexity), true
+
+	case "UserAccountDto.avatarBig":
+		if e.complexity.UserAccountDto.AvatarBig == nil {
+			break
+		}
+
+		return e.complexity.UserAccountDto.AvatarBig(childComplexity), true
+
+	case "UserAccountDto.id":
+		if e.complexity.UserAccountDto.ID == nil {
+			break
+		}
+
+		return e.complexity.UserAccountDto.ID(childComplexity), true
+
+	case "UserAccountDto.lastSeenDateTime":
+		if e.complexity.UserAccountDto.LastSeenDateTime == nil {
+			break
+		}
+
+		return e.complexity.UserAccountDto.LastSeenDateTime(childComplexity), true
+
+	case "UserAccountDto.ldap":
+		if e.complexity.UserAccountDto.Ldap == nil {
 			break
 		}
 
@@ -4045,8 +4528,8 @@ func (ec *executionContext) fieldContext_ChatDto_canWriteMessage(_ context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatDto_lastMessagePreview(ctx context.Context, field graphql.CollectedField, obj *model.ChatDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatDto_lastMessagePreview(ctx, field)
+func (ec *executionContext) _ChatDto_regularParticipantCanSeeEditHistory(ctx context.Context, field graphql.CollectedField, obj *model.ChatDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatDto_regularParticipantCanSeeEditHistory(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4059,35 +4542,38 @@ func (ec *executionContext) _ChatDto_lastMessagePreview(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LastMessagePreview, nil
+		return obj.RegularParticipantCanSeeEditHistory, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatDto_lastMessagePreview(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatDto_regularParticipantCanSeeEditHistory(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "ChatDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatEvent_eventType(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatEvent_eventType(ctx, field)
+func (ec *executionContext) _ChatDto_canSeeEditHistory(ctx context.Context, field graphql.CollectedField, obj *model.ChatDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatDto_canSeeEditHistory(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4100,7 +4586,7 @@ func (ec *executionContext) _ChatEvent_eventType(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EventType, nil
+		return obj.CanSeeEditHistory, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4112,26 +4598,26 @@ func (ec *executionContext) _ChatEvent_eventType(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatEvent_eventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatDto_canSeeEditHistory(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ChatEvent",
+		Object:     "ChatDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatEvent_messageEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatEvent_messageEvent(ctx, field)
+func (ec *executionContext) _ChatDto_messageRetentionSeconds(ctx context.Context, field graphql.CollectedField, obj *model.ChatDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatDto_messageRetentionSeconds(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4144,7 +4630,7 @@ func (ec *executionContext) _ChatEvent_messageEvent(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MessageEvent, nil
+		return obj.MessageRetentionSeconds, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4153,64 +4639,26 @@ func (ec *executionContext) _ChatEvent_messageEvent(ctx context.Context, field g
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.DisplayMessageDto)
+	res := resTmp.(*int64)
 	fc.Result = res
-	return ec.marshalODisplayMessageDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐDisplayMessageDto(ctx, field.Selections, res)
+	return ec.marshalOInt642ᚖint64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatEvent_messageEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatDto_messageRetentionSeconds(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ChatEvent",
+		Object:     "ChatDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_DisplayMessageDto_id(ctx, field)
-			case "text":
-				return ec.fieldContext_DisplayMessageDto_text(ctx, field)
-			case "chatId":
-				return ec.fieldContext_DisplayMessageDto_chatId(ctx, field)
-			case "ownerId":
-				return ec.fieldContext_DisplayMessageDto_ownerId(ctx, field)
-			case "createDateTime":
-				return ec.fieldContext_DisplayMessageDto_createDateTime(ctx, field)
-			case "editDateTime":
-				return ec.fieldContext_DisplayMessageDto_editDateTime(ctx, field)
-			case "owner":
-				return ec.fieldContext_DisplayMessageDto_owner(ctx, field)
-			case "canEdit":
-				return ec.fieldContext_DisplayMessageDto_canEdit(ctx, field)
-			case "canDelete":
-				return ec.fieldContext_DisplayMessageDto_canDelete(ctx, field)
-			case "fileItemUuid":
-				return ec.fieldContext_DisplayMessageDto_fileItemUuid(ctx, field)
-			case "embedMessage":
-				return ec.fieldContext_DisplayMessageDto_embedMessage(ctx, field)
-			case "pinned":
-				return ec.fieldContext_DisplayMessageDto_pinned(ctx, field)
-			case "blogPost":
-				return ec.fieldContext_DisplayMessageDto_blogPost(ctx, field)
-			case "pinnedPromoted":
-				return ec.fieldContext_DisplayMessageDto_pinnedPromoted(ctx, field)
-			case "reactions":
-				return ec.fieldContext_DisplayMessageDto_reactions(ctx, field)
-			case "published":
-				return ec.fieldContext_DisplayMessageDto_published(ctx, field)
-			case "canPublish":
-				return ec.fieldContext_DisplayMessageDto_canPublish(ctx, field)
-			case "canPin":
-				return ec.fieldContext_DisplayMessageDto_canPin(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type DisplayMessageDto", field.Name)
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatEvent_messageDeletedEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatEvent_messageDeletedEvent(ctx, field)
+func (ec *executionContext) _ChatDto_joinByRequest(ctx context.Context, field graphql.CollectedField, obj *model.ChatDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatDto_joinByRequest(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4223,41 +4671,38 @@ func (ec *executionContext) _ChatEvent_messageDeletedEvent(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MessageDeletedEvent, nil
+		return obj.JoinByRequest, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.MessageDeletedDto)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOMessageDeletedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐMessageDeletedDto(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatEvent_messageDeletedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatDto_joinByRequest(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ChatEvent",
+		Object:     "ChatDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_MessageDeletedDto_id(ctx, field)
-			case "chatId":
-				return ec.fieldContext_MessageDeletedDto_chatId(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type MessageDeletedDto", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatEvent_messageBroadcastEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatEvent_messageBroadcastEvent(ctx, field)
+func (ec *executionContext) _ChatDto_slowModeSeconds(ctx context.Context, field graphql.CollectedField, obj *model.ChatDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatDto_slowModeSeconds(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4270,7 +4715,7 @@ func (ec *executionContext) _ChatEvent_messageBroadcastEvent(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MessageBroadcastEvent, nil
+		return obj.SlowModeSeconds, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4279,34 +4724,26 @@ func (ec *executionContext) _ChatEvent_messageBroadcastEvent(ctx context.Context
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.MessageBroadcastNotification)
+	res := resTmp.(*int64)
 	fc.Result = res
-	return ec.marshalOMessageBroadcastNotification2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐMessageBroadcastNotification(ctx, field.Selections, res)
+	return ec.marshalOInt642ᚖint64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatEvent_messageBroadcastEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatDto_slowModeSeconds(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ChatEvent",
+		Object:     "ChatDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "login":
-				return ec.fieldContext_MessageBroadcastNotification_login(ctx, field)
-			case "userId":
-				return ec.fieldContext_MessageBroadcastNotification_userId(ctx, field)
-			case "text":
-				return ec.fieldContext_MessageBroadcastNotification_text(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type MessageBroadcastNotification", field.Name)
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatEvent_previewCreatedEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatEvent_previewCreatedEvent(ctx, field)
+func (ec *executionContext) _ChatDto_role(ctx context.Context, field graphql.CollectedField, obj *model.ChatDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatDto_role(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4319,47 +4756,38 @@ func (ec *executionContext) _ChatEvent_previewCreatedEvent(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PreviewCreatedEvent, nil
+		return obj.Role, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.PreviewCreatedEvent)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOPreviewCreatedEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPreviewCreatedEvent(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatEvent_previewCreatedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatDto_role(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ChatEvent",
+		Object:     "ChatDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_PreviewCreatedEvent_id(ctx, field)
-			case "url":
-				return ec.fieldContext_PreviewCreatedEvent_url(ctx, field)
-			case "previewUrl":
-				return ec.fieldContext_PreviewCreatedEvent_previewUrl(ctx, field)
-			case "aType":
-				return ec.fieldContext_PreviewCreatedEvent_aType(ctx, field)
-			case "correlationId":
-				return ec.fieldContext_PreviewCreatedEvent_correlationId(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type PreviewCreatedEvent", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatEvent_participantsEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatEvent_participantsEvent(ctx, field)
+func (ec *executionContext) _ChatDto_permissions(ctx context.Context, field graphql.CollectedField, obj *model.ChatDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatDto_permissions(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4372,49 +4800,38 @@ func (ec *executionContext) _ChatEvent_participantsEvent(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ParticipantsEvent, nil
+		return obj.Permissions, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.ParticipantWithAdmin)
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalOParticipantWithAdmin2ᚕᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipantWithAdminᚄ(ctx, field.Selections, res)
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatEvent_participantsEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatDto_permissions(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ChatEvent",
+		Object:     "ChatDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_ParticipantWithAdmin_id(ctx, field)
-			case "login":
-				return ec.fieldContext_ParticipantWithAdmin_login(ctx, field)
-			case "avatar":
-				return ec.fieldContext_ParticipantWithAdmin_avatar(ctx, field)
-			case "admin":
-				return ec.fieldContext_ParticipantWithAdmin_admin(ctx, field)
-			case "shortInfo":
-				return ec.fieldContext_ParticipantWithAdmin_shortInfo(ctx, field)
-			case "loginColor":
-				return ec.fieldContext_ParticipantWithAdmin_loginColor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ParticipantWithAdmin", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatEvent_promoteMessageEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatEvent_promoteMessageEvent(ctx, field)
+func (ec *executionContext) _ChatDto_lastMessagePreview(ctx context.Context, field graphql.CollectedField, obj *model.ChatDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatDto_lastMessagePreview(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4427,7 +4844,7 @@ func (ec *executionContext) _ChatEvent_promoteMessageEvent(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PromoteMessageEvent, nil
+		return obj.LastMessagePreview, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4436,32 +4853,26 @@ func (ec *executionContext) _ChatEvent_promoteMessageEvent(ctx context.Context,
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.PinnedMessageEvent)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOPinnedMessageEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPinnedMessageEvent(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatEvent_promoteMessageEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatDto_lastMessagePreview(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ChatEvent",
+		Object:     "ChatDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "message":
-				return ec.fieldContext_PinnedMessageEvent_message(ctx, field)
-			case "count":
-				return ec.fieldContext_PinnedMessageEvent_count(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type PinnedMessageEvent", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatEvent_fileEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatEvent_fileEvent(ctx, field)
+func (ec *executionContext) _ChatEvent_eventType(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_eventType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4474,39 +4885,38 @@ func (ec *executionContext) _ChatEvent_fileEvent(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.FileEvent, nil
+		return obj.EventType, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.WrappedFileInfoDto)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOWrappedFileInfoDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐWrappedFileInfoDto(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatEvent_fileEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_eventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "ChatEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "fileInfoDto":
-				return ec.fieldContext_WrappedFileInfoDto_fileInfoDto(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type WrappedFileInfoDto", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatEvent_publishedMessageEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatEvent_publishedMessageEvent(ctx, field)
+func (ec *executionContext) _ChatEvent_messageEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_messageEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4519,7 +4929,7 @@ func (ec *executionContext) _ChatEvent_publishedMessageEvent(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PublishedMessageEvent, nil
+		return obj.MessageEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4528,12 +4938,12 @@ func (ec *executionContext) _ChatEvent_publishedMessageEvent(ctx context.Context
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.PublishedMessageEvent)
+	res := resTmp.(*model.DisplayMessageDto)
 	fc.Result = res
-	return ec.marshalOPublishedMessageEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPublishedMessageEvent(ctx, field.Selections, res)
+	return ec.marshalODisplayMessageDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐDisplayMessageDto(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatEvent_publishedMessageEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_messageEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "ChatEvent",
 		Field:      field,
@@ -4541,19 +4951,65 @@ func (ec *executionContext) fieldContext_ChatEvent_publishedMessageEvent(_ conte
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "message":
-				return ec.fieldContext_PublishedMessageEvent_message(ctx, field)
-			case "count":
-				return ec.fieldContext_PublishedMessageEvent_count(ctx, field)
+			case "id":
+				return ec.fieldContext_DisplayMessageDto_id(ctx, field)
+			case "text":
+				return ec.fieldContext_DisplayMessageDto_text(ctx, field)
+			case "chatId":
+				return ec.fieldContext_DisplayMessageDto_chatId(ctx, field)
+			case "ownerId":
+				return ec.fieldContext_DisplayMessageDto_ownerId(ctx, field)
+			case "createDateTime":
+				return ec.fieldContext_DisplayMessageDto_createDateTime(ctx, field)
+			case "editDateTime":
+				return ec.fieldContext_DisplayMessageDto_editDateTime(ctx, field)
+			case "owner":
+				return ec.fieldContext_DisplayMessageDto_owner(ctx, field)
+			case "canEdit":
+				return ec.fieldContext_DisplayMessageDto_canEdit(ctx, field)
+			case "canDelete":
+				return ec.fieldContext_DisplayMessageDto_canDelete(ctx, field)
+			case "fileItemUuid":
+				return ec.fieldContext_DisplayMessageDto_fileItemUuid(ctx, field)
+			case "embedMessage":
+				return ec.fieldContext_DisplayMessageDto_embedMessage(ctx, field)
+			case "pinned":
+				return ec.fieldContext_DisplayMessageDto_pinned(ctx, field)
+			case "blogPost":
+				return ec.fieldContext_DisplayMessageDto_blogPost(ctx, field)
+			case "pinnedPromoted":
+				return ec.fieldContext_DisplayMessageDto_pinnedPromoted(ctx, field)
+			case "reactions":
+				return ec.fieldContext_DisplayMessageDto_reactions(ctx, field)
+			case "published":
+				return ec.fieldContext_DisplayMessageDto_published(ctx, field)
+			case "canPublish":
+				return ec.fieldContext_DisplayMessageDto_canPublish(ctx, field)
+			case "canPin":
+				return ec.fieldContext_DisplayMessageDto_canPin(ctx, field)
+			case "threadId":
+				return ec.fieldContext_DisplayMessageDto_threadId(ctx, field)
+			case "thread":
+				return ec.fieldContext_DisplayMessageDto_thread(ctx, field)
+			case "deletedDateTime":
+				return ec.fieldContext_DisplayMessageDto_deletedDateTime(ctx, field)
+			case "restorableUntil":
+				return ec.fieldContext_DisplayMessageDto_restorableUntil(ctx, field)
+			case "canRestore":
+				return ec.fieldContext_DisplayMessageDto_canRestore(ctx, field)
+			case "poll":
+				return ec.fieldContext_DisplayMessageDto_poll(ctx, field)
+			case "linkPreview":
+				return ec.fieldContext_DisplayMessageDto_linkPreview(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type PublishedMessageEvent", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type DisplayMessageDto", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatEvent_reactionChangedEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatEvent_reactionChangedEvent(ctx, field)
+func (ec *executionContext) _ChatEvent_messageDeletedEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_messageDeletedEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4566,7 +5022,7 @@ func (ec *executionContext) _ChatEvent_reactionChangedEvent(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ReactionChangedEvent, nil
+		return obj.MessageDeletedEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4575,12 +5031,12 @@ func (ec *executionContext) _ChatEvent_reactionChangedEvent(ctx context.Context,
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.ReactionChangedEvent)
+	res := resTmp.(*model.MessageDeletedDto)
 	fc.Result = res
-	return ec.marshalOReactionChangedEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐReactionChangedEvent(ctx, field.Selections, res)
+	return ec.marshalOMessageDeletedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐMessageDeletedDto(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatEvent_reactionChangedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_messageDeletedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "ChatEvent",
 		Field:      field,
@@ -4588,19 +5044,21 @@ func (ec *executionContext) fieldContext_ChatEvent_reactionChangedEvent(_ contex
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "messageId":
-				return ec.fieldContext_ReactionChangedEvent_messageId(ctx, field)
-			case "reaction":
-				return ec.fieldContext_ReactionChangedEvent_reaction(ctx, field)
+			case "id":
+				return ec.fieldContext_MessageDeletedDto_id(ctx, field)
+			case "chatId":
+				return ec.fieldContext_MessageDeletedDto_chatId(ctx, field)
+			case "threadId":
+				return ec.fieldContext_MessageDeletedDto_threadId(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type ReactionChangedEvent", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type MessageDeletedDto", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatUnreadMessageChanged_chatId(ctx context.Context, field graphql.CollectedField, obj *model.ChatUnreadMessageChanged) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatUnreadMessageChanged_chatId(ctx, field)
+func (ec *executionContext) _ChatEvent_messageBroadcastEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_messageBroadcastEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4613,38 +5071,43 @@ func (ec *executionContext) _ChatUnreadMessageChanged_chatId(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ChatID, nil
+		return obj.MessageBroadcastEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(*model.MessageBroadcastNotification)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalOMessageBroadcastNotification2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐMessageBroadcastNotification(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatUnreadMessageChanged_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_messageBroadcastEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ChatUnreadMessageChanged",
+		Object:     "ChatEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			switch field.Name {
+			case "login":
+				return ec.fieldContext_MessageBroadcastNotification_login(ctx, field)
+			case "userId":
+				return ec.fieldContext_MessageBroadcastNotification_userId(ctx, field)
+			case "text":
+				return ec.fieldContext_MessageBroadcastNotification_text(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MessageBroadcastNotification", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatUnreadMessageChanged_unreadMessages(ctx context.Context, field graphql.CollectedField, obj *model.ChatUnreadMessageChanged) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatUnreadMessageChanged_unreadMessages(ctx, field)
+func (ec *executionContext) _ChatEvent_previewCreatedEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_previewCreatedEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4657,38 +5120,47 @@ func (ec *executionContext) _ChatUnreadMessageChanged_unreadMessages(ctx context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.UnreadMessages, nil
+		return obj.PreviewCreatedEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(*model.PreviewCreatedEvent)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalOPreviewCreatedEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPreviewCreatedEvent(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatUnreadMessageChanged_unreadMessages(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_previewCreatedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ChatUnreadMessageChanged",
+		Object:     "ChatEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_PreviewCreatedEvent_id(ctx, field)
+			case "url":
+				return ec.fieldContext_PreviewCreatedEvent_url(ctx, field)
+			case "previewUrl":
+				return ec.fieldContext_PreviewCreatedEvent_previewUrl(ctx, field)
+			case "aType":
+				return ec.fieldContext_PreviewCreatedEvent_aType(ctx, field)
+			case "correlationId":
+				return ec.fieldContext_PreviewCreatedEvent_correlationId(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PreviewCreatedEvent", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ChatUnreadMessageChanged_lastUpdateDateTime(ctx context.Context, field graphql.CollectedField, obj *model.ChatUnreadMessageChanged) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatUnreadMessageChanged_lastUpdateDateTime(ctx, field)
+func (ec *executionContext) _ChatEvent_participantsEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_participantsEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4701,38 +5173,51 @@ func (ec *executionContext) _ChatUnreadMessageChanged_lastUpdateDateTime(ctx con
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LastUpdateDateTime, nil
+		return obj.ParticipantsEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.([]*model.ParticipantWithAdmin)
 	fc.Result = res
-	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalOParticipantWithAdmin2ᚕᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipantWithAdminᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ChatUnreadMessageChanged_lastUpdateDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_participantsEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ChatUnreadMessageChanged",
+		Object:     "ChatEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_ParticipantWithAdmin_id(ctx, field)
+			case "login":
+				return ec.fieldContext_ParticipantWithAdmin_login(ctx, field)
+			case "avatar":
+				return ec.fieldContext_ParticipantWithAdmin_avatar(ctx, field)
+			case "admin":
+				return ec.fieldContext_ParticipantWithAdmin_admin(ctx, field)
+			case "role":
+				return ec.fieldContext_ParticipantWithAdmin_role(ctx, field)
+			case "shortInfo":
+				return ec.fieldContext_ParticipantWithAdmin_shortInfo(ctx, field)
+			case "loginColor":
+				return ec.fieldContext_ParticipantWithAdmin_loginColor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ParticipantWithAdmin", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DataDTO_enabled(ctx context.Context, field graphql.CollectedField, obj *model.DataDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DataDTO_enabled(ctx, field)
+func (ec *executionContext) _ChatEvent_promoteMessageEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_promoteMessageEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4745,38 +5230,41 @@ func (ec *executionContext) _DataDTO_enabled(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Enabled, nil
+		return obj.PromoteMessageEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model.PinnedMessageEvent)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOPinnedMessageEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPinnedMessageEvent(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DataDTO_enabled(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_promoteMessageEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DataDTO",
+		Object:     "ChatEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "message":
+				return ec.fieldContext_PinnedMessageEvent_message(ctx, field)
+			case "count":
+				return ec.fieldContext_PinnedMessageEvent_count(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PinnedMessageEvent", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DataDTO_expired(ctx context.Context, field graphql.CollectedField, obj *model.DataDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DataDTO_expired(ctx, field)
+func (ec *executionContext) _ChatEvent_fileEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_fileEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4789,38 +5277,39 @@ func (ec *executionContext) _DataDTO_expired(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Expired, nil
+		return obj.FileEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model.WrappedFileInfoDto)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOWrappedFileInfoDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐWrappedFileInfoDto(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DataDTO_expired(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_fileEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DataDTO",
+		Object:     "ChatEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "fileInfoDto":
+				return ec.fieldContext_WrappedFileInfoDto_fileInfoDto(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type WrappedFileInfoDto", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DataDTO_locked(ctx context.Context, field graphql.CollectedField, obj *model.DataDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DataDTO_locked(ctx, field)
+func (ec *executionContext) _ChatEvent_publishedMessageEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_publishedMessageEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4833,38 +5322,41 @@ func (ec *executionContext) _DataDTO_locked(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Locked, nil
+		return obj.PublishedMessageEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model.PublishedMessageEvent)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOPublishedMessageEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPublishedMessageEvent(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DataDTO_locked(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_publishedMessageEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DataDTO",
+		Object:     "ChatEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "message":
+				return ec.fieldContext_PublishedMessageEvent_message(ctx, field)
+			case "count":
+				return ec.fieldContext_PublishedMessageEvent_count(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PublishedMessageEvent", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DataDTO_confirmed(ctx context.Context, field graphql.CollectedField, obj *model.DataDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DataDTO_confirmed(ctx, field)
+func (ec *executionContext) _ChatEvent_reactionChangedEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_reactionChangedEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4877,38 +5369,41 @@ func (ec *executionContext) _DataDTO_confirmed(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Confirmed, nil
+		return obj.ReactionChangedEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model.ReactionChangedEvent)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOReactionChangedEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐReactionChangedEvent(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DataDTO_confirmed(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_reactionChangedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DataDTO",
+		Object:     "ChatEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
-		},
-	}
-	return fc, nil
-}
-
-func (ec *executionContext) _DataDTO_roles(ctx context.Context, field graphql.CollectedField, obj *model.DataDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DataDTO_roles(ctx, field)
+			switch field.Name {
+			case "messageId":
+				return ec.fieldContext_ReactionChangedEvent_messageId(ctx, field)
+			case "reaction":
+				return ec.fieldContext_ReactionChangedEvent_reaction(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ReactionChangedEvent", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ChatEvent_threadChangedEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_threadChangedEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4921,38 +5416,41 @@ func (ec *executionContext) _DataDTO_roles(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Roles, nil
+		return obj.ThreadChangedEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]string)
+	res := resTmp.(*model.ThreadChangedEvent)
 	fc.Result = res
-	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+	return ec.marshalOThreadChangedEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐThreadChangedEvent(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DataDTO_roles(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_threadChangedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DataDTO",
+		Object:     "ChatEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "thread":
+				return ec.fieldContext_ThreadChangedEvent_thread(ctx, field)
+			case "unreadReplies":
+				return ec.fieldContext_ThreadChangedEvent_unreadReplies(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ThreadChangedEvent", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_id(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_id(ctx, field)
+func (ec *executionContext) _ChatEvent_pollChangedEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_pollChangedEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4965,38 +5463,41 @@ func (ec *executionContext) _DisplayMessageDto_id(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.PollChangedEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(*model.PollChangedEvent)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalOPollChangedEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPollChangedEvent(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_pollChangedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DisplayMessageDto",
+		Object:     "ChatEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			switch field.Name {
+			case "messageId":
+				return ec.fieldContext_PollChangedEvent_messageId(ctx, field)
+			case "poll":
+				return ec.fieldContext_PollChangedEvent_poll(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PollChangedEvent", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_text(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_text(ctx, field)
+func (ec *executionContext) _ChatEvent_ephemeralMessageEvent(ctx context.Context, field graphql.CollectedField, obj *model.ChatEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatEvent_ephemeralMessageEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5009,38 +5510,43 @@ func (ec *executionContext) _DisplayMessageDto_text(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Text, nil
+		return obj.EphemeralMessageEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*model.EphemeralMessageNotification)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOEphemeralMessageNotification2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐEphemeralMessageNotification(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_text(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatEvent_ephemeralMessageEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DisplayMessageDto",
+		Object:     "ChatEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "command":
+				return ec.fieldContext_EphemeralMessageNotification_command(ctx, field)
+			case "text":
+				return ec.fieldContext_EphemeralMessageNotification_text(ctx, field)
+			case "threadId":
+				return ec.fieldContext_EphemeralMessageNotification_threadId(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type EphemeralMessageNotification", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_chatId(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_chatId(ctx, field)
+func (ec *executionContext) _ChatUnreadMessageChanged_chatId(ctx context.Context, field graphql.CollectedField, obj *model.ChatUnreadMessageChanged) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatUnreadMessageChanged_chatId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5070,9 +5576,9 @@ func (ec *executionContext) _DisplayMessageDto_chatId(ctx context.Context, field
 	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatUnreadMessageChanged_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DisplayMessageDto",
+		Object:     "ChatUnreadMessageChanged",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5083,8 +5589,8 @@ func (ec *executionContext) fieldContext_DisplayMessageDto_chatId(_ context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_ownerId(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_ownerId(ctx, field)
+func (ec *executionContext) _ChatUnreadMessageChanged_unreadMessages(ctx context.Context, field graphql.CollectedField, obj *model.ChatUnreadMessageChanged) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatUnreadMessageChanged_unreadMessages(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5097,7 +5603,7 @@ func (ec *executionContext) _DisplayMessageDto_ownerId(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.OwnerID, nil
+		return obj.UnreadMessages, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5114,9 +5620,9 @@ func (ec *executionContext) _DisplayMessageDto_ownerId(ctx context.Context, fiel
 	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_ownerId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatUnreadMessageChanged_unreadMessages(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DisplayMessageDto",
+		Object:     "ChatUnreadMessageChanged",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5127,8 +5633,8 @@ func (ec *executionContext) fieldContext_DisplayMessageDto_ownerId(_ context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_createDateTime(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_createDateTime(ctx, field)
+func (ec *executionContext) _ChatUnreadMessageChanged_lastUpdateDateTime(ctx context.Context, field graphql.CollectedField, obj *model.ChatUnreadMessageChanged) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatUnreadMessageChanged_lastUpdateDateTime(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5141,7 +5647,7 @@ func (ec *executionContext) _DisplayMessageDto_createDateTime(ctx context.Contex
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CreateDateTime, nil
+		return obj.LastUpdateDateTime, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5158,9 +5664,9 @@ func (ec *executionContext) _DisplayMessageDto_createDateTime(ctx context.Contex
 	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_createDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ChatUnreadMessageChanged_lastUpdateDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DisplayMessageDto",
+		Object:     "ChatUnreadMessageChanged",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5171,8 +5677,8 @@ func (ec *executionContext) fieldContext_DisplayMessageDto_createDateTime(_ cont
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_editDateTime(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_editDateTime(ctx, field)
+func (ec *executionContext) _DataDTO_enabled(ctx context.Context, field graphql.CollectedField, obj *model.DataDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DataDTO_enabled(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5185,35 +5691,38 @@ func (ec *executionContext) _DisplayMessageDto_editDateTime(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EditDateTime, nil
+		return obj.Enabled, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*time.Time)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_editDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DataDTO_enabled(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DisplayMessageDto",
+		Object:     "DataDTO",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_owner(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_owner(ctx, field)
+func (ec *executionContext) _DataDTO_expired(ctx context.Context, field graphql.CollectedField, obj *model.DataDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DataDTO_expired(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5226,47 +5735,38 @@ func (ec *executionContext) _DisplayMessageDto_owner(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Owner, nil
+		return obj.Expired, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Participant)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOParticipant2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipant(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_owner(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DataDTO_expired(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DisplayMessageDto",
+		Object:     "DataDTO",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Participant_id(ctx, field)
-			case "login":
-				return ec.fieldContext_Participant_login(ctx, field)
-			case "avatar":
-				return ec.fieldContext_Participant_avatar(ctx, field)
-			case "shortInfo":
-				return ec.fieldContext_Participant_shortInfo(ctx, field)
-			case "loginColor":
-				return ec.fieldContext_Participant_loginColor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_canEdit(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_canEdit(ctx, field)
+func (ec *executionContext) _DataDTO_locked(ctx context.Context, field graphql.CollectedField, obj *model.DataDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DataDTO_locked(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5279,7 +5779,7 @@ func (ec *executionContext) _DisplayMessageDto_canEdit(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CanEdit, nil
+		return obj.Locked, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5296,9 +5796,9 @@ func (ec *executionContext) _DisplayMessageDto_canEdit(ctx context.Context, fiel
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_canEdit(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DataDTO_locked(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DisplayMessageDto",
+		Object:     "DataDTO",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5309,8 +5809,8 @@ func (ec *executionContext) fieldContext_DisplayMessageDto_canEdit(_ context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_canDelete(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_canDelete(ctx, field)
+func (ec *executionContext) _DataDTO_confirmed(ctx context.Context, field graphql.CollectedField, obj *model.DataDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DataDTO_confirmed(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5323,7 +5823,7 @@ func (ec *executionContext) _DisplayMessageDto_canDelete(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CanDelete, nil
+		return obj.Confirmed, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5340,9 +5840,9 @@ func (ec *executionContext) _DisplayMessageDto_canDelete(ctx context.Context, fi
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_canDelete(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DataDTO_confirmed(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DisplayMessageDto",
+		Object:     "DataDTO",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5353,8 +5853,8 @@ func (ec *executionContext) fieldContext_DisplayMessageDto_canDelete(_ context.C
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_fileItemUuid(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_fileItemUuid(ctx, field)
+func (ec *executionContext) _DataDTO_roles(ctx context.Context, field graphql.CollectedField, obj *model.DataDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DataDTO_roles(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5367,23 +5867,26 @@ func (ec *executionContext) _DisplayMessageDto_fileItemUuid(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.FileItemUUID, nil
+		return obj.Roles, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_fileItemUuid(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DataDTO_roles(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DisplayMessageDto",
+		Object:     "DataDTO",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5394,8 +5897,8 @@ func (ec *executionContext) fieldContext_DisplayMessageDto_fileItemUuid(_ contex
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_embedMessage(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_embedMessage(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_id(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5408,51 +5911,38 @@ func (ec *executionContext) _DisplayMessageDto_embedMessage(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EmbedMessage, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.EmbedMessageResponse)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalOEmbedMessageResponse2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐEmbedMessageResponse(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_embedMessage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_EmbedMessageResponse_id(ctx, field)
-			case "chatId":
-				return ec.fieldContext_EmbedMessageResponse_chatId(ctx, field)
-			case "chatName":
-				return ec.fieldContext_EmbedMessageResponse_chatName(ctx, field)
-			case "text":
-				return ec.fieldContext_EmbedMessageResponse_text(ctx, field)
-			case "owner":
-				return ec.fieldContext_EmbedMessageResponse_owner(ctx, field)
-			case "embedType":
-				return ec.fieldContext_EmbedMessageResponse_embedType(ctx, field)
-			case "isParticipant":
-				return ec.fieldContext_EmbedMessageResponse_isParticipant(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type EmbedMessageResponse", field.Name)
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_pinned(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_pinned(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_text(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_text(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5465,7 +5955,7 @@ func (ec *executionContext) _DisplayMessageDto_pinned(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Pinned, nil
+		return obj.Text, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5477,26 +5967,26 @@ func (ec *executionContext) _DisplayMessageDto_pinned(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_pinned(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_text(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_blogPost(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_blogPost(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_chatId(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_chatId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5509,7 +5999,7 @@ func (ec *executionContext) _DisplayMessageDto_blogPost(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.BlogPost, nil
+		return obj.ChatID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5521,26 +6011,26 @@ func (ec *executionContext) _DisplayMessageDto_blogPost(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_blogPost(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_pinnedPromoted(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_pinnedPromoted(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_ownerId(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_ownerId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5553,35 +6043,38 @@ func (ec *executionContext) _DisplayMessageDto_pinnedPromoted(ctx context.Contex
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PinnedPromoted, nil
+		return obj.OwnerID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*bool)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_pinnedPromoted(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_ownerId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_reactions(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_reactions(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_createDateTime(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_createDateTime(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5594,7 +6087,7 @@ func (ec *executionContext) _DisplayMessageDto_reactions(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Reactions, nil
+		return obj.CreateDateTime, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5606,34 +6099,26 @@ func (ec *executionContext) _DisplayMessageDto_reactions(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Reaction)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalNReaction2ᚕᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐReactionᚄ(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_reactions(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_createDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "count":
-				return ec.fieldContext_Reaction_count(ctx, field)
-			case "users":
-				return ec.fieldContext_Reaction_users(ctx, field)
-			case "reaction":
-				return ec.fieldContext_Reaction_reaction(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Reaction", field.Name)
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_published(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_published(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_editDateTime(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_editDateTime(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5646,38 +6131,35 @@ func (ec *executionContext) _DisplayMessageDto_published(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Published, nil
+		return obj.EditDateTime, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*time.Time)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_published(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_editDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_canPublish(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_canPublish(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_owner(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_owner(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5690,38 +6172,47 @@ func (ec *executionContext) _DisplayMessageDto_canPublish(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CanPublish, nil
+		return obj.Owner, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model.Participant)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOParticipant2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipant(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_canPublish(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_owner(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Participant_id(ctx, field)
+			case "login":
+				return ec.fieldContext_Participant_login(ctx, field)
+			case "avatar":
+				return ec.fieldContext_Participant_avatar(ctx, field)
+			case "shortInfo":
+				return ec.fieldContext_Participant_shortInfo(ctx, field)
+			case "loginColor":
+				return ec.fieldContext_Participant_loginColor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DisplayMessageDto_canPin(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DisplayMessageDto_canPin(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_canEdit(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_canEdit(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5734,7 +6225,7 @@ func (ec *executionContext) _DisplayMessageDto_canPin(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CanPin, nil
+		return obj.CanEdit, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5751,7 +6242,7 @@ func (ec *executionContext) _DisplayMessageDto_canPin(ctx context.Context, field
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DisplayMessageDto_canPin(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_canEdit(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "DisplayMessageDto",
 		Field:      field,
@@ -5764,8 +6255,8 @@ func (ec *executionContext) fieldContext_DisplayMessageDto_canPin(_ context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _EmbedMessageResponse_id(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EmbedMessageResponse_id(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_canDelete(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_canDelete(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5778,7 +6269,7 @@ func (ec *executionContext) _EmbedMessageResponse_id(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.CanDelete, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5790,26 +6281,26 @@ func (ec *executionContext) _EmbedMessageResponse_id(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EmbedMessageResponse_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_canDelete(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EmbedMessageResponse",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EmbedMessageResponse_chatId(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EmbedMessageResponse_chatId(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_fileItemUuid(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_fileItemUuid(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5822,7 +6313,7 @@ func (ec *executionContext) _EmbedMessageResponse_chatId(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ChatID, nil
+		return obj.FileItemUUID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5831,26 +6322,26 @@ func (ec *executionContext) _EmbedMessageResponse_chatId(ctx context.Context, fi
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int64)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt642ᚖint64(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EmbedMessageResponse_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_fileItemUuid(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EmbedMessageResponse",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EmbedMessageResponse_chatName(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EmbedMessageResponse_chatName(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_embedMessage(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_embedMessage(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5863,7 +6354,7 @@ func (ec *executionContext) _EmbedMessageResponse_chatName(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ChatName, nil
+		return obj.EmbedMessage, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5872,26 +6363,42 @@ func (ec *executionContext) _EmbedMessageResponse_chatName(ctx context.Context,
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model.EmbedMessageResponse)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOEmbedMessageResponse2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐEmbedMessageResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EmbedMessageResponse_chatName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_embedMessage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EmbedMessageResponse",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_EmbedMessageResponse_id(ctx, field)
+			case "chatId":
+				return ec.fieldContext_EmbedMessageResponse_chatId(ctx, field)
+			case "chatName":
+				return ec.fieldContext_EmbedMessageResponse_chatName(ctx, field)
+			case "text":
+				return ec.fieldContext_EmbedMessageResponse_text(ctx, field)
+			case "owner":
+				return ec.fieldContext_EmbedMessageResponse_owner(ctx, field)
+			case "embedType":
+				return ec.fieldContext_EmbedMessageResponse_embedType(ctx, field)
+			case "isParticipant":
+				return ec.fieldContext_EmbedMessageResponse_isParticipant(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type EmbedMessageResponse", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EmbedMessageResponse_text(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EmbedMessageResponse_text(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_pinned(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_pinned(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5904,7 +6411,7 @@ func (ec *executionContext) _EmbedMessageResponse_text(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Text, nil
+		return obj.Pinned, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5916,26 +6423,26 @@ func (ec *executionContext) _EmbedMessageResponse_text(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EmbedMessageResponse_text(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_pinned(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EmbedMessageResponse",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EmbedMessageResponse_owner(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EmbedMessageResponse_owner(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_blogPost(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_blogPost(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5948,47 +6455,38 @@ func (ec *executionContext) _EmbedMessageResponse_owner(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Owner, nil
+		return obj.BlogPost, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Participant)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOParticipant2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipant(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EmbedMessageResponse_owner(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_blogPost(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EmbedMessageResponse",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Participant_id(ctx, field)
-			case "login":
-				return ec.fieldContext_Participant_login(ctx, field)
-			case "avatar":
-				return ec.fieldContext_Participant_avatar(ctx, field)
-			case "shortInfo":
-				return ec.fieldContext_Participant_shortInfo(ctx, field)
-			case "loginColor":
-				return ec.fieldContext_Participant_loginColor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EmbedMessageResponse_embedType(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EmbedMessageResponse_embedType(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_pinnedPromoted(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_pinnedPromoted(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6001,38 +6499,35 @@ func (ec *executionContext) _EmbedMessageResponse_embedType(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EmbedType, nil
+		return obj.PinnedPromoted, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EmbedMessageResponse_embedType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_pinnedPromoted(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EmbedMessageResponse",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EmbedMessageResponse_isParticipant(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EmbedMessageResponse_isParticipant(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_reactions(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_reactions(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6045,7 +6540,7 @@ func (ec *executionContext) _EmbedMessageResponse_isParticipant(ctx context.Cont
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.IsParticipant, nil
+		return obj.Reactions, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6057,26 +6552,36 @@ func (ec *executionContext) _EmbedMessageResponse_isParticipant(ctx context.Cont
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.([]*model.Reaction)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNReaction2ᚕᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐReactionᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EmbedMessageResponse_isParticipant(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_reactions(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EmbedMessageResponse",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "count":
+				return ec.fieldContext_Reaction_count(ctx, field)
+			case "users":
+				return ec.fieldContext_Reaction_users(ctx, field)
+			case "reaction":
+				return ec.fieldContext_Reaction_reaction(ctx, field)
+			case "url":
+				return ec.fieldContext_Reaction_url(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Reaction", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_id(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_id(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_published(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_published(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6089,7 +6594,7 @@ func (ec *executionContext) _FileInfoDto_id(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Published, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6101,26 +6606,26 @@ func (ec *executionContext) _FileInfoDto_id(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_published(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_filename(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_filename(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_canPublish(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_canPublish(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6133,7 +6638,7 @@ func (ec *executionContext) _FileInfoDto_filename(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Filename, nil
+		return obj.CanPublish, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6145,26 +6650,26 @@ func (ec *executionContext) _FileInfoDto_filename(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_filename(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_canPublish(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_url(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_url(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_canPin(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_canPin(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6177,7 +6682,7 @@ func (ec *executionContext) _FileInfoDto_url(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.URL, nil
+		return obj.CanPin, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6189,26 +6694,26 @@ func (ec *executionContext) _FileInfoDto_url(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_url(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_canPin(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_publishedUrl(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_publishedUrl(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_threadId(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_threadId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6221,7 +6726,7 @@ func (ec *executionContext) _FileInfoDto_publishedUrl(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PublishedURL, nil
+		return obj.ThreadID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6230,26 +6735,26 @@ func (ec *executionContext) _FileInfoDto_publishedUrl(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int64)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt642ᚖint64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_publishedUrl(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_threadId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_previewUrl(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_previewUrl(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_thread(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_thread(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6262,7 +6767,7 @@ func (ec *executionContext) _FileInfoDto_previewUrl(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PreviewURL, nil
+		return obj.Thread, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6271,26 +6776,34 @@ func (ec *executionContext) _FileInfoDto_previewUrl(ctx context.Context, field g
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model.ThreadDto)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOThreadDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐThreadDto(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_previewUrl(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_thread(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "rootMessageId":
+				return ec.fieldContext_ThreadDto_rootMessageId(ctx, field)
+			case "replyCount":
+				return ec.fieldContext_ThreadDto_replyCount(ctx, field)
+			case "lastReplyDateTime":
+				return ec.fieldContext_ThreadDto_lastReplyDateTime(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ThreadDto", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_size(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_size(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_deletedDateTime(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_deletedDateTime(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6303,38 +6816,35 @@ func (ec *executionContext) _FileInfoDto_size(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Size, nil
+		return obj.DeletedDateTime, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(*time.Time)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_size(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_deletedDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_canDelete(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_canDelete(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_restorableUntil(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_restorableUntil(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6347,38 +6857,35 @@ func (ec *executionContext) _FileInfoDto_canDelete(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CanDelete, nil
+		return obj.RestorableUntil, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*time.Time)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_canDelete(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_restorableUntil(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_canEdit(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_canEdit(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_canRestore(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_canRestore(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6391,7 +6898,7 @@ func (ec *executionContext) _FileInfoDto_canEdit(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CanEdit, nil
+		return obj.CanRestore, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6408,9 +6915,9 @@ func (ec *executionContext) _FileInfoDto_canEdit(ctx context.Context, field grap
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_canEdit(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_canRestore(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -6421,8 +6928,8 @@ func (ec *executionContext) fieldContext_FileInfoDto_canEdit(_ context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_canShare(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_canShare(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_poll(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_poll(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6435,38 +6942,53 @@ func (ec *executionContext) _FileInfoDto_canShare(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CanShare, nil
+		return obj.Poll, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model.PollDto)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOPollDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPollDto(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_canShare(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_poll(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "multipleChoice":
+				return ec.fieldContext_PollDto_multipleChoice(ctx, field)
+			case "anonymous":
+				return ec.fieldContext_PollDto_anonymous(ctx, field)
+			case "closed":
+				return ec.fieldContext_PollDto_closed(ctx, field)
+			case "closedDateTime":
+				return ec.fieldContext_PollDto_closedDateTime(ctx, field)
+			case "options":
+				return ec.fieldContext_PollDto_options(ctx, field)
+			case "totalVoters":
+				return ec.fieldContext_PollDto_totalVoters(ctx, field)
+			case "canVote":
+				return ec.fieldContext_PollDto_canVote(ctx, field)
+			case "canClose":
+				return ec.fieldContext_PollDto_canClose(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PollDto", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_lastModified(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_lastModified(ctx, field)
+func (ec *executionContext) _DisplayMessageDto_linkPreview(ctx context.Context, field graphql.CollectedField, obj *model.DisplayMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DisplayMessageDto_linkPreview(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6479,38 +7001,47 @@ func (ec *executionContext) _FileInfoDto_lastModified(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LastModified, nil
+		return obj.LinkPreview, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.(*model.LinkPreviewDto)
 	fc.Result = res
-	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalOLinkPreviewDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐLinkPreviewDto(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_lastModified(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DisplayMessageDto_linkPreview(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "DisplayMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			switch field.Name {
+			case "url":
+				return ec.fieldContext_LinkPreviewDto_url(ctx, field)
+			case "title":
+				return ec.fieldContext_LinkPreviewDto_title(ctx, field)
+			case "description":
+				return ec.fieldContext_LinkPreviewDto_description(ctx, field)
+			case "imageUrl":
+				return ec.fieldContext_LinkPreviewDto_imageUrl(ctx, field)
+			case "siteName":
+				return ec.fieldContext_LinkPreviewDto_siteName(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type LinkPreviewDto", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_ownerId(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_ownerId(ctx, field)
+func (ec *executionContext) _EmbedMessageResponse_id(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EmbedMessageResponse_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6523,7 +7054,7 @@ func (ec *executionContext) _FileInfoDto_ownerId(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.OwnerID, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6540,9 +7071,9 @@ func (ec *executionContext) _FileInfoDto_ownerId(ctx context.Context, field grap
 	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_ownerId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EmbedMessageResponse_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "EmbedMessageResponse",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -6553,8 +7084,8 @@ func (ec *executionContext) fieldContext_FileInfoDto_ownerId(_ context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_owner(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_owner(ctx, field)
+func (ec *executionContext) _EmbedMessageResponse_chatId(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EmbedMessageResponse_chatId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6567,7 +7098,7 @@ func (ec *executionContext) _FileInfoDto_owner(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Owner, nil
+		return obj.ChatID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6576,38 +7107,26 @@ func (ec *executionContext) _FileInfoDto_owner(ctx context.Context, field graphq
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.Participant)
+	res := resTmp.(*int64)
 	fc.Result = res
-	return ec.marshalOParticipant2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipant(ctx, field.Selections, res)
+	return ec.marshalOInt642ᚖint64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_owner(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EmbedMessageResponse_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "EmbedMessageResponse",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Participant_id(ctx, field)
-			case "login":
-				return ec.fieldContext_Participant_login(ctx, field)
-			case "avatar":
-				return ec.fieldContext_Participant_avatar(ctx, field)
-			case "shortInfo":
-				return ec.fieldContext_Participant_shortInfo(ctx, field)
-			case "loginColor":
-				return ec.fieldContext_Participant_loginColor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_canPlayAsVideo(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_canPlayAsVideo(ctx, field)
+func (ec *executionContext) _EmbedMessageResponse_chatName(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EmbedMessageResponse_chatName(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6620,38 +7139,35 @@ func (ec *executionContext) _FileInfoDto_canPlayAsVideo(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CanPlayAsVideo, nil
+		return obj.ChatName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_canPlayAsVideo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EmbedMessageResponse_chatName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "EmbedMessageResponse",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_canShowAsImage(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_canShowAsImage(ctx, field)
+func (ec *executionContext) _EmbedMessageResponse_text(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EmbedMessageResponse_text(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6664,7 +7180,7 @@ func (ec *executionContext) _FileInfoDto_canShowAsImage(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CanShowAsImage, nil
+		return obj.Text, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6676,26 +7192,26 @@ func (ec *executionContext) _FileInfoDto_canShowAsImage(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_canShowAsImage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EmbedMessageResponse_text(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "EmbedMessageResponse",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_canPlayAsAudio(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_canPlayAsAudio(ctx, field)
+func (ec *executionContext) _EmbedMessageResponse_owner(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EmbedMessageResponse_owner(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6708,38 +7224,47 @@ func (ec *executionContext) _FileInfoDto_canPlayAsAudio(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CanPlayAsAudio, nil
+		return obj.Owner, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model.Participant)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOParticipant2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipant(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_canPlayAsAudio(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EmbedMessageResponse_owner(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "EmbedMessageResponse",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Participant_id(ctx, field)
+			case "login":
+				return ec.fieldContext_Participant_login(ctx, field)
+			case "avatar":
+				return ec.fieldContext_Participant_avatar(ctx, field)
+			case "shortInfo":
+				return ec.fieldContext_Participant_shortInfo(ctx, field)
+			case "loginColor":
+				return ec.fieldContext_Participant_loginColor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_fileItemUuid(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_fileItemUuid(ctx, field)
+func (ec *executionContext) _EmbedMessageResponse_embedType(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EmbedMessageResponse_embedType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6752,7 +7277,7 @@ func (ec *executionContext) _FileInfoDto_fileItemUuid(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.FileItemUUID, nil
+		return obj.EmbedType, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6769,9 +7294,9 @@ func (ec *executionContext) _FileInfoDto_fileItemUuid(ctx context.Context, field
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_fileItemUuid(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EmbedMessageResponse_embedType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "EmbedMessageResponse",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -6782,8 +7307,8 @@ func (ec *executionContext) fieldContext_FileInfoDto_fileItemUuid(_ context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _FileInfoDto_correlationId(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_FileInfoDto_correlationId(ctx, field)
+func (ec *executionContext) _EmbedMessageResponse_isParticipant(ctx context.Context, field graphql.CollectedField, obj *model.EmbedMessageResponse) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EmbedMessageResponse_isParticipant(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6796,35 +7321,38 @@ func (ec *executionContext) _FileInfoDto_correlationId(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CorrelationID, nil
+		return obj.IsParticipant, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_FileInfoDto_correlationId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EmbedMessageResponse_isParticipant(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "FileInfoDto",
+		Object:     "EmbedMessageResponse",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ForceLogoutEvent_reasonType(ctx context.Context, field graphql.CollectedField, obj *model.ForceLogoutEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ForceLogoutEvent_reasonType(ctx, field)
+func (ec *executionContext) _EphemeralMessageNotification_command(ctx context.Context, field graphql.CollectedField, obj *model.EphemeralMessageNotification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EphemeralMessageNotification_command(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6837,7 +7365,7 @@ func (ec *executionContext) _ForceLogoutEvent_reasonType(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ReasonType, nil
+		return obj.Command, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6854,9 +7382,9 @@ func (ec *executionContext) _ForceLogoutEvent_reasonType(ctx context.Context, fi
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ForceLogoutEvent_reasonType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EphemeralMessageNotification_command(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ForceLogoutEvent",
+		Object:     "EphemeralMessageNotification",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -6867,8 +7395,8 @@ func (ec *executionContext) fieldContext_ForceLogoutEvent_reasonType(_ context.C
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_eventType(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_eventType(ctx, field)
+func (ec *executionContext) _EphemeralMessageNotification_text(ctx context.Context, field graphql.CollectedField, obj *model.EphemeralMessageNotification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EphemeralMessageNotification_text(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6881,7 +7409,7 @@ func (ec *executionContext) _GlobalEvent_eventType(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EventType, nil
+		return obj.Text, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6898,9 +7426,9 @@ func (ec *executionContext) _GlobalEvent_eventType(ctx context.Context, field gr
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_eventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EphemeralMessageNotification_text(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "EphemeralMessageNotification",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -6911,8 +7439,8 @@ func (ec *executionContext) fieldContext_GlobalEvent_eventType(_ context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_chatEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_chatEvent(ctx, field)
+func (ec *executionContext) _EphemeralMessageNotification_threadId(ctx context.Context, field graphql.CollectedField, obj *model.EphemeralMessageNotification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EphemeralMessageNotification_threadId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6925,7 +7453,7 @@ func (ec *executionContext) _GlobalEvent_chatEvent(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ChatEvent, nil
+		return obj.ThreadID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6934,90 +7462,26 @@ func (ec *executionContext) _GlobalEvent_chatEvent(ctx context.Context, field gr
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.ChatDto)
+	res := resTmp.(*int64)
 	fc.Result = res
-	return ec.marshalOChatDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐChatDto(ctx, field.Selections, res)
+	return ec.marshalOInt642ᚖint64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_chatEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EphemeralMessageNotification_threadId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "EphemeralMessageNotification",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_ChatDto_id(ctx, field)
-			case "name":
-				return ec.fieldContext_ChatDto_name(ctx, field)
-			case "avatar":
-				return ec.fieldContext_ChatDto_avatar(ctx, field)
-			case "avatarBig":
-				return ec.fieldContext_ChatDto_avatarBig(ctx, field)
-			case "shortInfo":
-				return ec.fieldContext_ChatDto_shortInfo(ctx, field)
-			case "lastUpdateDateTime":
-				return ec.fieldContext_ChatDto_lastUpdateDateTime(ctx, field)
-			case "participantIds":
-				return ec.fieldContext_ChatDto_participantIds(ctx, field)
-			case "canEdit":
-				return ec.fieldContext_ChatDto_canEdit(ctx, field)
-			case "canDelete":
-				return ec.fieldContext_ChatDto_canDelete(ctx, field)
-			case "canLeave":
-				return ec.fieldContext_ChatDto_canLeave(ctx, field)
-			case "unreadMessages":
-				return ec.fieldContext_ChatDto_unreadMessages(ctx, field)
-			case "canBroadcast":
-				return ec.fieldContext_ChatDto_canBroadcast(ctx, field)
-			case "canVideoKick":
-				return ec.fieldContext_ChatDto_canVideoKick(ctx, field)
-			case "canChangeChatAdmins":
-				return ec.fieldContext_ChatDto_canChangeChatAdmins(ctx, field)
-			case "tetATet":
-				return ec.fieldContext_ChatDto_tetATet(ctx, field)
-			case "canAudioMute":
-				return ec.fieldContext_ChatDto_canAudioMute(ctx, field)
-			case "participants":
-				return ec.fieldContext_ChatDto_participants(ctx, field)
-			case "participantsCount":
-				return ec.fieldContext_ChatDto_participantsCount(ctx, field)
-			case "canResend":
-				return ec.fieldContext_ChatDto_canResend(ctx, field)
-			case "availableToSearch":
-				return ec.fieldContext_ChatDto_availableToSearch(ctx, field)
-			case "isResultFromSearch":
-				return ec.fieldContext_ChatDto_isResultFromSearch(ctx, field)
-			case "pinned":
-				return ec.fieldContext_ChatDto_pinned(ctx, field)
-			case "blog":
-				return ec.fieldContext_ChatDto_blog(ctx, field)
-			case "loginColor":
-				return ec.fieldContext_ChatDto_loginColor(ctx, field)
-			case "regularParticipantCanPublishMessage":
-				return ec.fieldContext_ChatDto_regularParticipantCanPublishMessage(ctx, field)
-			case "lastSeenDateTime":
-				return ec.fieldContext_ChatDto_lastSeenDateTime(ctx, field)
-			case "regularParticipantCanPinMessage":
-				return ec.fieldContext_ChatDto_regularParticipantCanPinMessage(ctx, field)
-			case "blogAbout":
-				return ec.fieldContext_ChatDto_blogAbout(ctx, field)
-			case "regularParticipantCanWriteMessage":
-				return ec.fieldContext_ChatDto_regularParticipantCanWriteMessage(ctx, field)
-			case "canWriteMessage":
-				return ec.fieldContext_ChatDto_canWriteMessage(ctx, field)
-			case "lastMessagePreview":
-				return ec.fieldContext_ChatDto_lastMessagePreview(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ChatDto", field.Name)
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_chatDeletedEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_chatDeletedEvent(ctx, field)
+func (ec *executionContext) _FileInfoDto_id(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7030,39 +7494,38 @@ func (ec *executionContext) _GlobalEvent_chatDeletedEvent(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ChatDeletedEvent, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.ChatDeletedDto)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOChatDeletedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐChatDeletedDto(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_chatDeletedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_ChatDeletedDto_id(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ChatDeletedDto", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_coChattedParticipantEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_coChattedParticipantEvent(ctx, field)
+func (ec *executionContext) _FileInfoDto_filename(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_filename(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7075,47 +7538,38 @@ func (ec *executionContext) _GlobalEvent_coChattedParticipantEvent(ctx context.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CoChattedParticipantEvent, nil
+		return obj.Filename, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Participant)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOParticipant2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipant(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_coChattedParticipantEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_filename(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Participant_id(ctx, field)
-			case "login":
-				return ec.fieldContext_Participant_login(ctx, field)
-			case "avatar":
-				return ec.fieldContext_Participant_avatar(ctx, field)
-			case "shortInfo":
-				return ec.fieldContext_Participant_shortInfo(ctx, field)
-			case "loginColor":
-				return ec.fieldContext_Participant_loginColor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_videoUserCountChangedEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_videoUserCountChangedEvent(ctx, field)
+func (ec *executionContext) _FileInfoDto_url(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_url(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7128,41 +7582,38 @@ func (ec *executionContext) _GlobalEvent_videoUserCountChangedEvent(ctx context.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.VideoUserCountChangedEvent, nil
+		return obj.URL, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.VideoUserCountChangedDto)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOVideoUserCountChangedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐVideoUserCountChangedDto(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_videoUserCountChangedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_url(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "usersCount":
-				return ec.fieldContext_VideoUserCountChangedDto_usersCount(ctx, field)
-			case "chatId":
-				return ec.fieldContext_VideoUserCountChangedDto_chatId(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type VideoUserCountChangedDto", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_videoRecordingChangedEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_videoRecordingChangedEvent(ctx, field)
+func (ec *executionContext) _FileInfoDto_publishedUrl(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_publishedUrl(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7175,7 +7626,7 @@ func (ec *executionContext) _GlobalEvent_videoRecordingChangedEvent(ctx context.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.VideoRecordingChangedEvent, nil
+		return obj.PublishedURL, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7184,32 +7635,26 @@ func (ec *executionContext) _GlobalEvent_videoRecordingChangedEvent(ctx context.
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.VideoRecordingChangedDto)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOVideoRecordingChangedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐVideoRecordingChangedDto(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_videoRecordingChangedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_publishedUrl(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "recordInProgress":
-				return ec.fieldContext_VideoRecordingChangedDto_recordInProgress(ctx, field)
-			case "chatId":
-				return ec.fieldContext_VideoRecordingChangedDto_chatId(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type VideoRecordingChangedDto", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_videoCallInvitation(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_videoCallInvitation(ctx, field)
+func (ec *executionContext) _FileInfoDto_previewUrl(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_previewUrl(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7222,7 +7667,7 @@ func (ec *executionContext) _GlobalEvent_videoCallInvitation(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.VideoCallInvitation, nil
+		return obj.PreviewURL, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7231,36 +7676,26 @@ func (ec *executionContext) _GlobalEvent_videoCallInvitation(ctx context.Context
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.VideoCallInvitationDto)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOVideoCallInvitationDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐVideoCallInvitationDto(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_videoCallInvitation(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_previewUrl(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "chatId":
-				return ec.fieldContext_VideoCallInvitationDto_chatId(ctx, field)
-			case "chatName":
-				return ec.fieldContext_VideoCallInvitationDto_chatName(ctx, field)
-			case "status":
-				return ec.fieldContext_VideoCallInvitationDto_status(ctx, field)
-			case "avatar":
-				return ec.fieldContext_VideoCallInvitationDto_avatar(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type VideoCallInvitationDto", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_videoParticipantDialEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_videoParticipantDialEvent(ctx, field)
+func (ec *executionContext) _FileInfoDto_size(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_size(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7273,41 +7708,38 @@ func (ec *executionContext) _GlobalEvent_videoParticipantDialEvent(ctx context.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.VideoParticipantDialEvent, nil
+		return obj.Size, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.VideoDialChanges)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalOVideoDialChanges2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐVideoDialChanges(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_videoParticipantDialEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_size(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "chatId":
-				return ec.fieldContext_VideoDialChanges_chatId(ctx, field)
-			case "dials":
-				return ec.fieldContext_VideoDialChanges_dials(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type VideoDialChanges", field.Name)
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_unreadMessagesNotification(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_unreadMessagesNotification(ctx, field)
+func (ec *executionContext) _FileInfoDto_canDelete(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_canDelete(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7320,43 +7752,38 @@ func (ec *executionContext) _GlobalEvent_unreadMessagesNotification(ctx context.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.UnreadMessagesNotification, nil
+		return obj.CanDelete, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.ChatUnreadMessageChanged)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOChatUnreadMessageChanged2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐChatUnreadMessageChanged(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_unreadMessagesNotification(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_canDelete(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "chatId":
-				return ec.fieldContext_ChatUnreadMessageChanged_chatId(ctx, field)
-			case "unreadMessages":
-				return ec.fieldContext_ChatUnreadMessageChanged_unreadMessages(ctx, field)
-			case "lastUpdateDateTime":
-				return ec.fieldContext_ChatUnreadMessageChanged_lastUpdateDateTime(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ChatUnreadMessageChanged", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_allUnreadMessagesNotification(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_allUnreadMessagesNotification(ctx, field)
+func (ec *executionContext) _FileInfoDto_canEdit(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_canEdit(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7369,39 +7796,38 @@ func (ec *executionContext) _GlobalEvent_allUnreadMessagesNotification(ctx conte
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.AllUnreadMessagesNotification, nil
+		return obj.CanEdit, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.AllUnreadMessages)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOAllUnreadMessages2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐAllUnreadMessages(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_allUnreadMessagesNotification(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_canEdit(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "allUnreadMessages":
-				return ec.fieldContext_AllUnreadMessages_allUnreadMessages(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type AllUnreadMessages", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_notificationEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_notificationEvent(ctx, field)
+func (ec *executionContext) _FileInfoDto_canShare(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_canShare(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7414,41 +7840,38 @@ func (ec *executionContext) _GlobalEvent_notificationEvent(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.NotificationEvent, nil
+		return obj.CanShare, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.WrapperNotificationDto)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOWrapperNotificationDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐWrapperNotificationDto(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_notificationEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_canShare(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "count":
-				return ec.fieldContext_WrapperNotificationDto_count(ctx, field)
-			case "notificationDto":
-				return ec.fieldContext_WrapperNotificationDto_notificationDto(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type WrapperNotificationDto", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_videoCallScreenShareChangedDto(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_videoCallScreenShareChangedDto(ctx, field)
+func (ec *executionContext) _FileInfoDto_lastModified(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_lastModified(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7461,41 +7884,38 @@ func (ec *executionContext) _GlobalEvent_videoCallScreenShareChangedDto(ctx cont
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.VideoCallScreenShareChangedDto, nil
+		return obj.LastModified, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.VideoCallScreenShareChangedDto)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalOVideoCallScreenShareChangedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐVideoCallScreenShareChangedDto(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_videoCallScreenShareChangedDto(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_lastModified(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "chatId":
-				return ec.fieldContext_VideoCallScreenShareChangedDto_chatId(ctx, field)
-			case "hasScreenShares":
-				return ec.fieldContext_VideoCallScreenShareChangedDto_hasScreenShares(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type VideoCallScreenShareChangedDto", field.Name)
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_forceLogout(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_forceLogout(ctx, field)
+func (ec *executionContext) _FileInfoDto_ownerId(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_ownerId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7508,39 +7928,38 @@ func (ec *executionContext) _GlobalEvent_forceLogout(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ForceLogout, nil
+		return obj.OwnerID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.ForceLogoutEvent)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalOForceLogoutEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐForceLogoutEvent(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_forceLogout(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_ownerId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "reasonType":
-				return ec.fieldContext_ForceLogoutEvent_reasonType(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ForceLogoutEvent", field.Name)
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_hasUnreadMessagesChanged(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_hasUnreadMessagesChanged(ctx, field)
+func (ec *executionContext) _FileInfoDto_owner(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_owner(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7553,7 +7972,7 @@ func (ec *executionContext) _GlobalEvent_hasUnreadMessagesChanged(ctx context.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.HasUnreadMessagesChanged, nil
+		return obj.Owner, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7562,30 +7981,38 @@ func (ec *executionContext) _GlobalEvent_hasUnreadMessagesChanged(ctx context.Co
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.HasUnreadMessagesChangedEvent)
+	res := resTmp.(*model.Participant)
 	fc.Result = res
-	return ec.marshalOHasUnreadMessagesChangedEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐHasUnreadMessagesChangedEvent(ctx, field.Selections, res)
+	return ec.marshalOParticipant2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipant(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_hasUnreadMessagesChanged(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_owner(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "hasUnreadMessages":
-				return ec.fieldContext_HasUnreadMessagesChangedEvent_hasUnreadMessages(ctx, field)
+			case "id":
+				return ec.fieldContext_Participant_id(ctx, field)
+			case "login":
+				return ec.fieldContext_Participant_login(ctx, field)
+			case "avatar":
+				return ec.fieldContext_Participant_avatar(ctx, field)
+			case "shortInfo":
+				return ec.fieldContext_Participant_shortInfo(ctx, field)
+			case "loginColor":
+				return ec.fieldContext_Participant_loginColor(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type HasUnreadMessagesChangedEvent", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_browserNotification(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_browserNotification(ctx, field)
+func (ec *executionContext) _FileInfoDto_canPlayAsVideo(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_canPlayAsVideo(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7598,51 +8025,38 @@ func (ec *executionContext) _GlobalEvent_browserNotification(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.BrowserNotification, nil
+		return obj.CanPlayAsVideo, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.BrowserNotification)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOBrowserNotification2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐBrowserNotification(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_browserNotification(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_canPlayAsVideo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "chatId":
-				return ec.fieldContext_BrowserNotification_chatId(ctx, field)
-			case "chatName":
-				return ec.fieldContext_BrowserNotification_chatName(ctx, field)
-			case "chatAvatar":
-				return ec.fieldContext_BrowserNotification_chatAvatar(ctx, field)
-			case "messageId":
-				return ec.fieldContext_BrowserNotification_messageId(ctx, field)
-			case "messageText":
-				return ec.fieldContext_BrowserNotification_messageText(ctx, field)
-			case "ownerId":
-				return ec.fieldContext_BrowserNotification_ownerId(ctx, field)
-			case "ownerLogin":
-				return ec.fieldContext_BrowserNotification_ownerLogin(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type BrowserNotification", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GlobalEvent_userTypingEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GlobalEvent_userTypingEvent(ctx, field)
+func (ec *executionContext) _FileInfoDto_canShowAsImage(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_canShowAsImage(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7655,43 +8069,38 @@ func (ec *executionContext) _GlobalEvent_userTypingEvent(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.UserTypingEvent, nil
+		return obj.CanShowAsImage, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.UserTypingDto)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOUserTypingDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐUserTypingDto(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GlobalEvent_userTypingEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_canShowAsImage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GlobalEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "login":
-				return ec.fieldContext_UserTypingDto_login(ctx, field)
-			case "participantId":
-				return ec.fieldContext_UserTypingDto_participantId(ctx, field)
-			case "chatId":
-				return ec.fieldContext_UserTypingDto_chatId(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type UserTypingDto", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _HasUnreadMessagesChangedEvent_hasUnreadMessages(ctx context.Context, field graphql.CollectedField, obj *model.HasUnreadMessagesChangedEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_HasUnreadMessagesChangedEvent_hasUnreadMessages(ctx, field)
+func (ec *executionContext) _FileInfoDto_canPlayAsAudio(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_canPlayAsAudio(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7704,7 +8113,7 @@ func (ec *executionContext) _HasUnreadMessagesChangedEvent_hasUnreadMessages(ctx
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.HasUnreadMessages, nil
+		return obj.CanPlayAsAudio, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7721,9 +8130,9 @@ func (ec *executionContext) _HasUnreadMessagesChangedEvent_hasUnreadMessages(ctx
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_HasUnreadMessagesChangedEvent_hasUnreadMessages(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_canPlayAsAudio(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "HasUnreadMessagesChangedEvent",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -7734,8 +8143,8 @@ func (ec *executionContext) fieldContext_HasUnreadMessagesChangedEvent_hasUnread
 	return fc, nil
 }
 
-func (ec *executionContext) _MessageBroadcastNotification_login(ctx context.Context, field graphql.CollectedField, obj *model.MessageBroadcastNotification) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MessageBroadcastNotification_login(ctx, field)
+func (ec *executionContext) _FileInfoDto_fileItemUuid(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_fileItemUuid(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7748,7 +8157,7 @@ func (ec *executionContext) _MessageBroadcastNotification_login(ctx context.Cont
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Login, nil
+		return obj.FileItemUUID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7765,9 +8174,9 @@ func (ec *executionContext) _MessageBroadcastNotification_login(ctx context.Cont
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MessageBroadcastNotification_login(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_fileItemUuid(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MessageBroadcastNotification",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -7778,8 +8187,8 @@ func (ec *executionContext) fieldContext_MessageBroadcastNotification_login(_ co
 	return fc, nil
 }
 
-func (ec *executionContext) _MessageBroadcastNotification_userId(ctx context.Context, field graphql.CollectedField, obj *model.MessageBroadcastNotification) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MessageBroadcastNotification_userId(ctx, field)
+func (ec *executionContext) _FileInfoDto_correlationId(ctx context.Context, field graphql.CollectedField, obj *model.FileInfoDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FileInfoDto_correlationId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7792,38 +8201,35 @@ func (ec *executionContext) _MessageBroadcastNotification_userId(ctx context.Con
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.UserID, nil
+		return obj.CorrelationID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MessageBroadcastNotification_userId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FileInfoDto_correlationId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MessageBroadcastNotification",
+		Object:     "FileInfoDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MessageBroadcastNotification_text(ctx context.Context, field graphql.CollectedField, obj *model.MessageBroadcastNotification) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MessageBroadcastNotification_text(ctx, field)
+func (ec *executionContext) _ForceLogoutEvent_reasonType(ctx context.Context, field graphql.CollectedField, obj *model.ForceLogoutEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ForceLogoutEvent_reasonType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7836,7 +8242,7 @@ func (ec *executionContext) _MessageBroadcastNotification_text(ctx context.Conte
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Text, nil
+		return obj.ReasonType, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7853,9 +8259,9 @@ func (ec *executionContext) _MessageBroadcastNotification_text(ctx context.Conte
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MessageBroadcastNotification_text(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ForceLogoutEvent_reasonType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MessageBroadcastNotification",
+		Object:     "ForceLogoutEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -7866,8 +8272,8 @@ func (ec *executionContext) fieldContext_MessageBroadcastNotification_text(_ con
 	return fc, nil
 }
 
-func (ec *executionContext) _MessageDeletedDto_id(ctx context.Context, field graphql.CollectedField, obj *model.MessageDeletedDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MessageDeletedDto_id(ctx, field)
+func (ec *executionContext) _GlobalEvent_eventType(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_eventType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7880,7 +8286,7 @@ func (ec *executionContext) _MessageDeletedDto_id(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.EventType, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7892,26 +8298,26 @@ func (ec *executionContext) _MessageDeletedDto_id(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MessageDeletedDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GlobalEvent_eventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MessageDeletedDto",
+		Object:     "GlobalEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MessageDeletedDto_chatId(ctx context.Context, field graphql.CollectedField, obj *model.MessageDeletedDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MessageDeletedDto_chatId(ctx, field)
+func (ec *executionContext) _GlobalEvent_chatEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_chatEvent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7924,38 +8330,2305 @@ func (ec *executionContext) _MessageDeletedDto_chatId(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ChatID, nil
+		return obj.ChatEvent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(*model.ChatDto)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalOChatDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐChatDto(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MessageDeletedDto_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GlobalEvent_chatEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_ChatDto_id(ctx, field)
+			case "name":
+				return ec.fieldContext_ChatDto_name(ctx, field)
+			case "avatar":
+				return ec.fieldContext_ChatDto_avatar(ctx, field)
+			case "avatarBig":
+				return ec.fieldContext_ChatDto_avatarBig(ctx, field)
+			case "shortInfo":
+				return ec.fieldContext_ChatDto_shortInfo(ctx, field)
+			case "lastUpdateDateTime":
+				return ec.fieldContext_ChatDto_lastUpdateDateTime(ctx, field)
+			case "participantIds":
+				return ec.fieldContext_ChatDto_participantIds(ctx, field)
+			case "canEdit":
+				return ec.fieldContext_ChatDto_canEdit(ctx, field)
+			case "canDelete":
+				return ec.fieldContext_ChatDto_canDelete(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_ChatDto_canLeave(ctx, field)
+			case "unreadMessages":
+				return ec.fieldContext_ChatDto_unreadMessages(ctx, field)
+			case "canBroadcast":
+				return ec.fieldContext_ChatDto_canBroadcast(ctx, field)
+			case "canVideoKick":
+				return ec.fieldContext_ChatDto_canVideoKick(ctx, field)
+			case "canChangeChatAdmins":
+				return ec.fieldContext_ChatDto_canChangeChatAdmins(ctx, field)
+			case "tetATet":
+				return ec.fieldContext_ChatDto_tetATet(ctx, field)
+			case "canAudioMute":
+				return ec.fieldContext_ChatDto_canAudioMute(ctx, field)
+			case "participants":
+				return ec.fieldContext_ChatDto_participants(ctx, field)
+			case "participantsCount":
+				return ec.fieldContext_ChatDto_participantsCount(ctx, field)
+			case "canResend":
+				return ec.fieldContext_ChatDto_canResend(ctx, field)
+			case "availableToSearch":
+				return ec.fieldContext_ChatDto_availableToSearch(ctx, field)
+			case "isResultFromSearch":
+				return ec.fieldContext_ChatDto_isResultFromSearch(ctx, field)
+			case "pinned":
+				return ec.fieldContext_ChatDto_pinned(ctx, field)
+			case "blog":
+				return ec.fieldContext_ChatDto_blog(ctx, field)
+			case "loginColor":
+				return ec.fieldContext_ChatDto_loginColor(ctx, field)
+			case "regularParticipantCanPublishMessage":
+				return ec.fieldContext_ChatDto_regularParticipantCanPublishMessage(ctx, field)
+			case "lastSeenDateTime":
+				return ec.fieldContext_ChatDto_lastSeenDateTime(ctx, field)
+			case "regularParticipantCanPinMessage":
+				return ec.fieldContext_ChatDto_regularParticipantCanPinMessage(ctx, field)
+			case "blogAbout":
+				return ec.fieldContext_ChatDto_blogAbout(ctx, field)
+			case "regularParticipantCanWriteMessage":
+				return ec.fieldContext_ChatDto_regularParticipantCanWriteMessage(ctx, field)
+			case "canWriteMessage":
+				return ec.fieldContext_ChatDto_canWriteMessage(ctx, field)
+			case "regularParticipantCanSeeEditHistory":
+				return ec.fieldContext_ChatDto_regularParticipantCanSeeEditHistory(ctx, field)
+			case "canSeeEditHistory":
+				return ec.fieldContext_ChatDto_canSeeEditHistory(ctx, field)
+			case "messageRetentionSeconds":
+				return ec.fieldContext_ChatDto_messageRetentionSeconds(ctx, field)
+			case "joinByRequest":
+				return ec.fieldContext_ChatDto_joinByRequest(ctx, field)
+			case "slowModeSeconds":
+				return ec.fieldContext_ChatDto_slowModeSeconds(ctx, field)
+			case "role":
+				return ec.fieldContext_ChatDto_role(ctx, field)
+			case "permissions":
+				return ec.fieldContext_ChatDto_permissions(ctx, field)
+			case "lastMessagePreview":
+				return ec.fieldContext_ChatDto_lastMessagePreview(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ChatDto", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_chatDeletedEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_chatDeletedEvent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ChatDeletedEvent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.ChatDeletedDto)
+	fc.Result = res
+	return ec.marshalOChatDeletedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐChatDeletedDto(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_chatDeletedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_ChatDeletedDto_id(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ChatDeletedDto", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_coChattedParticipantEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_coChattedParticipantEvent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CoChattedParticipantEvent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Participant)
+	fc.Result = res
+	return ec.marshalOParticipant2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipant(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_coChattedParticipantEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Participant_id(ctx, field)
+			case "login":
+				return ec.fieldContext_Participant_login(ctx, field)
+			case "avatar":
+				return ec.fieldContext_Participant_avatar(ctx, field)
+			case "shortInfo":
+				return ec.fieldContext_Participant_shortInfo(ctx, field)
+			case "loginColor":
+				return ec.fieldContext_Participant_loginColor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_videoUserCountChangedEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_videoUserCountChangedEvent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.VideoUserCountChangedEvent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.VideoUserCountChangedDto)
+	fc.Result = res
+	return ec.marshalOVideoUserCountChangedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐVideoUserCountChangedDto(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_videoUserCountChangedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "usersCount":
+				return ec.fieldContext_VideoUserCountChangedDto_usersCount(ctx, field)
+			case "chatId":
+				return ec.fieldContext_VideoUserCountChangedDto_chatId(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type VideoUserCountChangedDto", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_videoRecordingChangedEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_videoRecordingChangedEvent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.VideoRecordingChangedEvent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.VideoRecordingChangedDto)
+	fc.Result = res
+	return ec.marshalOVideoRecordingChangedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐVideoRecordingChangedDto(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_videoRecordingChangedEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "recordInProgress":
+				return ec.fieldContext_VideoRecordingChangedDto_recordInProgress(ctx, field)
+			case "chatId":
+				return ec.fieldContext_VideoRecordingChangedDto_chatId(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type VideoRecordingChangedDto", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_videoCallInvitation(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_videoCallInvitation(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.VideoCallInvitation, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.VideoCallInvitationDto)
+	fc.Result = res
+	return ec.marshalOVideoCallInvitationDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐVideoCallInvitationDto(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_videoCallInvitation(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "chatId":
+				return ec.fieldContext_VideoCallInvitationDto_chatId(ctx, field)
+			case "chatName":
+				return ec.fieldContext_VideoCallInvitationDto_chatName(ctx, field)
+			case "status":
+				return ec.fieldContext_VideoCallInvitationDto_status(ctx, field)
+			case "avatar":
+				return ec.fieldContext_VideoCallInvitationDto_avatar(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type VideoCallInvitationDto", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_videoParticipantDialEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_videoParticipantDialEvent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.VideoParticipantDialEvent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.VideoDialChanges)
+	fc.Result = res
+	return ec.marshalOVideoDialChanges2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐVideoDialChanges(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_videoParticipantDialEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "chatId":
+				return ec.fieldContext_VideoDialChanges_chatId(ctx, field)
+			case "dials":
+				return ec.fieldContext_VideoDialChanges_dials(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type VideoDialChanges", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_unreadMessagesNotification(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_unreadMessagesNotification(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.UnreadMessagesNotification, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.ChatUnreadMessageChanged)
+	fc.Result = res
+	return ec.marshalOChatUnreadMessageChanged2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐChatUnreadMessageChanged(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_unreadMessagesNotification(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "chatId":
+				return ec.fieldContext_ChatUnreadMessageChanged_chatId(ctx, field)
+			case "unreadMessages":
+				return ec.fieldContext_ChatUnreadMessageChanged_unreadMessages(ctx, field)
+			case "lastUpdateDateTime":
+				return ec.fieldContext_ChatUnreadMessageChanged_lastUpdateDateTime(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ChatUnreadMessageChanged", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_allUnreadMessagesNotification(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_allUnreadMessagesNotification(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.AllUnreadMessagesNotification, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.AllUnreadMessages)
+	fc.Result = res
+	return ec.marshalOAllUnreadMessages2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐAllUnreadMessages(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_allUnreadMessagesNotification(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "allUnreadMessages":
+				return ec.fieldContext_AllUnreadMessages_allUnreadMessages(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AllUnreadMessages", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_notificationEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_notificationEvent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.NotificationEvent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.WrapperNotificationDto)
+	fc.Result = res
+	return ec.marshalOWrapperNotificationDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐWrapperNotificationDto(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_notificationEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "count":
+				return ec.fieldContext_WrapperNotificationDto_count(ctx, field)
+			case "notificationDto":
+				return ec.fieldContext_WrapperNotificationDto_notificationDto(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type WrapperNotificationDto", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_videoCallScreenShareChangedDto(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_videoCallScreenShareChangedDto(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.VideoCallScreenShareChangedDto, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.VideoCallScreenShareChangedDto)
+	fc.Result = res
+	return ec.marshalOVideoCallScreenShareChangedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐVideoCallScreenShareChangedDto(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_videoCallScreenShareChangedDto(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "chatId":
+				return ec.fieldContext_VideoCallScreenShareChangedDto_chatId(ctx, field)
+			case "hasScreenShares":
+				return ec.fieldContext_VideoCallScreenShareChangedDto_hasScreenShares(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type VideoCallScreenShareChangedDto", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_forceLogout(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_forceLogout(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ForceLogout, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.ForceLogoutEvent)
+	fc.Result = res
+	return ec.marshalOForceLogoutEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐForceLogoutEvent(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_forceLogout(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "reasonType":
+				return ec.fieldContext_ForceLogoutEvent_reasonType(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ForceLogoutEvent", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_hasUnreadMessagesChanged(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_hasUnreadMessagesChanged(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.HasUnreadMessagesChanged, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.HasUnreadMessagesChangedEvent)
+	fc.Result = res
+	return ec.marshalOHasUnreadMessagesChangedEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐHasUnreadMessagesChangedEvent(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_hasUnreadMessagesChanged(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "hasUnreadMessages":
+				return ec.fieldContext_HasUnreadMessagesChangedEvent_hasUnreadMessages(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type HasUnreadMessagesChangedEvent", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_browserNotification(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_browserNotification(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.BrowserNotification, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.BrowserNotification)
+	fc.Result = res
+	return ec.marshalOBrowserNotification2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐBrowserNotification(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_browserNotification(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "chatId":
+				return ec.fieldContext_BrowserNotification_chatId(ctx, field)
+			case "chatName":
+				return ec.fieldContext_BrowserNotification_chatName(ctx, field)
+			case "chatAvatar":
+				return ec.fieldContext_BrowserNotification_chatAvatar(ctx, field)
+			case "messageId":
+				return ec.fieldContext_BrowserNotification_messageId(ctx, field)
+			case "messageText":
+				return ec.fieldContext_BrowserNotification_messageText(ctx, field)
+			case "ownerId":
+				return ec.fieldContext_BrowserNotification_ownerId(ctx, field)
+			case "ownerLogin":
+				return ec.fieldContext_BrowserNotification_ownerLogin(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type BrowserNotification", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_userTypingEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_userTypingEvent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.UserTypingEvent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.UserTypingDto)
+	fc.Result = res
+	return ec.marshalOUserTypingDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐUserTypingDto(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_userTypingEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "login":
+				return ec.fieldContext_UserTypingDto_login(ctx, field)
+			case "participantId":
+				return ec.fieldContext_UserTypingDto_participantId(ctx, field)
+			case "chatId":
+				return ec.fieldContext_UserTypingDto_chatId(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type UserTypingDto", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _GlobalEvent_messageDraftEvent(ctx context.Context, field graphql.CollectedField, obj *model.GlobalEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GlobalEvent_messageDraftEvent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MessageDraftEvent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.MessageDraftDto)
+	fc.Result = res
+	return ec.marshalOMessageDraftDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐMessageDraftDto(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_GlobalEvent_messageDraftEvent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "GlobalEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "chatId":
+				return ec.fieldContext_MessageDraftDto_chatId(ctx, field)
+			case "text":
+				return ec.fieldContext_MessageDraftDto_text(ctx, field)
+			case "threadId":
+				return ec.fieldContext_MessageDraftDto_threadId(ctx, field)
+			case "embedMessage":
+				return ec.fieldContext_MessageDraftDto_embedMessage(ctx, field)
+			case "editDateTime":
+				return ec.fieldContext_MessageDraftDto_editDateTime(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MessageDraftDto", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _HasUnreadMessagesChangedEvent_hasUnreadMessages(ctx context.Context, field graphql.CollectedField, obj *model.HasUnreadMessagesChangedEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_HasUnreadMessagesChangedEvent_hasUnreadMessages(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.HasUnreadMessages, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_HasUnreadMessagesChangedEvent_hasUnreadMessages(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "HasUnreadMessagesChangedEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _LinkPreviewDto_url(ctx context.Context, field graphql.CollectedField, obj *model.LinkPreviewDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_LinkPreviewDto_url(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.URL, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_LinkPreviewDto_url(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "LinkPreviewDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _LinkPreviewDto_title(ctx context.Context, field graphql.CollectedField, obj *model.LinkPreviewDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_LinkPreviewDto_title(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Title, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_LinkPreviewDto_title(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "LinkPreviewDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _LinkPreviewDto_description(ctx context.Context, field graphql.CollectedField, obj *model.LinkPreviewDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_LinkPreviewDto_description(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_LinkPreviewDto_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "LinkPreviewDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _LinkPreviewDto_imageUrl(ctx context.Context, field graphql.CollectedField, obj *model.LinkPreviewDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_LinkPreviewDto_imageUrl(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ImageURL, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_LinkPreviewDto_imageUrl(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "LinkPreviewDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _LinkPreviewDto_siteName(ctx context.Context, field graphql.CollectedField, obj *model.LinkPreviewDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_LinkPreviewDto_siteName(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SiteName, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_LinkPreviewDto_siteName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "LinkPreviewDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageBroadcastNotification_login(ctx context.Context, field graphql.CollectedField, obj *model.MessageBroadcastNotification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageBroadcastNotification_login(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Login, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageBroadcastNotification_login(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageBroadcastNotification",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageBroadcastNotification_userId(ctx context.Context, field graphql.CollectedField, obj *model.MessageBroadcastNotification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageBroadcastNotification_userId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.UserID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageBroadcastNotification_userId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageBroadcastNotification",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageBroadcastNotification_text(ctx context.Context, field graphql.CollectedField, obj *model.MessageBroadcastNotification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageBroadcastNotification_text(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Text, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageBroadcastNotification_text(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageBroadcastNotification",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageDeletedDto_id(ctx context.Context, field graphql.CollectedField, obj *model.MessageDeletedDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageDeletedDto_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageDeletedDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageDeletedDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageDeletedDto_chatId(ctx context.Context, field graphql.CollectedField, obj *model.MessageDeletedDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageDeletedDto_chatId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ChatID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageDeletedDto_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageDeletedDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageDeletedDto_threadId(ctx context.Context, field graphql.CollectedField, obj *model.MessageDeletedDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageDeletedDto_threadId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ThreadID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int64)
+	fc.Result = res
+	return ec.marshalOInt642ᚖint64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageDeletedDto_threadId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageDeletedDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageDraftDto_chatId(ctx context.Context, field graphql.CollectedField, obj *model.MessageDraftDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageDraftDto_chatId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ChatID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageDraftDto_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageDraftDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageDraftDto_text(ctx context.Context, field graphql.CollectedField, obj *model.MessageDraftDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageDraftDto_text(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Text, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageDraftDto_text(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageDraftDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageDraftDto_threadId(ctx context.Context, field graphql.CollectedField, obj *model.MessageDraftDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageDraftDto_threadId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ThreadID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int64)
+	fc.Result = res
+	return ec.marshalOInt642ᚖint64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageDraftDto_threadId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageDraftDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageDraftDto_embedMessage(ctx context.Context, field graphql.CollectedField, obj *model.MessageDraftDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageDraftDto_embedMessage(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EmbedMessage, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.MessageDraftEmbedDto)
+	fc.Result = res
+	return ec.marshalOMessageDraftEmbedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐMessageDraftEmbedDto(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageDraftDto_embedMessage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageDraftDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_MessageDraftEmbedDto_id(ctx, field)
+			case "chatId":
+				return ec.fieldContext_MessageDraftEmbedDto_chatId(ctx, field)
+			case "embedType":
+				return ec.fieldContext_MessageDraftEmbedDto_embedType(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MessageDraftEmbedDto", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageDraftDto_editDateTime(ctx context.Context, field graphql.CollectedField, obj *model.MessageDraftDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageDraftDto_editDateTime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EditDateTime, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageDraftDto_editDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageDraftDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageDraftEmbedDto_id(ctx context.Context, field graphql.CollectedField, obj *model.MessageDraftEmbedDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageDraftEmbedDto_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageDraftEmbedDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageDraftEmbedDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageDraftEmbedDto_chatId(ctx context.Context, field graphql.CollectedField, obj *model.MessageDraftEmbedDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageDraftEmbedDto_chatId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ChatID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageDraftEmbedDto_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageDraftEmbedDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MessageDraftEmbedDto_embedType(ctx context.Context, field graphql.CollectedField, obj *model.MessageDraftEmbedDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MessageDraftEmbedDto_embedType(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EmbedType, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MessageDraftEmbedDto_embedType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MessageDraftEmbedDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _NotificationDto_id(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_NotificationDto_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_NotificationDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "NotificationDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _NotificationDto_chatId(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_NotificationDto_chatId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ChatID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_NotificationDto_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "NotificationDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _NotificationDto_messageId(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_NotificationDto_messageId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MessageID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int64)
+	fc.Result = res
+	return ec.marshalOInt642ᚖint64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_NotificationDto_messageId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "NotificationDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _NotificationDto_notificationType(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_NotificationDto_notificationType(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.NotificationType, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_NotificationDto_notificationType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "NotificationDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _NotificationDto_description(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_NotificationDto_description(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_NotificationDto_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "NotificationDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _NotificationDto_createDateTime(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_NotificationDto_createDateTime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CreateDateTime, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_NotificationDto_createDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "NotificationDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _NotificationDto_byUserId(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_NotificationDto_byUserId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ByUserID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_NotificationDto_byUserId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "NotificationDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _NotificationDto_byLogin(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_NotificationDto_byLogin(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ByLogin, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_NotificationDto_byLogin(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "NotificationDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _NotificationDto_byAvatar(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_NotificationDto_byAvatar(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ByAvatar, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_NotificationDto_byAvatar(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "NotificationDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _NotificationDto_chatTitle(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_NotificationDto_chatTitle(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ChatTitle, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_NotificationDto_chatTitle(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "NotificationDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OAuth2Identifiers_facebookId(ctx context.Context, field graphql.CollectedField, obj *model.OAuth2Identifiers) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_OAuth2Identifiers_facebookId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.FacebookID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_OAuth2Identifiers_facebookId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OAuth2Identifiers",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OAuth2Identifiers_vkontakteId(ctx context.Context, field graphql.CollectedField, obj *model.OAuth2Identifiers) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_OAuth2Identifiers_vkontakteId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.VkontakteID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_OAuth2Identifiers_vkontakteId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OAuth2Identifiers",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OAuth2Identifiers_googleId(ctx context.Context, field graphql.CollectedField, obj *model.OAuth2Identifiers) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_OAuth2Identifiers_googleId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.GoogleID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_OAuth2Identifiers_googleId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MessageDeletedDto",
+		Object:     "OAuth2Identifiers",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _NotificationDto_id(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_NotificationDto_id(ctx, field)
+func (ec *executionContext) _OAuth2Identifiers_keycloakId(ctx context.Context, field graphql.CollectedField, obj *model.OAuth2Identifiers) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_OAuth2Identifiers_keycloakId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.KeycloakID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_OAuth2Identifiers_keycloakId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OAuth2Identifiers",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Participant_id(ctx context.Context, field graphql.CollectedField, obj *model.Participant) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Participant_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7985,9 +10658,9 @@ func (ec *executionContext) _NotificationDto_id(ctx context.Context, field graph
 	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_NotificationDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Participant_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "NotificationDto",
+		Object:     "Participant",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -7998,8 +10671,8 @@ func (ec *executionContext) fieldContext_NotificationDto_id(_ context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _NotificationDto_chatId(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_NotificationDto_chatId(ctx, field)
+func (ec *executionContext) _Participant_login(ctx context.Context, field graphql.CollectedField, obj *model.Participant) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Participant_login(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8012,7 +10685,7 @@ func (ec *executionContext) _NotificationDto_chatId(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ChatID, nil
+		return obj.Login, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8024,26 +10697,26 @@ func (ec *executionContext) _NotificationDto_chatId(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_NotificationDto_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Participant_login(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "NotificationDto",
+		Object:     "Participant",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _NotificationDto_messageId(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_NotificationDto_messageId(ctx, field)
+func (ec *executionContext) _Participant_avatar(ctx context.Context, field graphql.CollectedField, obj *model.Participant) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Participant_avatar(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8056,7 +10729,7 @@ func (ec *executionContext) _NotificationDto_messageId(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MessageID, nil
+		return obj.Avatar, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8065,26 +10738,26 @@ func (ec *executionContext) _NotificationDto_messageId(ctx context.Context, fiel
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int64)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt642ᚖint64(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_NotificationDto_messageId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Participant_avatar(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "NotificationDto",
+		Object:     "Participant",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _NotificationDto_notificationType(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_NotificationDto_notificationType(ctx, field)
+func (ec *executionContext) _Participant_shortInfo(ctx context.Context, field graphql.CollectedField, obj *model.Participant) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Participant_shortInfo(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8097,7 +10770,89 @@ func (ec *executionContext) _NotificationDto_notificationType(ctx context.Contex
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.NotificationType, nil
+		return obj.ShortInfo, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Participant_shortInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Participant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Participant_loginColor(ctx context.Context, field graphql.CollectedField, obj *model.Participant) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Participant_loginColor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.LoginColor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Participant_loginColor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Participant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ParticipantWithAdmin_id(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParticipantWithAdmin_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8109,26 +10864,26 @@ func (ec *executionContext) _NotificationDto_notificationType(ctx context.Contex
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_NotificationDto_notificationType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParticipantWithAdmin_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "NotificationDto",
+		Object:     "ParticipantWithAdmin",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _NotificationDto_description(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_NotificationDto_description(ctx, field)
+func (ec *executionContext) _ParticipantWithAdmin_login(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParticipantWithAdmin_login(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8141,7 +10896,7 @@ func (ec *executionContext) _NotificationDto_description(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Login, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8158,9 +10913,9 @@ func (ec *executionContext) _NotificationDto_description(ctx context.Context, fi
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_NotificationDto_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParticipantWithAdmin_login(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "NotificationDto",
+		Object:     "ParticipantWithAdmin",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -8171,8 +10926,8 @@ func (ec *executionContext) fieldContext_NotificationDto_description(_ context.C
 	return fc, nil
 }
 
-func (ec *executionContext) _NotificationDto_createDateTime(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_NotificationDto_createDateTime(ctx, field)
+func (ec *executionContext) _ParticipantWithAdmin_avatar(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParticipantWithAdmin_avatar(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8185,38 +10940,35 @@ func (ec *executionContext) _NotificationDto_createDateTime(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CreateDateTime, nil
+		return obj.Avatar, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_NotificationDto_createDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParticipantWithAdmin_avatar(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "NotificationDto",
+		Object:     "ParticipantWithAdmin",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _NotificationDto_byUserId(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_NotificationDto_byUserId(ctx, field)
+func (ec *executionContext) _ParticipantWithAdmin_admin(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParticipantWithAdmin_admin(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8229,7 +10981,7 @@ func (ec *executionContext) _NotificationDto_byUserId(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ByUserID, nil
+		return obj.Admin, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8241,26 +10993,26 @@ func (ec *executionContext) _NotificationDto_byUserId(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_NotificationDto_byUserId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParticipantWithAdmin_admin(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "NotificationDto",
+		Object:     "ParticipantWithAdmin",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _NotificationDto_byLogin(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_NotificationDto_byLogin(ctx, field)
+func (ec *executionContext) _ParticipantWithAdmin_role(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParticipantWithAdmin_role(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8273,7 +11025,7 @@ func (ec *executionContext) _NotificationDto_byLogin(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ByLogin, nil
+		return obj.Role, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8290,9 +11042,9 @@ func (ec *executionContext) _NotificationDto_byLogin(ctx context.Context, field
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_NotificationDto_byLogin(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParticipantWithAdmin_role(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "NotificationDto",
+		Object:     "ParticipantWithAdmin",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -8303,8 +11055,8 @@ func (ec *executionContext) fieldContext_NotificationDto_byLogin(_ context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _NotificationDto_byAvatar(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_NotificationDto_byAvatar(ctx, field)
+func (ec *executionContext) _ParticipantWithAdmin_shortInfo(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParticipantWithAdmin_shortInfo(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8317,7 +11069,7 @@ func (ec *executionContext) _NotificationDto_byAvatar(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ByAvatar, nil
+		return obj.ShortInfo, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8331,9 +11083,9 @@ func (ec *executionContext) _NotificationDto_byAvatar(ctx context.Context, field
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_NotificationDto_byAvatar(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParticipantWithAdmin_shortInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "NotificationDto",
+		Object:     "ParticipantWithAdmin",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -8344,8 +11096,8 @@ func (ec *executionContext) fieldContext_NotificationDto_byAvatar(_ context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _NotificationDto_chatTitle(ctx context.Context, field graphql.CollectedField, obj *model.NotificationDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_NotificationDto_chatTitle(ctx, field)
+func (ec *executionContext) _ParticipantWithAdmin_loginColor(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParticipantWithAdmin_loginColor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8358,26 +11110,23 @@ func (ec *executionContext) _NotificationDto_chatTitle(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ChatTitle, nil
+		return obj.LoginColor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_NotificationDto_chatTitle(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParticipantWithAdmin_loginColor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "NotificationDto",
+		Object:     "ParticipantWithAdmin",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -8388,8 +11137,8 @@ func (ec *executionContext) fieldContext_NotificationDto_chatTitle(_ context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _OAuth2Identifiers_facebookId(ctx context.Context, field graphql.CollectedField, obj *model.OAuth2Identifiers) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_OAuth2Identifiers_facebookId(ctx, field)
+func (ec *executionContext) _PinnedMessageDto_id(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PinnedMessageDto_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8402,35 +11151,38 @@ func (ec *executionContext) _OAuth2Identifiers_facebookId(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.FacebookID, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_OAuth2Identifiers_facebookId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PinnedMessageDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "OAuth2Identifiers",
+		Object:     "PinnedMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _OAuth2Identifiers_vkontakteId(ctx context.Context, field graphql.CollectedField, obj *model.OAuth2Identifiers) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_OAuth2Identifiers_vkontakteId(ctx, field)
+func (ec *executionContext) _PinnedMessageDto_text(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PinnedMessageDto_text(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8443,23 +11195,26 @@ func (ec *executionContext) _OAuth2Identifiers_vkontakteId(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.VkontakteID, nil
+		return obj.Text, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_OAuth2Identifiers_vkontakteId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PinnedMessageDto_text(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "OAuth2Identifiers",
+		Object:     "PinnedMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -8470,8 +11225,8 @@ func (ec *executionContext) fieldContext_OAuth2Identifiers_vkontakteId(_ context
 	return fc, nil
 }
 
-func (ec *executionContext) _OAuth2Identifiers_googleId(ctx context.Context, field graphql.CollectedField, obj *model.OAuth2Identifiers) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_OAuth2Identifiers_googleId(ctx, field)
+func (ec *executionContext) _PinnedMessageDto_chatId(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PinnedMessageDto_chatId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8484,35 +11239,38 @@ func (ec *executionContext) _OAuth2Identifiers_googleId(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.GoogleID, nil
+		return obj.ChatID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_OAuth2Identifiers_googleId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PinnedMessageDto_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "OAuth2Identifiers",
+		Object:     "PinnedMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _OAuth2Identifiers_keycloakId(ctx context.Context, field graphql.CollectedField, obj *model.OAuth2Identifiers) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_OAuth2Identifiers_keycloakId(ctx, field)
+func (ec *executionContext) _PinnedMessageDto_ownerId(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PinnedMessageDto_ownerId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8525,35 +11283,38 @@ func (ec *executionContext) _OAuth2Identifiers_keycloakId(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.KeycloakID, nil
+		return obj.OwnerID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_OAuth2Identifiers_keycloakId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PinnedMessageDto_ownerId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "OAuth2Identifiers",
+		Object:     "PinnedMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Participant_id(ctx context.Context, field graphql.CollectedField, obj *model.Participant) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Participant_id(ctx, field)
+func (ec *executionContext) _PinnedMessageDto_owner(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PinnedMessageDto_owner(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8566,38 +11327,47 @@ func (ec *executionContext) _Participant_id(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Owner, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(*model.Participant)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalOParticipant2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipant(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Participant_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PinnedMessageDto_owner(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Participant",
+		Object:     "PinnedMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Participant_id(ctx, field)
+			case "login":
+				return ec.fieldContext_Participant_login(ctx, field)
+			case "avatar":
+				return ec.fieldContext_Participant_avatar(ctx, field)
+			case "shortInfo":
+				return ec.fieldContext_Participant_shortInfo(ctx, field)
+			case "loginColor":
+				return ec.fieldContext_Participant_loginColor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Participant_login(ctx context.Context, field graphql.CollectedField, obj *model.Participant) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Participant_login(ctx, field)
+func (ec *executionContext) _PinnedMessageDto_pinnedPromoted(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PinnedMessageDto_pinnedPromoted(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8610,7 +11380,7 @@ func (ec *executionContext) _Participant_login(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Login, nil
+		return obj.PinnedPromoted, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8622,26 +11392,26 @@ func (ec *executionContext) _Participant_login(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Participant_login(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PinnedMessageDto_pinnedPromoted(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Participant",
+		Object:     "PinnedMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Participant_avatar(ctx context.Context, field graphql.CollectedField, obj *model.Participant) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Participant_avatar(ctx, field)
+func (ec *executionContext) _PinnedMessageDto_createDateTime(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PinnedMessageDto_createDateTime(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8654,35 +11424,38 @@ func (ec *executionContext) _Participant_avatar(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Avatar, nil
+		return obj.CreateDateTime, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Participant_avatar(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PinnedMessageDto_createDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Participant",
+		Object:     "PinnedMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Participant_shortInfo(ctx context.Context, field graphql.CollectedField, obj *model.Participant) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Participant_shortInfo(ctx, field)
+func (ec *executionContext) _PinnedMessageDto_canPin(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PinnedMessageDto_canPin(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8695,35 +11468,38 @@ func (ec *executionContext) _Participant_shortInfo(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ShortInfo, nil
+		return obj.CanPin, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Participant_shortInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PinnedMessageDto_canPin(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Participant",
+		Object:     "PinnedMessageDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Participant_loginColor(ctx context.Context, field graphql.CollectedField, obj *model.Participant) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Participant_loginColor(ctx, field)
+func (ec *executionContext) _PinnedMessageEvent_message(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PinnedMessageEvent_message(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8736,35 +11512,56 @@ func (ec *executionContext) _Participant_loginColor(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LoginColor, nil
+		return obj.Message, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model.PinnedMessageDto)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNPinnedMessageDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPinnedMessageDto(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Participant_loginColor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PinnedMessageEvent_message(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Participant",
+		Object:     "PinnedMessageEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_PinnedMessageDto_id(ctx, field)
+			case "text":
+				return ec.fieldContext_PinnedMessageDto_text(ctx, field)
+			case "chatId":
+				return ec.fieldContext_PinnedMessageDto_chatId(ctx, field)
+			case "ownerId":
+				return ec.fieldContext_PinnedMessageDto_ownerId(ctx, field)
+			case "owner":
+				return ec.fieldContext_PinnedMessageDto_owner(ctx, field)
+			case "pinnedPromoted":
+				return ec.fieldContext_PinnedMessageDto_pinnedPromoted(ctx, field)
+			case "createDateTime":
+				return ec.fieldContext_PinnedMessageDto_createDateTime(ctx, field)
+			case "canPin":
+				return ec.fieldContext_PinnedMessageDto_canPin(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PinnedMessageDto", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParticipantWithAdmin_id(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParticipantWithAdmin_id(ctx, field)
+func (ec *executionContext) _PinnedMessageEvent_count(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PinnedMessageEvent_count(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8777,7 +11574,7 @@ func (ec *executionContext) _ParticipantWithAdmin_id(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Count, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8794,9 +11591,9 @@ func (ec *executionContext) _ParticipantWithAdmin_id(ctx context.Context, field
 	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParticipantWithAdmin_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PinnedMessageEvent_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParticipantWithAdmin",
+		Object:     "PinnedMessageEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -8807,8 +11604,8 @@ func (ec *executionContext) fieldContext_ParticipantWithAdmin_id(_ context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _ParticipantWithAdmin_login(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParticipantWithAdmin_login(ctx, field)
+func (ec *executionContext) _PollChangedEvent_messageId(ctx context.Context, field graphql.CollectedField, obj *model.PollChangedEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollChangedEvent_messageId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8821,7 +11618,7 @@ func (ec *executionContext) _ParticipantWithAdmin_login(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Login, nil
+		return obj.MessageID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8833,26 +11630,26 @@ func (ec *executionContext) _ParticipantWithAdmin_login(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParticipantWithAdmin_login(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollChangedEvent_messageId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParticipantWithAdmin",
+		Object:     "PollChangedEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParticipantWithAdmin_avatar(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParticipantWithAdmin_avatar(ctx, field)
+func (ec *executionContext) _PollChangedEvent_poll(ctx context.Context, field graphql.CollectedField, obj *model.PollChangedEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollChangedEvent_poll(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8865,35 +11662,56 @@ func (ec *executionContext) _ParticipantWithAdmin_avatar(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Avatar, nil
+		return obj.Poll, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model.PollDto)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNPollDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPollDto(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParticipantWithAdmin_avatar(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollChangedEvent_poll(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParticipantWithAdmin",
+		Object:     "PollChangedEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "multipleChoice":
+				return ec.fieldContext_PollDto_multipleChoice(ctx, field)
+			case "anonymous":
+				return ec.fieldContext_PollDto_anonymous(ctx, field)
+			case "closed":
+				return ec.fieldContext_PollDto_closed(ctx, field)
+			case "closedDateTime":
+				return ec.fieldContext_PollDto_closedDateTime(ctx, field)
+			case "options":
+				return ec.fieldContext_PollDto_options(ctx, field)
+			case "totalVoters":
+				return ec.fieldContext_PollDto_totalVoters(ctx, field)
+			case "canVote":
+				return ec.fieldContext_PollDto_canVote(ctx, field)
+			case "canClose":
+				return ec.fieldContext_PollDto_canClose(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PollDto", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParticipantWithAdmin_admin(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParticipantWithAdmin_admin(ctx, field)
+func (ec *executionContext) _PollDto_multipleChoice(ctx context.Context, field graphql.CollectedField, obj *model.PollDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollDto_multipleChoice(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8906,7 +11724,7 @@ func (ec *executionContext) _ParticipantWithAdmin_admin(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Admin, nil
+		return obj.MultipleChoice, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8923,9 +11741,9 @@ func (ec *executionContext) _ParticipantWithAdmin_admin(ctx context.Context, fie
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParticipantWithAdmin_admin(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollDto_multipleChoice(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParticipantWithAdmin",
+		Object:     "PollDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -8936,8 +11754,8 @@ func (ec *executionContext) fieldContext_ParticipantWithAdmin_admin(_ context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _ParticipantWithAdmin_shortInfo(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParticipantWithAdmin_shortInfo(ctx, field)
+func (ec *executionContext) _PollDto_anonymous(ctx context.Context, field graphql.CollectedField, obj *model.PollDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollDto_anonymous(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8950,35 +11768,38 @@ func (ec *executionContext) _ParticipantWithAdmin_shortInfo(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ShortInfo, nil
+		return obj.Anonymous, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParticipantWithAdmin_shortInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollDto_anonymous(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParticipantWithAdmin",
+		Object:     "PollDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParticipantWithAdmin_loginColor(ctx context.Context, field graphql.CollectedField, obj *model.ParticipantWithAdmin) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParticipantWithAdmin_loginColor(ctx, field)
+func (ec *executionContext) _PollDto_closed(ctx context.Context, field graphql.CollectedField, obj *model.PollDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollDto_closed(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8991,35 +11812,38 @@ func (ec *executionContext) _ParticipantWithAdmin_loginColor(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LoginColor, nil
+		return obj.Closed, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParticipantWithAdmin_loginColor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollDto_closed(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParticipantWithAdmin",
+		Object:     "PollDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PinnedMessageDto_id(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PinnedMessageDto_id(ctx, field)
+func (ec *executionContext) _PollDto_closedDateTime(ctx context.Context, field graphql.CollectedField, obj *model.PollDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollDto_closedDateTime(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9032,38 +11856,35 @@ func (ec *executionContext) _PinnedMessageDto_id(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.ClosedDateTime, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(*time.Time)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PinnedMessageDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollDto_closedDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PinnedMessageDto",
+		Object:     "PollDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PinnedMessageDto_text(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PinnedMessageDto_text(ctx, field)
+func (ec *executionContext) _PollDto_options(ctx context.Context, field graphql.CollectedField, obj *model.PollDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollDto_options(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9076,7 +11897,7 @@ func (ec *executionContext) _PinnedMessageDto_text(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Text, nil
+		return obj.Options, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9088,26 +11909,38 @@ func (ec *executionContext) _PinnedMessageDto_text(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*model.PollOptionDto)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNPollOptionDto2ᚕᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPollOptionDtoᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PinnedMessageDto_text(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollDto_options(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PinnedMessageDto",
+		Object:     "PollDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_PollOptionDto_id(ctx, field)
+			case "text":
+				return ec.fieldContext_PollOptionDto_text(ctx, field)
+			case "count":
+				return ec.fieldContext_PollOptionDto_count(ctx, field)
+			case "users":
+				return ec.fieldContext_PollOptionDto_users(ctx, field)
+			case "voted":
+				return ec.fieldContext_PollOptionDto_voted(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PollOptionDto", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PinnedMessageDto_chatId(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PinnedMessageDto_chatId(ctx, field)
+func (ec *executionContext) _PollDto_totalVoters(ctx context.Context, field graphql.CollectedField, obj *model.PollDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollDto_totalVoters(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9120,7 +11953,7 @@ func (ec *executionContext) _PinnedMessageDto_chatId(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ChatID, nil
+		return obj.TotalVoters, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9137,9 +11970,9 @@ func (ec *executionContext) _PinnedMessageDto_chatId(ctx context.Context, field
 	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PinnedMessageDto_chatId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollDto_totalVoters(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PinnedMessageDto",
+		Object:     "PollDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -9150,8 +11983,8 @@ func (ec *executionContext) fieldContext_PinnedMessageDto_chatId(_ context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _PinnedMessageDto_ownerId(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PinnedMessageDto_ownerId(ctx, field)
+func (ec *executionContext) _PollDto_canVote(ctx context.Context, field graphql.CollectedField, obj *model.PollDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollDto_canVote(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9164,7 +11997,7 @@ func (ec *executionContext) _PinnedMessageDto_ownerId(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.OwnerID, nil
+		return obj.CanVote, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9176,26 +12009,26 @@ func (ec *executionContext) _PinnedMessageDto_ownerId(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PinnedMessageDto_ownerId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollDto_canVote(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PinnedMessageDto",
+		Object:     "PollDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PinnedMessageDto_owner(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PinnedMessageDto_owner(ctx, field)
+func (ec *executionContext) _PollDto_canClose(ctx context.Context, field graphql.CollectedField, obj *model.PollDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollDto_canClose(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9208,47 +12041,38 @@ func (ec *executionContext) _PinnedMessageDto_owner(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Owner, nil
+		return obj.CanClose, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Participant)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOParticipant2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipant(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PinnedMessageDto_owner(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollDto_canClose(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PinnedMessageDto",
+		Object:     "PollDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Participant_id(ctx, field)
-			case "login":
-				return ec.fieldContext_Participant_login(ctx, field)
-			case "avatar":
-				return ec.fieldContext_Participant_avatar(ctx, field)
-			case "shortInfo":
-				return ec.fieldContext_Participant_shortInfo(ctx, field)
-			case "loginColor":
-				return ec.fieldContext_Participant_loginColor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PinnedMessageDto_pinnedPromoted(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PinnedMessageDto_pinnedPromoted(ctx, field)
+func (ec *executionContext) _PollOptionDto_id(ctx context.Context, field graphql.CollectedField, obj *model.PollOptionDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollOptionDto_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9261,7 +12085,7 @@ func (ec *executionContext) _PinnedMessageDto_pinnedPromoted(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PinnedPromoted, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9273,26 +12097,26 @@ func (ec *executionContext) _PinnedMessageDto_pinnedPromoted(ctx context.Context
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PinnedMessageDto_pinnedPromoted(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollOptionDto_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PinnedMessageDto",
+		Object:     "PollOptionDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PinnedMessageDto_createDateTime(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PinnedMessageDto_createDateTime(ctx, field)
+func (ec *executionContext) _PollOptionDto_text(ctx context.Context, field graphql.CollectedField, obj *model.PollOptionDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollOptionDto_text(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9305,7 +12129,7 @@ func (ec *executionContext) _PinnedMessageDto_createDateTime(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CreateDateTime, nil
+		return obj.Text, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9317,26 +12141,26 @@ func (ec *executionContext) _PinnedMessageDto_createDateTime(ctx context.Context
 		}
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PinnedMessageDto_createDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollOptionDto_text(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PinnedMessageDto",
+		Object:     "PollOptionDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PinnedMessageDto_canPin(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageDto) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PinnedMessageDto_canPin(ctx, field)
+func (ec *executionContext) _PollOptionDto_count(ctx context.Context, field graphql.CollectedField, obj *model.PollOptionDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollOptionDto_count(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9349,7 +12173,7 @@ func (ec *executionContext) _PinnedMessageDto_canPin(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CanPin, nil
+		return obj.Count, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9361,26 +12185,26 @@ func (ec *executionContext) _PinnedMessageDto_canPin(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(int64)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PinnedMessageDto_canPin(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollOptionDto_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PinnedMessageDto",
+		Object:     "PollOptionDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Int64 does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PinnedMessageEvent_message(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PinnedMessageEvent_message(ctx, field)
+func (ec *executionContext) _PollOptionDto_users(ctx context.Context, field graphql.CollectedField, obj *model.PollOptionDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollOptionDto_users(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9393,7 +12217,7 @@ func (ec *executionContext) _PinnedMessageEvent_message(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Message, nil
+		return obj.Users, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9405,44 +12229,38 @@ func (ec *executionContext) _PinnedMessageEvent_message(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.PinnedMessageDto)
+	res := resTmp.([]*model.Participant)
 	fc.Result = res
-	return ec.marshalNPinnedMessageDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPinnedMessageDto(ctx, field.Selections, res)
+	return ec.marshalNParticipant2ᚕᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipantᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PinnedMessageEvent_message(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollOptionDto_users(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PinnedMessageEvent",
+		Object:     "PollOptionDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_PinnedMessageDto_id(ctx, field)
-			case "text":
-				return ec.fieldContext_PinnedMessageDto_text(ctx, field)
-			case "chatId":
-				return ec.fieldContext_PinnedMessageDto_chatId(ctx, field)
-			case "ownerId":
-				return ec.fieldContext_PinnedMessageDto_ownerId(ctx, field)
-			case "owner":
-				return ec.fieldContext_PinnedMessageDto_owner(ctx, field)
-			case "pinnedPromoted":
-				return ec.fieldContext_PinnedMessageDto_pinnedPromoted(ctx, field)
-			case "createDateTime":
-				return ec.fieldContext_PinnedMessageDto_createDateTime(ctx, field)
-			case "canPin":
-				return ec.fieldContext_PinnedMessageDto_canPin(ctx, field)
+				return ec.fieldContext_Participant_id(ctx, field)
+			case "login":
+				return ec.fieldContext_Participant_login(ctx, field)
+			case "avatar":
+				return ec.fieldContext_Participant_avatar(ctx, field)
+			case "shortInfo":
+				return ec.fieldContext_Participant_shortInfo(ctx, field)
+			case "loginColor":
+				return ec.fieldContext_Participant_loginColor(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type PinnedMessageDto", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PinnedMessageEvent_count(ctx context.Context, field graphql.CollectedField, obj *model.PinnedMessageEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PinnedMessageEvent_count(ctx, field)
+func (ec *executionContext) _PollOptionDto_voted(ctx context.Context, field graphql.CollectedField, obj *model.PollOptionDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PollOptionDto_voted(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9455,7 +12273,7 @@ func (ec *executionContext) _PinnedMessageEvent_count(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Count, nil
+		return obj.Voted, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9467,19 +12285,19 @@ func (ec *executionContext) _PinnedMessageEvent_count(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int64)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNInt642int64(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PinnedMessageEvent_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PollOptionDto_voted(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PinnedMessageEvent",
+		Object:     "PollOptionDto",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int64 does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
@@ -10362,33 +13180,77 @@ func (ec *executionContext) _Reaction_users(ctx context.Context, field graphql.C
 	return ec.marshalNParticipant2ᚕᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐParticipantᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Reaction_users(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Reaction_users(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Reaction",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Participant_id(ctx, field)
+			case "login":
+				return ec.fieldContext_Participant_login(ctx, field)
+			case "avatar":
+				return ec.fieldContext_Participant_avatar(ctx, field)
+			case "shortInfo":
+				return ec.fieldContext_Participant_shortInfo(ctx, field)
+			case "loginColor":
+				return ec.fieldContext_Participant_loginColor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Reaction_reaction(ctx context.Context, field graphql.CollectedField, obj *model.Reaction) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Reaction_reaction(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Reaction, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Reaction_reaction(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Reaction",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Participant_id(ctx, field)
-			case "login":
-				return ec.fieldContext_Participant_login(ctx, field)
-			case "avatar":
-				return ec.fieldContext_Participant_avatar(ctx, field)
-			case "shortInfo":
-				return ec.fieldContext_Participant_shortInfo(ctx, field)
-			case "loginColor":
-				return ec.fieldContext_Participant_loginColor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Participant", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Reaction_reaction(ctx context.Context, field graphql.CollectedField, obj *model.Reaction) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Reaction_reaction(ctx, field)
+func (ec *executionContext) _Reaction_url(ctx context.Context, field graphql.CollectedField, obj *model.Reaction) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Reaction_url(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10401,24 +13263,21 @@ func (ec *executionContext) _Reaction_reaction(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Reaction, nil
+		return obj.URL, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Reaction_reaction(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Reaction_url(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Reaction",
 		Field:      field,
@@ -10520,6 +13379,8 @@ func (ec *executionContext) fieldContext_ReactionChangedEvent_reaction(_ context
 				return ec.fieldContext_Reaction_users(ctx, field)
 			case "reaction":
 				return ec.fieldContext_Reaction_reaction(ctx, field)
+			case "url":
+				return ec.fieldContext_Reaction_url(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Reaction", field.Name)
 		},
@@ -10600,6 +13461,12 @@ func (ec *executionContext) fieldContext_Subscription_chatEvents(ctx context.Con
 				return ec.fieldContext_ChatEvent_publishedMessageEvent(ctx, field)
 			case "reactionChangedEvent":
 				return ec.fieldContext_ChatEvent_reactionChangedEvent(ctx, field)
+			case "threadChangedEvent":
+				return ec.fieldContext_ChatEvent_threadChangedEvent(ctx, field)
+			case "pollChangedEvent":
+				return ec.fieldContext_ChatEvent_pollChangedEvent(ctx, field)
+			case "ephemeralMessageEvent":
+				return ec.fieldContext_ChatEvent_ephemeralMessageEvent(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type ChatEvent", field.Name)
 		},
@@ -10703,6 +13570,8 @@ func (ec *executionContext) fieldContext_Subscription_globalEvents(_ context.Con
 				return ec.fieldContext_GlobalEvent_browserNotification(ctx, field)
 			case "userTypingEvent":
 				return ec.fieldContext_GlobalEvent_userTypingEvent(ctx, field)
+			case "messageDraftEvent":
+				return ec.fieldContext_GlobalEvent_messageDraftEvent(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type GlobalEvent", field.Name)
 		},
@@ -10800,69 +13669,294 @@ func (ec *executionContext) _Subscription_userAccountEvents(ctx context.Context,
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
-			ret = nil
+			ret = nil
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Subscription().UserAccountEvents(rctx, fc.Args["userIdsFilter"].([]int64))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return nil
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return nil
+	}
+	return func(ctx context.Context) graphql.Marshaler {
+		select {
+		case res, ok := <-resTmp.(<-chan *model.UserAccountEvent):
+			if !ok {
+				return nil
+			}
+			return graphql.WriterFunc(func(w io.Writer) {
+				w.Write([]byte{'{'})
+				graphql.MarshalString(field.Alias).MarshalGQL(w)
+				w.Write([]byte{':'})
+				ec.marshalNUserAccountEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐUserAccountEvent(ctx, field.Selections, res).MarshalGQL(w)
+				w.Write([]byte{'}'})
+			})
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (ec *executionContext) fieldContext_Subscription_userAccountEvents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Subscription",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "eventType":
+				return ec.fieldContext_UserAccountEvent_eventType(ctx, field)
+			case "userAccountEvent":
+				return ec.fieldContext_UserAccountEvent_userAccountEvent(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type UserAccountEvent", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Subscription_userAccountEvents_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ThreadChangedEvent_thread(ctx context.Context, field graphql.CollectedField, obj *model.ThreadChangedEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ThreadChangedEvent_thread(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Thread, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.ThreadDto)
+	fc.Result = res
+	return ec.marshalNThreadDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐThreadDto(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ThreadChangedEvent_thread(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ThreadChangedEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "rootMessageId":
+				return ec.fieldContext_ThreadDto_rootMessageId(ctx, field)
+			case "replyCount":
+				return ec.fieldContext_ThreadDto_replyCount(ctx, field)
+			case "lastReplyDateTime":
+				return ec.fieldContext_ThreadDto_lastReplyDateTime(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ThreadDto", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ThreadChangedEvent_unreadReplies(ctx context.Context, field graphql.CollectedField, obj *model.ThreadChangedEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ThreadChangedEvent_unreadReplies(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.UnreadReplies, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ThreadChangedEvent_unreadReplies(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ThreadChangedEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ThreadDto_rootMessageId(ctx context.Context, field graphql.CollectedField, obj *model.ThreadDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ThreadDto_rootMessageId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.RootMessageID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ThreadDto_rootMessageId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ThreadDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ThreadDto_replyCount(ctx context.Context, field graphql.CollectedField, obj *model.ThreadDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ThreadDto_replyCount(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ReplyCount, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalNInt642int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ThreadDto_replyCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ThreadDto",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int64 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ThreadDto_lastReplyDateTime(ctx context.Context, field graphql.CollectedField, obj *model.ThreadDto) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ThreadDto_lastReplyDateTime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().UserAccountEvents(rctx, fc.Args["userIdsFilter"].([]int64))
+		return obj.LastReplyDateTime, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
-		return nil
+		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
-		return nil
-	}
-	return func(ctx context.Context) graphql.Marshaler {
-		select {
-		case res, ok := <-resTmp.(<-chan *model.UserAccountEvent):
-			if !ok {
-				return nil
-			}
-			return graphql.WriterFunc(func(w io.Writer) {
-				w.Write([]byte{'{'})
-				graphql.MarshalString(field.Alias).MarshalGQL(w)
-				w.Write([]byte{':'})
-				ec.marshalNUserAccountEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐUserAccountEvent(ctx, field.Selections, res).MarshalGQL(w)
-				w.Write([]byte{'}'})
-			})
-		case <-ctx.Done():
-			return nil
-		}
+		return graphql.Null
 	}
+	res := resTmp.(*time.Time)
+	fc.Result = res
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Subscription_userAccountEvents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ThreadDto_lastReplyDateTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Subscription",
+		Object:     "ThreadDto",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "eventType":
-				return ec.fieldContext_UserAccountEvent_eventType(ctx, field)
-			case "userAccountEvent":
-				return ec.fieldContext_UserAccountEvent_userAccountEvent(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type UserAccountEvent", field.Name)
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Subscription_userAccountEvents_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
@@ -15445,6 +18539,35 @@ func (ec *executionContext) _ChatDto(ctx context.Context, sel ast.SelectionSet,
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "regularParticipantCanSeeEditHistory":
+			out.Values[i] = ec._ChatDto_regularParticipantCanSeeEditHistory(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "canSeeEditHistory":
+			out.Values[i] = ec._ChatDto_canSeeEditHistory(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "messageRetentionSeconds":
+			out.Values[i] = ec._ChatDto_messageRetentionSeconds(ctx, field, obj)
+		case "joinByRequest":
+			out.Values[i] = ec._ChatDto_joinByRequest(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "slowModeSeconds":
+			out.Values[i] = ec._ChatDto_slowModeSeconds(ctx, field, obj)
+		case "role":
+			out.Values[i] = ec._ChatDto_role(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "permissions":
+			out.Values[i] = ec._ChatDto_permissions(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "lastMessagePreview":
 			out.Values[i] = ec._ChatDto_lastMessagePreview(ctx, field, obj)
 		default:
@@ -15504,6 +18627,12 @@ func (ec *executionContext) _ChatEvent(ctx context.Context, sel ast.SelectionSet
 			out.Values[i] = ec._ChatEvent_publishedMessageEvent(ctx, field, obj)
 		case "reactionChangedEvent":
 			out.Values[i] = ec._ChatEvent_reactionChangedEvent(ctx, field, obj)
+		case "threadChangedEvent":
+			out.Values[i] = ec._ChatEvent_threadChangedEvent(ctx, field, obj)
+		case "pollChangedEvent":
+			out.Values[i] = ec._ChatEvent_pollChangedEvent(ctx, field, obj)
+		case "ephemeralMessageEvent":
+			out.Values[i] = ec._ChatEvent_ephemeralMessageEvent(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -15721,6 +18850,23 @@ func (ec *executionContext) _DisplayMessageDto(ctx context.Context, sel ast.Sele
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "threadId":
+			out.Values[i] = ec._DisplayMessageDto_threadId(ctx, field, obj)
+		case "thread":
+			out.Values[i] = ec._DisplayMessageDto_thread(ctx, field, obj)
+		case "deletedDateTime":
+			out.Values[i] = ec._DisplayMessageDto_deletedDateTime(ctx, field, obj)
+		case "restorableUntil":
+			out.Values[i] = ec._DisplayMessageDto_restorableUntil(ctx, field, obj)
+		case "canRestore":
+			out.Values[i] = ec._DisplayMessageDto_canRestore(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "poll":
+			out.Values[i] = ec._DisplayMessageDto_poll(ctx, field, obj)
+		case "linkPreview":
+			out.Values[i] = ec._DisplayMessageDto_linkPreview(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -15804,6 +18950,52 @@ func (ec *executionContext) _EmbedMessageResponse(ctx context.Context, sel ast.S
 	return out
 }
 
+var ephemeralMessageNotificationImplementors = []string{"EphemeralMessageNotification"}
+
+func (ec *executionContext) _EphemeralMessageNotification(ctx context.Context, sel ast.SelectionSet, obj *model.EphemeralMessageNotification) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, ephemeralMessageNotificationImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("EphemeralMessageNotification")
+		case "command":
+			out.Values[i] = ec._EphemeralMessageNotification_command(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "text":
+			out.Values[i] = ec._EphemeralMessageNotification_text(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "threadId":
+			out.Values[i] = ec._EphemeralMessageNotification_threadId(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var fileInfoDtoImplementors = []string{"FileInfoDto"}
 
 func (ec *executionContext) _FileInfoDto(ctx context.Context, sel ast.SelectionSet, obj *model.FileInfoDto) graphql.Marshaler {
@@ -15966,36 +19158,173 @@ func (ec *executionContext) _GlobalEvent(ctx context.Context, sel ast.SelectionS
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "chatEvent":
-			out.Values[i] = ec._GlobalEvent_chatEvent(ctx, field, obj)
-		case "chatDeletedEvent":
-			out.Values[i] = ec._GlobalEvent_chatDeletedEvent(ctx, field, obj)
-		case "coChattedParticipantEvent":
-			out.Values[i] = ec._GlobalEvent_coChattedParticipantEvent(ctx, field, obj)
-		case "videoUserCountChangedEvent":
-			out.Values[i] = ec._GlobalEvent_videoUserCountChangedEvent(ctx, field, obj)
-		case "videoRecordingChangedEvent":
-			out.Values[i] = ec._GlobalEvent_videoRecordingChangedEvent(ctx, field, obj)
-		case "videoCallInvitation":
-			out.Values[i] = ec._GlobalEvent_videoCallInvitation(ctx, field, obj)
-		case "videoParticipantDialEvent":
-			out.Values[i] = ec._GlobalEvent_videoParticipantDialEvent(ctx, field, obj)
-		case "unreadMessagesNotification":
-			out.Values[i] = ec._GlobalEvent_unreadMessagesNotification(ctx, field, obj)
-		case "allUnreadMessagesNotification":
-			out.Values[i] = ec._GlobalEvent_allUnreadMessagesNotification(ctx, field, obj)
-		case "notificationEvent":
-			out.Values[i] = ec._GlobalEvent_notificationEvent(ctx, field, obj)
-		case "videoCallScreenShareChangedDto":
-			out.Values[i] = ec._GlobalEvent_videoCallScreenShareChangedDto(ctx, field, obj)
-		case "forceLogout":
-			out.Values[i] = ec._GlobalEvent_forceLogout(ctx, field, obj)
-		case "hasUnreadMessagesChanged":
-			out.Values[i] = ec._GlobalEvent_hasUnreadMessagesChanged(ctx, field, obj)
-		case "browserNotification":
-			out.Values[i] = ec._GlobalEvent_browserNotification(ctx, field, obj)
-		case "userTypingEvent":
-			out.Values[i] = ec._GlobalEvent_userTypingEvent(ctx, field, obj)
+		case "chatEvent":
+			out.Values[i] = ec._GlobalEvent_chatEvent(ctx, field, obj)
+		case "chatDeletedEvent":
+			out.Values[i] = ec._GlobalEvent_chatDeletedEvent(ctx, field, obj)
+		case "coChattedParticipantEvent":
+			out.Values[i] = ec._GlobalEvent_coChattedParticipantEvent(ctx, field, obj)
+		case "videoUserCountChangedEvent":
+			out.Values[i] = ec._GlobalEvent_videoUserCountChangedEvent(ctx, field, obj)
+		case "videoRecordingChangedEvent":
+			out.Values[i] = ec._GlobalEvent_videoRecordingChangedEvent(ctx, field, obj)
+		case "videoCallInvitation":
+			out.Values[i] = ec._GlobalEvent_videoCallInvitation(ctx, field, obj)
+		case "videoParticipantDialEvent":
+			out.Values[i] = ec._GlobalEvent_videoParticipantDialEvent(ctx, field, obj)
+		case "unreadMessagesNotification":
+			out.Values[i] = ec._GlobalEvent_unreadMessagesNotification(ctx, field, obj)
+		case "allUnreadMessagesNotification":
+			out.Values[i] = ec._GlobalEvent_allUnreadMessagesNotification(ctx, field, obj)
+		case "notificationEvent":
+			out.Values[i] = ec._GlobalEvent_notificationEvent(ctx, field, obj)
+		case "videoCallScreenShareChangedDto":
+			out.Values[i] = ec._GlobalEvent_videoCallScreenShareChangedDto(ctx, field, obj)
+		case "forceLogout":
+			out.Values[i] = ec._GlobalEvent_forceLogout(ctx, field, obj)
+		case "hasUnreadMessagesChanged":
+			out.Values[i] = ec._GlobalEvent_hasUnreadMessagesChanged(ctx, field, obj)
+		case "browserNotification":
+			out.Values[i] = ec._GlobalEvent_browserNotification(ctx, field, obj)
+		case "userTypingEvent":
+			out.Values[i] = ec._GlobalEvent_userTypingEvent(ctx, field, obj)
+		case "messageDraftEvent":
+			out.Values[i] = ec._GlobalEvent_messageDraftEvent(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var hasUnreadMessagesChangedEventImplementors = []string{"HasUnreadMessagesChangedEvent"}
+
+func (ec *executionContext) _HasUnreadMessagesChangedEvent(ctx context.Context, sel ast.SelectionSet, obj *model.HasUnreadMessagesChangedEvent) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, hasUnreadMessagesChangedEventImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HasUnreadMessagesChangedEvent")
+		case "hasUnreadMessages":
+			out.Values[i] = ec._HasUnreadMessagesChangedEvent_hasUnreadMessages(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var linkPreviewDtoImplementors = []string{"LinkPreviewDto"}
+
+func (ec *executionContext) _LinkPreviewDto(ctx context.Context, sel ast.SelectionSet, obj *model.LinkPreviewDto) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, linkPreviewDtoImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("LinkPreviewDto")
+		case "url":
+			out.Values[i] = ec._LinkPreviewDto_url(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "title":
+			out.Values[i] = ec._LinkPreviewDto_title(ctx, field, obj)
+		case "description":
+			out.Values[i] = ec._LinkPreviewDto_description(ctx, field, obj)
+		case "imageUrl":
+			out.Values[i] = ec._LinkPreviewDto_imageUrl(ctx, field, obj)
+		case "siteName":
+			out.Values[i] = ec._LinkPreviewDto_siteName(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var messageBroadcastNotificationImplementors = []string{"MessageBroadcastNotification"}
+
+func (ec *executionContext) _MessageBroadcastNotification(ctx context.Context, sel ast.SelectionSet, obj *model.MessageBroadcastNotification) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, messageBroadcastNotificationImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("MessageBroadcastNotification")
+		case "login":
+			out.Values[i] = ec._MessageBroadcastNotification_login(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "userId":
+			out.Values[i] = ec._MessageBroadcastNotification_userId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "text":
+			out.Values[i] = ec._MessageBroadcastNotification_text(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -16019,22 +19348,29 @@ func (ec *executionContext) _GlobalEvent(ctx context.Context, sel ast.SelectionS
 	return out
 }
 
-var hasUnreadMessagesChangedEventImplementors = []string{"HasUnreadMessagesChangedEvent"}
+var messageDeletedDtoImplementors = []string{"MessageDeletedDto"}
 
-func (ec *executionContext) _HasUnreadMessagesChangedEvent(ctx context.Context, sel ast.SelectionSet, obj *model.HasUnreadMessagesChangedEvent) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, hasUnreadMessagesChangedEventImplementors)
+func (ec *executionContext) _MessageDeletedDto(ctx context.Context, sel ast.SelectionSet, obj *model.MessageDeletedDto) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, messageDeletedDtoImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("HasUnreadMessagesChangedEvent")
-		case "hasUnreadMessages":
-			out.Values[i] = ec._HasUnreadMessagesChangedEvent_hasUnreadMessages(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("MessageDeletedDto")
+		case "id":
+			out.Values[i] = ec._MessageDeletedDto_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "chatId":
+			out.Values[i] = ec._MessageDeletedDto_chatId(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "threadId":
+			out.Values[i] = ec._MessageDeletedDto_threadId(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -16058,29 +19394,33 @@ func (ec *executionContext) _HasUnreadMessagesChangedEvent(ctx context.Context,
 	return out
 }
 
-var messageBroadcastNotificationImplementors = []string{"MessageBroadcastNotification"}
+var messageDraftDtoImplementors = []string{"MessageDraftDto"}
 
-func (ec *executionContext) _MessageBroadcastNotification(ctx context.Context, sel ast.SelectionSet, obj *model.MessageBroadcastNotification) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, messageBroadcastNotificationImplementors)
+func (ec *executionContext) _MessageDraftDto(ctx context.Context, sel ast.SelectionSet, obj *model.MessageDraftDto) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, messageDraftDtoImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("MessageBroadcastNotification")
-		case "login":
-			out.Values[i] = ec._MessageBroadcastNotification_login(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("MessageDraftDto")
+		case "chatId":
+			out.Values[i] = ec._MessageDraftDto_chatId(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "userId":
-			out.Values[i] = ec._MessageBroadcastNotification_userId(ctx, field, obj)
+		case "text":
+			out.Values[i] = ec._MessageDraftDto_text(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "text":
-			out.Values[i] = ec._MessageBroadcastNotification_text(ctx, field, obj)
+		case "threadId":
+			out.Values[i] = ec._MessageDraftDto_threadId(ctx, field, obj)
+		case "embedMessage":
+			out.Values[i] = ec._MessageDraftDto_embedMessage(ctx, field, obj)
+		case "editDateTime":
+			out.Values[i] = ec._MessageDraftDto_editDateTime(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -16107,24 +19447,29 @@ func (ec *executionContext) _MessageBroadcastNotification(ctx context.Context, s
 	return out
 }
 
-var messageDeletedDtoImplementors = []string{"MessageDeletedDto"}
+var messageDraftEmbedDtoImplementors = []string{"MessageDraftEmbedDto"}
 
-func (ec *executionContext) _MessageDeletedDto(ctx context.Context, sel ast.SelectionSet, obj *model.MessageDeletedDto) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, messageDeletedDtoImplementors)
+func (ec *executionContext) _MessageDraftEmbedDto(ctx context.Context, sel ast.SelectionSet, obj *model.MessageDraftEmbedDto) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, messageDraftEmbedDtoImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("MessageDeletedDto")
+			out.Values[i] = graphql.MarshalString("MessageDraftEmbedDto")
 		case "id":
-			out.Values[i] = ec._MessageDeletedDto_id(ctx, field, obj)
+			out.Values[i] = ec._MessageDraftEmbedDto_id(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
 		case "chatId":
-			out.Values[i] = ec._MessageDeletedDto_chatId(ctx, field, obj)
+			out.Values[i] = ec._MessageDraftEmbedDto_chatId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "embedType":
+			out.Values[i] = ec._MessageDraftEmbedDto_embedType(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -16281,23 +19626,198 @@ func (ec *executionContext) _Participant(ctx context.Context, sel ast.SelectionS
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("Participant")
-		case "id":
-			out.Values[i] = ec._Participant_id(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("Participant")
+		case "id":
+			out.Values[i] = ec._Participant_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "login":
+			out.Values[i] = ec._Participant_login(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "avatar":
+			out.Values[i] = ec._Participant_avatar(ctx, field, obj)
+		case "shortInfo":
+			out.Values[i] = ec._Participant_shortInfo(ctx, field, obj)
+		case "loginColor":
+			out.Values[i] = ec._Participant_loginColor(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var participantWithAdminImplementors = []string{"ParticipantWithAdmin"}
+
+func (ec *executionContext) _ParticipantWithAdmin(ctx context.Context, sel ast.SelectionSet, obj *model.ParticipantWithAdmin) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, participantWithAdminImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ParticipantWithAdmin")
+		case "id":
+			out.Values[i] = ec._ParticipantWithAdmin_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "login":
+			out.Values[i] = ec._ParticipantWithAdmin_login(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "avatar":
+			out.Values[i] = ec._ParticipantWithAdmin_avatar(ctx, field, obj)
+		case "admin":
+			out.Values[i] = ec._ParticipantWithAdmin_admin(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "role":
+			out.Values[i] = ec._ParticipantWithAdmin_role(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "shortInfo":
+			out.Values[i] = ec._ParticipantWithAdmin_shortInfo(ctx, field, obj)
+		case "loginColor":
+			out.Values[i] = ec._ParticipantWithAdmin_loginColor(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var pinnedMessageDtoImplementors = []string{"PinnedMessageDto"}
+
+func (ec *executionContext) _PinnedMessageDto(ctx context.Context, sel ast.SelectionSet, obj *model.PinnedMessageDto) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, pinnedMessageDtoImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PinnedMessageDto")
+		case "id":
+			out.Values[i] = ec._PinnedMessageDto_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "text":
+			out.Values[i] = ec._PinnedMessageDto_text(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "chatId":
+			out.Values[i] = ec._PinnedMessageDto_chatId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "ownerId":
+			out.Values[i] = ec._PinnedMessageDto_ownerId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "owner":
+			out.Values[i] = ec._PinnedMessageDto_owner(ctx, field, obj)
+		case "pinnedPromoted":
+			out.Values[i] = ec._PinnedMessageDto_pinnedPromoted(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "createDateTime":
+			out.Values[i] = ec._PinnedMessageDto_createDateTime(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "canPin":
+			out.Values[i] = ec._PinnedMessageDto_canPin(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var pinnedMessageEventImplementors = []string{"PinnedMessageEvent"}
+
+func (ec *executionContext) _PinnedMessageEvent(ctx context.Context, sel ast.SelectionSet, obj *model.PinnedMessageEvent) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, pinnedMessageEventImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PinnedMessageEvent")
+		case "message":
+			out.Values[i] = ec._PinnedMessageEvent_message(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "login":
-			out.Values[i] = ec._Participant_login(ctx, field, obj)
+		case "count":
+			out.Values[i] = ec._PinnedMessageEvent_count(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "avatar":
-			out.Values[i] = ec._Participant_avatar(ctx, field, obj)
-		case "shortInfo":
-			out.Values[i] = ec._Participant_shortInfo(ctx, field, obj)
-		case "loginColor":
-			out.Values[i] = ec._Participant_loginColor(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -16321,38 +19841,27 @@ func (ec *executionContext) _Participant(ctx context.Context, sel ast.SelectionS
 	return out
 }
 
-var participantWithAdminImplementors = []string{"ParticipantWithAdmin"}
+var pollChangedEventImplementors = []string{"PollChangedEvent"}
 
-func (ec *executionContext) _ParticipantWithAdmin(ctx context.Context, sel ast.SelectionSet, obj *model.ParticipantWithAdmin) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, participantWithAdminImplementors)
+func (ec *executionContext) _PollChangedEvent(ctx context.Context, sel ast.SelectionSet, obj *model.PollChangedEvent) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, pollChangedEventImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("ParticipantWithAdmin")
-		case "id":
-			out.Values[i] = ec._ParticipantWithAdmin_id(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
-			}
-		case "login":
-			out.Values[i] = ec._ParticipantWithAdmin_login(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("PollChangedEvent")
+		case "messageId":
+			out.Values[i] = ec._PollChangedEvent_messageId(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "avatar":
-			out.Values[i] = ec._ParticipantWithAdmin_avatar(ctx, field, obj)
-		case "admin":
-			out.Values[i] = ec._ParticipantWithAdmin_admin(ctx, field, obj)
+		case "poll":
+			out.Values[i] = ec._PollChangedEvent_poll(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "shortInfo":
-			out.Values[i] = ec._ParticipantWithAdmin_shortInfo(ctx, field, obj)
-		case "loginColor":
-			out.Values[i] = ec._ParticipantWithAdmin_loginColor(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -16376,51 +19885,51 @@ func (ec *executionContext) _ParticipantWithAdmin(ctx context.Context, sel ast.S
 	return out
 }
 
-var pinnedMessageDtoImplementors = []string{"PinnedMessageDto"}
+var pollDtoImplementors = []string{"PollDto"}
 
-func (ec *executionContext) _PinnedMessageDto(ctx context.Context, sel ast.SelectionSet, obj *model.PinnedMessageDto) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, pinnedMessageDtoImplementors)
+func (ec *executionContext) _PollDto(ctx context.Context, sel ast.SelectionSet, obj *model.PollDto) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, pollDtoImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("PinnedMessageDto")
-		case "id":
-			out.Values[i] = ec._PinnedMessageDto_id(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("PollDto")
+		case "multipleChoice":
+			out.Values[i] = ec._PollDto_multipleChoice(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "text":
-			out.Values[i] = ec._PinnedMessageDto_text(ctx, field, obj)
+		case "anonymous":
+			out.Values[i] = ec._PollDto_anonymous(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "chatId":
-			out.Values[i] = ec._PinnedMessageDto_chatId(ctx, field, obj)
+		case "closed":
+			out.Values[i] = ec._PollDto_closed(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "ownerId":
-			out.Values[i] = ec._PinnedMessageDto_ownerId(ctx, field, obj)
+		case "closedDateTime":
+			out.Values[i] = ec._PollDto_closedDateTime(ctx, field, obj)
+		case "options":
+			out.Values[i] = ec._PollDto_options(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "owner":
-			out.Values[i] = ec._PinnedMessageDto_owner(ctx, field, obj)
-		case "pinnedPromoted":
-			out.Values[i] = ec._PinnedMessageDto_pinnedPromoted(ctx, field, obj)
+		case "totalVoters":
+			out.Values[i] = ec._PollDto_totalVoters(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "createDateTime":
-			out.Values[i] = ec._PinnedMessageDto_createDateTime(ctx, field, obj)
+		case "canVote":
+			out.Values[i] = ec._PollDto_canVote(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "canPin":
-			out.Values[i] = ec._PinnedMessageDto_canPin(ctx, field, obj)
+		case "canClose":
+			out.Values[i] = ec._PollDto_canClose(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -16447,24 +19956,39 @@ func (ec *executionContext) _PinnedMessageDto(ctx context.Context, sel ast.Selec
 	return out
 }
 
-var pinnedMessageEventImplementors = []string{"PinnedMessageEvent"}
+var pollOptionDtoImplementors = []string{"PollOptionDto"}
 
-func (ec *executionContext) _PinnedMessageEvent(ctx context.Context, sel ast.SelectionSet, obj *model.PinnedMessageEvent) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, pinnedMessageEventImplementors)
+func (ec *executionContext) _PollOptionDto(ctx context.Context, sel ast.SelectionSet, obj *model.PollOptionDto) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, pollOptionDtoImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("PinnedMessageEvent")
-		case "message":
-			out.Values[i] = ec._PinnedMessageEvent_message(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("PollOptionDto")
+		case "id":
+			out.Values[i] = ec._PollOptionDto_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "text":
+			out.Values[i] = ec._PollOptionDto_text(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
 		case "count":
-			out.Values[i] = ec._PinnedMessageEvent_count(ctx, field, obj)
+			out.Values[i] = ec._PollOptionDto_count(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "users":
+			out.Values[i] = ec._PollOptionDto_users(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "voted":
+			out.Values[i] = ec._PollOptionDto_voted(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -16746,6 +20270,8 @@ func (ec *executionContext) _Reaction(ctx context.Context, sel ast.SelectionSet,
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "url":
+			out.Values[i] = ec._Reaction_url(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -16839,6 +20365,96 @@ func (ec *executionContext) _Subscription(ctx context.Context, sel ast.Selection
 	}
 }
 
+var threadChangedEventImplementors = []string{"ThreadChangedEvent"}
+
+func (ec *executionContext) _ThreadChangedEvent(ctx context.Context, sel ast.SelectionSet, obj *model.ThreadChangedEvent) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, threadChangedEventImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ThreadChangedEvent")
+		case "thread":
+			out.Values[i] = ec._ThreadChangedEvent_thread(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "unreadReplies":
+			out.Values[i] = ec._ThreadChangedEvent_unreadReplies(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var threadDtoImplementors = []string{"ThreadDto"}
+
+func (ec *executionContext) _ThreadDto(ctx context.Context, sel ast.SelectionSet, obj *model.ThreadDto) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, threadDtoImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ThreadDto")
+		case "rootMessageId":
+			out.Values[i] = ec._ThreadDto_rootMessageId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "replyCount":
+			out.Values[i] = ec._ThreadDto_replyCount(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "lastReplyDateTime":
+			out.Values[i] = ec._ThreadDto_lastReplyDateTime(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var userAccountDtoImplementors = []string{"UserAccountDto"}
 
 func (ec *executionContext) _UserAccountDto(ctx context.Context, sel ast.SelectionSet, obj *model.UserAccountDto) graphql.Marshaler {
@@ -18047,6 +21663,70 @@ func (ec *executionContext) marshalNPinnedMessageDto2ᚖnkonevᚗnameᚋeventᚋ
 	return ec._PinnedMessageDto(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNPollDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPollDto(ctx context.Context, sel ast.SelectionSet, v *model.PollDto) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._PollDto(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNPollOptionDto2ᚕᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPollOptionDtoᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.PollOptionDto) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNPollOptionDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPollOptionDto(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNPollOptionDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPollOptionDto(ctx context.Context, sel ast.SelectionSet, v *model.PollOptionDto) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._PollOptionDto(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNPublishedMessageDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPublishedMessageDto(ctx context.Context, sel ast.SelectionSet, v *model.PublishedMessageDto) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
@@ -18158,6 +21838,16 @@ func (ec *executionContext) marshalNString2ᚕstringᚄ(ctx context.Context, sel
 	return ret
 }
 
+func (ec *executionContext) marshalNThreadDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐThreadDto(ctx context.Context, sel ast.SelectionSet, v *model.ThreadDto) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._ThreadDto(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNTime2timeᚐTime(ctx context.Context, v interface{}) (time.Time, error) {
 	res, err := graphql.UnmarshalTime(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -18630,6 +22320,13 @@ func (ec *executionContext) marshalOEmbedMessageResponse2ᚖnkonevᚗnameᚋeven
 	return ec._EmbedMessageResponse(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalOEphemeralMessageNotification2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐEphemeralMessageNotification(ctx context.Context, sel ast.SelectionSet, v *model.EphemeralMessageNotification) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._EphemeralMessageNotification(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalOFileInfoDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐFileInfoDto(ctx context.Context, sel ast.SelectionSet, v *model.FileInfoDto) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -18705,6 +22402,13 @@ func (ec *executionContext) marshalOInt642ᚖint64(ctx context.Context, sel ast.
 	return res
 }
 
+func (ec *executionContext) marshalOLinkPreviewDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐLinkPreviewDto(ctx context.Context, sel ast.SelectionSet, v *model.LinkPreviewDto) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._LinkPreviewDto(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalOMessageBroadcastNotification2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐMessageBroadcastNotification(ctx context.Context, sel ast.SelectionSet, v *model.MessageBroadcastNotification) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -18719,6 +22423,20 @@ func (ec *executionContext) marshalOMessageDeletedDto2ᚖnkonevᚗnameᚋevent
 	return ec._MessageDeletedDto(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalOMessageDraftDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐMessageDraftDto(ctx context.Context, sel ast.SelectionSet, v *model.MessageDraftDto) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._MessageDraftDto(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalOMessageDraftEmbedDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐMessageDraftEmbedDto(ctx context.Context, sel ast.SelectionSet, v *model.MessageDraftEmbedDto) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._MessageDraftEmbedDto(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalOOAuth2Identifiers2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐOAuth2Identifiers(ctx context.Context, sel ast.SelectionSet, v *model.OAuth2Identifiers) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -18787,6 +22505,20 @@ func (ec *executionContext) marshalOPinnedMessageEvent2ᚖnkonevᚗnameᚋevent
 	return ec._PinnedMessageEvent(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalOPollChangedEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPollChangedEvent(ctx context.Context, sel ast.SelectionSet, v *model.PollChangedEvent) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._PollChangedEvent(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalOPollDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPollDto(ctx context.Context, sel ast.SelectionSet, v *model.PollDto) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._PollDto(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalOPreviewCreatedEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐPreviewCreatedEvent(ctx context.Context, sel ast.SelectionSet, v *model.PreviewCreatedEvent) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -18824,6 +22556,20 @@ func (ec *executionContext) marshalOString2ᚖstring(ctx context.Context, sel as
 	return res
 }
 
+func (ec *executionContext) marshalOThreadChangedEvent2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐThreadChangedEvent(ctx context.Context, sel ast.SelectionSet, v *model.ThreadChangedEvent) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._ThreadChangedEvent(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalOThreadDto2ᚖnkonevᚗnameᚋeventᚋgraphᚋmodelᚐThreadDto(ctx context.Context, sel ast.SelectionSet, v *model.ThreadDto) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._ThreadDto(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOTime2ᚖtimeᚐTime(ctx context.Context, v interface{}) (*time.Time, error) {
 	if v == nil {
 		return nil, nil
@@ -59,6 +59,13 @@ type ChatDto struct {
 	BlogAbout                           bool           `json:"blogAbout"`
 	RegularParticipantCanWriteMessage   bool           `json:"regularParticipantCanWriteMessage"`
 	CanWriteMessage                     bool           `json:"canWriteMessage"`
+	RegularParticipantCanSeeEditHistory bool           `json:"regularParticipantCanSeeEditHistory"`
+	CanSeeEditHistory                   bool           `json:"canSeeEditHistory"`
+	MessageRetentionSeconds             *int64         `json:"messageRetentionSeconds"`
+	JoinByRequest                       bool           `json:"joinByRequest"`
+	SlowModeSeconds                     *int64         `json:"slowModeSeconds"`
+	Role                                string         `json:"role"`
+	Permissions                         []string       `json:"permissions"`
 	LastMessagePreview                  *string        `json:"lastMessagePreview"`
 }
 
@@ -73,6 +80,9 @@ type ChatEvent struct {
 	FileEvent             *WrappedFileInfoDto           `json:"fileEvent"`
 	PublishedMessageEvent *PublishedMessageEvent        `json:"publishedMessageEvent"`
 	ReactionChangedEvent  *ReactionChangedEvent         `json:"reactionChangedEvent"`
+	ThreadChangedEvent    *ThreadChangedEvent           `json:"threadChangedEvent"`
+	PollChangedEvent      *PollChangedEvent             `json:"pollChangedEvent"`
+	EphemeralMessageEvent *EphemeralMessageNotification `json:"ephemeralMessageEvent"`
 }
 
 type ChatUnreadMessageChanged struct {
@@ -90,24 +100,31 @@ type DataDto struct {
 }
 
 type DisplayMessageDto struct {
-	ID             int64                 `json:"id"`
-	Text           string                `json:"text"`
-	ChatID         int64                 `json:"chatId"`
-	OwnerID        int64                 `json:"ownerId"`
-	CreateDateTime time.Time             `json:"createDateTime"`
-	EditDateTime   *time.Time            `json:"editDateTime"`
-	Owner          *Participant          `json:"owner"`
-	CanEdit        bool                  `json:"canEdit"`
-	CanDelete      bool                  `json:"canDelete"`
-	FileItemUUID   *string               `json:"fileItemUuid"`
-	EmbedMessage   *EmbedMessageResponse `json:"embedMessage"`
-	Pinned         bool                  `json:"pinned"`
-	BlogPost       bool                  `json:"blogPost"`
-	PinnedPromoted *bool                 `json:"pinnedPromoted"`
-	Reactions      []*Reaction           `json:"reactions"`
-	Published      bool                  `json:"published"`
-	CanPublish     bool                  `json:"canPublish"`
-	CanPin         bool                  `json:"canPin"`
+	ID              int64                 `json:"id"`
+	Text            string                `json:"text"`
+	ChatID          int64                 `json:"chatId"`
+	OwnerID         int64                 `json:"ownerId"`
+	CreateDateTime  time.Time             `json:"createDateTime"`
+	EditDateTime    *time.Time            `json:"editDateTime"`
+	Owner           *Participant          `json:"owner"`
+	CanEdit         bool                  `json:"canEdit"`
+	CanDelete       bool                  `json:"canDelete"`
+	FileItemUUID    *string               `json:"fileItemUuid"`
+	EmbedMessage    *EmbedMessageResponse `json:"embedMessage"`
+	Pinned          bool                  `json:"pinned"`
+	BlogPost        bool                  `json:"blogPost"`
+	PinnedPromoted  *bool                 `json:"pinnedPromoted"`
+	Reactions       []*Reaction           `json:"reactions"`
+	Published       bool                  `json:"published"`
+	CanPublish      bool                  `json:"canPublish"`
+	CanPin          bool                  `json:"canPin"`
+	ThreadID        *int64                `json:"threadId"`
+	Thread          *ThreadDto            `json:"thread"`
+	DeletedDateTime *time.Time            `json:"deletedDateTime"`
+	RestorableUntil *time.Time            `json:"restorableUntil"`
+	CanRestore      bool                  `json:"canRestore"`
+	Poll            *PollDto              `json:"poll"`
+	LinkPreview     *LinkPreviewDto       `json:"linkPreview"`
 }
 
 type EmbedMessageResponse struct {
@@ -120,6 +137,12 @@ type EmbedMessageResponse struct {
 	IsParticipant bool         `json:"isParticipant"`
 }
 
+type EphemeralMessageNotification struct {
+	Command  string `json:"command"`
+	Text     string `json:"text"`
+	ThreadID *int64 `json:"threadId"`
+}
+
 type FileInfoDto struct {
 	ID             string       `json:"id"`
 	Filename       string       `json:"filename"`
@@ -161,12 +184,21 @@ type GlobalEvent struct {
 	HasUnreadMessagesChanged       *HasUnreadMessagesChangedEvent  `json:"hasUnreadMessagesChanged"`
 	BrowserNotification            *BrowserNotification            `json:"browserNotification"`
 	UserTypingEvent                *UserTypingDto                  `json:"userTypingEvent"`
+	MessageDraftEvent              *MessageDraftDto                `json:"messageDraftEvent"`
 }
 
 type HasUnreadMessagesChangedEvent struct {
 	HasUnreadMessages bool `json:"hasUnreadMessages"`
 }
 
+type LinkPreviewDto struct {
+	URL         string  `json:"url"`
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	ImageURL    *string `json:"imageUrl"`
+	SiteName    *string `json:"siteName"`
+}
+
 type MessageBroadcastNotification struct {
 	Login  string `json:"login"`
 	UserID int64  `json:"userId"`
@@ -174,8 +206,23 @@ type MessageBroadcastNotification struct {
 }
 
 type MessageDeletedDto struct {
-	ID     int64 `json:"id"`
-	ChatID int64 `json:"chatId"`
+	ID       int64  `json:"id"`
+	ChatID   int64  `json:"chatId"`
+	ThreadID *int64 `json:"threadId"`
+}
+
+type MessageDraftDto struct {
+	ChatID       int64                 `json:"chatId"`
+	Text         string                `json:"text"`
+	ThreadID     *int64                `json:"threadId"`
+	EmbedMessage *MessageDraftEmbedDto `json:"embedMessage"`
+	EditDateTime time.Time             `json:"editDateTime"`
+}
+
+type MessageDraftEmbedDto struct {
+	ID        int64  `json:"id"`
+	ChatID    int64  `json:"chatId"`
+	EmbedType string `json:"embedType"`
 }
 
 type NotificationDto struct {
@@ -211,6 +258,7 @@ type ParticipantWithAdmin struct {
 	Login      string  `json:"login"`
 	Avatar     *string `json:"avatar"`
 	Admin      bool    `json:"admin"`
+	Role       string  `json:"role"`
 	ShortInfo  *string `json:"shortInfo"`
 	LoginColor *string `json:"loginColor"`
 }
@@ -231,6 +279,30 @@ type PinnedMessageEvent struct {
 	Count   int64             `json:"count"`
 }
 
+type PollChangedEvent struct {
+	MessageID int64    `json:"messageId"`
+	Poll      *PollDto `json:"poll"`
+}
+
+type PollDto struct {
+	MultipleChoice bool             `json:"multipleChoice"`
+	Anonymous      bool             `json:"anonymous"`
+	Closed         bool             `json:"closed"`
+	ClosedDateTime *time.Time       `json:"closedDateTime"`
+	Options        []*PollOptionDto `json:"options"`
+	TotalVoters    int64            `json:"totalVoters"`
+	CanVote        bool             `json:"canVote"`
+	CanClose       bool             `json:"canClose"`
+}
+
+type PollOptionDto struct {
+	ID    int64          `json:"id"`
+	Text  string         `json:"text"`
+	Count int64          `json:"count"`
+	Users []*Participant `json:"users"`
+	Voted bool           `json:"voted"`
+}
+
 type PreviewCreatedEvent struct {
 	ID            string  `json:"id"`
 	URL           string  `json:"url"`
@@ -261,6 +333,7 @@ type Reaction struct {
 	Count    int64          `json:"count"`
 	Users    []*Participant `json:"users"`
 	Reaction string         `json:"reaction"`
+	URL      *string        `json:"url"`
 }
 
 type ReactionChangedEvent struct {
@@ -271,6 +344,17 @@ type ReactionChangedEvent struct {
 type Subscription struct {
 }
 
+type ThreadChangedEvent struct {
+	Thread        *ThreadDto `json:"thread"`
+	UnreadReplies int64      `json:"unreadReplies"`
+}
+
+type ThreadDto struct {
+	RootMessageID     int64      `json:"rootMessageId"`
+	ReplyCount        int64      `json:"replyCount"`
+	LastReplyDateTime *time.Time `json:"lastReplyDateTime"`
+}
+
 type UserAccountDto struct {
 	ID                int64              `json:"id"`
 	Login             string             `json:"login"`
@@ -538,8 +538,9 @@ func convertToChatEvent(e *dto.ChatEvent) *model.ChatEvent {
 	messageDeleted := e.MessageDeletedNotification
 	if messageDeleted != nil {
 		result.MessageDeletedEvent = &model.MessageDeletedDto{
-			ID:     messageDeleted.Id,
-			ChatID: messageDeleted.ChatId,
+			ID:       messageDeleted.Id,
+			ChatID:   messageDeleted.ChatId,
+			ThreadID: messageDeleted.ThreadId,
 		}
 	}
 
@@ -611,26 +612,55 @@ func convertToChatEvent(e *dto.ChatEvent) *model.ChatEvent {
 		}
 	}
 
+	threadChangedEvent := e.ThreadChangedEvent
+	if threadChangedEvent != nil {
+		result.ThreadChangedEvent = &model.ThreadChangedEvent{
+			Thread:        convertThreadDto(&threadChangedEvent.Thread),
+			UnreadReplies: threadChangedEvent.UnreadReplies,
+		}
+	}
+
+	pollChangedEvent := e.PollChangedEvent
+	if pollChangedEvent != nil && pollChangedEvent.Poll != nil {
+		result.PollChangedEvent = &model.PollChangedEvent{
+			MessageID: pollChangedEvent.MessageId,
+			Poll:      convertPollDto(pollChangedEvent.Poll),
+		}
+	}
+
+	ephemeralMessage := e.EphemeralMessageNotification
+	if ephemeralMessage != nil {
+		result.EphemeralMessageEvent = &model.EphemeralMessageNotification{
+			Command:  ephemeralMessage.Command,
+			Text:     ephemeralMessage.Text,
+			ThreadID: ephemeralMessage.ThreadId,
+		}
+	}
+
 	return result
 }
 func convertDisplayMessageDto(messageDto *dto.DisplayMessageDto) *model.DisplayMessageDto {
 	var result = &model.DisplayMessageDto{ // dto.DisplayMessageDto
-		ID:             messageDto.Id,
-		Text:           messageDto.Text,
-		ChatID:         messageDto.ChatId,
-		OwnerID:        messageDto.OwnerId,
-		CreateDateTime: messageDto.CreateDateTime,
-		EditDateTime:   messageDto.EditDateTime.Ptr(),
-		Owner:          convertParticipant(messageDto.Owner),
-		CanEdit:        messageDto.CanEdit,
-		CanDelete:      messageDto.CanDelete,
-		FileItemUUID:   messageDto.FileItemUuid,
-		Pinned:         messageDto.Pinned,
-		BlogPost:       messageDto.BlogPost,
-		PinnedPromoted: messageDto.PinnedPromoted,
-		Published:      messageDto.Published,
-		CanPublish:     messageDto.CanPublish,
-		CanPin:         messageDto.CanPin,
+		ID:              messageDto.Id,
+		Text:            messageDto.Text,
+		ChatID:          messageDto.ChatId,
+		OwnerID:         messageDto.OwnerId,
+		CreateDateTime:  messageDto.CreateDateTime,
+		EditDateTime:    messageDto.EditDateTime.Ptr(),
+		Owner:           convertParticipant(messageDto.Owner),
+		CanEdit:         messageDto.CanEdit,
+		CanDelete:       messageDto.CanDelete,
+		FileItemUUID:    messageDto.FileItemUuid,
+		Pinned:          messageDto.Pinned,
+		BlogPost:        messageDto.BlogPost,
+		PinnedPromoted:  messageDto.PinnedPromoted,
+		Published:       messageDto.Published,
+		CanPublish:      messageDto.CanPublish,
+		CanPin:          messageDto.CanPin,
+		ThreadID:        messageDto.ThreadId,
+		DeletedDateTime: messageDto.DeletedDateTime.Ptr(),
+		RestorableUntil: messageDto.RestorableUntil.Ptr(),
+		CanRestore:      messageDto.CanRestore,
 	}
 	embedMessageDto := messageDto.EmbedMessage
 	if embedMessageDto != nil {
@@ -648,8 +678,55 @@ func convertDisplayMessageDto(messageDto *dto.DisplayMessageDto) *model.DisplayM
 	if reactions != nil {
 		result.Reactions = convertReactions(reactions)
 	}
+	thread := messageDto.Thread
+	if thread != nil {
+		result.Thread = convertThreadDto(thread)
+	}
+	poll := messageDto.Poll
+	if poll != nil {
+		result.Poll = convertPollDto(poll)
+	}
+	linkPreview := messageDto.LinkPreview
+	if linkPreview != nil {
+		result.LinkPreview = &model.LinkPreviewDto{
+			URL:         linkPreview.Url,
+			Title:       linkPreview.Title.Ptr(),
+			Description: linkPreview.Description.Ptr(),
+			ImageURL:    linkPreview.ImageUrl.Ptr(),
+			SiteName:    linkPreview.SiteName.Ptr(),
+		}
+	}
 	return result
 }
+func convertThreadDto(t *dto.ThreadDto) *model.ThreadDto {
+	return &model.ThreadDto{
+		RootMessageID:     t.RootMessageId,
+		ReplyCount:        t.ReplyCount,
+		LastReplyDateTime: t.LastReplyDateTime.Ptr(),
+	}
+}
+func convertPollDto(p *dto.PollDto) *model.PollDto {
+	options := make([]*model.PollOptionDto, 0)
+	for _, o := range p.Options {
+		options = append(options, &model.PollOptionDto{
+			ID:    o.Id,
+			Text:  o.Text,
+			Count: o.Count,
+			Users: convertParticipants(o.Users),
+			Voted: o.Voted,
+		})
+	}
+	return &model.PollDto{
+		MultipleChoice: p.MultipleChoice,
+		Anonymous:      p.Anonymous,
+		Closed:         p.Closed,
+		ClosedDateTime: p.ClosedDateTime.Ptr(),
+		Options:        options,
+		TotalVoters:    p.TotalVoters,
+		CanVote:        p.CanVote,
+		CanClose:       p.CanClose,
+	}
+}
 func convertReactions(reactions []dto.Reaction) []*model.Reaction {
 	ret := make([]*model.Reaction, 0)
 	for _, r := range reactions {
@@ -663,6 +740,7 @@ func convertReaction(r *dto.Reaction) *model.Reaction {
 		Count:    r.Count,
 		Reaction: r.Reaction,
 		Users:    convertParticipants(r.Users),
+		URL:      r.Url.Ptr(),
 	}
 }
 func convertPinnedMessageEvent(e *dto.PinnedMessageEvent) *model.PinnedMessageEvent {
@@ -738,6 +816,13 @@ func convertToGlobalEvent(e *dto.GlobalUserEvent) *model.GlobalEvent {
 			BlogAbout:                           chatEvent.BlogAbout,
 			RegularParticipantCanWriteMessage:   chatEvent.RegularParticipantCanWriteMessage,
 			CanWriteMessage:                     chatEvent.CanWriteMessage,
+			RegularParticipantCanSeeEditHistory: chatEvent.RegularParticipantCanSeeEditHistory,
+			CanSeeEditHistory:                   chatEvent.CanSeeEditHistory,
+			MessageRetentionSeconds:             chatEvent.MessageRetentionSeconds.Ptr(),
+			JoinByRequest:                       chatEvent.JoinByRequest,
+			SlowModeSeconds:                     chatEvent.SlowModeSeconds.Ptr(),
+			Role:                                chatEvent.Role,
+			Permissions:                         chatEvent.Permissions,
 			LastMessagePreview:                  chatEvent.LastMessagePreview,
 		}
 	}
@@ -866,6 +951,23 @@ func convertToGlobalEvent(e *dto.GlobalUserEvent) *model.GlobalEvent {
 		}
 	}
 
+	messageDraftEvent := e.MessageDraftNotification
+	if messageDraftEvent != nil {
+		ret.MessageDraftEvent = &model.MessageDraftDto{
+			ChatID:       messageDraftEvent.ChatId,
+			Text:         messageDraftEvent.Text,
+			ThreadID:     messageDraftEvent.ThreadId,
+			EditDateTime: messageDraftEvent.EditDateTime,
+		}
+		if messageDraftEvent.EmbedMessageRequest != nil {
+			ret.MessageDraftEvent.EmbedMessage = &model.MessageDraftEmbedDto{
+				ID:        messageDraftEvent.EmbedMessageRequest.Id,
+				ChatID:    messageDraftEvent.EmbedMessageRequest.ChatId,
+				EmbedType: messageDraftEvent.EmbedMessageRequest.EmbedType,
+			}
+		}
+	}
+
 	return ret
 }
 func convertToUserSessionsKilledEvent(aDto *dto.UserSessionsKilledEvent) *model.GlobalEvent {
@@ -907,6 +1009,7 @@ func convertParticipantWithAdmin(owner *dto.UserWithAdmin) *model.ParticipantWit
 		Login:      owner.Login,
 		Avatar:     owner.Avatar.Ptr(),
 		Admin:      owner.Admin,
+		Role:       owner.Role,
 		ShortInfo:  owner.ShortInfo.Ptr(),
 		LoginColor: owner.LoginColor.Ptr(),
 	}
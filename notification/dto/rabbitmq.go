@@ -21,6 +21,21 @@ type ReactionEvent struct {
 	MessageId int64 `json:"messageId"`
 }
 
+type ChatExportNotification struct {
+	ExportId int64  `json:"exportId"`
+	Filename string `json:"filename"`
+	Url      string `json:"url"`
+}
+
+type JoinRequestNotification struct {
+	RequestId int64 `json:"requestId"`
+}
+
+type ReminderNotification struct {
+	MessageId int64  `json:"messageId"`
+	Text      string `json:"text"`
+}
+
 // for input data from another microservies
 type NotificationEvent struct {
 	EventType              string                  `json:"eventType"`
@@ -34,6 +49,9 @@ type NotificationEvent struct {
 	ByAvatar               *string                 `json:"byAvatar"`
 	ChatTitle              string                  `json:"chatTitle"`
 	ReactionEvent          *ReactionEvent		   `json:"reactionEvent"`
+	ChatExportNotification *ChatExportNotification `json:"chatExportNotification"`
+	JoinRequestNotification *JoinRequestNotification `json:"joinRequestNotification"`
+	ReminderNotification *ReminderNotification `json:"reminderNotification"`
 }
 
 type GlobalUserEvent struct {
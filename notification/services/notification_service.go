@@ -291,6 +291,140 @@ func (srv *NotificationService) HandleChatNotification(ctx context.Context, even
 				srv.lgr.WithTracing(ctx).Errorf("Unable to send notification delete %v", err)
 			}
 		}
+	} else if event.ChatExportNotification != nil {
+		// the user explicitly requested the export, so it isn't subject to the settings
+		err := srv.removeExcessNotificationsIfNeed(ctx, event.UserId)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to delete excess notifications %v", err)
+			return
+		}
+
+		notification := event.ChatExportNotification
+		notificationType := "chat_export"
+		id, createDateTime, err := srv.dbs.PutNotification(ctx, nil, event.UserId, event.ChatId, notificationType, notification.Filename, event.ByUserId, event.ByLogin, event.ChatTitle, nil)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to put notification %v", err)
+			return
+		}
+
+		count, err = srv.dbs.GetNotificationCount(ctx, event.UserId)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to count notification %v", err)
+			return
+		}
+
+		err = srv.rabbitEventsPublisher.Publish(
+			ctx,
+			event.UserId,
+			&dto.WrapperNotificationDto{
+				NotificationDto: dto.NotificationDto{
+					Id:               id,
+					ChatId:           event.ChatId,
+					MessageId:        nil,
+					NotificationType: notificationType,
+					Description:      notification.Filename,
+					CreateDateTime:   createDateTime,
+					ByUserId:         event.ByUserId,
+					ByLogin:          event.ByLogin,
+					ByAvatar:         event.ByAvatar,
+					ChatTitle:        event.ChatTitle,
+				},
+				TotalCount: count,
+			},
+			NotificationAdd,
+		)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to send notification add %v", err)
+		}
+	} else if event.JoinRequestNotification != nil {
+		// the admins have to decide on the request, so it isn't subject to the settings
+		err := srv.removeExcessNotificationsIfNeed(ctx, event.UserId)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to delete excess notifications %v", err)
+			return
+		}
+
+		notificationType := "join_request"
+		id, createDateTime, err := srv.dbs.PutNotification(ctx, nil, event.UserId, event.ChatId, notificationType, event.ByLogin, event.ByUserId, event.ByLogin, event.ChatTitle, nil)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to put notification %v", err)
+			return
+		}
+
+		count, err = srv.dbs.GetNotificationCount(ctx, event.UserId)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to count notification %v", err)
+			return
+		}
+
+		err = srv.rabbitEventsPublisher.Publish(
+			ctx,
+			event.UserId,
+			&dto.WrapperNotificationDto{
+				NotificationDto: dto.NotificationDto{
+					Id:               id,
+					ChatId:           event.ChatId,
+					MessageId:        nil,
+					NotificationType: notificationType,
+					Description:      event.ByLogin,
+					CreateDateTime:   createDateTime,
+					ByUserId:         event.ByUserId,
+					ByLogin:          event.ByLogin,
+					ByAvatar:         event.ByAvatar,
+					ChatTitle:        event.ChatTitle,
+				},
+				TotalCount: count,
+			},
+			NotificationAdd,
+		)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to send notification add %v", err)
+		}
+	} else if event.ReminderNotification != nil {
+		// the user has asked for it explicitly, so it isn't subject to the settings
+		err := srv.removeExcessNotificationsIfNeed(ctx, event.UserId)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to delete excess notifications %v", err)
+			return
+		}
+
+		notification := event.ReminderNotification
+		notificationType := "reminder"
+		id, createDateTime, err := srv.dbs.PutNotification(ctx, &notification.MessageId, event.UserId, event.ChatId, notificationType, notification.Text, event.ByUserId, event.ByLogin, event.ChatTitle, nil)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to put notification %v", err)
+			return
+		}
+
+		count, err = srv.dbs.GetNotificationCount(ctx, event.UserId)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to count notification %v", err)
+			return
+		}
+
+		err = srv.rabbitEventsPublisher.Publish(
+			ctx,
+			event.UserId,
+			&dto.WrapperNotificationDto{
+				NotificationDto: dto.NotificationDto{
+					Id:               id,
+					ChatId:           event.ChatId,
+					MessageId:        &notification.MessageId,
+					NotificationType: notificationType,
+					Description:      notification.Text,
+					CreateDateTime:   createDateTime,
+					ByUserId:         event.ByUserId,
+					ByLogin:          event.ByLogin,
+					ByAvatar:         event.ByAvatar,
+					ChatTitle:        event.ChatTitle,
+				},
+				TotalCount: count,
+			},
+			NotificationAdd,
+		)
+		if err != nil {
+			srv.lgr.WithTracing(ctx).Errorf("Unable to send notification add %v", err)
+		}
 	}
 
 }
@@ -9,8 +9,11 @@ import (
 	"github.com/siyouyun-open/imaging"
 	"image"
 	"image/jpeg"
+	"image/png"
+	"io"
 	"net/http"
 	"nkonev.name/storage/auth"
+	"nkonev.name/storage/client"
 	"nkonev.name/storage/logger"
 	"nkonev.name/storage/s3"
 	"nkonev.name/storage/utils"
@@ -86,26 +89,38 @@ func (h *abstractAvatarHandler) PutAvatar(c echo.Context) error {
 
 func (h *abstractAvatarHandler) putSizedFile(c echo.Context, srcImage image.Image, err error, bucketName string, contentType string, width, height int, avatarType AvatarType, currTime int64) (string, string, error) {
 	dstImage := imaging.Resize(srcImage, width, height, imaging.Lanczos)
-	byteBuffer := new(bytes.Buffer)
-	err = jpeg.Encode(byteBuffer, dstImage, nil)
-	if err != nil {
-		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during encoding image: %v", err)
-		return "", "", err
-	}
 	filename, err := h.getAvatarFileName(c, avatarType)
 	if err != nil {
 		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during get avatar filename: %v", err)
 		return "", "", err
 	}
-	if _, err := h.minio.PutObject(c.Request().Context(), bucketName, filename, byteBuffer, int64(byteBuffer.Len()), minio.PutObjectOptions{ContentType: contentType}); err != nil {
-		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during upload object: %v", err)
+	relativeUrl, err := h.putImage(c, dstImage, encodeJpeg, bucketName, filename, contentType, currTime)
+	if err != nil {
 		return "", "", err
 	}
-	relativeUrl := fmt.Sprintf("%v/%v?%v=%v", h.delegate.GetUrlPath(), filename, utils.TimeParam, currTime)
 
 	return filename, relativeUrl, nil
 }
 
+func encodeJpeg(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, nil)
+}
+
+// encodes the already resized image and uploads it, returns the url to download
+func (h *abstractAvatarHandler) putImage(c echo.Context, dstImage image.Image, encode func(w io.Writer, img image.Image) error, bucketName, filename, contentType string, currTime int64) (string, error) {
+	byteBuffer := new(bytes.Buffer)
+	err := encode(byteBuffer, dstImage)
+	if err != nil {
+		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during encoding image: %v", err)
+		return "", err
+	}
+	if _, err := h.minio.PutObject(c.Request().Context(), bucketName, filename, byteBuffer, int64(byteBuffer.Len()), minio.PutObjectOptions{ContentType: contentType}); err != nil {
+		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during upload object: %v", err)
+		return "", err
+	}
+	return fmt.Sprintf("%v/%v?%v=%v", h.delegate.GetUrlPath(), filename, utils.TimeParam, currTime), nil
+}
+
 func (r *abstractAvatarHandler) getAvatarFileName(c echo.Context, avatarType AvatarType) (string, error) {
 	return r.delegate.getAvatarFileName(c, avatarType)
 }
@@ -199,3 +214,93 @@ func (r *ChatAvatarHandler) getAvatarFileName(c echo.Context, avatarType AvatarT
 func (r *ChatAvatarHandler) GetUrlPath() string {
 	return urlStorageGetChatAvatar
 }
+
+type ChatEmojiHandler struct {
+	abstractAvatarHandler
+	restClient *client.RestClient
+}
+
+func NewChatEmojiHandler(lgr *logger.Logger, minio *s3.InternalMinioClient, minioConfig *utils.MinioConfig, restClient *client.RestClient) *ChatEmojiHandler {
+	ceh := ChatEmojiHandler{}
+	ceh.minio = minio
+	ceh.delegate = &ceh
+	ceh.minioConfig = minioConfig
+	ceh.lgr = lgr
+	ceh.restClient = restClient
+	return &ceh
+}
+
+const urlStorageGetChatEmoji = "/api/storage/public/chat/emoji"
+
+// the emoji keeps its proportions and the transparency
+const chatEmojiSize = 128
+
+func (h *ChatEmojiHandler) ensureAndGetAvatarBucket() (string, error) {
+	return h.minioConfig.ChatEmoji, nil
+}
+
+// every upload gets the new file, because the chat can have many emojis, the chat id prefix is used by the cleaning task
+func (r *ChatEmojiHandler) getAvatarFileName(c echo.Context, avatarType AvatarType) (string, error) {
+	return fmt.Sprintf("%v_%v.png", c.Param("chatId"), utils.GetFileItemId()), nil
+}
+
+func (r *ChatEmojiHandler) GetUrlPath() string {
+	return urlStorageGetChatEmoji
+}
+
+// the uploaded image is registered as the emoji by the chat admin in the chat service afterwards
+func (h *ChatEmojiHandler) PutEmoji(c echo.Context) error {
+	var userPrincipalDto, ok = c.Get(utils.USER_PRINCIPAL_DTO).(*auth.AuthResult)
+	if !ok {
+		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting auth context")
+		return errors.New("Error during getting auth context")
+	}
+
+	chatId, err := utils.ParseInt64(c.Param("chatId"))
+	if err != nil {
+		return err
+	}
+
+	if ok, err := h.restClient.CheckAccess(c.Request().Context(), &userPrincipalDto.UserId, chatId); err != nil {
+		return c.NoContent(http.StatusInternalServerError)
+	} else if !ok {
+		return c.NoContent(http.StatusUnauthorized)
+	}
+
+	filePart, err := c.FormFile(FormFile)
+	if err != nil {
+		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during extracting form %v parameter: %v", FormFile, err)
+		return err
+	}
+
+	bucketName, err := h.delegate.ensureAndGetAvatarBucket()
+	if err != nil {
+		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during get bucket: %v", err)
+		return err
+	}
+
+	src, err := filePart.Open()
+	if err != nil {
+		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during opening multipart file: %v", err)
+		return err
+	}
+	defer src.Close()
+
+	srcImage, _, err := image.Decode(src)
+	if err != nil {
+		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during decoding image: %v", err)
+		return err
+	}
+
+	filename, err := h.getAvatarFileName(c, "")
+	if err != nil {
+		return err
+	}
+	dstImage := imaging.Fit(srcImage, chatEmojiSize, chatEmojiSize, imaging.Lanczos)
+	relativeUrl, err := h.putImage(c, dstImage, png.Encode, bucketName, filename, "image/png", time.Now().UTC().Unix())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, &utils.H{"status": "ok", "filename": filename, "relativeUrl": relativeUrl})
+}
@@ -1118,6 +1118,58 @@ func (h *FilesHandler) S3Handler(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+type InternalUploadResponse struct {
+	FileItemUuid string `json:"fileItemUuid"`
+	Id           string `json:"id"`
+	Url          string `json:"url"`
+}
+
+// is used by other services to put generated files, for example chat exports, the body is the file's content
+func (h *FilesHandler) InternalUploadHandler(c echo.Context) error {
+	chatId, err := utils.ParseInt64(c.QueryParam("chatId"))
+	if err != nil {
+		return err
+	}
+	ownerId, err := utils.ParseInt64(c.QueryParam("ownerId"))
+	if err != nil {
+		return err
+	}
+	fileName := c.QueryParam("fileName")
+	if fileName == "" {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	fileSize := c.Request().ContentLength
+	if fileSize < 0 {
+		return c.NoContent(http.StatusLengthRequired)
+	}
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+
+	bucketName := h.minioConfig.Files
+
+	chatFileItemUuid := utils.GetFileItemId()
+
+	aKey := services.GetKey(fileName, chatFileItemUuid, chatId)
+
+	var userMetadata = services.SerializeMetadataSimple(ownerId, chatId, nil, nil, nil)
+
+	if _, err := h.minio.PutObject(c.Request().Context(), bucketName, aKey, c.Request().Body, fileSize, minio.PutObjectOptions{ContentType: contentType, UserMetadata: userMetadata}); err != nil {
+		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during upload object: %v", err)
+		return err
+	}
+
+	downloadUrl, err := h.filesService.GetConstantDownloadUrl(aKey)
+	if err != nil {
+		h.lgr.WithTracing(c.Request().Context()).Errorf("Error during getting url: %v", err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, &InternalUploadResponse{
+		FileItemUuid: chatFileItemUuid,
+		Id:           aKey,
+		Url:          downloadUrl,
+	})
+}
+
 type MediaDto struct {
 	Id         string  `json:"id"`
 	Filename   string  `json:"filename"`
@@ -1570,3 +1622,49 @@ func (h *FilesHandler) PublicDownloadHandler(c echo.Context) error {
 	c.Response().WriteHeader(http.StatusTemporaryRedirect)
 	return nil
 }
+
+// is used by other services to remove the file item which isn't referenced anymore, for example after the message retention
+func (h *FilesHandler) InternalDeleteFileItemHandler(c echo.Context) error {
+	chatId, err := utils.ParseInt64(c.QueryParam("chatId"))
+	if err != nil {
+		return err
+	}
+	fileItemUuid := c.QueryParam("fileItemUuid")
+	if fileItemUuid == "" {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	bucketName := h.minioConfig.Files
+	filenameChatPrefix := fmt.Sprintf("chat/%v/%v/", chatId, fileItemUuid)
+
+	var objects <-chan minio.ObjectInfo = h.minio.ListObjects(c.Request().Context(), bucketName, minio.ListObjectsOptions{
+		Prefix:    filenameChatPrefix,
+		Recursive: true,
+	})
+	for objInfo := range objects {
+		if objInfo.Err != nil {
+			h.lgr.WithTracing(c.Request().Context()).Errorf("Error during listing objects %v", objInfo.Err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		err = h.minio.RemoveObject(c.Request().Context(), bucketName, objInfo.Key, minio.RemoveObjectOptions{})
+		if err != nil {
+			h.lgr.WithTracing(c.Request().Context()).Errorf("Error during removing object %v", err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+
+		var previewToCheck string
+		if utils.IsVideo(objInfo.Key) {
+			previewToCheck = utils.SetVideoPreviewExtension(objInfo.Key)
+		} else if utils.IsImage(objInfo.Key) {
+			previewToCheck = utils.SetImagePreviewExtension(objInfo.Key)
+		}
+		if previewToCheck != "" {
+			err = h.minio.RemoveObject(c.Request().Context(), h.minioConfig.FilesPreview, previewToCheck, minio.RemoveObjectOptions{})
+			if err != nil {
+				h.lgr.WithTracing(c.Request().Context()).Errorf("Error during removing object %v", err)
+			}
+		}
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -72,6 +72,7 @@ func main() {
 			handlers.ConfigureAuthMiddleware,
 			handlers.NewUserAvatarHandler,
 			handlers.NewChatAvatarHandler,
+			handlers.NewChatEmojiHandler,
 			handlers.NewFilesHandler,
 			listener.CreateMinioEventsListener,
 			producer.NewRabbitFileUploadedPublisher,
@@ -134,6 +135,7 @@ func configureEcho(
 	lc fx.Lifecycle,
 	uah *handlers.UserAvatarHandler,
 	cha *handlers.ChatAvatarHandler,
+	ceh *handlers.ChatEmojiHandler,
 	fh *handlers.FilesHandler,
 	tp *sdktrace.TracerProvider,
 ) *echo.Echo {
@@ -203,7 +205,11 @@ func configureEcho(
 	e.GET(fmt.Sprintf("%v/:filename", uah.GetUrlPath()), uah.Download)
 	e.POST("/api/storage/chat/:chatId/avatar", cha.PutAvatar)
 	e.GET(fmt.Sprintf("%v/:filename", cha.GetUrlPath()), cha.Download)
+	e.POST("/api/storage/chat/:chatId/emoji", ceh.PutEmoji)
+	e.GET(fmt.Sprintf("%v/:filename", ceh.GetUrlPath()), ceh.Download)
 	e.POST("/internal/s3", fh.S3Handler)
+	e.PUT("/internal/upload", fh.InternalUploadHandler)
+	e.DELETE("/internal/file-item", fh.InternalDeleteFileItemHandler)
 	e.PUT("/api/storage/:chatId/upload/init", fh.InitMultipartUpload)
 	e.PUT("/api/storage/:chatId/upload/finish", fh.FinishMultipartUpload)
 	e.PUT("/api/storage/:chatId/replace/file", fh.ReplaceHandler)
@@ -336,7 +342,7 @@ func runEcho(lgr *logger.Logger, e *echo.Echo) {
 }
 
 func configureMinioEntities(lgr *logger.Logger, client *s3.InternalMinioClient) (*utils.MinioConfig, error) {
-	var ua, ca, f, p string
+	var ua, ca, ce, f, p string
 	var err error
 	if ua, err = utils.EnsureAndGetUserAvatarBucket(lgr, client); err != nil {
 		return nil, err
@@ -344,6 +350,9 @@ func configureMinioEntities(lgr *logger.Logger, client *s3.InternalMinioClient)
 	if ca, err = utils.EnsureAndGetChatAvatarBucket(lgr, client); err != nil {
 		return nil, err
 	}
+	if ce, err = utils.EnsureAndGetChatEmojiBucket(lgr, client); err != nil {
+		return nil, err
+	}
 	if f, err = utils.EnsureAndGetFilesBucket(lgr, client); err != nil {
 		return nil, err
 	}
@@ -395,6 +404,7 @@ func configureMinioEntities(lgr *logger.Logger, client *s3.InternalMinioClient)
 	return &utils.MinioConfig{
 		UserAvatar:   ua,
 		ChatAvatar:   ca,
+		ChatEmoji:    ce,
 		Files:        f,
 		FilesPreview: p,
 	}, nil
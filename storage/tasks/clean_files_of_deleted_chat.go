@@ -46,6 +46,7 @@ func (srv *CleanFilesOfDeletedChatService) doJob() {
 	ctx, span := srv.tracer.Start(context.Background(), "scheduler.cleanFilesOfDeletedChat")
 	defer span.End()
 	srv.processChats(ctx)
+	srv.processEmojis(ctx)
 }
 
 func (srv *CleanFilesOfDeletedChatService) processChats(c context.Context) {
@@ -122,6 +123,66 @@ func (srv *CleanFilesOfDeletedChatService) processBatch(c context.Context, chatI
 	}
 }
 
+// the emojis are stored flat, the chat id is the prefix of the filename
+func (srv *CleanFilesOfDeletedChatService) processEmojis(c context.Context) {
+	srv.lgr.WithTracing(c).Infof("Starting cleaning emojis of deleted chats job")
+
+	var objects <-chan minio.ObjectInfo = srv.minioClient.ListObjects(c, srv.minioBucketsConfig.ChatEmoji, minio.ListObjectsOptions{
+		Recursive: false,
+	})
+
+	keysOfChats := map[int64][]string{}
+	for objInfo := range objects {
+		chatId, err := utils.ParseChatIdOfEmoji(objInfo.Key)
+		if err != nil {
+			srv.lgr.WithTracing(c).Errorf("Unable to extract chat id from %v", objInfo.Key)
+			continue
+		}
+		keysOfChats[chatId] = append(keysOfChats[chatId], objInfo.Key)
+
+		if len(keysOfChats) >= viper.GetInt("schedulers.cleanFilesOfDeletedChatTask.batchChats") {
+			srv.processEmojisBatch(c, keysOfChats)
+			keysOfChats = map[int64][]string{}
+		}
+	}
+
+	// process leftovers
+	if len(keysOfChats) > 0 {
+		srv.processEmojisBatch(c, keysOfChats)
+	}
+
+	srv.lgr.WithTracing(c).Infof("End of cleaning emojis of deleted chats job")
+}
+
+func (srv *CleanFilesOfDeletedChatService) processEmojisBatch(c context.Context, keysOfChats map[int64][]string) {
+	chatIds := make([]int64, 0, len(keysOfChats))
+	for chatId := range keysOfChats {
+		chatIds = append(chatIds, chatId)
+	}
+
+	chatsExists, err := srv.chatClient.CheckIsChatExists(c, chatIds)
+	if err != nil {
+		srv.lgr.WithTracing(c).Errorf("Unable to chech existence of chat id %v", chatIds)
+		return
+	}
+
+	for _, chatExists := range *chatsExists {
+		if chatExists.Exists {
+			srv.lgr.WithTracing(c).Debugf("Chat %v is present, skipping", chatExists.ChatId)
+			continue
+		}
+		for _, key := range keysOfChats[chatExists.ChatId] {
+			srv.lgr.WithTracing(c).Infof("Deleting emoji object %v", key)
+			err := srv.minioClient.RemoveObject(c, srv.minioBucketsConfig.ChatEmoji, key, minio.RemoveObjectOptions{})
+			if err != nil {
+				srv.lgr.WithTracing(c).Errorf("Object emoji %v has been cleared from minio with error: %v", key, err)
+			} else {
+				srv.lgr.WithTracing(c).Debugf("Object emoji %v has been cleared from minio successfully", key)
+			}
+		}
+	}
+}
+
 func NewCleanFilesOfDeletedChatService(lgr *logger.Logger, minioClient *s3.InternalMinioClient, minioBucketsConfig *utils.MinioConfig, chatClient *client.RestClient) *CleanFilesOfDeletedChatService {
 	trcr := otel.Tracer("scheduler/clean-files-of-deleted-chat")
 	return &CleanFilesOfDeletedChatService{
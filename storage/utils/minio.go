@@ -46,6 +46,13 @@ func EnsureAndGetChatAvatarBucket(lgr *logger.Logger, minioClient *s3.InternalMi
 	return bucketName, err
 }
 
+func EnsureAndGetChatEmojiBucket(lgr *logger.Logger, minioClient *s3.InternalMinioClient) (string, error) {
+	bucketName := viper.GetString("minio.bucket.chatEmoji")
+	bucketLocation := viper.GetString("minio.location")
+	err := ensureBucket(lgr, minioClient, bucketName, bucketLocation)
+	return bucketName, err
+}
+
 func EnsureAndGetFilesBucket(lgr *logger.Logger, minioClient *s3.InternalMinioClient) (string, error) {
 	bucketName := viper.GetString("minio.bucket.files")
 	bucketLocation := viper.GetString("minio.location")
@@ -61,7 +68,7 @@ func EnsureAndGetFilesPreviewBucket(lgr *logger.Logger, minioClient *s3.Internal
 }
 
 type MinioConfig struct {
-	UserAvatar, ChatAvatar, Files, FilesPreview string
+	UserAvatar, ChatAvatar, ChatEmoji, Files, FilesPreview string
 }
 
 // https://min.io/docs/minio/linux/reference/minio-mc/mc-event-add.html#mc-event-supported-events
@@ -99,6 +106,15 @@ func ParseChatId(minioKey string) (int64, error) {
 	return 0, errors.New("Unable to parse chat id")
 }
 
+func ParseChatIdOfEmoji(minioKey string) (int64, error) {
+	// "116_0W007Z2P0CRT2G4E1X0DCWB0DK.png"
+	split := strings.SplitN(minioKey, "_", 2)
+	if len(split) == 2 {
+		return ParseInt64(split[0])
+	}
+	return 0, errors.New("Unable to parse chat id")
+}
+
 func ParseFileItemUuid(minioKey string) (string, error) {
 	// "chat/116/0W007Z2P0CRT2G4E1X0DCWB0DK/561ae246-7eff-45a6-a480-2b2be254c768.jpg"
 	split := strings.Split(minioKey, "/")
@@ -24,7 +24,7 @@ type RestClient struct {
 	client                          *http.Client
 	chatBaseUrl                     string
 	accessPath                      string
-	isAdminPath                     string
+	hasPermissionPath               string
 	doesParticipantBelongToChatPath string
 	chatParticipantIdsPath          string
 	chatInviteNamePath              string
@@ -52,7 +52,7 @@ func NewRestClient(config *config.ExtendedConfig, lgr *logger.Logger) *RestClien
 		client:                          client,
 		chatBaseUrl:                     config.ChatConfig.ChatUrlConfig.Base,
 		accessPath:                      config.ChatConfig.ChatUrlConfig.Access,
-		isAdminPath:                     config.ChatConfig.ChatUrlConfig.IsChatAdmin,
+		hasPermissionPath:               config.ChatConfig.ChatUrlConfig.HasChatPermission,
 		doesParticipantBelongToChatPath: config.ChatConfig.ChatUrlConfig.DoesParticipantBelongToChat,
 		chatParticipantIdsPath:          config.ChatConfig.ChatUrlConfig.ChatParticipantIds,
 		chatInviteNamePath:              config.ChatConfig.ChatUrlConfig.ChatInviteName,
@@ -96,8 +96,9 @@ func (h *RestClient) CheckAccess(c context.Context, userId int64, chatId int64)
 	}
 }
 
-func (h *RestClient) IsAdmin(c context.Context, userId int64, chatId int64) (bool, error) {
-	url := fmt.Sprintf("%v%v?userId=%v&chatId=%v", h.chatBaseUrl, h.isAdminPath, userId, chatId)
+// permission is one of the dto.ChatPermission* of the chat service
+func (h *RestClient) HasPermission(c context.Context, userId int64, chatId int64, permission string) (bool, error) {
+	url := fmt.Sprintf("%v%v?userId=%v&chatId=%v&permission=%v", h.chatBaseUrl, h.hasPermissionPath, userId, chatId, permission)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -105,7 +106,7 @@ func (h *RestClient) IsAdmin(c context.Context, userId int64, chatId int64) (boo
 		return false, err
 	}
 
-	ctx, span := h.tracer.Start(c, "chat.IsAdmin")
+	ctx, span := h.tracer.Start(c, "chat.HasPermission")
 	defer span.End()
 	req = req.WithContext(ctx)
 
@@ -66,7 +66,7 @@ type StorageConfig struct {
 type ChatUrlConfig struct {
 	Base                        string `mapstructure:"base"`
 	Access                      string `mapstructure:"access"`
-	IsChatAdmin                 string `mapstructure:"isChatAdmin"`
+	HasChatPermission           string `mapstructure:"hasChatPermission"`
 	DoesParticipantBelongToChat string `mapstructure:"doesParticipantBelongToChat"`
 	ChatParticipantIds          string `mapstructure:"chatParticipants"`
 	ChatInviteName              string `mapstructure:"chatInviteName"`
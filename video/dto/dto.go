@@ -1,5 +1,11 @@
 package dto
 
+// the permissions of the chat service which are checked by the video
+const (
+	ChatPermissionStartRecording = "start_recording"
+	ChatPermissionKickFromVideo  = "kick_from_video"
+)
+
 type VideoInviteDto struct {
 	ChatId       int64   `json:"chatId"`
 	UserIds      []int64 `json:"userIds"`
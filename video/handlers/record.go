@@ -11,6 +11,7 @@ import (
 	"nkonev.name/video/auth"
 	"nkonev.name/video/client"
 	"nkonev.name/video/config"
+	"nkonev.name/video/dto"
 	"nkonev.name/video/logger"
 	"nkonev.name/video/services"
 	"nkonev.name/video/utils"
@@ -57,8 +58,8 @@ func (rh *RecordHandler) canRecord(ctx context.Context, chatId int64, userPrinci
 	if rh.conf.OnlyRoleAdminRecording && !userPrincipalDto.HasRole("ROLE_ADMIN") {
 		return false, nil
 	}
-	if ok, err := rh.restClient.IsAdmin(ctx, userPrincipalDto.UserId, chatId); err != nil {
-		return false, fmt.Errorf("Error during cheching is chat admin for userId %v, chatId %v", userPrincipalDto.UserId, chatId)
+	if ok, err := rh.restClient.HasPermission(ctx, userPrincipalDto.UserId, chatId, dto.ChatPermissionStartRecording); err != nil {
+		return false, fmt.Errorf("Error during cheching the recording permission for userId %v, chatId %v", userPrincipalDto.UserId, chatId)
 	} else {
 		return ok, nil
 	}
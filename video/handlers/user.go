@@ -60,7 +60,7 @@ func (h *UserHandler) Kick(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	if ok, err := h.chatClient.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId); err != nil {
+	if ok, err := h.chatClient.HasPermission(c.Request().Context(), userPrincipalDto.UserId, chatId, dto.ChatPermissionKickFromVideo); err != nil {
 		return c.NoContent(http.StatusInternalServerError)
 	} else if !ok {
 		return c.NoContent(http.StatusUnauthorized)
@@ -86,7 +86,7 @@ func (h *UserHandler) Mute(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	if ok, err := h.chatClient.IsAdmin(c.Request().Context(), userPrincipalDto.UserId, chatId); err != nil {
+	if ok, err := h.chatClient.HasPermission(c.Request().Context(), userPrincipalDto.UserId, chatId, dto.ChatPermissionKickFromVideo); err != nil {
 		return c.NoContent(http.StatusInternalServerError)
 	} else if !ok {
 		return c.NoContent(http.StatusUnauthorized)